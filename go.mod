@@ -8,6 +8,7 @@ require (
 	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btcutil v0.0.0-20190425235716-9e5f4b9a998d
 	github.com/evanphx/json-patch v4.1.0+incompatible
+	github.com/golang/protobuf v1.4.1
 	github.com/gorilla/mux v1.7.3
 	github.com/minio/sha256-simd v0.1.1 // indirect
 	github.com/multiformats/go-multihash v0.0.14
@@ -16,6 +17,9 @@ require (
 	github.com/stretchr/testify v1.4.0
 	github.com/trustbloc/edge-core v0.1.4-0.20200709143857-e104bb29f6c6
 	golang.org/x/crypto v0.0.0-20200210222208-86ce3cb69678
+	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 go 1.13
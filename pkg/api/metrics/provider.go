@@ -0,0 +1,18 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"time"
+)
+
+// Provider defines interface for recording metrics (e.g. backed by Prometheus).
+type Provider interface {
+	// HTTPRequest records HTTP request processed by the given handler (e.g. "GET /identifiers/{id}")
+	// with response status code and request duration.
+	HTTPRequest(handler string, status int, duration time.Duration)
+}
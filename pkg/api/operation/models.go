@@ -74,3 +74,19 @@ type QueuedOperationAtTime struct {
 	QueuedOperation
 	ProtocolGenesisTime uint64
 }
+
+// Position identifies anchored operation in document's operation history (operations are ordered
+// by transaction time and transaction number).
+type Position struct {
+	TransactionTime   uint64 `json:"transactionTime"`
+	TransactionNumber uint64 `json:"transactionNumber"`
+}
+
+// Before returns true if the given operation is anchored before the position.
+func (p *Position) Before(op *AnchoredOperation) bool {
+	if p.TransactionTime == op.TransactionTime {
+		return p.TransactionNumber < op.TransactionNumber
+	}
+
+	return p.TransactionTime < op.TransactionTime
+}
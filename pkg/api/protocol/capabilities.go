@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+// Capabilities describes features supported by a protocol version so that clients can adapt
+// request construction to the node (e.g. choose signature algorithm or avoid unsupported patches).
+type Capabilities struct {
+	// PatchActions contains supported patch actions.
+	PatchActions []string `json:"patchActions"`
+	// MultihashAlgorithms contains supported multihash algorithm codes (the last one is the latest).
+	MultihashAlgorithms []uint `json:"multihashAlgorithms"`
+	// SignatureAlgorithms contains supported signature algorithms for signed operations.
+	SignatureAlgorithms []string `json:"signatureAlgorithms"`
+	// KeyAlgorithms contains supported key algorithms for signed operations.
+	KeyAlgorithms []string `json:"keyAlgorithms"`
+	// DocumentKeyAlgorithms contains allowed algorithms of document public keys ('kty:crv'); empty means any.
+	DocumentKeyAlgorithms []string `json:"documentKeyAlgorithms,omitempty"`
+	// KeyPurposes contains allowed public key purposes; empty means all standard purposes.
+	KeyPurposes []string `json:"keyPurposes,omitempty"`
+	// LongFormDID is true if long-form DIDs (unpublished DIDs with initial state) are supported.
+	LongFormDID bool `json:"longFormDid"`
+}
+
+// Capabilities returns capabilities derived from protocol parameters; features that are not described
+// by parameters (e.g. long-form DID support) are set by protocol version.
+func (p Protocol) Capabilities() Capabilities {
+	return Capabilities{
+		PatchActions:          copyStrings(p.Patches),
+		MultihashAlgorithms:   append([]uint(nil), p.MultihashAlgorithms...),
+		SignatureAlgorithms:   copyStrings(p.SignatureAlgorithms),
+		KeyAlgorithms:         copyStrings(p.KeyAlgorithms),
+		DocumentKeyAlgorithms: copyStrings(p.DocumentKeyAlgorithms),
+		KeyPurposes:           copyStrings(p.KeyPurposes),
+	}
+}
+
+// SupportsPatch returns true if the patch action is supported.
+func (c Capabilities) SupportsPatch(action string) bool {
+	return contains(c.PatchActions, action)
+}
+
+// SupportsSignatureAlgorithm returns true if the signature algorithm is supported.
+func (c Capabilities) SupportsSignatureAlgorithm(alg string) bool {
+	return contains(c.SignatureAlgorithms, alg)
+}
+
+// SupportsKeyAlgorithm returns true if the key algorithm is supported for signed operations.
+func (c Capabilities) SupportsKeyAlgorithm(alg string) bool {
+	return contains(c.KeyAlgorithms, alg)
+}
+
+// SupportsMultihashAlgorithm returns true if the multihash algorithm is supported.
+func (c Capabilities) SupportsMultihashAlgorithm(code uint) bool {
+	for _, alg := range c.MultihashAlgorithms {
+		if alg == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	return append([]string{}, values...)
+}
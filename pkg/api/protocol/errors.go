@@ -0,0 +1,182 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import (
+	"errors"
+)
+
+// ErrorCode classifies errors returned while applying operations.
+type ErrorCode string
+
+const (
+	// ErrorCodeRejected is returned when operation is permanently rejected (e.g. invalid signature,
+	// stale commitment, invalid delta); such operation is skipped during resolution.
+	ErrorCodeRejected ErrorCode = "rejected"
+
+	// ErrorCodeInternal is returned for transient/internal failures (e.g. protocol version not available,
+	// storage errors); resolution should be failed (or retried) instead of skipping the operation.
+	ErrorCodeInternal ErrorCode = "internal"
+)
+
+// ApplyError is an error with error code returned while applying operations.
+type ApplyError struct {
+	code ErrorCode
+	err  error
+}
+
+// NewRejectedError returns error for permanently rejected operation.
+func NewRejectedError(err error) *ApplyError {
+	return &ApplyError{code: ErrorCodeRejected, err: err}
+}
+
+// NewInternalError returns error for transient/internal failure.
+func NewInternalError(err error) *ApplyError {
+	return &ApplyError{code: ErrorCodeInternal, err: err}
+}
+
+// Error returns the error string.
+func (e *ApplyError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ApplyError) Unwrap() error {
+	return e.err
+}
+
+// Code returns the error code.
+func (e *ApplyError) Code() ErrorCode {
+	return e.code
+}
+
+// GetErrorCode returns error code of the error; errors without error code are considered rejections.
+func GetErrorCode(err error) ErrorCode {
+	var applyErr *ApplyError
+	if errors.As(err, &applyErr) {
+		return applyErr.code
+	}
+
+	return ErrorCodeRejected
+}
+
+// IsRejectedError returns true if operation has been permanently rejected.
+func IsRejectedError(err error) bool {
+	return err != nil && GetErrorCode(err) == ErrorCodeRejected
+}
+
+// IsInternalError returns true if error is transient/internal failure.
+func IsInternalError(err error) bool {
+	return err != nil && GetErrorCode(err) == ErrorCodeInternal
+}
+
+// Error codes returned to clients (e.g. in REST API error responses).
+const (
+	// CodeBadRequest is returned for invalid requests that don't have more specific error code.
+	CodeBadRequest = "bad_request"
+
+	// CodeOperationExceedsMaximumSize is returned if operation size exceeds protocol maximum operation size.
+	CodeOperationExceedsMaximumSize = "operation_exceeds_maximum_size"
+
+	// CodeDeltaExceedsMaximumSize is returned if delta size exceeds protocol maximum delta size.
+	CodeDeltaExceedsMaximumSize = "delta_exceeds_maximum_size"
+
+	// CodeDIDNotFound is returned if DID document was not found.
+	CodeDIDNotFound = "did_not_found"
+
+	// CodeDIDDeactivated is returned if DID document was deactivated.
+	CodeDIDDeactivated = "did_deactivated"
+
+	// CodeInternalError is returned for unexpected (internal) errors.
+	CodeInternalError = "internal_error"
+)
+
+// CodedError is an error with machine-readable error code that is returned to clients.
+type CodedError struct {
+	code string
+	err  error
+}
+
+// NewCodedError returns error with the given error code.
+func NewCodedError(code string, err error) *CodedError {
+	return &CodedError{code: code, err: err}
+}
+
+// Error returns the error string.
+func (e *CodedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *CodedError) Unwrap() error {
+	return e.err
+}
+
+// Code returns the error code.
+func (e *CodedError) Code() string {
+	return e.code
+}
+
+// GetCode returns error code of the (first) coded error in the error chain; empty string is returned
+// if error doesn't have error code.
+func GetCode(err error) string {
+	var codedErr *CodedError
+	if errors.As(err, &codedErr) {
+		return codedErr.code
+	}
+
+	return ""
+}
+
+// FieldError is an error for an invalid field of the request; the field is identified by JSON pointer
+// (RFC 6901), e.g. /delta/patches/0/publicKeys/1/purposes.
+type FieldError struct {
+	pointer string
+	err     error
+}
+
+// NewFieldError returns error for the field identified by the given JSON pointer.
+func NewFieldError(pointer string, err error) *FieldError {
+	return &FieldError{pointer: pointer, err: err}
+}
+
+// Error returns the error string.
+func (e *FieldError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+// Pointer returns JSON pointer of the invalid field.
+func (e *FieldError) Pointer() string {
+	return e.pointer
+}
+
+// WithPointer returns field error for the field identified by the given JSON pointer. If the error already
+// identifies a (nested) field, its pointer is relative to the given pointer, e.g. pointer /publicKeys/1
+// returned by the patch validator becomes /delta/patches/0/publicKeys/1. The error message is not changed.
+func WithPointer(pointer string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &FieldError{pointer: pointer + GetPointer(err), err: err}
+}
+
+// GetPointer returns JSON pointer of the (first) field error in the error chain; empty string is returned
+// if error doesn't identify a field.
+func GetPointer(err error) string {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return fieldErr.pointer
+	}
+
+	return ""
+}
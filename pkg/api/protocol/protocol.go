@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package protocol
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/txn"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
@@ -55,9 +59,164 @@ type Protocol struct {
 	// Patches contains the list of allowed patches.
 	Patches []string `json:"patches"`
 	// SignatureAlgorithms contain supported signature algorithms for signed operations (e.g. EdDSA, ES256, ES384, ES512, ES256K).
+	// RSA signatures (PS256, RS256) are accepted only if they are listed here (and RSA is listed in key algorithms).
 	SignatureAlgorithms []string `json:"signatureAlgorithms"`
-	// KeyAlgorithms contain supported key algorithms for signed operations (e.g. secp256k1, P-256, P-384, P-512, Ed25519).
+	// KeyAlgorithms contain supported key algorithms for signed operations (e.g. secp256k1, P-256, P-384, P-512, Ed25519, RSA).
 	KeyAlgorithms []string `json:"keyAlgorithms"`
+	// MaxPatchesPerDelta is maximum number of patches in operation's delta (0 means no limit).
+	MaxPatchesPerDelta uint `json:"maxPatchesPerDelta"`
+	// MaxPatchSize is maximum size of (canonicalized) patch in operation's delta (0 means no limit).
+	MaxPatchSize uint `json:"maxPatchSize"`
+	// MaxPublicKeysPerPatch is maximum number of public keys in add-public-keys (and replace) patch (0 means no limit).
+	MaxPublicKeysPerPatch uint `json:"maxPublicKeysPerPatch"`
+	// MaxServicesPerPatch is maximum number of services in add-services, replace-services (and replace) patch (0 means no limit).
+	MaxServicesPerPatch uint `json:"maxServicesPerPatch"`
+	// MaxPublicKeys is maximum number of public keys in the document (0 means no limit).
+	MaxPublicKeys uint `json:"maxPublicKeys"`
+	// MaxServices is maximum number of services in the document (0 means no limit).
+	MaxServices uint `json:"maxServices"`
+	// MaxJSONPatchOperations is maximum number of operations in ietf-json-patch patch (0 means no limit).
+	MaxJSONPatchOperations uint `json:"maxJsonPatchOperations"`
+	// JSONPatchProtectedPaths are additional document paths (JSON pointers) that cannot be modified by ietf-json-patch
+	// (public keys and services are always protected, see ProtectIdentityPaths for '/id' and '/controller').
+	JSONPatchProtectedPaths []string `json:"jsonPatchProtectedPaths"`
+	// KeyPurposes contains allowed public key purposes (standard and/or custom purposes, e.g. authentication,
+	// assertionMethod, keyAgreement, capabilityInvocation, capabilityDelegation); empty means all standard purposes.
+	KeyPurposes []string `json:"keyPurposes"`
+	// DocumentKeyAlgorithms contains allowed algorithms of document public keys as JWK key type and curve
+	// combinations in 'kty:crv' format (e.g. EC:P-256, EC:secp256k1, OKP:Ed25519); empty means any key algorithm.
+	DocumentKeyAlgorithms []string `json:"documentKeyAlgorithms"`
+	// ValidateDIDSuffix enables validation of DID suffix format: base64url character set, maximum length
+	// (MaxOperationHashLength) and multihash algorithm (MultihashAlgorithms).
+	ValidateDIDSuffix bool `json:"validateDidSuffix"`
+
+	// The following validation rules are disabled by default. They apply to anchored operations as well
+	// (operations that break a rule are rejected during resolution) so they can only be enabled for a new
+	// protocol version.
+
+	// ValidatePatchConflicts enables rejection of deltas with conflicting patches (patches that modify the same
+	// document entry, e.g. add and remove the same public key).
+	ValidatePatchConflicts bool `json:"validatePatchConflicts"`
+	// ValidateUniqueIDs enables validation that ids are unique across public keys and services of the document
+	// (patches that produce a document with the same public key and service id are rejected).
+	ValidateUniqueIDs bool `json:"validateUniqueIds"`
+	// ValidateKeyMaterial enables validation of document public key material (EC points have to be on the curve,
+	// Ed25519 and X25519 keys have to be valid 32 byte keys).
+	ValidateKeyMaterial bool `json:"validateKeyMaterial"`
+	// ProtectIdentityPaths enables protection of document id and controller (and document root) from
+	// ietf-json-patch modifications; 'from' location of move operation is validated as well.
+	ProtectIdentityPaths bool `json:"protectIdentityPaths"`
+	// ValidateDIDCommServices enables validation of DIDCommMessaging services (service endpoint has to be URI
+	// or object with uri; accept and routingKeys have to be arrays of strings).
+	ValidateDIDCommServices bool `json:"validateDidCommServices"`
+	// ValidateServiceEndpoints enables strict validation of service endpoint objects (object cannot be empty
+	// and its optional 'uri' property has to be a valid URI); otherwise only URI endpoints are validated.
+	ValidateServiceEndpoints bool `json:"validateServiceEndpoints"`
+
+	// KeyIDPolicy is policy for validating optional 'kid' protected header of signed data against the signing key
+	// (e.g. thumbprint); empty means that 'kid' is not validated. The policy applies to both operation submission
+	// and resolution of anchored operations.
+	KeyIDPolicy string `json:"keyIdPolicy"`
+	// UnencodedPayload allows signed data with unencoded payload (RFC 7797): 'b64' protected header set to false
+	// and listed in 'crit' protected header. By default only 'alg' and 'kid' protected headers are allowed.
+	UnencodedPayload bool `json:"unencodedPayload"`
+	// AllowedProtectedHeaders are additional protected headers allowed in signed data
+	// (by default only 'alg' and 'kid' protected headers are allowed).
+	AllowedProtectedHeaders []string `json:"allowedProtectedHeaders"`
+	// ForbiddenProtectedHeaders are protected headers that are not allowed in signed data (e.g. 'kid');
+	// forbidden headers take precedence over allowed headers.
+	ForbiddenProtectedHeaders []string `json:"forbiddenProtectedHeaders"`
+	// CriticalHeaders are extension headers that are understood (validated) by implementation and may therefore
+	// be listed in 'crit' protected header of signed data (critical headers are allowed protected headers).
+	// Signed data with any other critical header is rejected.
+	CriticalHeaders []string `json:"criticalHeaders"`
+}
+
+// KeyIDPolicyThumbprint is key ID policy that requires 'kid' (if present) to be the JWK thumbprint (RFC 7638)
+// of the signing key.
+const KeyIDPolicyThumbprint = "thumbprint"
+
+// Validate validates protocol parameters; an error is returned for missing or inconsistent parameters
+// (e.g. max delta size greater than max operation size, unsupported multihash algorithm, unknown patch).
+// Custom patch actions have to be registered (patch.RegisterAction) before parameters are validated.
+func (p Protocol) Validate() error {
+	if len(p.MultihashAlgorithms) == 0 {
+		return errors.New("missing multihash algorithms")
+	}
+
+	for _, code := range p.MultihashAlgorithms {
+		if _, err := hashing.GetHashFromMultihash(code); err != nil {
+			return fmt.Errorf("multihash algorithm %d is not supported", code)
+		}
+	}
+
+	if err := p.validateLimits(); err != nil {
+		return err
+	}
+
+	if p.CompressionAlgorithm == "" {
+		return errors.New("missing compression algorithm")
+	}
+
+	if len(p.Patches) == 0 {
+		return errors.New("missing patches")
+	}
+
+	for _, action := range p.Patches {
+		if !patch.IsRegistered(patch.Action(action)) {
+			return fmt.Errorf("patch action '%s' is not supported", action)
+		}
+	}
+
+	if len(p.SignatureAlgorithms) == 0 {
+		return errors.New("missing signature algorithms")
+	}
+
+	if p.KeyIDPolicy != "" && p.KeyIDPolicy != KeyIDPolicyThumbprint {
+		return fmt.Errorf("key ID policy '%s' is not supported", p.KeyIDPolicy)
+	}
+
+	return nil
+}
+
+func (p Protocol) validateLimits() error {
+	required := []struct {
+		name  string
+		value uint
+	}{
+		{"max operation count", p.MaxOperationCount},
+		{"max operation size", p.MaxOperationSize},
+		{"max operation hash length", p.MaxOperationHashLength},
+		{"max delta size", p.MaxDeltaSize},
+		{"max CAS URI length", p.MaxCasURILength},
+		{"max core index file size", p.MaxCoreIndexFileSize},
+		{"max provisional index file size", p.MaxProvisionalIndexFileSize},
+		{"max proof file size", p.MaxProofFileSize},
+		{"max chunk file size", p.MaxChunkFileSize},
+	}
+
+	for _, limit := range required {
+		if limit.value == 0 {
+			return fmt.Errorf("%s must be greater than zero", limit.name)
+		}
+	}
+
+	if p.MaxDeltaSize > p.MaxOperationSize {
+		return fmt.Errorf("max delta size[%d] cannot be greater than max operation size[%d]",
+			p.MaxDeltaSize, p.MaxOperationSize)
+	}
+
+	if p.MaxPatchSize > p.MaxDeltaSize {
+		return fmt.Errorf("max patch size[%d] cannot be greater than max delta size[%d]",
+			p.MaxPatchSize, p.MaxDeltaSize)
+	}
+
+	if p.MaxDeltaSize > p.MaxChunkFileSize {
+		return fmt.Errorf("max delta size[%d] cannot be greater than max chunk file size[%d]",
+			p.MaxDeltaSize, p.MaxChunkFileSize)
+	}
+
+	return nil
 }
 
 // TxnProcessor defines the functions for processing a Sidetree transaction.
@@ -79,10 +238,30 @@ type ResolutionModel struct {
 	LastOperationTransactionTime     uint64
 	LastOperationTransactionNumber   uint64
 	LastOperationProtocolGenesisTime uint64
+	CreatedTransactionTime           uint64 // transaction time of the create operation
+	CreatedTransactionNumber         uint64 // transaction number of the create operation
+	UpdatedTransactionTime           uint64 // transaction time of the latest non-create operation (zero if none)
+	UpdatedTransactionNumber         uint64 // transaction number of the latest non-create operation
 	UpdateCommitment                 string
 	RecoveryCommitment               string
+	LastUpdateNonce                  uint64
+	PatchMode                        PatchMode
+	SkippedPatches                   []string
+	Deactivated                      bool
+	FinalDoc                         document.Document
 }
 
+// PatchMode defines how operation applier handles patches that cannot be applied.
+type PatchMode string
+
+const (
+	// PatchModeStrict means that none of the operation's patches are applied if any of the patches fails (default).
+	PatchModeStrict PatchMode = "strict"
+
+	// PatchModeLenient means that patches that fail are skipped (and recorded) while remaining patches are applied.
+	PatchModeLenient PatchMode = "lenient"
+)
+
 // OperationApplier applies the given operation to the document.
 type OperationApplier interface {
 	Apply(op *operation.AnchoredOperation, rm *ResolutionModel) (*ResolutionModel, error)
@@ -110,6 +289,28 @@ type DocumentValidator interface {
 	IsValidPayload(payload []byte) error
 }
 
+// ValidationResult contains errors (document is rejected) and non-fatal warnings (e.g. deprecated properties,
+// limits that will be enforced in the future) produced by document validation.
+type ValidationResult struct {
+	Errors   []error
+	Warnings []string
+}
+
+// Err returns the first validation error (nil if there are no errors).
+func (r *ValidationResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	return r.Errors[0]
+}
+
+// DocumentResultValidator is optionally implemented by document validators that report warnings
+// in addition to errors.
+type DocumentResultValidator interface {
+	ValidateOriginalDocument(payload []byte) *ValidationResult
+}
+
 // DocumentTransformer transforms internal resolution model into external document(resolution result).
 type DocumentTransformer interface {
 	TransformDocument(rm *ResolutionModel, info TransformationInfo) (*document.ResolutionResult, error)
@@ -122,6 +323,7 @@ type TransformationInfo map[string]interface{}
 type Version interface {
 	Version() string
 	Protocol() Protocol
+	Capabilities() Capabilities
 	TransactionProcessor() TxnProcessor
 	OperationParser() OperationParser
 	OperationApplier() OperationApplier
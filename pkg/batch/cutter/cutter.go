@@ -67,39 +67,64 @@ func (r *BatchCutter) Add(op *operation.QueuedOperation, protocolGenesisTime uin
 }
 
 // Cut returns the current batch along with number of items that should be remaining in the queue after the committer is called.
+// Operations added using different protocol versions are never mixed in one batch: the batch contains operations from the
+// head of the queue that were added using the same protocol version and its size is limited by max operation count of
+// that version (so that batch files are created according to that version).
 // If force is false then the batch will be cut only if it has reached the max batch size (as specified in the protocol)
+// or if it is followed by operations of another protocol version or its protocol version is older than the current one
+// (i.e. no more operations will be added to the batch)
 // If force is true then the batch will be cut if there is at least one Data in the batch
 // Note that the operations are removed from the queue when Result.Commit is invoked, otherwise they remain in the queue.
 func (r *BatchCutter) Cut(force bool) (Result, error) {
-	pending := r.pendingBatch.Len()
-
 	currentProtocol, err := r.client.Current()
 	if err != nil {
 		return Result{}, err
 	}
 
-	maxOperationsPerBatch := currentProtocol.Protocol().MaxOperationCount
-	if !force && pending < maxOperationsPerBatch {
+	pending := r.pendingBatch.Len()
+	if pending == 0 {
 		return Result{Pending: pending}, nil
 	}
 
-	batchSize := min(pending, maxOperationsPerBatch)
-	ops, err := r.pendingBatch.Peek(batchSize)
+	head, err := r.pendingBatch.Peek(1)
+	if err != nil || len(head) == 0 {
+		return Result{Pending: pending}, nil
+	}
+
+	protocolGenesisTime := head[0].ProtocolGenesisTime
+
+	// batch size is limited by the protocol version that was used to add the operations to the queue
+	batchProtocol, err := r.client.Get(protocolGenesisTime)
+	if err != nil {
+		return Result{Pending: pending}, err
+	}
+
+	maxOperationsPerBatch := batchProtocol.Protocol().MaxOperationCount
+
+	ops, err := r.pendingBatch.Peek(min(pending, maxOperationsPerBatch))
 	if err != nil {
 		return Result{Pending: pending}, nil
 	}
 
-	operations, protocolGenesisTime := getOperationsAtProtocolVersion(ops)
+	operations := getOperationsAtProtocolVersion(ops, protocolGenesisTime)
 
-	batchSize = uint(len(operations))
+	batchSize := uint(len(operations))
 
 	if batchSize == 0 {
 		return Result{Pending: pending}, nil
 	}
 
+	complete := batchSize == maxOperationsPerBatch || batchSize < pending ||
+		protocolGenesisTime < currentProtocol.Protocol().GenesisTime
+
+	if !force && !complete {
+		return Result{Pending: pending}, nil
+	}
+
 	pending -= batchSize
 
-	logger.Infof("Pending Size: %d, MaxOperationsPerBatch: %d, Batch Size: %d", pending, maxOperationsPerBatch, batchSize)
+	logger.Infof("Pending Size: %d, MaxOperationsPerBatch: %d, Batch Size: %d, Protocol Genesis Time: %d",
+		pending, maxOperationsPerBatch, batchSize, protocolGenesisTime)
 
 	committer := func() (uint, error) {
 		logger.Infof("Removing %d operations from the queue", batchSize)
@@ -117,18 +142,14 @@ func (r *BatchCutter) Cut(force bool) (Result, error) {
 	}, nil
 }
 
-// getOperationsAtProtocolVersion iterates through the operations and returns the operations which are at the same protocol genesis time.
-func getOperationsAtProtocolVersion(opsAtTime []*operation.QueuedOperationAtTime) ([]*operation.QueuedOperation, uint64) {
+// getOperationsAtProtocolVersion iterates through the operations and returns the leading operations which are at the given
+// protocol genesis time.
+func getOperationsAtProtocolVersion(opsAtTime []*operation.QueuedOperationAtTime, protocolGenesisTime uint64) []*operation.QueuedOperation {
 	var ops []*operation.QueuedOperation
-	var protocolGenesisTime uint64
 
 	for _, op := range opsAtTime {
-		if protocolGenesisTime == 0 {
-			protocolGenesisTime = op.ProtocolGenesisTime
-		}
-
 		if op.ProtocolGenesisTime != protocolGenesisTime {
-			// This operation was added using a different transaction time so it can't go into the same batch
+			// This operation was added using a different protocol version so it can't go into the same batch
 			logger.Infof("Not adding operation since its protocol genesis time [%d] is different from the protocol genesis time [%d] of the existing ops in the batch", op.ProtocolGenesisTime, protocolGenesisTime)
 
 			break
@@ -143,7 +164,7 @@ func getOperationsAtProtocolVersion(opsAtTime []*operation.QueuedOperationAtTime
 		)
 	}
 
-	return ops, protocolGenesisTime
+	return ops
 }
 
 func min(i, j uint) uint {
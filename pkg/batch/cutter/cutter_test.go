@@ -132,3 +132,98 @@ func TestBatchCutter(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, pending)
 }
+
+func TestBatchCutter_ProtocolVersions(t *testing.T) {
+	c := mocks.NewMockProtocolClient()
+
+	p1 := mocks.GetDefaultProtocolParameters()
+	p1.MaxOperationCount = 2
+
+	p2 := mocks.GetDefaultProtocolParameters()
+	p2.GenesisTime = 100
+	p2.MaxOperationCount = 3
+
+	v1 := mocks.GetProtocolVersion(p1)
+	v2 := mocks.GetProtocolVersion(p2)
+
+	c.Versions = []*mocks.ProtocolVersion{v1, v2}
+	c.CurrentVersion = v1
+
+	t.Run("batch size is limited by protocol version of the operations", func(t *testing.T) {
+		r := New(c, &opqueue.MemQueue{})
+
+		for _, op := range []*operation.QueuedOperation{operation1, operation2, operation3} {
+			_, err := r.Add(op, 100)
+			require.NoError(t, err)
+		}
+
+		result, err := r.Cut(false)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 3)
+		require.Equal(t, uint64(100), result.ProtocolGenesisTime)
+		require.Zero(t, result.Pending)
+	})
+
+	t.Run("operations of different versions are not mixed", func(t *testing.T) {
+		r := New(c, &opqueue.MemQueue{})
+
+		_, err := r.Add(operation1, 0)
+		require.NoError(t, err)
+		_, err = r.Add(operation2, 100)
+		require.NoError(t, err)
+
+		result, err := r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1)
+		require.Equal(t, operation1, result.Operations[0])
+		require.Equal(t, uint64(0), result.ProtocolGenesisTime)
+		require.Equal(t, uint(1), result.Pending)
+
+		pending, err := result.Commit()
+		require.NoError(t, err)
+		require.Equal(t, uint(1), pending)
+
+		result, err = r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1)
+		require.Equal(t, operation2, result.Operations[0])
+		require.Equal(t, uint64(100), result.ProtocolGenesisTime)
+	})
+
+	t.Run("operations of previous version are cut after version transition", func(t *testing.T) {
+		r := New(c, &opqueue.MemQueue{})
+
+		_, err := r.Add(operation1, 0)
+		require.NoError(t, err)
+
+		result, err := r.Cut(false)
+		require.NoError(t, err)
+		require.Empty(t, result.Operations)
+		require.Equal(t, uint(1), result.Pending)
+
+		c.CurrentVersion = v2
+		defer func() { c.CurrentVersion = v1 }()
+
+		result, err = r.Cut(false)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1)
+		require.Equal(t, uint64(0), result.ProtocolGenesisTime)
+		require.Zero(t, result.Pending)
+	})
+
+	t.Run("error - protocol version of the operations", func(t *testing.T) {
+		c := mocks.NewMockProtocolClient()
+		c.Versions[0].ProtocolReturns(p2)
+
+		r := New(c, &opqueue.MemQueue{})
+
+		_, err := r.Add(operation1, 10)
+		require.NoError(t, err)
+
+		result, err := r.Cut(true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "protocol parameters are not defined for blockchain time: 10")
+		require.Empty(t, result.Operations)
+		require.Equal(t, uint(1), result.Pending)
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+const defaultMaxDeadLetters = 100
+
+// FailedBatch contains operations that could not be batched and anchored after the configured number
+// of attempts (the operations are removed from the queue so that they don't block subsequent operations).
+type FailedBatch struct {
+	Operations          []*operation.QueuedOperation `json:"operations"`
+	ProtocolGenesisTime uint64                       `json:"protocolGenesisTime"`
+	Attempts            int                          `json:"attempts"`
+	Error               string                       `json:"error"`
+	Failed              int64                        `json:"failed"`
+}
+
+// DeadLetterQueue receives batches of operations that failed processing.
+type DeadLetterQueue interface {
+	// Add adds failed batch to the queue
+	Add(batch *FailedBatch)
+}
+
+// MemDeadLetterQueue keeps failed batches in memory; only the configured number of batches is retained
+// (the oldest are evicted first).
+type MemDeadLetterQueue struct {
+	mutex   sync.RWMutex
+	batches []*FailedBatch
+	max     int
+}
+
+// NewMemDeadLetterQueue returns a new in-memory dead-letter queue.
+func NewMemDeadLetterQueue() *MemDeadLetterQueue {
+	return &MemDeadLetterQueue{
+		max: defaultMaxDeadLetters,
+	}
+}
+
+// WithMaxBatches sets the maximum number of failed batches retained by the queue.
+func (q *MemDeadLetterQueue) WithMaxBatches(max int) *MemDeadLetterQueue {
+	q.max = max
+
+	return q
+}
+
+// Add adds failed batch to the queue.
+func (q *MemDeadLetterQueue) Add(batch *FailedBatch) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if batch.Failed == 0 {
+		batch.Failed = time.Now().Unix()
+	}
+
+	q.batches = append(q.batches, batch)
+
+	if len(q.batches) > q.max {
+		q.batches = q.batches[len(q.batches)-q.max:]
+	}
+}
+
+// DeadLetters returns failed batches (oldest first).
+func (q *MemDeadLetterQueue) DeadLetters() []*FailedBatch {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	batches := make([]*FailedBatch, len(q.batches))
+	copy(batches, q.batches)
+
+	return batches
+}
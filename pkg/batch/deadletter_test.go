@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+func TestMemDeadLetterQueue(t *testing.T) {
+	q := NewMemDeadLetterQueue().WithMaxBatches(2)
+	require.Empty(t, q.DeadLetters())
+
+	for _, suffix := range []string{"suffix1", "suffix2", "suffix3"} {
+		q.Add(&FailedBatch{
+			Operations: []*operation.QueuedOperation{{UniqueSuffix: suffix}},
+			Error:      "CAS error",
+		})
+	}
+
+	batches := q.DeadLetters()
+	require.Len(t, batches, 2)
+	require.Equal(t, "suffix2", batches[0].Operations[0].UniqueSuffix)
+	require.Equal(t, "suffix3", batches[1].Operations[0].UniqueSuffix)
+	require.NotZero(t, batches[0].Failed)
+}
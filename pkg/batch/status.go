@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+const defaultMaxAnchoredStatuses = 10000
+
+// Status is status of an operation submitted to the batch writer.
+type Status string
+
+const (
+	// StatusQueued is status of operations that were added to the queue and are waiting to be batched.
+	StatusQueued Status = "queued"
+
+	// StatusWritten is status of operations whose batch files were written to CAS and whose anchor string
+	// was written to the blockchain (transaction is not confirmed yet).
+	StatusWritten Status = "written"
+
+	// StatusAnchored is status of operations whose anchor string was observed in a Sidetree transaction
+	// (operations are resolvable).
+	StatusAnchored Status = "anchored"
+
+	// StatusFailed is status of operations whose batch failed processing the maximum number of times and
+	// was moved to the dead-letter queue (operations will not be anchored unless they are resubmitted).
+	StatusFailed Status = "failed"
+)
+
+// OperationStatus contains status of the latest operation submitted for a document.
+type OperationStatus struct {
+	UniqueSuffix      string `json:"uniqueSuffix"`
+	Status            Status `json:"status"`
+	AnchorString      string `json:"anchorString,omitempty"`
+	TransactionTime   uint64 `json:"transactionTime,omitempty"`
+	TransactionNumber uint64 `json:"transactionNumber,omitempty"`
+	Error             string `json:"error,omitempty"`
+	Updated           int64  `json:"updated"`
+}
+
+// StatusTracker is notified by the batch writer as operations move through the batching process.
+type StatusTracker interface {
+	// Queued is invoked after the operation has been added to the queue
+	Queued(op *operation.QueuedOperation)
+	// Written is invoked after the anchor string for the batch of operations has been written to the blockchain
+	Written(anchorString string, ops []*operation.QueuedOperation)
+	// Failed is invoked after the batch of operations has been moved to the dead-letter queue
+	Failed(reason string, ops []*operation.QueuedOperation)
+}
+
+// OperationStatusTracker keeps status of operations in memory. Operations are tracked from the time
+// they are queued by the batch writer until they are anchored (Anchored is invoked by the observer
+// when the anchor string is observed). Only the configured number of anchored statuses is retained
+// (the oldest are evicted first); anchored operations are available from the operation store.
+type OperationStatusTracker struct {
+	mutex       sync.RWMutex
+	statuses    map[string]*OperationStatus
+	anchors     map[string][]string
+	anchored    []*OperationStatus
+	maxAnchored int
+}
+
+// NewOperationStatusTracker returns a new in-memory operation status tracker.
+func NewOperationStatusTracker() *OperationStatusTracker {
+	return &OperationStatusTracker{
+		statuses:    make(map[string]*OperationStatus),
+		anchors:     make(map[string][]string),
+		maxAnchored: defaultMaxAnchoredStatuses,
+	}
+}
+
+// WithMaxAnchored sets the maximum number of anchored statuses retained by the tracker.
+func (t *OperationStatusTracker) WithMaxAnchored(max int) *OperationStatusTracker {
+	t.maxAnchored = max
+
+	return t
+}
+
+// Queued records that the operation was added to the queue.
+func (t *OperationStatusTracker) Queued(op *operation.QueuedOperation) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.statuses[op.UniqueSuffix] = &OperationStatus{
+		UniqueSuffix: op.UniqueSuffix,
+		Status:       StatusQueued,
+		Updated:      time.Now().Unix(),
+	}
+}
+
+// Written records that the batch containing the given operations was written with the given anchor string.
+func (t *OperationStatusTracker) Written(anchorString string, ops []*operation.QueuedOperation) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	suffixes := make([]string, len(ops))
+
+	for i, op := range ops {
+		suffixes[i] = op.UniqueSuffix
+
+		t.statuses[op.UniqueSuffix] = &OperationStatus{
+			UniqueSuffix: op.UniqueSuffix,
+			Status:       StatusWritten,
+			AnchorString: anchorString,
+			Updated:      time.Now().Unix(),
+		}
+	}
+
+	t.anchors[anchorString] = suffixes
+}
+
+// Failed records that the batch containing the given operations failed processing with the given reason.
+func (t *OperationStatusTracker) Failed(reason string, ops []*operation.QueuedOperation) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, op := range ops {
+		t.statuses[op.UniqueSuffix] = &OperationStatus{
+			UniqueSuffix: op.UniqueSuffix,
+			Status:       StatusFailed,
+			Error:        reason,
+			Updated:      time.Now().Unix(),
+		}
+	}
+}
+
+// Anchored records that the Sidetree transaction with the given anchor string was observed.
+func (t *OperationStatusTracker) Anchored(anchorString string, txnTime, txnNumber uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	suffixes, ok := t.anchors[anchorString]
+	if !ok {
+		// batch wasn't written by this node
+		return
+	}
+
+	delete(t.anchors, anchorString)
+
+	for _, suffix := range suffixes {
+		status, ok := t.statuses[suffix]
+		if !ok || status.AnchorString != anchorString {
+			// a newer operation has been submitted for the document
+			continue
+		}
+
+		anchored := &OperationStatus{
+			UniqueSuffix:      suffix,
+			Status:            StatusAnchored,
+			AnchorString:      anchorString,
+			TransactionTime:   txnTime,
+			TransactionNumber: txnNumber,
+			Updated:           time.Now().Unix(),
+		}
+
+		t.statuses[suffix] = anchored
+		t.anchored = append(t.anchored, anchored)
+	}
+
+	t.evict()
+}
+
+// Get returns status of the latest operation submitted for the document with the given unique suffix.
+func (t *OperationStatusTracker) Get(uniqueSuffix string) (*OperationStatus, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	status, ok := t.statuses[uniqueSuffix]
+	if !ok {
+		return nil, fmt.Errorf("status for operation [%s] not found", uniqueSuffix)
+	}
+
+	return status, nil
+}
+
+// GetByAnchor returns status of operations in the batch with the given anchor string.
+func (t *OperationStatusTracker) GetByAnchor(anchorString string) ([]*OperationStatus, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var statuses []*OperationStatus
+
+	for _, status := range t.statuses {
+		if status.AnchorString == anchorString {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("status for batch [%s] not found", anchorString)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].UniqueSuffix < statuses[j].UniqueSuffix
+	})
+
+	return statuses, nil
+}
+
+// evict removes the oldest anchored statuses exceeding the maximum.
+func (t *OperationStatusTracker) evict() {
+	for len(t.anchored) > t.maxAnchored {
+		oldest := t.anchored[0]
+		t.anchored = t.anchored[1:]
+
+		// status is removed only if no operation has been submitted for the document since
+		if t.statuses[oldest.UniqueSuffix] == oldest {
+			delete(t.statuses, oldest.UniqueSuffix)
+		}
+	}
+}
+
+type noopStatusTracker struct{}
+
+func (noopStatusTracker) Queued(*operation.QueuedOperation) {}
+
+func (noopStatusTracker) Written(string, []*operation.QueuedOperation) {}
+
+func (noopStatusTracker) Failed(string, []*operation.QueuedOperation) {}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+func TestOperationStatusTracker(t *testing.T) {
+	op1 := &operation.QueuedOperation{UniqueSuffix: "suffix1"}
+	op2 := &operation.QueuedOperation{UniqueSuffix: "suffix2"}
+
+	t.Run("success", func(t *testing.T) {
+		tracker := NewOperationStatusTracker()
+
+		tracker.Queued(op1)
+		tracker.Queued(op2)
+
+		status, err := tracker.Get(op1.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, status.Status)
+		require.Empty(t, status.AnchorString)
+
+		tracker.Written("anchor", []*operation.QueuedOperation{op1, op2})
+
+		statuses, err := tracker.GetByAnchor("anchor")
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		require.Equal(t, op1.UniqueSuffix, statuses[0].UniqueSuffix)
+		require.Equal(t, StatusWritten, statuses[0].Status)
+
+		tracker.Anchored("anchor", 100, 2)
+
+		status, err = tracker.Get(op2.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusAnchored, status.Status)
+		require.Equal(t, "anchor", status.AnchorString)
+		require.Equal(t, uint64(100), status.TransactionTime)
+		require.Equal(t, uint64(2), status.TransactionNumber)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		tracker := NewOperationStatusTracker()
+
+		status, err := tracker.Get("suffix")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+		require.Nil(t, status)
+
+		statuses, err := tracker.GetByAnchor("anchor")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+		require.Nil(t, statuses)
+	})
+
+	t.Run("anchor written by other node", func(t *testing.T) {
+		tracker := NewOperationStatusTracker()
+
+		tracker.Queued(op1)
+		tracker.Anchored("other", 100, 0)
+
+		status, err := tracker.Get(op1.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, status.Status)
+	})
+
+	t.Run("newer operation submitted", func(t *testing.T) {
+		tracker := NewOperationStatusTracker()
+
+		tracker.Queued(op1)
+		tracker.Written("anchor", []*operation.QueuedOperation{op1})
+		tracker.Queued(op1)
+		tracker.Anchored("anchor", 100, 0)
+
+		status, err := tracker.Get(op1.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, status.Status)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		tracker := NewOperationStatusTracker()
+
+		tracker.Queued(op1)
+		tracker.Failed("CAS error", []*operation.QueuedOperation{op1})
+
+		status, err := tracker.Get(op1.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusFailed, status.Status)
+		require.Equal(t, "CAS error", status.Error)
+		require.Empty(t, status.AnchorString)
+	})
+
+	t.Run("anchored statuses evicted", func(t *testing.T) {
+		tracker := NewOperationStatusTracker().WithMaxAnchored(1)
+
+		tracker.Written("anchor1", []*operation.QueuedOperation{op1})
+		tracker.Written("anchor2", []*operation.QueuedOperation{op2})
+
+		tracker.Anchored("anchor1", 100, 0)
+		tracker.Anchored("anchor2", 101, 0)
+
+		_, err := tracker.Get(op1.UniqueSuffix)
+		require.Error(t, err)
+
+		status, err := tracker.Get(op2.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusAnchored, status.Status)
+	})
+}
@@ -11,7 +11,8 @@ SPDX-License-Identifier: Apache-2.0
 // Batch Writer basic flow:
 //
 // 1) accept operations being delivered via Add method
-// 2) 'cut' configurable number of operations into batch files
+// 2) 'cut' configurable number of operations into batch files (operations queued using different protocol
+// versions are cut into separate batches and batch files are created by the respective protocol version)
 // 3) store batch files into CAS (content addressable storage)
 // 4) write the anchor string referencing core index file URI to the underlying blockchain
 package batch
@@ -49,6 +50,9 @@ type process struct {
 	// force indicates that the operation is to be processed
 	// immediately, i.e. don't wait for the batch timeout
 	force bool
+
+	// done (optional) receives number of pending operations after processing
+	done chan uint
 }
 
 // Writer implements batch writer.
@@ -60,7 +64,12 @@ type Writer struct {
 	exitChan     chan struct{}
 	batchTimeout time.Duration
 	stopped      uint32
+	paused       uint32
 	protocol     protocol.Client
+	tracker      StatusTracker
+	deadLetters  DeadLetterQueue
+	maxAttempts  int
+	attempts     int
 }
 
 // Context contains batch writer context.
@@ -103,6 +112,11 @@ func New(namespace string, context Context, options ...Option) (*Writer, error)
 		batchTimeout = rOpts.BatchTimeout
 	}
 
+	var tracker StatusTracker = noopStatusTracker{}
+	if rOpts.StatusTracker != nil {
+		tracker = rOpts.StatusTracker
+	}
+
 	return &Writer{
 		namespace:    namespace,
 		batchCutter:  cutter.New(context.Protocol(), context.OperationQueue()),
@@ -111,6 +125,9 @@ func New(namespace string, context Context, options ...Option) (*Writer, error)
 		batchTimeout: batchTimeout,
 		context:      context,
 		protocol:     context.Protocol(),
+		tracker:      tracker,
+		deadLetters:  rOpts.DeadLetterQueue,
+		maxAttempts:  rOpts.MaxAttempts,
 	}, nil
 }
 
@@ -134,11 +151,79 @@ func (r *Writer) Stop() {
 	}
 }
 
+// Flush forces all pending operations to be cut into batches and anchored (e.g. on shutdown, after new
+// operations are no longer accepted, so that queued operations are not left waiting for the batch timeout).
+// An error is returned if operations are still pending after processing.
+func (r *Writer) Flush() error {
+	if r.Paused() {
+		return errors.New("writer is paused")
+	}
+
+	done := make(chan uint, 1)
+
+	select {
+	case r.sendChan <- process{force: true, done: done}:
+	case <-r.exitChan:
+		return errors.New("writer is stopped")
+	}
+
+	select {
+	case pending := <-done:
+		if pending > 0 {
+			return fmt.Errorf("%d operations are still pending", pending)
+		}
+
+		return nil
+	case <-r.exitChan:
+		return errors.New("writer is stopped")
+	}
+}
+
 // Stopped returns true if the writer has been stopped.
 func (r *Writer) Stopped() bool {
 	return atomic.LoadUint32(&r.stopped) == 1
 }
 
+// Pause stops cutting operations into batches; operations are still accepted and added to the queue
+// (e.g. while the blockchain or CAS is under maintenance).
+func (r *Writer) Pause() {
+	if atomic.CompareAndSwapUint32(&r.paused, 0, 1) {
+		logger.Infof("[%s] batch writer paused", r.namespace)
+	}
+}
+
+// Resume resumes cutting operations into batches (operations that were queued while the writer was paused
+// are processed).
+func (r *Writer) Resume() error {
+	if !atomic.CompareAndSwapUint32(&r.paused, 1, 0) {
+		return nil
+	}
+
+	logger.Infof("[%s] batch writer resumed", r.namespace)
+
+	select {
+	case r.sendChan <- process{force: false}:
+		return nil
+	case <-r.exitChan:
+		return errors.New("writer is stopped")
+	}
+}
+
+// Paused returns true if the writer has been paused.
+func (r *Writer) Paused() bool {
+	return atomic.LoadUint32(&r.paused) == 1
+}
+
+// QueueDepth returns the number of operations waiting in the queue.
+func (r *Writer) QueueDepth() uint {
+	return r.context.OperationQueue().Len()
+}
+
+// PendingOperations returns (up to) the given number of operations from the head of the queue.
+func (r *Writer) PendingOperations(num uint) ([]*operation.QueuedOperationAtTime, error) {
+	return r.context.OperationQueue().Peek(num)
+}
+
 // Add the given operation to a queue of operations to be batched and anchored on blockchain.
 func (r *Writer) Add(op *operation.QueuedOperation, protocolGenesisTime uint64) error {
 	if r.Stopped() {
@@ -150,6 +235,8 @@ func (r *Writer) Add(op *operation.QueuedOperation, protocolGenesisTime uint64)
 		return err
 	}
 
+	r.tracker.Queued(op)
+
 	select {
 	case r.sendChan <- process{force: false}:
 		// Send a notification that an operation was added to the queue
@@ -172,11 +259,35 @@ func (r *Writer) main() {
 		select {
 		case p := <-r.sendChan:
 			logger.Infof("[%s] Handling process notification for batch writer: %v", r.namespace, p)
-			pending := r.processAvailable(p.force) > 0
-			timer = r.handleTimer(timer, pending)
+
+			if r.Paused() {
+				logger.Debugf("[%s] Batch writer is paused", r.namespace)
+
+				timer = nil
+
+				if p.done != nil {
+					p.done <- r.QueueDepth()
+				}
+
+				continue
+			}
+
+			pending := r.processAvailable(p.force)
+			timer = r.handleTimer(timer, pending > 0)
+
+			if p.done != nil {
+				p.done <- pending
+			}
 
 		case <-timer:
 			logger.Infof("[%s] Handling batch writer timeout", r.namespace)
+
+			if r.Paused() {
+				timer = nil
+
+				continue
+			}
+
 			pending := r.processAvailable(true) > 0
 			timer = r.handleTimer(nil, pending)
 
@@ -255,10 +366,14 @@ func (r *Writer) cutAndProcess(forceCut bool) (numProcessed int, pending uint, e
 	if err != nil {
 		logger.Errorf("[%s] Error processing %d batch operations: %s", r.namespace, len(result.Operations), err)
 
-		return 0, result.Pending + uint(len(result.Operations)), err
-	}
+		if !r.moveToDeadLetters(result, err) {
+			return 0, result.Pending + uint(len(result.Operations)), err
+		}
+	} else {
+		r.attempts = 0
 
-	logger.Infof("[%s] Successfully processed %d batch operations. Committing to batch cutter ...", r.namespace, len(result.Operations))
+		logger.Infof("[%s] Successfully processed %d batch operations. Committing to batch cutter ...", r.namespace, len(result.Operations))
+	}
 
 	pending, err = result.Commit()
 	if err != nil {
@@ -273,6 +388,36 @@ func (r *Writer) cutAndProcess(forceCut bool) (numProcessed int, pending uint, e
 	return len(result.Operations), pending, nil
 }
 
+// moveToDeadLetters moves the batch to dead-letter queue if processing of the batch failed the maximum number
+// of times; true is returned if the batch was moved (and removed from the operation queue).
+func (r *Writer) moveToDeadLetters(result cutter.Result, processErr error) bool {
+	if r.deadLetters == nil || r.maxAttempts <= 0 {
+		return false
+	}
+
+	r.attempts++
+
+	if r.attempts < r.maxAttempts {
+		return false
+	}
+
+	logger.Warnf("[%s] Moving %d operations to dead-letter queue after %d attempts", r.namespace,
+		len(result.Operations), r.attempts)
+
+	r.deadLetters.Add(&FailedBatch{
+		Operations:          result.Operations,
+		ProtocolGenesisTime: result.ProtocolGenesisTime,
+		Attempts:            r.attempts,
+		Error:               processErr.Error(),
+	})
+
+	r.tracker.Failed(processErr.Error(), result.Operations)
+
+	r.attempts = 0
+
+	return true
+}
+
 func (r *Writer) process(ops []*operation.QueuedOperation, protocolGenesisTime uint64) error {
 	if len(ops) == 0 {
 		return errors.New("create batch called with no pending operations, should not happen")
@@ -291,7 +436,14 @@ func (r *Writer) process(ops []*operation.QueuedOperation, protocolGenesisTime u
 	logger.Infof("[%s] writing anchor string: %s", r.namespace, anchorString)
 
 	// Create Sidetree transaction in blockchain (write anchor string)
-	return r.context.Blockchain().WriteAnchor(anchorString, protocolGenesisTime)
+	err = r.context.Blockchain().WriteAnchor(anchorString, protocolGenesisTime)
+	if err != nil {
+		return err
+	}
+
+	r.tracker.Written(anchorString, ops)
+
+	return nil
 }
 
 func (r *Writer) handleTimer(timer <-chan time.Time, pending bool) <-chan time.Time {
@@ -319,9 +471,37 @@ func WithBatchTimeout(batchTimeout time.Duration) Option {
 	}
 }
 
+// WithStatusTracker allows for specifying tracker that is notified as operations are queued, written and failed
+// (moved to the dead-letter queue).
+func WithStatusTracker(tracker StatusTracker) Option {
+	return func(o *Options) error {
+		o.StatusTracker = tracker
+
+		return nil
+	}
+}
+
+// WithDeadLetterQueue allows for specifying queue that receives batches of operations that failed processing
+// maxAttempts times in a row (by default failed batches remain in the operation queue and are retried).
+func WithDeadLetterQueue(queue DeadLetterQueue, maxAttempts int) Option {
+	return func(o *Options) error {
+		if maxAttempts <= 0 {
+			return errors.New("max attempts must be greater than zero")
+		}
+
+		o.DeadLetterQueue = queue
+		o.MaxAttempts = maxAttempts
+
+		return nil
+	}
+}
+
 // Options allows the user to specify more advanced options.
 type Options struct {
-	BatchTimeout time.Duration
+	BatchTimeout    time.Duration
+	StatusTracker   StatusTracker
+	DeadLetterQueue DeadLetterQueue
+	MaxAttempts     int
 }
 
 // prepareOptsFromOptions reads options.
@@ -97,6 +97,52 @@ func TestStart(t *testing.T) {
 	require.Equal(t, 2, len(cf.Deltas))
 }
 
+func TestStatusTracker(t *testing.T) {
+	ctx := newMockContext()
+
+	tracker := NewOperationStatusTracker()
+
+	writer, err := New(namespace, ctx, WithStatusTracker(tracker))
+	require.Nil(t, err)
+
+	operations := generateOperations(2)
+
+	for _, op := range operations {
+		err = writer.Add(op, 0)
+		require.Nil(t, err)
+
+		status, err := tracker.Get(op.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, status.Status)
+	}
+
+	writer.Start()
+	defer writer.Stop()
+
+	time.Sleep(time.Second)
+
+	anchors := ctx.BlockchainClient.GetAnchors()
+	require.Equal(t, 1, len(anchors))
+
+	for _, op := range operations {
+		status, err := tracker.Get(op.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusWritten, status.Status)
+		require.Equal(t, anchors[0], status.AnchorString)
+	}
+
+	tracker.Anchored(anchors[0], 10, 1)
+
+	statuses, err := tracker.GetByAnchor(anchors[0])
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	for _, status := range statuses {
+		require.Equal(t, StatusAnchored, status.Status)
+		require.Equal(t, uint64(10), status.TransactionTime)
+	}
+}
+
 func getBatchFiles(cc cas.Client, anchor string) (*models.CoreIndexFile, *models.ProvisionalIndexFile, *models.ChunkFile, error) { //nolint: interfacer
 	bytes, err := cc.Read(anchor)
 	if err != nil {
@@ -266,6 +312,155 @@ func TestBlockchainError(t *testing.T) {
 	require.Equal(t, 0, len(ctx.BlockchainClient.GetAnchors()))
 }
 
+func TestFlush(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx := newMockContext()
+		writer, err := New(namespace, ctx, WithBatchTimeout(time.Minute))
+		require.Nil(t, err)
+
+		writer.Start()
+		defer writer.Stop()
+
+		// wait for startup processing
+		time.Sleep(100 * time.Millisecond)
+
+		operations := generateOperations(3)
+		for _, op := range operations {
+			err = writer.Add(op, 0)
+			require.Nil(t, err)
+		}
+
+		require.NoError(t, writer.Flush())
+		require.Equal(t, 2, len(ctx.BlockchainClient.GetAnchors()))
+	})
+
+	t.Run("error - operations pending", func(t *testing.T) {
+		ctx := newMockContext()
+		ctx.ProtocolClient.CasClient.SetError(fmt.Errorf("CAS Error"))
+
+		writer, err := New(namespace, ctx, WithBatchTimeout(time.Minute))
+		require.Nil(t, err)
+
+		writer.Start()
+		defer writer.Stop()
+
+		err = writer.Add(generateOperations(1)[0], 0)
+		require.Nil(t, err)
+
+		err = writer.Flush()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "operations are still pending")
+	})
+
+	t.Run("error - writer stopped", func(t *testing.T) {
+		writer, err := New(namespace, newMockContext())
+		require.Nil(t, err)
+
+		writer.Stop()
+
+		require.EqualError(t, writer.Flush(), "writer is stopped")
+	})
+}
+
+func TestPauseResume(t *testing.T) {
+	ctx := newMockContext()
+	writer, err := New(namespace, ctx, WithBatchTimeout(100*time.Millisecond))
+	require.Nil(t, err)
+
+	writer.Start()
+	defer writer.Stop()
+
+	// wait for startup processing
+	time.Sleep(100 * time.Millisecond)
+
+	writer.Pause()
+	require.True(t, writer.Paused())
+
+	operations := generateOperations(3)
+	for _, op := range operations {
+		err = writer.Add(op, 0)
+		require.Nil(t, err)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	require.Equal(t, 0, len(ctx.BlockchainClient.GetAnchors()))
+	require.Equal(t, uint(3), writer.QueueDepth())
+
+	pending, err := writer.PendingOperations(2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, operations[0].UniqueSuffix, pending[0].UniqueSuffix)
+
+	require.EqualError(t, writer.Flush(), "writer is paused")
+
+	require.NoError(t, writer.Resume())
+	require.False(t, writer.Paused())
+
+	require.NoError(t, writer.Flush())
+	require.Equal(t, 2, len(ctx.BlockchainClient.GetAnchors()))
+	require.Equal(t, uint(0), writer.QueueDepth())
+
+	// resume is no-op if writer is not paused
+	require.NoError(t, writer.Resume())
+}
+
+func TestDeadLetterQueue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx := newMockContext()
+		ctx.ProtocolClient.CasClient.SetError(fmt.Errorf("CAS Error"))
+
+		deadLetters := NewMemDeadLetterQueue()
+		tracker := NewOperationStatusTracker()
+
+		writer, err := New(namespace, ctx, WithBatchTimeout(time.Minute), WithDeadLetterQueue(deadLetters, 2),
+			WithStatusTracker(tracker))
+		require.Nil(t, err)
+
+		writer.Start()
+		defer writer.Stop()
+
+		// wait for startup processing
+		time.Sleep(100 * time.Millisecond)
+
+		op := generateOperations(1)[0]
+
+		err = writer.Add(op, 0)
+		require.Nil(t, err)
+
+		// first attempt fails and the operation remains in the queue
+		require.Error(t, writer.Flush())
+		require.Empty(t, deadLetters.DeadLetters())
+
+		status, err := tracker.Get(op.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusQueued, status.Status)
+
+		// second attempt fails and the operation is moved to dead-letter queue
+		require.NoError(t, writer.Flush())
+		require.Equal(t, uint(0), writer.QueueDepth())
+
+		failed := deadLetters.DeadLetters()
+		require.Len(t, failed, 1)
+		require.Len(t, failed[0].Operations, 1)
+		require.Equal(t, 2, failed[0].Attempts)
+		require.Contains(t, failed[0].Error, "CAS Error")
+		require.NotZero(t, failed[0].Failed)
+
+		status, err = tracker.Get(op.UniqueSuffix)
+		require.NoError(t, err)
+		require.Equal(t, StatusFailed, status.Status)
+		require.Contains(t, status.Error, "CAS Error")
+	})
+
+	t.Run("error - invalid max attempts", func(t *testing.T) {
+		writer, err := New(namespace, newMockContext(), WithDeadLetterQueue(NewMemDeadLetterQueue(), 0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "max attempts must be greater than zero")
+		require.Nil(t, writer)
+	})
+}
+
 func TestAddAfterStop(t *testing.T) {
 	writer, err := New(namespace, newMockContext())
 	require.Nil(t, err)
@@ -356,6 +551,64 @@ func TestStartWithExistingItems(t *testing.T) {
 	require.Equal(t, numBatchesExpected, len(ctx.BlockchainClient.GetAnchors()))
 }
 
+func TestProtocolVersionTransition(t *testing.T) {
+	opQueue := &opqueue.MemQueue{}
+
+	ctx := newMockContext()
+	ctx.OpQueue = opQueue
+
+	p1 := ctx.ProtocolClient.Protocol
+	p1.MaxOperationCount = 10
+
+	p2 := p1
+	p2.GenesisTime = 100
+
+	handler1 := &mocks.OperationHandler{}
+	handler1.PrepareTxnFilesReturns("anchor1", nil)
+
+	handler2 := &mocks.OperationHandler{}
+	handler2.PrepareTxnFilesReturns("anchor2", nil)
+
+	v1 := mocks.GetProtocolVersion(p1)
+	v1.OperationHandlerReturns(handler1)
+
+	v2 := mocks.GetProtocolVersion(p2)
+	v2.OperationHandlerReturns(handler2)
+
+	ctx.ProtocolClient.Versions = []*mocks.ProtocolVersion{v1, v2}
+	ctx.ProtocolClient.CurrentVersion = v2
+
+	operations := generateOperations(5)
+
+	// operations queued before and after protocol upgrade
+	for i, op := range operations {
+		genesisTime := p1.GenesisTime
+		if i >= 3 {
+			genesisTime = p2.GenesisTime
+		}
+
+		_, err := opQueue.Add(op, genesisTime)
+		require.NoError(t, err)
+	}
+
+	writer, err := New(namespace, ctx, WithBatchTimeout(time.Minute))
+	require.NoError(t, err)
+
+	writer.Start()
+	defer writer.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// each protocol version has its own batch (anchor)
+	require.Equal(t, []string{"anchor1", "anchor2"}, ctx.BlockchainClient.GetAnchors())
+
+	require.Equal(t, 1, handler1.PrepareTxnFilesCallCount())
+	require.Equal(t, operations[:3], handler1.PrepareTxnFilesArgsForCall(0))
+
+	require.Equal(t, 1, handler2.PrepareTxnFilesCallCount())
+	require.Equal(t, operations[3:], handler2.PrepareTxnFilesArgsForCall(0))
+}
+
 func TestProcessError(t *testing.T) {
 	t.Run("process operation error", func(t *testing.T) {
 		q := &mocks.OperationQueue{}
@@ -7,17 +7,36 @@ SPDX-License-Identifier: Apache-2.0
 package canonicalizer
 
 import (
+	"bytes"
 	"encoding/json"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/bufferpool"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/jsoncanonicalizer"
 )
 
 // MarshalCanonical is using JCS RFC canonicalization.
 func MarshalCanonical(value interface{}) ([]byte, error) {
-	jsonLiteralValByte, err := json.Marshal(value)
+	jsonLiteralValBuf, err := marshal(value)
 	if err != nil {
 		return nil, err
 	}
 
-	return jsoncanonicalizer.Transform(jsonLiteralValByte)
+	defer bufferpool.Put(jsonLiteralValBuf)
+
+	return jsoncanonicalizer.Transform(jsonLiteralValBuf.Bytes())
+}
+
+// marshal marshals value into buffer from the pool (marshalled value is only needed until it is canonicalized).
+// Buffer has to be returned to the pool by the caller.
+func marshal(value interface{}) (*bytes.Buffer, error) {
+	buf := bufferpool.Get()
+
+	// encoder produces the same output as json.Marshal (followed by new line which is ignored by canonicalization)
+	if err := json.NewEncoder(buf).Encode(value); err != nil {
+		bufferpool.Put(buf)
+
+		return nil, err
+	}
+
+	return buf, nil
 }
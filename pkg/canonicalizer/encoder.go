@@ -0,0 +1,475 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package canonicalizer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf16"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/bufferpool"
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/jsoncanonicalizer"
+)
+
+// MarshalCanonicalTo is using JCS RFC canonicalization and writes canonical JSON of the value to w.
+// Unlike MarshalCanonical the canonical output is produced iteratively from the marshalled value (object members
+// are sorted by reference into the marshalled value) so the canonical representation is never built in memory;
+// it should be used for large payloads (e.g. batch files) where w streams the output further.
+func MarshalCanonicalTo(w io.Writer, value interface{}) error {
+	jsonLiteralValBuf, err := marshal(value)
+	if err != nil {
+		return err
+	}
+
+	defer bufferpool.Put(jsonLiteralValBuf)
+
+	return TransformTo(w, jsonLiteralValBuf.Bytes())
+}
+
+// TransformTo writes JCS canonicalization of JSON data to w. As with MarshalCanonical JSON data has to be
+// an object or an array.
+func TransformTo(w io.Writer, jsonData []byte) error {
+	bw := getWriter(w)
+	defer putWriter(bw)
+
+	e := &encoder{data: jsonData, w: bw}
+
+	e.skipWhiteSpace()
+
+	if e.index < len(e.data) && e.data[e.index] != '{' && e.data[e.index] != '[' {
+		// report the same error as MarshalCanonical
+		_, err := jsoncanonicalizer.Transform(jsonData)
+
+		return err
+	}
+
+	if err := e.encodeValue(); err != nil {
+		return err
+	}
+
+	e.skipWhiteSpace()
+
+	if e.index < len(e.data) {
+		return errors.New("improperly terminated JSON value")
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.w.Flush()
+}
+
+// nolint:gochecknoglobals
+var writerPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewWriter(nil)
+	},
+}
+
+func getWriter(w io.Writer) *bufio.Writer {
+	bw, ok := writerPool.Get().(*bufio.Writer)
+	if !ok {
+		return bufio.NewWriter(w)
+	}
+
+	bw.Reset(w)
+
+	return bw
+}
+
+func putWriter(bw *bufio.Writer) {
+	// release the underlying writer
+	bw.Reset(nil)
+	writerPool.Put(bw)
+}
+
+type member struct {
+	name    string
+	sortKey []uint16
+	start   int
+	end     int
+}
+
+type encoder struct {
+	data  []byte
+	index int
+	w     *bufio.Writer
+	err   error
+}
+
+func (e *encoder) encodeValue() error {
+	if e.index >= len(e.data) {
+		return errors.New("unexpected end of JSON data")
+	}
+
+	switch c := e.data[e.index]; {
+	case c == '{':
+		return e.encodeObject()
+	case c == '[':
+		return e.encodeArray()
+	case c == '"':
+		value, err := e.readString()
+		if err != nil {
+			return err
+		}
+
+		e.writeJSONString(value)
+
+		return nil
+	default:
+		return e.encodeLiteral()
+	}
+}
+
+// encodeObject collects object members (names and positions of their values), sorts them on UTF-16 code units
+// of their names and then encodes member values in that order.
+func (e *encoder) encodeObject() error {
+	var members []member
+
+	e.index++
+	e.skipWhiteSpace()
+
+	for e.index < len(e.data) && e.data[e.index] != '}' {
+		if len(members) > 0 {
+			if err := e.expect(','); err != nil {
+				return err
+			}
+		}
+
+		name, err := e.readString()
+		if err != nil {
+			return err
+		}
+
+		if err := e.expect(':'); err != nil {
+			return err
+		}
+
+		m := member{name: name, sortKey: utf16.Encode([]rune(name)), start: e.index}
+
+		if err := e.skipValue(); err != nil {
+			return err
+		}
+
+		e.skipWhiteSpace()
+
+		m.end = e.index
+		members = append(members, m)
+	}
+
+	if err := e.expect('}'); err != nil {
+		return err
+	}
+
+	end := e.index
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return lessUTF16(members[i].sortKey, members[j].sortKey)
+	})
+
+	e.writeByte('{')
+
+	for i, m := range members {
+		if i > 0 {
+			if equalUTF16(members[i-1].sortKey, m.sortKey) {
+				return fmt.Errorf("duplicate key: %s", m.name)
+			}
+
+			e.writeByte(',')
+		}
+
+		e.writeJSONString(m.name)
+		e.writeByte(':')
+
+		e.index = m.start
+
+		if err := e.encodeValue(); err != nil {
+			return err
+		}
+
+		e.skipWhiteSpace()
+
+		if e.index != m.end {
+			return fmt.Errorf("invalid value of member '%s'", m.name)
+		}
+	}
+
+	e.writeByte('}')
+
+	e.index = end
+
+	return nil
+}
+
+func (e *encoder) encodeArray() error {
+	e.index++
+	e.skipWhiteSpace()
+
+	e.writeByte('[')
+
+	for next := false; e.index < len(e.data) && e.data[e.index] != ']'; next = true {
+		if next {
+			if err := e.expect(','); err != nil {
+				return err
+			}
+
+			e.writeByte(',')
+		}
+
+		if err := e.encodeValue(); err != nil {
+			return err
+		}
+
+		e.skipWhiteSpace()
+	}
+
+	if err := e.expect(']'); err != nil {
+		return err
+	}
+
+	e.writeByte(']')
+
+	return nil
+}
+
+func (e *encoder) encodeLiteral() error {
+	token := e.readLiteral()
+
+	switch token {
+	case "":
+		return errors.New("missing JSON value")
+	case "true", "false", "null":
+		e.writeString(token)
+
+		return nil
+	}
+
+	// apparently not a JSON literal so we assume that it is a I-JSON number
+	ieeeF64, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return err
+	}
+
+	value, err := jsoncanonicalizer.NumberToJSON(ieeeF64)
+	if err != nil {
+		return err
+	}
+
+	e.writeString(value)
+
+	return nil
+}
+
+// skipValue moves past the value at the current position without encoding it.
+func (e *encoder) skipValue() error {
+	depth := 0
+
+	for e.index < len(e.data) {
+		switch e.data[e.index] {
+		case '"':
+			if err := e.skipString(); err != nil {
+				return err
+			}
+		case '{', '[':
+			depth++
+			e.index++
+		case '}', ']':
+			if depth == 0 {
+				return nil
+			}
+
+			depth--
+			e.index++
+		case ',':
+			if depth == 0 {
+				return nil
+			}
+
+			e.index++
+		default:
+			e.index++
+		}
+
+		if depth == 0 && e.isValueEnd() {
+			return nil
+		}
+	}
+
+	if depth > 0 {
+		return errors.New("unexpected end of JSON data")
+	}
+
+	return nil
+}
+
+func (e *encoder) isValueEnd() bool {
+	if e.index >= len(e.data) {
+		return true
+	}
+
+	switch e.data[e.index] {
+	case ',', '}', ']', ' ', '\t', '\n', '\r':
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *encoder) skipString() error {
+	for i := e.index + 1; i < len(e.data); i++ {
+		switch e.data[i] {
+		case '\\':
+			i++
+		case '"':
+			e.index = i + 1
+
+			return nil
+		}
+	}
+
+	return errors.New("unterminated string literal")
+}
+
+// readString decodes the string at the current position.
+func (e *encoder) readString() (string, error) {
+	e.skipWhiteSpace()
+
+	if e.index >= len(e.data) || e.data[e.index] != '"' {
+		return "", errors.New("expected string")
+	}
+
+	start := e.index
+
+	if err := e.skipString(); err != nil {
+		return "", err
+	}
+
+	var value string
+	if err := json.Unmarshal(e.data[start:e.index], &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+func (e *encoder) readLiteral() string {
+	start := e.index
+
+	for e.index < len(e.data) && !e.isValueEnd() {
+		e.index++
+	}
+
+	return string(e.data[start:e.index])
+}
+
+func (e *encoder) expect(c byte) error {
+	e.skipWhiteSpace()
+
+	if e.index >= len(e.data) {
+		return errors.New("unexpected end of JSON data")
+	}
+
+	if e.data[e.index] != c {
+		return fmt.Errorf("expected '%c' but got '%c'", c, e.data[e.index])
+	}
+
+	e.index++
+	e.skipWhiteSpace()
+
+	return nil
+}
+
+func (e *encoder) skipWhiteSpace() {
+	for e.index < len(e.data) {
+		switch e.data[e.index] {
+		case ' ', '\t', '\n', '\r':
+			e.index++
+		default:
+			return
+		}
+	}
+}
+
+// writeJSONString writes string using JCS escaping rules (JSON standard escapes and \u00hh for other control
+// characters).
+func (e *encoder) writeJSONString(value string) {
+	const hex = "0123456789abcdef"
+
+	e.writeByte('"')
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch c {
+		case '\\', '"':
+			e.writeByte('\\')
+			e.writeByte(c)
+		case '\b':
+			e.writeString(`\b`)
+		case '\f':
+			e.writeString(`\f`)
+		case '\n':
+			e.writeString(`\n`)
+		case '\r':
+			e.writeString(`\r`)
+		case '\t':
+			e.writeString(`\t`)
+		default:
+			if c < 0x20 {
+				e.writeString(`\u00` + string(hex[c>>4]) + string(hex[c&0xf]))
+			} else {
+				e.writeByte(c)
+			}
+		}
+	}
+
+	e.writeByte('"')
+}
+
+// writeByte and writeString record the first write error; no more data is written after an error.
+func (e *encoder) writeByte(c byte) {
+	if e.err == nil {
+		e.err = e.w.WriteByte(c)
+	}
+}
+
+func (e *encoder) writeString(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+func lessUTF16(a, b []uint16) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+func equalUTF16(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
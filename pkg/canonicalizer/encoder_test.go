@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package canonicalizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCanonicalTo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		test := struct {
+			Beta  string `json:"beta"`
+			Alpha string `json:"alpha"`
+		}{
+			Beta:  "beta",
+			Alpha: "alpha",
+		}
+
+		var buf bytes.Buffer
+		err := MarshalCanonicalTo(&buf, test)
+		require.NoError(t, err)
+		require.Equal(t, `{"alpha":"alpha","beta":"beta"}`, buf.String())
+	})
+
+	t.Run("same as MarshalCanonical", func(t *testing.T) {
+		values := []interface{}{
+			map[string]interface{}{},
+			[]interface{}{},
+			map[string]interface{}{
+				"numbers":    []interface{}{333333333.33333329, 1e30, 4.50, 2e-3, 0.000000000000000000000000001, -0, 10, -1.5},
+				"string":     "€$\u000F\u000aA'B\"\\\\\"/<>& \u2028",
+				"literals":   []interface{}{nil, true, false},
+				"€":          "Euro Sign",
+				"\r":         "Carriage Return",
+				"דּ":          "Hebrew Letter Dalet With Dagesh",
+				"1":          "One",
+				"\U0001f600": "Emoji: Grinning Face",
+				"\u0080":     "Control",
+				"ö":          "Latin Small Letter O With Diaeresis",
+			},
+			[]interface{}{
+				map[string]interface{}{"b": []interface{}{map[string]interface{}{"d": 1, "c": nil}}, "a": map[string]interface{}{}},
+				"text",
+				[]interface{}{[]interface{}{}, map[string]interface{}{"z": "", "y": []interface{}{1, 2}}},
+			},
+			getLargeValue(),
+		}
+
+		for _, value := range values {
+			expected, err := MarshalCanonical(value)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			err = MarshalCanonicalTo(&buf, value)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), buf.String())
+		}
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		var c chan int
+
+		var buf bytes.Buffer
+		err := MarshalCanonicalTo(&buf, c)
+		require.Error(t, err)
+		require.Empty(t, buf.Bytes())
+		require.Contains(t, err.Error(), "json: unsupported type: chan int")
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		err := MarshalCanonicalTo(&mockWriter{Err: errors.New("write error")}, getLargeValue())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "write error")
+	})
+}
+
+func TestTransformTo(t *testing.T) {
+	t.Run("success - whitespace", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := TransformTo(&buf, []byte(" { \"b\" : [ 1 , 2.0 , \"x\" ] ,\n\t\"a\" : { } } "))
+		require.NoError(t, err)
+		require.Equal(t, `{"a":{},"b":[1,2,"x"]}`, buf.String())
+	})
+
+	t.Run("error - invalid JSON", func(t *testing.T) {
+		tests := map[string]string{
+			`{"a":1`:        "unexpected end of JSON data",
+			`{"a":1,"a":2}`: "duplicate key: a",
+			`{"a":}`:        "missing JSON value",
+			`{"a" 1}`:       "expected ':' but got '1'",
+			`{a:1}`:         "expected string",
+			`{"a":{}x}`:     "invalid value of member 'a'",
+			`{"a":"b}`:      "unterminated string literal",
+			`[1 2]`:         "expected ',' but got '2'",
+			`[1,2]x`:        "improperly terminated JSON value",
+			`[abc]`:         "invalid syntax",
+			`["\x"]`:        "invalid",
+			``:              "unexpected end of JSON data",
+			`"abc"`:         "Expected '{' but got '\"'",
+		}
+
+		for data, expected := range tests {
+			var buf bytes.Buffer
+			err := TransformTo(&buf, []byte(data))
+			require.Error(t, err, data)
+			require.Contains(t, err.Error(), expected, data)
+		}
+	})
+}
+
+func getLargeValue() interface{} {
+	var deltas []interface{}
+
+	for i := 0; i < 1000; i++ {
+		deltas = append(deltas, map[string]interface{}{
+			"updateCommitment": fmt.Sprintf("commitment-%d", i),
+			"patches": []interface{}{
+				map[string]interface{}{
+					"action":   "add-services",
+					"services": []interface{}{map[string]interface{}{"type": "type", "id": fmt.Sprintf("svc-%d", i)}},
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{"deltas": deltas}
+}
+
+type mockWriter struct {
+	Err error
+}
+
+func (w *mockWriter) Write([]byte) (int, error) {
+	return 0, w.Err
+}
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package commitment
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
 
 	"github.com/trustbloc/edge-core/pkg/log"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
@@ -70,3 +73,60 @@ func GetCommitmentFromRevealValue(rv string) (string, error) {
 
 	return encoder.EncodeToString(multiHash), nil
 }
+
+// GetHashAlgorithm returns multihash code and corresponding hash function that are used for commitments
+// according to protocol parameters (the first of protocol multihash algorithms, the same algorithm that is used
+// for unique suffix, see docutil.CalculateUniqueSuffix).
+func GetHashAlgorithm(p protocol.Protocol) (uint, crypto.Hash, error) {
+	if len(p.MultihashAlgorithms) == 0 {
+		return 0, 0, errors.New("protocol doesn't define multihash algorithms")
+	}
+
+	multihashCode := p.MultihashAlgorithms[0]
+
+	hash, err := hashing.GetHashFromMultihash(multihashCode)
+	if err != nil {
+		return 0, 0, fmt.Errorf("multihash algorithm %d: %s", multihashCode, err.Error())
+	}
+
+	if !hash.Available() {
+		return 0, 0, fmt.Errorf("hash function for multihash algorithm %d is not available", multihashCode)
+	}
+
+	return multihashCode, hash, nil
+}
+
+// GetProtocolCommitment will calculate commitment from JWK using hash algorithm selected from protocol parameters.
+func GetProtocolCommitment(jwk *jws.JWK, p protocol.Protocol) (string, error) {
+	multihashCode, _, err := GetHashAlgorithm(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commitment: %s", err.Error())
+	}
+
+	return GetCommitment(jwk, multihashCode)
+}
+
+// GetProtocolRevealValue will calculate reveal value from JWK using hash algorithm selected from protocol parameters.
+func GetProtocolRevealValue(jwk *jws.JWK, p protocol.Protocol) (string, error) {
+	multihashCode, _, err := GetHashAlgorithm(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to get reveal value: %s", err.Error())
+	}
+
+	return GetRevealValue(jwk, multihashCode)
+}
+
+// ValidateProtocolCommitment checks that commitment (or reveal value) has been calculated using one of the
+// multihash algorithms allowed by protocol parameters.
+func ValidateProtocolCommitment(value string, p protocol.Protocol) error {
+	code, err := hashing.GetMultihashCode(value)
+	if err != nil {
+		return err
+	}
+
+	if !hashing.IsComputedUsingMultihashAlgorithms(value, p.MultihashAlgorithms) {
+		return fmt.Errorf("multihash algorithm %d is not allowed by protocol", code)
+	}
+
+	return nil
+}
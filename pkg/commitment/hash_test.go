@@ -7,16 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package commitment
 
 import (
+	"crypto"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
 
 const (
 	sha2_256 uint = 18 // multihash code
+	sha2_512 uint = 19 // multihash code
+	sha3_256 uint = 22 // multihash code
 )
 
 func TestGetCommitment(t *testing.T) {
@@ -112,3 +118,102 @@ func TestGetCommitmentFromRevealValue(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to get commitment from reveal value")
 	})
 }
+
+func TestGetHashAlgorithm(t *testing.T) {
+	t.Run("success - first algorithm", func(t *testing.T) {
+		code, hash, err := GetHashAlgorithm(protocol.Protocol{MultihashAlgorithms: []uint{sha2_256, sha3_256}})
+		require.NoError(t, err)
+		require.Equal(t, sha2_256, code)
+		require.Equal(t, crypto.SHA256, hash)
+
+		code, hash, err = GetHashAlgorithm(protocol.Protocol{MultihashAlgorithms: []uint{sha3_256, sha2_256}})
+		require.NoError(t, err)
+		require.Equal(t, sha3_256, code)
+		require.Equal(t, crypto.SHA3_256, hash)
+
+		code, hash, err = GetHashAlgorithm(protocol.Protocol{MultihashAlgorithms: []uint{sha2_512}})
+		require.NoError(t, err)
+		require.Equal(t, sha2_512, code)
+		require.Equal(t, crypto.SHA512, hash)
+	})
+
+	t.Run("success - same algorithm as unique suffix", func(t *testing.T) {
+		algs := []uint{sha3_256, sha2_256}
+
+		code, _, err := GetHashAlgorithm(protocol.Protocol{MultihashAlgorithms: algs})
+		require.NoError(t, err)
+
+		suffix, err := docutil.CalculateUniqueSuffix(map[string]string{"key": "value"}, algs)
+		require.NoError(t, err)
+
+		suffixCode, err := hashing.GetMultihashCode(suffix)
+		require.NoError(t, err)
+		require.Equal(t, uint64(code), suffixCode)
+	})
+
+	t.Run("error - missing algorithms", func(t *testing.T) {
+		_, _, err := GetHashAlgorithm(protocol.Protocol{})
+		require.EqualError(t, err, "protocol doesn't define multihash algorithms")
+	})
+
+	t.Run("error - algorithm not supported", func(t *testing.T) {
+		_, _, err := GetHashAlgorithm(protocol.Protocol{MultihashAlgorithms: []uint{55}})
+		require.EqualError(t, err, "multihash algorithm 55: algorithm not supported, unable to compute hash")
+	})
+}
+
+func TestGetProtocolCommitment(t *testing.T) {
+	jwk := &jws.JWK{
+		Crv: "crv",
+		Kty: "kty",
+		X:   "x",
+		Y:   "y",
+	}
+
+	p := protocol.Protocol{MultihashAlgorithms: []uint{sha2_256, sha3_256}}
+
+	t.Run("success", func(t *testing.T) {
+		c, err := GetProtocolCommitment(jwk, p)
+		require.NoError(t, err)
+
+		expected, err := GetCommitment(jwk, sha2_256)
+		require.NoError(t, err)
+		require.Equal(t, expected, c)
+
+		code, err := hashing.GetMultihashCode(c)
+		require.NoError(t, err)
+		require.Equal(t, uint64(sha2_256), code)
+
+		rv, err := GetProtocolRevealValue(jwk, p)
+		require.NoError(t, err)
+
+		cFromRv, err := GetCommitmentFromRevealValue(rv)
+		require.NoError(t, err)
+		require.Equal(t, c, cFromRv)
+
+		require.NoError(t, ValidateProtocolCommitment(c, p))
+		require.NoError(t, ValidateProtocolCommitment(rv, p))
+	})
+
+	t.Run("error - invalid protocol", func(t *testing.T) {
+		c, err := GetProtocolCommitment(jwk, protocol.Protocol{})
+		require.EqualError(t, err, "failed to get commitment: protocol doesn't define multihash algorithms")
+		require.Empty(t, c)
+
+		rv, err := GetProtocolRevealValue(jwk, protocol.Protocol{})
+		require.EqualError(t, err, "failed to get reveal value: protocol doesn't define multihash algorithms")
+		require.Empty(t, rv)
+	})
+
+	t.Run("error - commitment algorithm not allowed", func(t *testing.T) {
+		c, err := GetCommitment(jwk, sha2_512)
+		require.NoError(t, err)
+
+		err = ValidateProtocolCommitment(c, p)
+		require.EqualError(t, err, "multihash algorithm 19 is not allowed by protocol")
+
+		err = ValidateProtocolCommitment("commitment", p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get decoded multihash")
+	})
+}
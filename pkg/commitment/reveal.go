@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commitment
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// VerifyRevealValue verifies that reveal value has been calculated from JWK (reveal value is multihash of canonicalized
+// JWK; multihash algorithm of the reveal value is used).
+func VerifyRevealValue(jwk *jws.JWK, rv string) error {
+	code, err := hashing.GetMultihashCode(rv)
+	if err != nil {
+		return fmt.Errorf("failed to verify reveal value: %s", err.Error())
+	}
+
+	expected, err := GetRevealValue(jwk, uint(code))
+	if err != nil {
+		return err
+	}
+
+	if !equal(expected, rv) {
+		return errors.New("reveal value doesn't match JWK")
+	}
+
+	return nil
+}
+
+// VerifyCommitment verifies that reveal value matches commitment (commitment is multihash of reveal value digest,
+// i.e. canonicalized JWK is hashed twice).
+func VerifyCommitment(rv, c string) error {
+	expected, err := GetCommitmentFromRevealValue(rv)
+	if err != nil {
+		return err
+	}
+
+	if !equal(expected, c) {
+		return errors.New("reveal value doesn't match commitment")
+	}
+
+	return nil
+}
+
+// MatchesJWK returns true if commitment has been calculated from JWK (multihash algorithm of the commitment is used),
+// e.g. to prevent re-using keys for the next commitment.
+func MatchesJWK(jwk *jws.JWK, c string) (bool, error) {
+	code, err := hashing.GetMultihashCode(c)
+	if err != nil {
+		return false, err
+	}
+
+	expected, err := GetCommitment(jwk, uint(code))
+	if err != nil {
+		return false, err
+	}
+
+	return equal(expected, c), nil
+}
+
+// equal compares values in constant time.
+func equal(expected, actual string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1
+}
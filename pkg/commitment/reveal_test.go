@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commitment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+func TestVerifyRevealValue(t *testing.T) {
+	jwk := &jws.JWK{Crv: "crv", Kty: "kty", X: "x", Y: "y"}
+
+	t.Run("success", func(t *testing.T) {
+		for _, code := range []uint{sha2_256, sha2_512, sha3_256} {
+			rv, err := GetRevealValue(jwk, code)
+			require.NoError(t, err)
+
+			require.NoError(t, VerifyRevealValue(jwk, rv))
+		}
+	})
+
+	t.Run("error - different JWK", func(t *testing.T) {
+		rv, err := GetRevealValue(&jws.JWK{Crv: "crv", Kty: "kty", X: "x", Y: "other"}, sha2_256)
+		require.NoError(t, err)
+
+		err = VerifyRevealValue(jwk, rv)
+		require.EqualError(t, err, "reveal value doesn't match JWK")
+	})
+
+	t.Run("error - reveal value is not a multihash", func(t *testing.T) {
+		err := VerifyRevealValue(jwk, "reveal")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify reveal value")
+	})
+
+	t.Run("error - canonicalization failed", func(t *testing.T) {
+		rv, err := GetRevealValue(jwk, sha2_256)
+		require.NoError(t, err)
+
+		err = VerifyRevealValue(nil, rv)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get reveal value")
+	})
+}
+
+func TestVerifyCommitment(t *testing.T) {
+	jwk := &jws.JWK{Crv: "crv", Kty: "kty", X: "x", Y: "y"}
+
+	t.Run("success", func(t *testing.T) {
+		for _, code := range []uint{sha2_256, sha2_512, sha3_256} {
+			rv, err := GetRevealValue(jwk, code)
+			require.NoError(t, err)
+
+			c, err := GetCommitment(jwk, code)
+			require.NoError(t, err)
+
+			require.NoError(t, VerifyCommitment(rv, c))
+		}
+	})
+
+	t.Run("error - reveal value doesn't match commitment", func(t *testing.T) {
+		rv, err := GetRevealValue(jwk, sha2_256)
+		require.NoError(t, err)
+
+		c, err := GetCommitment(jwk, sha3_256)
+		require.NoError(t, err)
+
+		err = VerifyCommitment(rv, c)
+		require.EqualError(t, err, "reveal value doesn't match commitment")
+
+		// reveal value itself is not a commitment (commitment is double hash)
+		err = VerifyCommitment(rv, rv)
+		require.EqualError(t, err, "reveal value doesn't match commitment")
+	})
+
+	t.Run("error - invalid reveal value", func(t *testing.T) {
+		err := VerifyCommitment("reveal", "commitment")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get commitment from reveal value")
+	})
+}
+
+func TestMatchesJWK(t *testing.T) {
+	jwk := &jws.JWK{Crv: "crv", Kty: "kty", X: "x", Y: "y"}
+
+	c, err := GetCommitment(jwk, sha3_256)
+	require.NoError(t, err)
+
+	matches, err := MatchesJWK(jwk, c)
+	require.NoError(t, err)
+	require.True(t, matches)
+
+	matches, err = MatchesJWK(&jws.JWK{Crv: "crv", Kty: "kty", X: "x", Y: "other"}, c)
+	require.NoError(t, err)
+	require.False(t, matches)
+
+	matches, err = MatchesJWK(jwk, "commitment")
+	require.Error(t, err)
+	require.False(t, matches)
+
+	matches, err = MatchesJWK(nil, c)
+	require.Error(t, err)
+	require.False(t, matches)
+}
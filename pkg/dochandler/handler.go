@@ -84,11 +84,12 @@ func (r *DocumentHandler) ProcessOperation(operationBuffer []byte, protocolGenes
 
 	op, err := pv.OperationParser().Parse(r.namespace, operationBuffer)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, newBadRequestError(err)
 	}
 
 	// perform validation for operation request
-	if err := r.validateOperation(op, pv); err != nil {
+	warnings, err := r.validateOperation(op, pv)
+	if err != nil {
 		logger.Warnf("Failed to validate operation: %s", err.Error())
 
 		return nil, err
@@ -105,7 +106,7 @@ func (r *DocumentHandler) ProcessOperation(operationBuffer []byte, protocolGenes
 
 	// create operation will also return document
 	if op.Type == operation.TypeCreate {
-		return r.getCreateResponse(op, pv)
+		return r.getCreateResponse(op, pv, warnings)
 	}
 
 	return nil, nil
@@ -133,13 +134,27 @@ func (r *DocumentHandler) getCreateResult(op *operation.Operation, pv protocol.V
 	return rm, nil
 }
 
-func (r *DocumentHandler) getCreateResponse(op *operation.Operation, pv protocol.Version) (*document.ResolutionResult, error) {
+func (r *DocumentHandler) getCreateResponse(op *operation.Operation, pv protocol.Version, warnings []string) (*document.ResolutionResult, error) {
 	rm, err := r.getCreateResult(op, pv)
 	if err != nil {
 		return nil, err
 	}
 
-	return pv.DocumentTransformer().TransformDocument(rm, getTransformationInfo(op.ID, false))
+	result, err := pv.DocumentTransformer().TransformDocument(rm, getTransformationInfo(op.ID, false))
+	if err != nil {
+		return nil, err
+	}
+
+	// validation warnings are propagated to the client
+	if len(warnings) > 0 {
+		if result.MethodMetadata == nil {
+			result.MethodMetadata = make(document.Metadata)
+		}
+
+		result.MethodMetadata[document.WarningsProperty] = warnings
+	}
+
+	return result, nil
 }
 
 func getTransformationInfo(id string, published bool) protocol.TransformationInfo {
@@ -150,6 +165,13 @@ func getTransformationInfo(id string, published bool) protocol.TransformationInf
 	return ti
 }
 
+// setContentType adds requested representation to transformation info.
+func setContentType(ti protocol.TransformationInfo, options document.ResolutionOptions) {
+	if options.ContentType != "" {
+		ti[document.ContentTypeProperty] = options.ContentType
+	}
+}
+
 // ResolveDocument fetches the latest DID Document of a DID. Two forms of string can be passed in the URI:
 //
 // 1. Standard DID format: did:METHOD:<did-suffix>
@@ -161,10 +183,14 @@ func getTransformationInfo(id string, published bool) protocol.TransformationInf
 // If the DID Document cannot be found, the <suffix-data-object> and <delta-object> are used
 // to generate and return resolved DID Document. In this case the supplied delta and suffix objects
 // are subject to the same validation as during processing create operation.
-func (r *DocumentHandler) ResolveDocument(shortOrLongFormDID string) (*document.ResolutionResult, error) {
+//
+// Resolution options specify requested representation of the document (e.g. plain JSON without @context).
+func (r *DocumentHandler) ResolveDocument(shortOrLongFormDID string, opts ...document.ResolutionOption) (*document.ResolutionResult, error) {
+	options := document.GetResolutionOptions(opts...)
+
 	ns, err := r.getNamespace(shortOrLongFormDID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, newBadRequestError(err)
 	}
 
 	pv, err := r.protocol.Current()
@@ -175,23 +201,24 @@ func (r *DocumentHandler) ResolveDocument(shortOrLongFormDID string) (*document.
 	// extract did and optional initial document value
 	shortFormDID, createReq, err := pv.OperationParser().ParseDID(ns, shortOrLongFormDID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, newBadRequestError(err)
 	}
 
-	uniquePortion, err := getSuffix(ns, shortFormDID)
+	uniquePortion, err := getSuffix(ns, shortFormDID, pv.Protocol())
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, newBadRequestError(err)
 	}
 
 	// resolve document from the blockchain
-	doc, err := r.resolveRequestWithID(ns, uniquePortion, pv)
+	doc, err := r.resolveRequestWithID(ns, uniquePortion, pv, options)
 	if err == nil {
 		return doc, nil
 	}
 
 	// if document was not found on the blockchain and initial value has been provided resolve using initial value
-	if createReq != nil && strings.Contains(err.Error(), "not found") {
-		return r.resolveRequestWithInitialState(uniquePortion, shortOrLongFormDID, createReq, pv)
+	// (internal errors are returned since document may exist on the blockchain)
+	if createReq != nil && !protocol.IsInternalError(err) && strings.Contains(err.Error(), "not found") {
+		return r.resolveRequestWithInitialState(uniquePortion, shortOrLongFormDID, createReq, pv, options)
 	}
 
 	return nil, err
@@ -213,7 +240,7 @@ func (r *DocumentHandler) getNamespace(shortOrLongFormDID string) (string, error
 	return "", fmt.Errorf("did must start with configured namespace[%s] or aliases%v", r.namespace, r.aliases)
 }
 
-func (r *DocumentHandler) resolveRequestWithID(namespace, uniquePortion string, pv protocol.Version) (*document.ResolutionResult, error) {
+func (r *DocumentHandler) resolveRequestWithID(namespace, uniquePortion string, pv protocol.Version, options document.ResolutionOptions) (*document.ResolutionResult, error) {
 	internalResult, err := r.processor.Resolve(uniquePortion)
 	if err != nil {
 		logger.Errorf("Failed to resolve uniquePortion[%s]: %s", uniquePortion, err.Error())
@@ -221,7 +248,12 @@ func (r *DocumentHandler) resolveRequestWithID(namespace, uniquePortion string,
 		return nil, err
 	}
 
+	if internalResult.Deactivated {
+		return getDeactivatedResult(namespace+docutil.NamespaceDelimiter+uniquePortion, internalResult, pv.Protocol())
+	}
+
 	ti := getTransformationInfo(namespace+docutil.NamespaceDelimiter+uniquePortion, true)
+	setContentType(ti, options)
 
 	if r.namespace != namespace {
 		// we got here using alias; suggest using namespace
@@ -231,14 +263,51 @@ func (r *DocumentHandler) resolveRequestWithID(namespace, uniquePortion string,
 	return pv.DocumentTransformer().TransformDocument(internalResult, ti)
 }
 
-func (r *DocumentHandler) resolveRequestWithInitialState(uniqueSuffix, longFormDID string, initialBytes []byte, pv protocol.Version) (*document.ResolutionResult, error) {
+// getDeactivatedResult returns resolution result (tombstone) for deactivated document.
+func getDeactivatedResult(id string, rm *protocol.ResolutionModel, p protocol.Protocol) (*document.ResolutionResult, error) {
+	docMetadata := make(document.Metadata)
+	docMetadata[document.DeactivatedProperty] = true
+	docMetadata[document.DeactivateTransactionTimeProperty] = rm.LastOperationTransactionTime
+	docMetadata[document.DeactivateTransactionNumberProperty] = rm.LastOperationTransactionNumber
+
+	if rm.CreatedTransactionTime > 0 {
+		docMetadata[document.CreatedTransactionTimeProperty] = rm.CreatedTransactionTime
+		docMetadata[document.CreatedTransactionNumberProperty] = rm.CreatedTransactionNumber
+	}
+
+	if rm.UpdatedTransactionTime > 0 {
+		docMetadata[document.UpdatedTransactionTimeProperty] = rm.UpdatedTransactionTime
+		docMetadata[document.UpdatedTransactionNumberProperty] = rm.UpdatedTransactionNumber
+	}
+
+	if rm.FinalDoc != nil && len(p.MultihashAlgorithms) > 0 {
+		finalDocHash, err := document.Hash(rm.FinalDoc, p.MultihashAlgorithms[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate final document hash: %s", err.Error())
+		}
+
+		docMetadata[document.FinalDocumentProperty] = rm.FinalDoc.Copy()
+		docMetadata[document.FinalDocumentHashProperty] = finalDocHash
+	}
+
+	methodMetadata := make(document.Metadata)
+	methodMetadata[document.PublishedProperty] = true
+
+	return &document.ResolutionResult{
+		Document:         document.Document{document.IDProperty: id},
+		MethodMetadata:   methodMetadata,
+		DocumentMetadata: docMetadata,
+	}, nil
+}
+
+func (r *DocumentHandler) resolveRequestWithInitialState(uniqueSuffix, longFormDID string, initialBytes []byte, pv protocol.Version, options document.ResolutionOptions) (*document.ResolutionResult, error) {
 	op, err := pv.OperationParser().Parse(r.namespace, initialBytes)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, newBadRequestError(err)
 	}
 
 	if uniqueSuffix != op.UniqueSuffix {
-		return nil, fmt.Errorf("%s: provided did doesn't match did created from initial state", badRequest)
+		return nil, newBadRequestError(errors.New("provided did doesn't match did created from initial state"))
 	}
 
 	rm, err := r.getCreateResult(op, pv)
@@ -253,10 +322,13 @@ func (r *DocumentHandler) resolveRequestWithInitialState(uniqueSuffix, longFormD
 
 	err = pv.DocumentValidator().IsValidOriginalDocument(docBytes)
 	if err != nil {
-		return nil, fmt.Errorf("%s: validate initial document: %s", badRequest, err.Error())
+		return nil, newBadRequestError(fmt.Errorf("validate initial document: %s", err.Error()))
 	}
 
-	externalResult, err := pv.DocumentTransformer().TransformDocument(rm, getTransformationInfo(longFormDID, false))
+	ti := getTransformationInfo(longFormDID, false)
+	setContentType(ti, options)
+
+	externalResult, err := pv.DocumentTransformer().TransformDocument(rm, ti)
 	if err != nil {
 		return nil, fmt.Errorf("failed to transform create with initial state to external document: %s", err.Error())
 	}
@@ -274,40 +346,66 @@ func (r *DocumentHandler) addToBatch(op *operation.Operation, genesisTime uint64
 		}, genesisTime)
 }
 
-func (r *DocumentHandler) validateOperation(op *operation.Operation, pv protocol.Version) error {
+// validateOperation validates operation and returns validation warnings.
+func (r *DocumentHandler) validateOperation(op *operation.Operation, pv protocol.Version) ([]string, error) {
 	if op.Type == operation.TypeCreate {
 		return r.validateCreateDocument(op, pv)
 	}
 
-	return pv.DocumentValidator().IsValidPayload(op.OperationBuffer)
+	return nil, pv.DocumentValidator().IsValidPayload(op.OperationBuffer)
 }
 
-func (r *DocumentHandler) validateCreateDocument(op *operation.Operation, pv protocol.Version) error {
+func (r *DocumentHandler) validateCreateDocument(op *operation.Operation, pv protocol.Version) ([]string, error) {
 	rm, err := r.getCreateResult(op, pv)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	docBytes, err := canonicalizer.MarshalCanonical(rm.Doc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return pv.DocumentValidator().IsValidOriginalDocument(docBytes)
+	// validators that report warnings reject documents with errors but accept documents with warnings
+	if v, ok := pv.DocumentValidator().(protocol.DocumentResultValidator); ok {
+		result := v.ValidateOriginalDocument(docBytes)
+
+		return result.Warnings, result.Err()
+	}
+
+	return nil, pv.DocumentValidator().IsValidOriginalDocument(docBytes)
+}
+
+// newBadRequestError returns bad request error; error code of the cause (e.g. delta_exceeds_maximum_size)
+// and JSON pointer of the invalid field are preserved so that they can be returned to the client.
+func newBadRequestError(err error) error {
+	code := protocol.GetCode(err)
+	if code == "" {
+		code = protocol.CodeBadRequest
+	}
+
+	badRequestErr := fmt.Errorf("%s: %s", badRequest, err.Error())
+
+	if pointer := protocol.GetPointer(err); pointer != "" {
+		badRequestErr = protocol.NewFieldError(pointer, badRequestErr)
+	}
+
+	return protocol.NewCodedError(code, badRequestErr)
 }
 
-// getSuffix fetches unique portion of ID which is string after namespace.
-func getSuffix(namespace, idOrDocument string) (string, error) {
-	ns := namespace + docutil.NamespaceDelimiter
-	pos := strings.Index(idOrDocument, ns)
-	if pos == -1 {
-		return "", errors.New("did must start with configured namespace")
+// getSuffix fetches unique portion of ID which is string after namespace. Suffix format is validated
+// if suffix validation is enabled in protocol.
+func getSuffix(namespace, id string, p protocol.Protocol) (string, error) {
+	did, err := docutil.ParseDID(namespace, id)
+	if err != nil {
+		return "", err
 	}
 
-	adjustedPos := pos + len(ns)
-	if adjustedPos >= len(idOrDocument) {
-		return "", errors.New("did suffix is empty")
+	if p.ValidateDIDSuffix {
+		if err := docutil.ValidateSuffix(did.UniqueSuffix, p.MultihashAlgorithms, p.MaxOperationHashLength); err != nil {
+			return "", err
+		}
 	}
 
-	return idOrDocument[adjustedPos:], nil
+	return did.UniqueSuffix, nil
 }
@@ -72,6 +72,71 @@ func TestDocumentHandler_ProcessOperation_Create(t *testing.T) {
 	require.NotNil(t, doc)
 }
 
+func TestDocumentHandler_ProcessOperation_Create_Warnings(t *testing.T) {
+	t.Run("success - warnings are returned", func(t *testing.T) {
+		pc := newMockProtocolClient()
+		pc.CurrentVersion.DocumentValidatorReturns(&resultValidator{
+			DocumentValidator: &mocks.DocumentValidator{},
+			result:            &protocol.ValidationResult{Warnings: []string{"property 'x' is deprecated"}},
+		})
+
+		dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
+		require.NotNil(t, dochandler)
+		defer cleanup()
+
+		result, err := dochandler.ProcessOperation(getCreateOperation().OperationBuffer, 0)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, []string{"property 'x' is deprecated"}, result.MethodMetadata[document.WarningsProperty])
+	})
+
+	t.Run("error - errors are not ignored", func(t *testing.T) {
+		pc := newMockProtocolClient()
+		pc.CurrentVersion.DocumentValidatorReturns(&resultValidator{
+			DocumentValidator: &mocks.DocumentValidator{},
+			result: &protocol.ValidationResult{
+				Errors:   []error{errors.New("validation error")},
+				Warnings: []string{"property 'x' is deprecated"},
+			},
+		})
+
+		dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
+		require.NotNil(t, dochandler)
+		defer cleanup()
+
+		result, err := dochandler.ProcessOperation(getCreateOperation().OperationBuffer, 0)
+		require.EqualError(t, err, "validation error")
+		require.Nil(t, result)
+	})
+
+	t.Run("success - no warnings", func(t *testing.T) {
+		pc := newMockProtocolClient()
+		pc.CurrentVersion.DocumentValidatorReturns(&resultValidator{
+			DocumentValidator: &mocks.DocumentValidator{},
+			result:            &protocol.ValidationResult{},
+		})
+
+		dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
+		require.NotNil(t, dochandler)
+		defer cleanup()
+
+		result, err := dochandler.ProcessOperation(getCreateOperation().OperationBuffer, 0)
+		require.NoError(t, err)
+		require.NotContains(t, result.MethodMetadata, document.WarningsProperty)
+	})
+}
+
+// resultValidator is document validator that reports warnings.
+type resultValidator struct {
+	*mocks.DocumentValidator
+
+	result *protocol.ValidationResult
+}
+
+func (v *resultValidator) ValidateOriginalDocument([]byte) *protocol.ValidationResult {
+	return v.result
+}
+
 func TestDocumentHandler_ProcessOperation_Create_ApplyDeltaError(t *testing.T) {
 	dochandler, cleanup := getDocumentHandler(mocks.NewMockOperationStore(nil))
 	require.NotNil(t, dochandler)
@@ -158,6 +223,59 @@ func TestDocumentHandler_ResolveDocument_DID(t *testing.T) {
 	require.Contains(t, err.Error(), "did suffix is empty")
 }
 
+func TestGetDeactivatedResult(t *testing.T) {
+	const id = namespace + docutil.NamespaceDelimiter + "suffix"
+
+	finalDoc := document.Document{"key": "value"}
+
+	rm := &protocol.ResolutionModel{
+		Deactivated:                    true,
+		FinalDoc:                       finalDoc,
+		LastOperationTransactionTime:   10,
+		LastOperationTransactionNumber: 11,
+		CreatedTransactionTime:         5,
+		CreatedTransactionNumber:       6,
+		UpdatedTransactionTime:         10,
+		UpdatedTransactionNumber:       11,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		result, err := getDeactivatedResult(id, rm, mocks.GetDefaultProtocolParameters())
+		require.NoError(t, err)
+		require.Equal(t, id, result.Document[document.IDProperty])
+		require.Equal(t, true, result.MethodMetadata[document.PublishedProperty])
+		require.Equal(t, true, result.DocumentMetadata[document.DeactivatedProperty])
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.DeactivateTransactionTimeProperty])
+		require.Equal(t, uint64(11), result.DocumentMetadata[document.DeactivateTransactionNumberProperty])
+		require.Equal(t, uint64(5), result.DocumentMetadata[document.CreatedTransactionTimeProperty])
+		require.Equal(t, uint64(6), result.DocumentMetadata[document.CreatedTransactionNumberProperty])
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.UpdatedTransactionTimeProperty])
+		require.Equal(t, uint64(11), result.DocumentMetadata[document.UpdatedTransactionNumberProperty])
+		require.Equal(t, finalDoc, result.DocumentMetadata[document.FinalDocumentProperty])
+
+		expectedHash, err := hashing.CalculateModelMultihash(finalDoc, sha2_256)
+		require.NoError(t, err)
+		require.Equal(t, expectedHash, result.DocumentMetadata[document.FinalDocumentHashProperty])
+	})
+
+	t.Run("success - final document not available", func(t *testing.T) {
+		result, err := getDeactivatedResult(id, &protocol.ResolutionModel{Deactivated: true}, mocks.GetDefaultProtocolParameters())
+		require.NoError(t, err)
+		require.Equal(t, true, result.DocumentMetadata[document.DeactivatedProperty])
+		require.Nil(t, result.DocumentMetadata[document.FinalDocumentHashProperty])
+	})
+
+	t.Run("error - unsupported hash algorithm", func(t *testing.T) {
+		p := mocks.GetDefaultProtocolParameters()
+		p.MultihashAlgorithms = []uint{55}
+
+		result, err := getDeactivatedResult(id, rm, p)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "failed to calculate final document hash")
+	})
+}
+
 func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 	pc := newMockProtocolClient()
 	dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
@@ -182,11 +300,24 @@ func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 		require.Equal(t, false, result.MethodMetadata[document.PublishedProperty])
 	})
 
+	t.Run("error - internal resolution error (initial state is not used)", func(t *testing.T) {
+		internalErr := protocol.NewInternalError(errors.New("protocol version not found"))
+
+		handler := New(namespace, []string{alias}, pc, dochandler.writer, &mockProcessor{err: internalErr})
+
+		result, err := handler.ResolveDocument(docID + longFormPart)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsInternalError(err))
+		require.Contains(t, err.Error(), "protocol version not found")
+	})
+
 	t.Run("error - invalid initial state format (not encoded JCS)", func(t *testing.T) {
 		result, err := dochandler.ResolveDocument(docID + ":payload")
 		require.Error(t, err)
 		require.Nil(t, result)
-		require.Contains(t, err.Error(), "bad request: invalid character")
+		require.Contains(t, err.Error(), "bad request: failed to decode initial state")
+		require.Equal(t, protocol.CodeBadRequest, protocol.GetCode(err))
 	})
 
 	t.Run("error - did doesn't match the one created by parsing original create request", func(t *testing.T) {
@@ -268,6 +399,13 @@ func TestDocumentHandler_ResolveDocument_Interop(t *testing.T) {
 	result, err := dochandler.ResolveDocument(interopResolveDidWithInitialState)
 	require.NoError(t, err)
 	require.NotNil(t, result)
+	require.Contains(t, result.Document, document.ContextProperty)
+
+	result, err = dochandler.ResolveDocument(interopResolveDidWithInitialState,
+		document.WithContentType(document.ContentTypeDIDJSON))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.NotContains(t, result.Document, document.ContextProperty)
 }
 
 func TestDocumentHandler_ResolveDocument_InitialDocumentNotValid(t *testing.T) {
@@ -301,20 +439,46 @@ func TestGetUniquePortion(t *testing.T) {
 	const namespace = "did:sidetree"
 
 	// id doesn't contain namespace
-	uniquePortion, err := getSuffix(namespace, "invalid")
+	uniquePortion, err := getSuffix(namespace, "invalid", protocol.Protocol{})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "did must start with configured namespace")
 
 	// id equals namespace; unique portion is empty
-	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter)
+	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter, protocol.Protocol{})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "did suffix is empty")
 
 	// valid unique portion
 	const unique = "exKwW0HjS5y4zBtJ7vYDwglYhtckdO15JDt1j5F5Q0A"
-	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter+unique)
+	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter+unique, protocol.Protocol{})
 	require.NoError(t, err)
 	require.Equal(t, unique, uniquePortion)
+
+	// suffix validation enabled
+	p := protocol.Protocol{
+		ValidateDIDSuffix:      true,
+		MultihashAlgorithms:    []uint{sha2_256},
+		MaxOperationHashLength: 100,
+	}
+
+	// not a multihash
+	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter+unique, p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "did suffix is not computed with the required hash algorithms")
+	require.Empty(t, uniquePortion)
+
+	// invalid character
+	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter+"abc$", p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "did suffix contains invalid character '$'")
+	require.Empty(t, uniquePortion)
+
+	suffix, err := hashing.CalculateModelMultihash(map[string]interface{}{"key": "value"}, sha2_256)
+	require.NoError(t, err)
+
+	uniquePortion, err = getSuffix(namespace, namespace+docutil.NamespaceDelimiter+suffix, p)
+	require.NoError(t, err)
+	require.Equal(t, suffix, uniquePortion)
 }
 
 func TestProcessOperation_ParseOperationError(t *testing.T) {
@@ -331,6 +495,8 @@ func TestProcessOperation_ParseOperationError(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, doc)
 	require.Contains(t, err.Error(), "bad request: missing signed data")
+	require.Equal(t, protocol.CodeBadRequest, protocol.GetCode(err))
+	require.Equal(t, "/signedData", protocol.GetPointer(err))
 }
 
 // BatchContext implements batch writer context.
@@ -610,3 +776,11 @@ func newMockProtocolClient() *mocks.MockProtocolClient {
 
 	return pc
 }
+
+type mockProcessor struct {
+	err error
+}
+
+func (m *mockProcessor) Resolve(uniqueSuffix string) (*protocol.ResolutionModel, error) {
+	return nil, m.err
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ContextRegistry contains JSON-LD contexts that are known (available locally) and optional allowlist
+// of contexts that may be used in DID documents.
+type ContextRegistry struct {
+	mutex     sync.RWMutex
+	known     map[string]bool
+	allowlist map[string]bool
+	offline   bool
+}
+
+// ContextRegistryOption is a context registry instance option.
+type ContextRegistryOption func(opts *ContextRegistry)
+
+// WithContextAllowlist sets contexts that are allowed in documents; any other (remote) context is rejected.
+// DID context is always allowed.
+func WithContextAllowlist(contexts ...string) ContextRegistryOption {
+	return func(opts *ContextRegistry) {
+		opts.allowlist = map[string]bool{DIDContext: true}
+
+		for _, c := range contexts {
+			opts.allowlist[c] = true
+		}
+	}
+}
+
+// WithOfflineMode rejects remote contexts that are not registered (can't be resolved without network access).
+func WithOfflineMode(enabled bool) ContextRegistryOption {
+	return func(opts *ContextRegistry) {
+		opts.offline = enabled
+	}
+}
+
+// NewContextRegistry creates new context registry; DID context is always registered.
+func NewContextRegistry(opts ...ContextRegistryOption) *ContextRegistry {
+	r := &ContextRegistry{
+		known: map[string]bool{DIDContext: true},
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Register registers known contexts.
+func (r *ContextRegistry) Register(contexts ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, c := range contexts {
+		r.known[c] = true
+	}
+}
+
+// IsRegistered returns true if context is registered.
+func (r *ContextRegistry) IsRegistered(ctx string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.known[ctx]
+}
+
+// IsAllowed returns true if (remote) context may be used in documents.
+func (r *ContextRegistry) IsAllowed(ctx string) bool {
+	return r.checkContext(ctx) == nil
+}
+
+// Validate validates document contexts: DID context has to be the first context, contexts can't repeat and
+// remote contexts have to be allowed (and registered in offline mode); embedded contexts (objects) are allowed.
+func (r *ContextRegistry) Validate(contexts []interface{}) error {
+	if len(contexts) == 0 || contexts[0] != DIDContext {
+		return fmt.Errorf("first context must be '%s'", DIDContext)
+	}
+
+	existing := make(map[string]bool)
+
+	for _, entry := range contexts {
+		switch ctx := entry.(type) {
+		case string:
+			if existing[ctx] {
+				return fmt.Errorf("duplicate context '%s'", ctx)
+			}
+
+			existing[ctx] = true
+
+			if err := r.checkContext(ctx); err != nil {
+				return err
+			}
+		case map[string]interface{}:
+			continue
+		default:
+			return errors.New("context must be a string or an object")
+		}
+	}
+
+	return nil
+}
+
+func (r *ContextRegistry) checkContext(ctx string) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.allowlist != nil && !r.allowlist[ctx] {
+		return fmt.Errorf("context '%s' is not allowed", ctx)
+	}
+
+	if r.offline && !r.known[ctx] {
+		return fmt.Errorf("unknown remote context '%s' is not allowed in offline mode", ctx)
+	}
+
+	return nil
+}
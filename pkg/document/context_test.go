@@ -0,0 +1,80 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jwsContext      = "https://w3id.org/security/suites/jws-2020/v1"
+	ed25519Context  = "https://w3id.org/security/suites/ed25519-2020/v1"
+	unknownContext  = "https://example.com/context/v1"
+	embeddedContext = "@base"
+)
+
+func TestContextRegistry(t *testing.T) {
+	t.Run("success - default registry", func(t *testing.T) {
+		r := NewContextRegistry()
+		require.True(t, r.IsRegistered(DIDContext))
+		require.False(t, r.IsRegistered(jwsContext))
+		require.True(t, r.IsAllowed(unknownContext))
+
+		require.NoError(t, r.Validate([]interface{}{DIDContext, jwsContext, map[string]interface{}{embeddedContext: "did:example:123"}}))
+	})
+
+	t.Run("success - register", func(t *testing.T) {
+		r := NewContextRegistry(WithOfflineMode(true))
+		require.False(t, r.IsAllowed(jwsContext))
+
+		r.Register(jwsContext)
+		require.True(t, r.IsRegistered(jwsContext))
+		require.True(t, r.IsAllowed(jwsContext))
+		require.NoError(t, r.Validate([]interface{}{DIDContext, jwsContext}))
+	})
+
+	t.Run("error - offline mode", func(t *testing.T) {
+		r := NewContextRegistry(WithOfflineMode(true))
+
+		err := r.Validate([]interface{}{DIDContext, unknownContext})
+		require.EqualError(t, err, "unknown remote context 'https://example.com/context/v1' is not allowed in offline mode")
+	})
+
+	t.Run("error - allowlist", func(t *testing.T) {
+		r := NewContextRegistry(WithContextAllowlist(jwsContext))
+		require.True(t, r.IsAllowed(DIDContext))
+		require.True(t, r.IsAllowed(jwsContext))
+		require.False(t, r.IsAllowed(ed25519Context))
+
+		require.NoError(t, r.Validate([]interface{}{DIDContext, jwsContext}))
+
+		err := r.Validate([]interface{}{DIDContext, ed25519Context})
+		require.EqualError(t, err, "context 'https://w3id.org/security/suites/ed25519-2020/v1' is not allowed")
+	})
+
+	t.Run("error - ordering", func(t *testing.T) {
+		r := NewContextRegistry()
+
+		err := r.Validate([]interface{}{jwsContext, DIDContext})
+		require.EqualError(t, err, "first context must be 'https://www.w3.org/ns/did/v1'")
+
+		err = r.Validate(nil)
+		require.EqualError(t, err, "first context must be 'https://www.w3.org/ns/did/v1'")
+	})
+
+	t.Run("error - duplicate context", func(t *testing.T) {
+		err := NewContextRegistry().Validate([]interface{}{DIDContext, jwsContext, jwsContext})
+		require.EqualError(t, err, "duplicate context 'https://w3id.org/security/suites/jws-2020/v1'")
+	})
+
+	t.Run("error - invalid context", func(t *testing.T) {
+		err := NewContextRegistry().Validate([]interface{}{DIDContext, 1})
+		require.EqualError(t, err, "context must be a string or an object")
+	})
+}
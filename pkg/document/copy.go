@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+)
+
+// Copy returns deep copy of the document. Objects and arrays (including typed document arrays such as
+// public keys and services) are copied recursively; other values (strings, numbers, booleans) are immutable.
+// Values of any other type (e.g. pointers to structs) are shared between the document and its copy.
+func (doc Document) Copy() Document {
+	if doc == nil {
+		return nil
+	}
+
+	return copyObject(doc)
+}
+
+// Copy returns deep copy of the DID document.
+func (doc DIDDocument) Copy() DIDDocument {
+	if doc == nil {
+		return nil
+	}
+
+	return copyObject(doc)
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(obj))
+
+	for key, value := range obj {
+		result[key] = copyValue(value)
+	}
+
+	return result
+}
+
+func copyArray(arr []interface{}) []interface{} {
+	result := make([]interface{}, len(arr))
+
+	for i, value := range arr {
+		result[i] = copyValue(value)
+	}
+
+	return result
+}
+
+func copyValue(value interface{}) interface{} { //nolint:gocyclo
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyObject(v)
+	case Document:
+		return Document(copyObject(v))
+	case DIDDocument:
+		return DIDDocument(copyObject(v))
+	case PublicKey:
+		return PublicKey(copyObject(v))
+	case Service:
+		return Service(copyObject(v))
+	case JWK:
+		return JWK(copyObject(v))
+	case Metadata:
+		return Metadata(copyObject(v))
+	case []interface{}:
+		return copyArray(v)
+	case []string:
+		return append([]string(nil), v...)
+	case []PublicKey:
+		result := make([]PublicKey, len(v))
+		for i, pk := range v {
+			result[i] = copyObject(pk)
+		}
+
+		return result
+	case []Service:
+		result := make([]Service, len(v))
+		for i, s := range v {
+			result[i] = copyObject(s)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// FrozenDocument is read-only view of the document; document is copied when it is frozen and every value
+// returned by frozen document is a copy, so frozen document can be safely shared (e.g. cached).
+type FrozenDocument struct {
+	doc Document
+}
+
+// Freeze returns read-only view of the (copy of) document.
+func (doc Document) Freeze() *FrozenDocument {
+	return &FrozenDocument{doc: doc.Copy()}
+}
+
+// ID returns document ID.
+func (fd *FrozenDocument) ID() string {
+	return fd.doc.ID()
+}
+
+// Get returns copy of the value for the specified key and true if the key exists.
+func (fd *FrozenDocument) Get(key string) (interface{}, bool) {
+	value, ok := fd.doc[key]
+	if !ok {
+		return nil, false
+	}
+
+	return copyValue(value), true
+}
+
+// Document returns (mutable) copy of the document.
+func (fd *FrozenDocument) Document() Document {
+	return fd.doc.Copy()
+}
+
+// Bytes returns byte representation of the document.
+func (fd *FrozenDocument) Bytes() ([]byte, error) {
+	return fd.doc.Bytes()
+}
+
+// MarshalJSON marshals the document.
+func (fd *FrozenDocument) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fd.doc)
+}
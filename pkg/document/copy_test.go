@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	t.Run("success - JSON document", func(t *testing.T) {
+		doc, err := FromBytes([]byte(copyDoc))
+		require.NoError(t, err)
+
+		docCopy := doc.Copy()
+		require.Equal(t, doc, docCopy)
+
+		// modify nested values of the copy
+		docCopy[AlsoKnownAs].([]interface{})[0] = "changed"
+		docCopy[PublicKeyProperty].([]interface{})[0].(map[string]interface{})[IDProperty] = "changed"
+		docCopy[ServiceProperty].([]interface{})[0].(map[string]interface{})[ServiceEndpointProperty].(map[string]interface{})["uri"] = "changed"
+
+		original, err := FromBytes([]byte(copyDoc))
+		require.NoError(t, err)
+		require.Equal(t, original, doc)
+		require.NotEqual(t, original, docCopy)
+	})
+
+	t.Run("success - typed values", func(t *testing.T) {
+		base := &struct{}{}
+
+		doc := Document{
+			VerificationMethodProperty: []PublicKey{{PublicKeyJwkProperty: JWK{"kty": "OKP"}}},
+			ServiceProperty:            []Service{{IDProperty: "svc"}},
+			AlsoKnownAs:                []string{"https://example.com"},
+			"nested":                   Document{"metadata": Metadata{"key": "value"}},
+			"did":                      DIDDocument{IDProperty: "did:example:123"},
+			"pk":                       PublicKey{IDProperty: "key"},
+			"svc":                      Service{IDProperty: "svc"},
+			"base":                     base,
+		}
+
+		docCopy := doc.Copy()
+		require.Equal(t, doc, docCopy)
+
+		docCopy[VerificationMethodProperty].([]PublicKey)[0][PublicKeyJwkProperty].(JWK)["kty"] = "EC"
+		docCopy[ServiceProperty].([]Service)[0][IDProperty] = "changed"
+		docCopy[AlsoKnownAs].([]string)[0] = "changed"
+		docCopy["nested"].(Document)["metadata"].(Metadata)["key"] = "changed"
+		docCopy["did"].(DIDDocument)[IDProperty] = "changed"
+		docCopy["pk"].(PublicKey)[IDProperty] = "changed"
+		docCopy["svc"].(Service)[IDProperty] = "changed"
+
+		require.Equal(t, "OKP", doc[VerificationMethodProperty].([]PublicKey)[0][PublicKeyJwkProperty].(JWK)["kty"])
+		require.Equal(t, "svc", doc[ServiceProperty].([]Service)[0].ID())
+		require.Equal(t, "https://example.com", doc[AlsoKnownAs].([]string)[0])
+		require.Equal(t, "value", doc["nested"].(Document)["metadata"].(Metadata)["key"])
+		require.Equal(t, "did:example:123", doc["did"].(DIDDocument).ID())
+		require.Equal(t, "key", doc["pk"].(PublicKey).ID())
+		require.Equal(t, "svc", doc["svc"].(Service).ID())
+
+		// other values are shared
+		require.True(t, base == docCopy["base"])
+	})
+
+	t.Run("success - DID document", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(copyDoc))
+		require.NoError(t, err)
+
+		docCopy := doc.Copy()
+		require.Equal(t, doc, docCopy)
+
+		docCopy.PublicKeys()[0][IDProperty] = "changed"
+		require.Equal(t, "key1", doc.PublicKeys()[0].ID())
+	})
+
+	t.Run("success - nil document", func(t *testing.T) {
+		require.Nil(t, Document(nil).Copy())
+		require.Nil(t, DIDDocument(nil).Copy())
+	})
+}
+
+func TestFreeze(t *testing.T) {
+	doc, err := FromBytes([]byte(copyDoc))
+	require.NoError(t, err)
+
+	frozen := doc.Freeze()
+
+	// changes to original document are not visible
+	doc[IDProperty] = "did:example:changed"
+	require.Equal(t, "did:example:123", frozen.ID())
+
+	value, ok := frozen.Get(AlsoKnownAs)
+	require.True(t, ok)
+	require.Equal(t, []interface{}{"https://example.com"}, value)
+
+	// returned values are copies
+	value.([]interface{})[0] = "changed"
+
+	value, ok = frozen.Get(AlsoKnownAs)
+	require.True(t, ok)
+	require.Equal(t, []interface{}{"https://example.com"}, value)
+
+	value, ok = frozen.Get("other")
+	require.False(t, ok)
+	require.Nil(t, value)
+
+	docCopy := frozen.Document()
+	docCopy[IDProperty] = "did:example:changed"
+	require.Equal(t, "did:example:123", frozen.ID())
+
+	bytes, err := frozen.Bytes()
+	require.NoError(t, err)
+
+	jsonBytes, err := json.Marshal(frozen)
+	require.NoError(t, err)
+	require.JSONEq(t, string(bytes), string(jsonBytes))
+}
+
+const copyDoc = `{
+  "id": "did:example:123",
+  "alsoKnownAs": ["https://example.com"],
+  "publicKey": [{"id": "key1", "publicKeyJwk": {"kty": "OKP"}}],
+  "service": [{"id": "svc", "serviceEndpoint": {"uri": "https://example.com"}}]
+}`
@@ -40,6 +40,9 @@ const (
 
 	// InvocationKeyProperty defines key for invocation key property.
 	InvocationKeyProperty = "capabilityInvocation"
+
+	// AlsoKnownAs defines also known as property.
+	AlsoKnownAs = "alsoKnownAs"
 )
 
 // DIDDocument Defines DID Document data structure used by Sidetree for basic type safety checks.
@@ -65,6 +68,27 @@ func (doc DIDDocument) VerificationMethods() []PublicKey {
 	return ParsePublicKeys(doc[VerificationMethodProperty])
 }
 
+// AlsoKnownAs are alternate identifiers for DID subject.
+func (doc DIDDocument) AlsoKnownAs() []string {
+	return StringArray(doc[AlsoKnownAs])
+}
+
+// Controller returns controller(s) of DID subject (controller may be a single value or a set).
+func (doc DIDDocument) Controller() []string {
+	return stringOrStringArray(doc[ControllerProperty])
+}
+
+// SetAlsoKnownAs sets alternate identifiers for DID subject; empty list removes the property.
+func (doc DIDDocument) SetAlsoKnownAs(uris []string) {
+	setStringArray(doc, AlsoKnownAs, uris)
+}
+
+// SetController sets controller(s) of DID subject; single controller is set as a string and multiple
+// controllers are set as a set (array); empty list removes the property.
+func (doc DIDDocument) SetController(controllers []string) {
+	setStringOrStringArray(doc, ControllerProperty, controllers)
+}
+
 // ParsePublicKeys is helper function for parsing public keys.
 func ParsePublicKeys(entry interface{}) []PublicKey {
 	if entry == nil {
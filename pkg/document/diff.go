@@ -0,0 +1,159 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeOperation is type of document change (IETF JSON patch operation).
+type ChangeOperation string
+
+const (
+	// ChangeAdd is returned for values that exist only in new document.
+	ChangeAdd ChangeOperation = "add"
+
+	// ChangeRemove is returned for values that exist only in old document.
+	ChangeRemove ChangeOperation = "remove"
+
+	// ChangeReplace is returned for values that are different in old and new document.
+	ChangeReplace ChangeOperation = "replace"
+)
+
+// Change describes single change between two document states.
+type Change struct {
+	Op       ChangeOperation `json:"op"`
+	Path     string          `json:"path"`
+	Value    interface{}     `json:"value,omitempty"`
+	OldValue interface{}     `json:"-"`
+}
+
+// String returns human-readable representation of the change.
+func (c Change) String() string {
+	switch c.Op {
+	case ChangeAdd:
+		return fmt.Sprintf("added %s: %s", c.Path, valueString(c.Value))
+	case ChangeRemove:
+		return fmt.Sprintf("removed %s: %s", c.Path, valueString(c.OldValue))
+	default:
+		return fmt.Sprintf("replaced %s: %s -> %s", c.Path, valueString(c.OldValue), valueString(c.Value))
+	}
+}
+
+// Changes is list of changes between two document states.
+type Changes []Change
+
+// JSONPatch returns IETF JSON patch (RFC 6902) that transforms old document into new document.
+func (c Changes) JSONPatch() ([]byte, error) {
+	ops := make([]map[string]interface{}, len(c))
+
+	for i, change := range c {
+		op := map[string]interface{}{
+			"op":   string(change.Op),
+			"path": change.Path,
+		}
+
+		if change.Op != ChangeRemove {
+			op["value"] = change.Value
+		}
+
+		ops[i] = op
+	}
+
+	return json.Marshal(ops)
+}
+
+// Diff returns changes between old and new document state. Objects are compared property by property
+// (in sorted order) while arrays and other values are compared (and replaced) as a whole.
+func Diff(oldDoc, newDoc Document) Changes {
+	changes := Changes{}
+
+	diffObjects("", oldDoc, newDoc, &changes)
+
+	return changes
+}
+
+func diffObjects(path string, oldObj, newObj map[string]interface{}, changes *Changes) {
+	for _, key := range sortedKeys(oldObj, newObj) {
+		keyPath := path + "/" + escapePathKey(key)
+
+		oldValue, oldExists := oldObj[key]
+		newValue, newExists := newObj[key]
+
+		switch {
+		case !newExists:
+			*changes = append(*changes, Change{Op: ChangeRemove, Path: keyPath, OldValue: oldValue})
+		case !oldExists:
+			*changes = append(*changes, Change{Op: ChangeAdd, Path: keyPath, Value: newValue})
+		default:
+			diffValues(keyPath, oldValue, newValue, changes)
+		}
+	}
+}
+
+func diffValues(path string, oldValue, newValue interface{}, changes *Changes) {
+	oldObj, oldIsObj := toObject(oldValue)
+	newObj, newIsObj := toObject(newValue)
+
+	if oldIsObj && newIsObj {
+		diffObjects(path, oldObj, newObj, changes)
+
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, Change{Op: ChangeReplace, Path: path, Value: newValue, OldValue: oldValue})
+	}
+}
+
+func toObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case Document:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+func sortedKeys(objs ...map[string]interface{}) []string {
+	keys := make(map[string]bool)
+
+	for _, obj := range objs {
+		for key := range obj {
+			keys[key] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// escapePathKey escapes key for use in JSON pointer (RFC 6901).
+func escapePathKey(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func valueString(value interface{}) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(bytes)
+}
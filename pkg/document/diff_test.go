@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	oldDoc, err := FromBytes([]byte(diffOldDoc))
+	require.NoError(t, err)
+
+	newDoc, err := FromBytes([]byte(diffNewDoc))
+	require.NoError(t, err)
+
+	t.Run("success - changes", func(t *testing.T) {
+		changes := Diff(oldDoc, newDoc)
+		require.Len(t, changes, 5)
+
+		require.Equal(t, Change{Op: ChangeAdd, Path: "/a~1b", Value: "slash"}, changes[0])
+		require.Equal(t, ChangeReplace, changes[1].Op)
+		require.Equal(t, "/alsoKnownAs", changes[1].Path)
+		require.Equal(t, []interface{}{"did:domain.com", "did:other.com"}, changes[1].Value)
+		require.Equal(t, []interface{}{"did:domain.com"}, changes[1].OldValue)
+		require.Equal(t, Change{Op: ChangeRemove, Path: "/controller", OldValue: "did:example:123"}, changes[2])
+		require.Equal(t, Change{Op: ChangeReplace, Path: "/nested/key", Value: "new", OldValue: "old"}, changes[3])
+		require.Equal(t, Change{Op: ChangeAdd, Path: "/nested/other", Value: 1.0}, changes[4])
+
+		require.Equal(t, `added /a~1b: "slash"`, changes[0].String())
+		require.Equal(t, `replaced /alsoKnownAs: ["did:domain.com"] -> ["did:domain.com","did:other.com"]`,
+			changes[1].String())
+		require.Equal(t, `removed /controller: "did:example:123"`, changes[2].String())
+	})
+
+	t.Run("success - no changes", func(t *testing.T) {
+		require.Empty(t, Diff(oldDoc, oldDoc))
+	})
+
+	t.Run("success - JSON patch transforms old into new document", func(t *testing.T) {
+		patchBytes, err := Diff(oldDoc, newDoc).JSONPatch()
+		require.NoError(t, err)
+
+		jsonPatch, err := jsonpatch.DecodePatch(patchBytes)
+		require.NoError(t, err)
+
+		oldBytes, err := json.Marshal(oldDoc)
+		require.NoError(t, err)
+
+		patchedBytes, err := jsonPatch.Apply(oldBytes)
+		require.NoError(t, err)
+
+		patched, err := FromBytes(patchedBytes)
+		require.NoError(t, err)
+		require.Equal(t, newDoc, patched)
+	})
+
+	t.Run("success - unmarshallable value is formatted", func(t *testing.T) {
+		change := Change{Op: ChangeAdd, Path: "/ch", Value: make(chan int)}
+		require.Contains(t, change.String(), "added /ch: 0x")
+	})
+}
+
+const diffOldDoc = `{
+  "alsoKnownAs": ["did:domain.com"],
+  "controller": "did:example:123",
+  "nested": {"key": "old"},
+  "same": {"key": "value"}
+}`
+
+const diffNewDoc = `{
+  "a/b": "slash",
+  "alsoKnownAs": ["did:domain.com", "did:other.com"],
+  "nested": {"key": "new", "other": 1},
+  "same": {"key": "value"}
+}`
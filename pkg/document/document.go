@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 )
 
 // IDProperty describes id key.
@@ -44,6 +45,27 @@ func (doc Document) PublicKeys() []PublicKey {
 	return ParsePublicKeys(doc[PublicKeyProperty])
 }
 
+// AlsoKnownAs are alternate identifiers for DID subject.
+func (doc Document) AlsoKnownAs() []string {
+	return StringArray(doc[AlsoKnownAs])
+}
+
+// Controller returns controller(s) of DID subject (controller may be a single value or a set).
+func (doc Document) Controller() []string {
+	return stringOrStringArray(doc[ControllerProperty])
+}
+
+// SetAlsoKnownAs sets alternate identifiers for DID subject; empty list removes the property.
+func (doc Document) SetAlsoKnownAs(uris []string) {
+	setStringArray(doc, AlsoKnownAs, uris)
+}
+
+// SetController sets controller(s) of DID subject; single controller is set as a string and multiple
+// controllers are set as a set (array); empty list removes the property.
+func (doc Document) SetController(controllers []string) {
+	setStringOrStringArray(doc, ControllerProperty, controllers)
+}
+
 // GetStringValue returns string value for specified key or "" if not found or wrong type.
 func (doc Document) GetStringValue(key string) string {
 	return stringEntry(doc[key])
@@ -54,6 +76,11 @@ func (doc Document) Bytes() ([]byte, error) {
 	return docutil.MarshalCanonical(doc)
 }
 
+// Hash returns encoded multihash of canonicalized (JCS) document using specified multihash algorithm.
+func Hash(doc Document, multihashCode uint) (string, error) {
+	return hashing.CalculateModelMultihash(doc, multihashCode)
+}
+
 // JSONLdObject returns map that represents JSON LD Object.
 func (doc Document) JSONLdObject() map[string]interface{} {
 	return doc
@@ -71,6 +98,46 @@ func stringEntry(entry interface{}) string {
 	return id
 }
 
+// stringOrStringArray returns string array from interface that is either a string or an array of strings.
+func stringOrStringArray(entry interface{}) []string {
+	if value, ok := entry.(string); ok {
+		if value == "" {
+			return nil
+		}
+
+		return []string{value}
+	}
+
+	return StringArray(entry)
+}
+
+// setStringArray sets string array value (as array of interfaces) or removes the key if array is empty.
+func setStringArray(obj map[string]interface{}, key string, values []string) {
+	if len(values) == 0 {
+		delete(obj, key)
+
+		return
+	}
+
+	entries := make([]interface{}, len(values))
+	for i, v := range values {
+		entries[i] = v
+	}
+
+	obj[key] = entries
+}
+
+// setStringOrStringArray sets single value as string and multiple values as array; empty array removes the key.
+func setStringOrStringArray(obj map[string]interface{}, key string, values []string) {
+	if len(values) == 1 {
+		obj[key] = values[0]
+
+		return
+	}
+
+	setStringArray(obj, key, values)
+}
+
 // StringArray is utility function to return string array from interface.
 func StringArray(entry interface{}) []string {
 	if entry == nil {
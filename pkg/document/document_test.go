@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 )
 
 func TestFromBytes(t *testing.T) {
@@ -68,6 +70,122 @@ func TestGetStringValue(t *testing.T) {
 	require.Equal(t, "", doc.GetStringValue(key))
 }
 
+func TestAlsoKnownAs(t *testing.T) {
+	doc, err := FromBytes([]byte(`{"alsoKnownAs": ["did:domain.com", "https://other.com"]}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"did:domain.com", "https://other.com"}, doc.AlsoKnownAs())
+
+	didDoc := DidDocumentFromJSONLDObject(doc.JSONLdObject())
+	require.Equal(t, []string{"did:domain.com", "https://other.com"}, didDoc.AlsoKnownAs())
+
+	require.Empty(t, Document{}.AlsoKnownAs())
+}
+
+func TestController(t *testing.T) {
+	doc, err := FromBytes([]byte(`{"controller": "did:example:123"}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"did:example:123"}, doc.Controller())
+
+	doc, err = FromBytes([]byte(`{"controller": ["did:example:123", "did:example:456"]}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"did:example:123", "did:example:456"}, doc.Controller())
+
+	didDoc := DidDocumentFromJSONLDObject(doc.JSONLdObject())
+	require.Equal(t, []string{"did:example:123", "did:example:456"}, didDoc.Controller())
+
+	replaceDoc := ReplaceDocumentFromJSONLDObject(map[string]interface{}{ReplaceControllerProperty: "did:example:123"})
+	require.Equal(t, []string{"did:example:123"}, replaceDoc.Controller())
+
+	require.Empty(t, Document{}.Controller())
+	require.Empty(t, Document{ControllerProperty: ""}.Controller())
+}
+
+func TestSetAlsoKnownAs(t *testing.T) {
+	doc := make(Document)
+
+	doc.SetAlsoKnownAs([]string{"did:domain.com", "https://other.com"})
+	require.Equal(t, []interface{}{"did:domain.com", "https://other.com"}, doc[AlsoKnownAs])
+	require.Equal(t, []string{"did:domain.com", "https://other.com"}, doc.AlsoKnownAs())
+
+	doc.SetAlsoKnownAs(nil)
+	require.NotContains(t, doc, AlsoKnownAs)
+
+	didDoc := make(DIDDocument)
+
+	didDoc.SetAlsoKnownAs([]string{"did:domain.com"})
+	require.Equal(t, []string{"did:domain.com"}, didDoc.AlsoKnownAs())
+
+	didDoc.SetAlsoKnownAs([]string{})
+	require.NotContains(t, didDoc, AlsoKnownAs)
+}
+
+func TestSetController(t *testing.T) {
+	doc := make(Document)
+
+	doc.SetController([]string{"did:example:123"})
+	require.Equal(t, "did:example:123", doc[ControllerProperty])
+	require.Equal(t, []string{"did:example:123"}, doc.Controller())
+
+	doc.SetController([]string{"did:example:123", "did:example:456"})
+	require.Equal(t, []interface{}{"did:example:123", "did:example:456"}, doc[ControllerProperty])
+	require.Equal(t, []string{"did:example:123", "did:example:456"}, doc.Controller())
+
+	doc.SetController(nil)
+	require.NotContains(t, doc, ControllerProperty)
+
+	didDoc := make(DIDDocument)
+
+	didDoc.SetController([]string{"did:example:123", "did:example:456"})
+	require.Equal(t, []string{"did:example:123", "did:example:456"}, didDoc.Controller())
+
+	didDoc.SetController(nil)
+	require.NotContains(t, didDoc, ControllerProperty)
+}
+
+func TestHash(t *testing.T) {
+	const sha2_256 = 18
+
+	t.Run("success", func(t *testing.T) {
+		doc1, err := FromBytes([]byte(`{"id":"did:example:123","alsoKnownAs":["a","b"]}`))
+		require.NoError(t, err)
+
+		doc2, err := FromBytes([]byte(`{"alsoKnownAs":["a","b"], "id":"did:example:123"}`))
+		require.NoError(t, err)
+
+		hash1, err := Hash(doc1, sha2_256)
+		require.NoError(t, err)
+
+		hash2, err := Hash(doc2, sha2_256)
+		require.NoError(t, err)
+
+		require.Equal(t, hash1, hash2)
+		require.NoError(t, hashing.IsValidModelMultihash(doc1, hash1))
+
+		code, err := hashing.GetMultihashCode(hash1)
+		require.NoError(t, err)
+		require.Equal(t, uint64(sha2_256), code)
+
+		doc2.SetAlsoKnownAs([]string{"b", "a"})
+
+		hash2, err = Hash(doc2, sha2_256)
+		require.NoError(t, err)
+		require.NotEqual(t, hash1, hash2)
+	})
+
+	t.Run("error - multihash code not supported", func(t *testing.T) {
+		hash, err := Hash(Document{IDProperty: "did:example:123"}, 55)
+		require.Error(t, err)
+		require.Empty(t, hash)
+		require.Contains(t, err.Error(), "algorithm not supported")
+	})
+
+	t.Run("error - marshal", func(t *testing.T) {
+		hash, err := Hash(Document{IDProperty: make(chan int)}, sha2_256)
+		require.Error(t, err)
+		require.Empty(t, hash)
+	})
+}
+
 func TestStringEntry(t *testing.T) {
 	// not a string
 	str := stringEntry([]string{"hello"})
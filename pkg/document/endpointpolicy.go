@@ -0,0 +1,158 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// nolint:gochecknoglobals
+var privateNetworks = parseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"10.0.0.0/8",     // private
+	"100.64.0.0/10",  // carrier-grade NAT
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local
+	"172.16.0.0/12",  // private
+	"192.168.0.0/16", // private
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"fc00::/7",       // unique local
+	"fe80::/10",      // link-local
+)
+
+// DefaultEndpointSchemes returns default service endpoint URI schemes.
+func DefaultEndpointSchemes() []string {
+	return []string{"https", "did", "dns"}
+}
+
+// EndpointPolicy contains restrictions for service endpoint URIs (zero value doesn't restrict URIs).
+type EndpointPolicy struct {
+	// Schemes contains allowed URI schemes; any scheme is allowed if empty.
+	Schemes []string
+
+	// DenyPrivateAddresses rejects URIs with loopback, private and link-local hosts
+	// (IP literals and localhost); host names are not resolved.
+	DenyPrivateAddresses bool
+
+	// MaxLength is maximum URI length; zero means no limit.
+	MaxLength int
+}
+
+// URIs returns all URIs contained in service endpoint: URI, 'uri' property of an object
+// and URIs of array entries.
+func (se ServiceEndpoint) URIs() []string {
+	if uri, ok := se.URI(); ok {
+		return []string{uri}
+	}
+
+	if obj, ok := se.AsMap(); ok {
+		if uri, ok := obj[URIProperty].(string); ok {
+			return []string{uri}
+		}
+
+		return nil
+	}
+
+	list, ok := se.AsList()
+	if !ok {
+		return nil
+	}
+
+	var uris []string
+	for _, entry := range list {
+		uris = append(uris, entry.URIs()...)
+	}
+
+	return uris
+}
+
+// Validate validates service endpoint structure and checks all of its URIs against the policy.
+func (p EndpointPolicy) Validate(se ServiceEndpoint) error {
+	if err := se.Validate(); err != nil {
+		return err
+	}
+
+	for _, uri := range se.URIs() {
+		if err := p.CheckURI(uri); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckURI checks service endpoint URI against the policy.
+func (p EndpointPolicy) CheckURI(uri string) error {
+	if p.MaxLength > 0 && len(uri) > p.MaxLength {
+		return fmt.Errorf("service endpoint URI length %d exceeds maximum length %d", len(uri), p.MaxLength)
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("service endpoint '%s' is not a valid URI: %s", uri, err.Error())
+	}
+
+	if len(p.Schemes) > 0 && !containsString(p.Schemes, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("service endpoint '%s' scheme '%s' is not allowed", uri, u.Scheme)
+	}
+
+	if p.DenyPrivateAddresses && isPrivateHost(u.Hostname()) {
+		return fmt.Errorf("service endpoint '%s' host is a private or loopback address", uri)
+	}
+
+	return nil
+}
+
+func isPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks[i] = network
+	}
+
+	return networks
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
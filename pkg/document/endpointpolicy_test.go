@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceEndpoint_URIs(t *testing.T) {
+	require.Equal(t, []string{"https://example.com"}, NewServiceEndpoint("https://example.com").URIs())
+	require.Equal(t, []string{"https://example.com"},
+		NewServiceEndpoint(map[string]interface{}{"uri": "https://example.com"}).URIs())
+	require.Empty(t, NewServiceEndpoint(map[string]interface{}{"origins": "https://example.com"}).URIs())
+	require.Empty(t, NewServiceEndpoint(5).URIs())
+
+	endpoint := NewServiceEndpoint([]interface{}{"did:example:123", map[string]interface{}{"uri": "https://example.com"}})
+	require.Equal(t, []string{"did:example:123", "https://example.com"}, endpoint.URIs())
+}
+
+func TestEndpointPolicy(t *testing.T) {
+	policy := EndpointPolicy{
+		Schemes:              DefaultEndpointSchemes(),
+		DenyPrivateAddresses: true,
+		MaxLength:            40,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		for _, uri := range []string{
+			"https://example.com/hub",
+			"HTTPS://example.com",
+			"did:example:123",
+			"dns:example.com",
+			"https://8.8.8.8",
+			"https://[2001:db8::1]:8080",
+		} {
+			require.NoError(t, policy.CheckURI(uri), uri)
+		}
+
+		require.NoError(t, EndpointPolicy{}.CheckURI("http://127.0.0.1"))
+	})
+
+	t.Run("error - scheme not allowed", func(t *testing.T) {
+		err := policy.CheckURI("http://example.com")
+		require.EqualError(t, err, "service endpoint 'http://example.com' scheme 'http' is not allowed")
+	})
+
+	t.Run("error - private or loopback address", func(t *testing.T) {
+		for _, uri := range []string{
+			"https://localhost",
+			"https://api.localhost.:8080",
+			"https://127.0.0.1",
+			"https://10.1.2.3",
+			"https://172.16.0.1",
+			"https://192.168.1.1",
+			"https://169.254.169.254/latest",
+			"https://0.0.0.0",
+			"https://[::1]",
+			"https://[fd00::1]",
+			"https://[fe80::1]",
+		} {
+			err := policy.CheckURI(uri)
+			require.Error(t, err, uri)
+			require.Contains(t, err.Error(), "host is a private or loopback address")
+		}
+	})
+
+	t.Run("error - URI too long", func(t *testing.T) {
+		err := policy.CheckURI("https://example.com/" + strings.Repeat("a", 30))
+		require.EqualError(t, err, "service endpoint URI length 50 exceeds maximum length 40")
+	})
+
+	t.Run("error - invalid URI", func(t *testing.T) {
+		err := policy.CheckURI("https://example.com/%zz")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not a valid URI")
+	})
+
+	t.Run("validate service endpoint", func(t *testing.T) {
+		require.NoError(t, policy.Validate(NewServiceEndpoint([]interface{}{"https://example.com", "did:example:123"})))
+
+		err := policy.Validate(NewServiceEndpoint(map[string]interface{}{"uri": "https://localhost"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "host is a private or loopback address")
+
+		err = policy.Validate(NewServiceEndpoint(nil))
+		require.EqualError(t, err, "service endpoint is missing")
+	})
+}
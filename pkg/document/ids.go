@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import "fmt"
+
+// CheckUniqueIDs checks that public key and service ids are unique within the document, including
+// across public key and service sections (otherwise references in transformed document are ambiguous).
+func CheckUniqueIDs(pubKeys []PublicKey, services []Service) error {
+	sections := make(map[string]string)
+
+	add := func(id, section string) error {
+		if existing, ok := sections[id]; ok {
+			if existing == section {
+				return fmt.Errorf("duplicate %s id: %s", section, id)
+			}
+
+			return fmt.Errorf("duplicate id: %s is used by both %s and %s", id, existing, section)
+		}
+
+		sections[id] = section
+
+		return nil
+	}
+
+	for _, pk := range pubKeys {
+		if err := add(pk.ID(), "public key"); err != nil {
+			return err
+		}
+	}
+
+	for _, svc := range services {
+		if err := add(svc.ID(), "service"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUniqueIDs(t *testing.T) {
+	key := func(id string) PublicKey { return NewPublicKey(map[string]interface{}{IDProperty: id}) }
+	svc := func(id string) Service { return NewService(map[string]interface{}{IDProperty: id}) }
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, CheckUniqueIDs(nil, nil))
+		require.NoError(t, CheckUniqueIDs([]PublicKey{key("key1"), key("key2")}, []Service{svc("svc1")}))
+	})
+
+	t.Run("error - duplicate public key id", func(t *testing.T) {
+		err := CheckUniqueIDs([]PublicKey{key("key1"), key("key1")}, nil)
+		require.EqualError(t, err, "duplicate public key id: key1")
+	})
+
+	t.Run("error - duplicate service id", func(t *testing.T) {
+		err := CheckUniqueIDs(nil, []Service{svc("svc1"), svc("svc1")})
+		require.EqualError(t, err, "duplicate service id: svc1")
+	})
+
+	t.Run("error - public key and service with the same id", func(t *testing.T) {
+		err := CheckUniqueIDs([]PublicKey{key("abc")}, []Service{svc("abc")})
+		require.EqualError(t, err, "duplicate id: abc is used by both public key and service")
+	})
+}
@@ -8,6 +8,17 @@ package document
 
 import "errors"
 
+const (
+	// BLS12381G2Kty is key type of BLS12-381 G2 public key in JWK format.
+	BLS12381G2Kty = "EC"
+
+	// BLS12381G2Crv is curve of BLS12-381 G2 public key in JWK format.
+	BLS12381G2Crv = "BLS12381_G2"
+
+	// BLS12381G2PublicKeySize is size of (compressed) BLS12-381 G2 public key.
+	BLS12381G2PublicKeySize = 96
+)
+
 // JWK represents public key in JWK format.
 type JWK map[string]interface{}
 
@@ -36,6 +47,11 @@ func (jwk JWK) Y() string {
 	return stringEntry(jwk["y"])
 }
 
+// IsBLS12381G2 returns true if JWK is BLS12-381 G2 public key.
+func (jwk JWK) IsBLS12381G2() bool {
+	return jwk.Kty() == BLS12381G2Kty && jwk.Crv() == BLS12381G2Crv
+}
+
 // Validate will validate JWK properties.
 func (jwk JWK) Validate() error {
 	// TODO: validation of the JWK fields depends on the algorithm (issue-409)
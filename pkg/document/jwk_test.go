@@ -30,6 +30,15 @@ func TestJWK(t *testing.T) {
 	require.Equal(t, "crv", jwk.Crv())
 	require.Equal(t, "x", jwk.X())
 	require.Equal(t, "y", jwk.Y())
+	require.False(t, jwk.IsBLS12381G2())
+
+	jwk = NewJWK(map[string]interface{}{
+		"kty": "EC",
+		"crv": "BLS12381_G2",
+		"x":   "x",
+	})
+
+	require.True(t, jwk.IsBLS12381G2())
 }
 
 func TestValidate(t *testing.T) {
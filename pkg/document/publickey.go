@@ -22,6 +22,9 @@ const (
 
 	// PublicKeyBase58Property defines base 58 encoding for public key.
 	PublicKeyBase58Property = "publicKeyBase58"
+
+	// PublicKeyMultibaseProperty defines multibase encoding for public key.
+	PublicKeyMultibaseProperty = "publicKeyMultibase"
 )
 
 // KeyPurpose defines key purpose.
@@ -83,6 +86,11 @@ func (pk PublicKey) PublicKeyBase58() string {
 	return stringEntry(pk[PublicKeyBase58Property])
 }
 
+// PublicKeyMultibase is multibase encoded public key.
+func (pk PublicKey) PublicKeyMultibase() string {
+	return stringEntry(pk[PublicKeyMultibaseProperty])
+}
+
 // Purpose describes key purpose.
 func (pk PublicKey) Purpose() []string {
 	return StringArray(pk[PurposesProperty])
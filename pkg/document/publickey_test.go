@@ -29,8 +29,14 @@ func TestPublicKey(t *testing.T) {
 	require.Empty(t, pk.Purpose())
 	require.Empty(t, pk.PublicKeyJwk())
 	require.Empty(t, pk.PublicKeyBase58())
+	require.Empty(t, pk.PublicKeyMultibase())
 
 	require.NotEmpty(t, pk.JSONLdObject())
+
+	pk = NewPublicKey(map[string]interface{}{
+		"publicKeyMultibase": "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",
+	})
+	require.Equal(t, "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", pk.PublicKeyMultibase())
 }
 
 func TestPublicKeyJWK(t *testing.T) {
@@ -17,6 +17,12 @@ const (
 
 	// ReplacePublicKeyProperty defines key for public key property.
 	ReplacePublicKeyProperty = "publicKeys"
+
+	// ReplaceAlsoKnownAsProperty defines key for also known as property.
+	ReplaceAlsoKnownAsProperty = "alsoKnownAs"
+
+	// ReplaceControllerProperty defines key for controller property.
+	ReplaceControllerProperty = "controller"
 )
 
 // ReplaceDocument defines replace document data structure.
@@ -48,6 +54,16 @@ func (doc ReplaceDocument) Services() []Service {
 	return ParseServices(doc[ReplaceServiceProperty])
 }
 
+// AlsoKnownAs returns also known as URIs for replace document.
+func (doc ReplaceDocument) AlsoKnownAs() []string {
+	return StringArray(doc[ReplaceAlsoKnownAsProperty])
+}
+
+// Controller returns controller(s) for replace document.
+func (doc ReplaceDocument) Controller() []string {
+	return stringOrStringArray(doc[ReplaceControllerProperty])
+}
+
 // JSONLdObject returns map that represents JSON LD Object.
 func (doc ReplaceDocument) JSONLdObject() map[string]interface{} {
 	return doc
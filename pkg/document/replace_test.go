@@ -26,6 +26,16 @@ func TestReplaceDocumentFromBytes(t *testing.T) {
 	require.Equal(t, doc.PublicKeys()[0], new.PublicKeys()[0])
 }
 
+func TestReplaceDocument_AlsoKnownAs(t *testing.T) {
+	doc, err := ReplaceDocumentFromBytes([]byte(replaceDoc))
+	require.NoError(t, err)
+	require.Empty(t, doc.AlsoKnownAs())
+
+	doc, err = ReplaceDocumentFromBytes([]byte(`{"alsoKnownAs": ["did:example:123"]}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"did:example:123"}, doc.AlsoKnownAs())
+}
+
 func TestReplaceDocumentFromBytesError(t *testing.T) {
 	doc, err := ReplaceDocumentFromBytes([]byte("[test : 123]"))
 	require.NotNil(t, err)
@@ -6,12 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 
 package document
 
+import "time"
+
 // ResolutionResult describes resolution result.
 type ResolutionResult struct {
-	Context          string   `json:"@context"`
-	Document         Document `json:"didDocument"`
-	MethodMetadata   Metadata `json:"methodMetadata"`
-	DocumentMetadata Metadata `json:"didDocumentMetadata,omitempty"`
+	Context            string   `json:"@context"`
+	Document           Document `json:"didDocument"`
+	MethodMetadata     Metadata `json:"methodMetadata"`
+	DocumentMetadata   Metadata `json:"didDocumentMetadata,omitempty"`
+	ResolutionMetadata Metadata `json:"didResolutionMetadata,omitempty"`
 }
 
 // Metadata can contains various metadata such as document metadata and method metadata..
@@ -29,4 +32,123 @@ const (
 
 	// CanonicalIDProperty is canonical ID key.
 	CanonicalIDProperty = "canonicalId"
+
+	// PatchModeProperty is patch mode key.
+	PatchModeProperty = "patchMode"
+
+	// SkippedPatchesProperty is skipped patches key.
+	SkippedPatchesProperty = "skippedPatches"
+
+	// WarningsProperty is validation warnings key.
+	WarningsProperty = "warnings"
+
+	// CreatedTransactionTimeProperty is created transaction time key (logical blockchain time of the create operation).
+	CreatedTransactionTimeProperty = "createdTransactionTime"
+
+	// CreatedTransactionNumberProperty is created transaction number key.
+	CreatedTransactionNumberProperty = "createdTransactionNumber"
+
+	// UpdatedTransactionTimeProperty is updated transaction time key (logical blockchain time of the latest
+	// operation after create).
+	UpdatedTransactionTimeProperty = "updatedTransactionTime"
+
+	// UpdatedTransactionNumberProperty is updated transaction number key.
+	UpdatedTransactionNumberProperty = "updatedTransactionNumber"
+
+	// DeactivatedProperty is deactivated key.
+	DeactivatedProperty = "deactivated"
+
+	// FinalDocumentProperty is final (pre-deactivation) document key.
+	FinalDocumentProperty = "finalDocument"
+
+	// FinalDocumentHashProperty is final (pre-deactivation) document hash key.
+	FinalDocumentHashProperty = "finalDocumentHash"
+
+	// DeactivateTransactionTimeProperty is deactivate transaction time key.
+	DeactivateTransactionTimeProperty = "deactivateTransactionTime"
+
+	// DeactivateTransactionNumberProperty is deactivate transaction number key.
+	DeactivateTransactionNumberProperty = "deactivateTransactionNumber"
 )
+
+const (
+	// ContentTypeProperty is resolution metadata key for media type of the returned representation.
+	ContentTypeProperty = "contentType"
+
+	// ErrorProperty is resolution metadata key for resolution error code.
+	ErrorProperty = "error"
+
+	// DurationProperty is resolution metadata key for resolution duration (in milliseconds).
+	DurationProperty = "duration"
+
+	// ContentTypeDIDLDJSON is media type of JSON-LD DID document representation.
+	ContentTypeDIDLDJSON = "application/did+ld+json"
+
+	// ContentTypeDIDJSON is media type of plain JSON DID document representation (without @context).
+	ContentTypeDIDJSON = "application/did+json"
+
+	// DIDResolutionProfile is JSON-LD profile of DID resolution result.
+	DIDResolutionProfile = "https://w3id.org/did-resolution"
+
+	// ContentTypeDIDResolution is media type of DID resolution result (document and metadata).
+	ContentTypeDIDResolution = `application/ld+json;profile="` + DIDResolutionProfile + `"`
+)
+
+// Resolution error codes as defined by DID Resolution specification.
+const (
+	// ResolutionErrorInvalidDID is returned if DID is not valid.
+	ResolutionErrorInvalidDID = "invalidDid"
+
+	// ResolutionErrorNotFound is returned if DID document was not found.
+	ResolutionErrorNotFound = "notFound"
+
+	// ResolutionErrorRepresentationNotSupported is returned if requested representation is not supported.
+	ResolutionErrorRepresentationNotSupported = "representationNotSupported"
+
+	// ResolutionErrorInternal is returned for unexpected errors during resolution.
+	ResolutionErrorInternal = "internalError"
+)
+
+// NewResolutionMetadata creates resolution metadata (didResolutionMetadata) for successful resolution.
+func NewResolutionMetadata(contentType string, duration time.Duration) Metadata {
+	return Metadata{
+		ContentTypeProperty: contentType,
+		DurationProperty:    duration.Milliseconds(),
+	}
+}
+
+// NewResolutionErrorMetadata creates resolution metadata (didResolutionMetadata) for failed resolution.
+func NewResolutionErrorMetadata(code string, duration time.Duration) Metadata {
+	return Metadata{
+		ErrorProperty:    code,
+		DurationProperty: duration.Milliseconds(),
+	}
+}
+
+// ResolutionOptions contains options for document resolution.
+type ResolutionOptions struct {
+	// ContentType is requested DID document representation (default is application/did+ld+json)
+	ContentType string
+}
+
+// ResolutionOption is an option for document resolution.
+type ResolutionOption func(opts *ResolutionOptions)
+
+// WithContentType requests DID document representation (application/did+json or application/did+ld+json).
+func WithContentType(contentType string) ResolutionOption {
+	return func(opts *ResolutionOptions) {
+		opts.ContentType = contentType
+	}
+}
+
+// GetResolutionOptions returns resolution options for the given option functions.
+func GetResolutionOptions(opts ...ResolutionOption) ResolutionOptions {
+	options := ResolutionOptions{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
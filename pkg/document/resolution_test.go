@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolutionMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		md := NewResolutionMetadata(ContentTypeDIDLDJSON, 1500*time.Millisecond)
+		require.Equal(t, ContentTypeDIDLDJSON, md[ContentTypeProperty])
+		require.Equal(t, int64(1500), md[DurationProperty])
+		require.NotContains(t, md, ErrorProperty)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		md := NewResolutionErrorMetadata(ResolutionErrorNotFound, time.Second)
+		require.Equal(t, ResolutionErrorNotFound, md[ErrorProperty])
+		require.Equal(t, int64(1000), md[DurationProperty])
+		require.NotContains(t, md, ContentTypeProperty)
+	})
+
+	t.Run("marshal resolution result", func(t *testing.T) {
+		result := &ResolutionResult{
+			Document:       Document{IDProperty: "did:example:123"},
+			MethodMetadata: Metadata{PublishedProperty: true},
+		}
+
+		bytes, err := json.Marshal(result)
+		require.NoError(t, err)
+		require.NotContains(t, string(bytes), "didResolutionMetadata")
+
+		result.ResolutionMetadata = NewResolutionMetadata(ContentTypeDIDLDJSON, time.Millisecond)
+
+		bytes, err = json.Marshal(result)
+		require.NoError(t, err)
+		require.Contains(t, string(bytes), `"didResolutionMetadata":{"contentType":"application/did+ld+json","duration":1}`)
+	})
+}
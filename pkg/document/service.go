@@ -6,8 +6,22 @@ SPDX-License-Identifier: Apache-2.0
 
 package document
 
-// ServiceEndpointProperty describes external service endpoint property.
-const ServiceEndpointProperty = "serviceEndpoint"
+const (
+	// ServiceEndpointProperty describes external service endpoint property.
+	ServiceEndpointProperty = "serviceEndpoint"
+
+	// DIDCommMessagingServiceType is type of DIDComm v2 messaging service.
+	DIDCommMessagingServiceType = "DIDCommMessaging"
+
+	// URIProperty describes service endpoint URI property (object-valued service endpoint).
+	URIProperty = "uri"
+
+	// AcceptProperty describes media types (profiles) accepted by DIDComm messaging service.
+	AcceptProperty = "accept"
+
+	// RoutingKeysProperty describes routing keys (mediators) of DIDComm messaging service.
+	RoutingKeysProperty = "routingKeys"
+)
 
 // Service represents any type of service the entity wishes to advertise.
 type Service map[string]interface{}
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ServiceEndpoint is typed model of service endpoint that can be represented as a URI (string),
+// an object (map) or an array of URIs and/or objects.
+type ServiceEndpoint struct {
+	value interface{}
+}
+
+// NewServiceEndpoint creates service endpoint model from service endpoint value.
+func NewServiceEndpoint(value interface{}) ServiceEndpoint {
+	return ServiceEndpoint{value: value}
+}
+
+// Endpoint returns typed model of service endpoint.
+func (s Service) Endpoint() ServiceEndpoint {
+	return NewServiceEndpoint(s[ServiceEndpointProperty])
+}
+
+// Value returns raw service endpoint value.
+func (se ServiceEndpoint) Value() interface{} {
+	return se.value
+}
+
+// IsEmpty returns true if service endpoint is not specified.
+func (se ServiceEndpoint) IsEmpty() bool {
+	return se.value == nil
+}
+
+// URI returns service endpoint URI if service endpoint is a string.
+func (se ServiceEndpoint) URI() (string, bool) {
+	uri, ok := se.value.(string)
+
+	return uri, ok
+}
+
+// AsMap returns service endpoint object if service endpoint is an object.
+func (se ServiceEndpoint) AsMap() (map[string]interface{}, bool) {
+	switch v := se.value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case Document:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// AsList returns service endpoint entries if service endpoint is an array.
+func (se ServiceEndpoint) AsList() ([]ServiceEndpoint, bool) {
+	switch v := se.value.(type) {
+	case []interface{}:
+		list := make([]ServiceEndpoint, len(v))
+		for i, entry := range v {
+			list[i] = NewServiceEndpoint(entry)
+		}
+
+		return list, true
+	case []string:
+		list := make([]ServiceEndpoint, len(v))
+		for i, entry := range v {
+			list[i] = NewServiceEndpoint(entry)
+		}
+
+		return list, true
+	default:
+		return nil, false
+	}
+}
+
+// Validate validates service endpoint: URI has to be valid, object cannot be empty (and its 'uri' property
+// has to be valid URI if specified) and array has to contain at least one entry where each entry is either
+// URI or object.
+func (se ServiceEndpoint) Validate() error {
+	if se.IsEmpty() {
+		return errors.New("service endpoint is missing")
+	}
+
+	if uri, ok := se.URI(); ok {
+		return ValidateServiceEndpointURI(uri)
+	}
+
+	if obj, ok := se.AsMap(); ok {
+		return validateServiceEndpointObject(obj)
+	}
+
+	list, ok := se.AsList()
+	if !ok {
+		return errors.New("service endpoint must be a URI, an object or an array")
+	}
+
+	if len(list) == 0 {
+		return errors.New("service endpoint array is empty")
+	}
+
+	for _, entry := range list {
+		if _, ok := entry.AsList(); ok || entry.IsEmpty() {
+			return errors.New("service endpoint array entries must be URIs or objects")
+		}
+
+		if err := entry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateServiceEndpointURI validates service endpoint URI.
+func ValidateServiceEndpointURI(uri string) error {
+	if uri == "" {
+		return errors.New("service endpoint URI is empty")
+	}
+
+	if _, err := url.ParseRequestURI(uri); err != nil {
+		return fmt.Errorf("service endpoint '%s' is not a valid URI: %s", uri, err.Error())
+	}
+
+	return nil
+}
+
+func validateServiceEndpointObject(obj map[string]interface{}) error {
+	if len(obj) == 0 {
+		return errors.New("service endpoint object is empty")
+	}
+
+	entry, ok := obj[URIProperty]
+	if !ok {
+		return nil
+	}
+
+	uri, ok := entry.(string)
+	if !ok {
+		return fmt.Errorf("service endpoint object property '%s' must be a string", URIProperty)
+	}
+
+	return ValidateServiceEndpointURI(uri)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceEndpoint(t *testing.T) {
+	t.Run("URI", func(t *testing.T) {
+		svc := NewService(map[string]interface{}{ServiceEndpointProperty: "https://example.com"})
+
+		endpoint := svc.Endpoint()
+		require.False(t, endpoint.IsEmpty())
+		require.Equal(t, "https://example.com", endpoint.Value())
+		require.NoError(t, endpoint.Validate())
+
+		uri, ok := endpoint.URI()
+		require.True(t, ok)
+		require.Equal(t, "https://example.com", uri)
+
+		_, ok = endpoint.AsMap()
+		require.False(t, ok)
+
+		_, ok = endpoint.AsList()
+		require.False(t, ok)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(`{"service":[{"serviceEndpoint":{"uri":"https://example.com"}}]}`))
+		require.NoError(t, err)
+
+		endpoint := doc.Services()[0].Endpoint()
+		require.NoError(t, endpoint.Validate())
+
+		obj, ok := endpoint.AsMap()
+		require.True(t, ok)
+		require.Equal(t, "https://example.com", obj[URIProperty])
+
+		_, ok = endpoint.URI()
+		require.False(t, ok)
+
+		_, ok = NewServiceEndpoint(Document{"origins": "https://example.com"}).AsMap()
+		require.True(t, ok)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(
+			`{"service":[{"serviceEndpoint":["https://example.com",{"uri":"https://example.org"}]}]}`))
+		require.NoError(t, err)
+
+		endpoint := doc.Services()[0].Endpoint()
+		require.NoError(t, endpoint.Validate())
+
+		list, ok := endpoint.AsList()
+		require.True(t, ok)
+		require.Len(t, list, 2)
+
+		uri, ok := list[0].URI()
+		require.True(t, ok)
+		require.Equal(t, "https://example.com", uri)
+
+		obj, ok := list[1].AsMap()
+		require.True(t, ok)
+		require.Equal(t, "https://example.org", obj[URIProperty])
+
+		list, ok = NewServiceEndpoint([]string{"https://example.com"}).AsList()
+		require.True(t, ok)
+		require.Len(t, list, 1)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		tests := []struct {
+			value interface{}
+			err   string
+		}{
+			{nil, "service endpoint is missing"},
+			{"", "service endpoint URI is empty"},
+			{"hello", "service endpoint 'hello' is not a valid URI"},
+			{map[string]interface{}{}, "service endpoint object is empty"},
+			{map[string]interface{}{URIProperty: 1}, "service endpoint object property 'uri' must be a string"},
+			{map[string]interface{}{URIProperty: "hello"}, "service endpoint 'hello' is not a valid URI"},
+			{[]interface{}{}, "service endpoint array is empty"},
+			{[]interface{}{[]interface{}{"https://example.com"}}, "service endpoint array entries must be URIs or objects"},
+			{[]interface{}{nil}, "service endpoint array entries must be URIs or objects"},
+			{[]string{"hello"}, "service endpoint 'hello' is not a valid URI"},
+			{true, "service endpoint must be a URI, an object or an array"},
+		}
+
+		for _, tc := range tests {
+			err := NewServiceEndpoint(tc.value).Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+)
+
+// Stats contains document size and complexity information.
+type Stats struct {
+	// Size is size of canonicalized document in bytes.
+	Size int `json:"size"`
+	// PublicKeys is number of public keys (verification methods) in the document.
+	PublicKeys int `json:"publicKeys"`
+	// Services is number of services in the document.
+	Services int `json:"services"`
+	// Depth is maximum nesting depth of objects and arrays in the document (document itself has depth 1).
+	Depth int `json:"depth"`
+}
+
+// Limits defines document size and complexity limits (0 means no limit).
+type Limits struct {
+	MaxSize       uint
+	MaxPublicKeys uint
+	MaxServices   uint
+	MaxDepth      uint
+}
+
+// GetStats returns document size and complexity information; public keys are counted in both internal
+// (publicKey) and external (verificationMethod) representation of the document.
+func GetStats(doc Document) (*Stats, error) {
+	bytes, err := docutil.MarshalCanonical(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize document: %s", err.Error())
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document: %s", err.Error())
+	}
+
+	didDoc := DidDocumentFromJSONLDObject(normalized)
+
+	return &Stats{
+		Size:       len(bytes),
+		PublicKeys: len(didDoc.PublicKeys()) + len(didDoc.VerificationMethods()),
+		Services:   len(didDoc.Services()),
+		Depth:      depth(normalized),
+	}, nil
+}
+
+// CheckLimits returns an error if document stats exceed any of the specified limits.
+func (s *Stats) CheckLimits(limits Limits) error {
+	if exceeds(s.Size, limits.MaxSize) {
+		return fmt.Errorf("document size %d exceeds maximum size %d", s.Size, limits.MaxSize)
+	}
+
+	if exceeds(s.PublicKeys, limits.MaxPublicKeys) {
+		return fmt.Errorf("document public keys count %d exceeds maximum %d", s.PublicKeys, limits.MaxPublicKeys)
+	}
+
+	if exceeds(s.Services, limits.MaxServices) {
+		return fmt.Errorf("document services count %d exceeds maximum %d", s.Services, limits.MaxServices)
+	}
+
+	if exceeds(s.Depth, limits.MaxDepth) {
+		return fmt.Errorf("document depth %d exceeds maximum depth %d", s.Depth, limits.MaxDepth)
+	}
+
+	return nil
+}
+
+func exceeds(value int, limit uint) bool {
+	return limit > 0 && value > int(limit)
+}
+
+func depth(value interface{}) int {
+	var children []interface{}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			children = append(children, child)
+		}
+	case []interface{}:
+		children = v
+	default:
+		return 0
+	}
+
+	max := 0
+
+	for _, child := range children {
+		if d := depth(child); d > max {
+			max = d
+		}
+	}
+
+	return max + 1
+}
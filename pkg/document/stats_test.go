@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	t.Run("success - internal document", func(t *testing.T) {
+		doc, err := FromBytes([]byte(`{"publicKey":[{"id":"key1"},{"id":"key2"}],"service":[{"id":"svc"}]}`))
+		require.NoError(t, err)
+
+		bytes, err := doc.Bytes()
+		require.NoError(t, err)
+
+		stats, err := GetStats(doc)
+		require.NoError(t, err)
+		require.Equal(t, len(bytes), stats.Size)
+		require.Equal(t, 2, stats.PublicKeys)
+		require.Equal(t, 1, stats.Services)
+		require.Equal(t, 3, stats.Depth)
+	})
+
+	t.Run("success - external document with typed arrays", func(t *testing.T) {
+		doc := Document{
+			IDProperty:                 "did:example:123",
+			VerificationMethodProperty: []PublicKey{{IDProperty: "#key1", PublicKeyJwkProperty: JWK{"kty": "OKP"}}},
+			ServiceProperty: []Service{{
+				IDProperty:              "#svc",
+				ServiceEndpointProperty: map[string]interface{}{"origins": []string{"https://example.com"}},
+			}},
+		}
+
+		stats, err := GetStats(doc)
+		require.NoError(t, err)
+		require.Equal(t, 1, stats.PublicKeys)
+		require.Equal(t, 1, stats.Services)
+		require.Equal(t, 5, stats.Depth)
+	})
+
+	t.Run("success - empty document", func(t *testing.T) {
+		stats, err := GetStats(Document{})
+		require.NoError(t, err)
+		require.Equal(t, &Stats{Size: 2, Depth: 1}, stats)
+	})
+
+	t.Run("error - marshal", func(t *testing.T) {
+		stats, err := GetStats(Document{IDProperty: make(chan int)})
+		require.Error(t, err)
+		require.Nil(t, stats)
+		require.Contains(t, err.Error(), "failed to canonicalize document")
+	})
+}
+
+func TestStats_CheckLimits(t *testing.T) {
+	stats := &Stats{Size: 100, PublicKeys: 2, Services: 3, Depth: 4}
+
+	require.NoError(t, stats.CheckLimits(Limits{}))
+	require.NoError(t, stats.CheckLimits(Limits{MaxSize: 100, MaxPublicKeys: 2, MaxServices: 3, MaxDepth: 4}))
+
+	err := stats.CheckLimits(Limits{MaxSize: 99})
+	require.EqualError(t, err, "document size 100 exceeds maximum size 99")
+
+	err = stats.CheckLimits(Limits{MaxPublicKeys: 1})
+	require.EqualError(t, err, "document public keys count 2 exceeds maximum 1")
+
+	err = stats.CheckLimits(Limits{MaxServices: 2})
+	require.EqualError(t, err, "document services count 3 exceeds maximum 2")
+
+	err = stats.CheckLimits(Limits{MaxDepth: 3})
+	require.EqualError(t, err, "document depth 4 exceeds maximum depth 3")
+}
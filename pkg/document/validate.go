@@ -0,0 +1,406 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DIDContext is the base JSON-LD context of DID document.
+	DIDContext = "https://www.w3.org/ns/did/v1"
+
+	didScheme = "did:"
+)
+
+// nolint:gochecknoglobals
+var (
+	// method name is lowercase alphanumeric, method specific ID is non-empty.
+	didRegex = regexp.MustCompile(`^did:[a-z0-9]+:\S+$`)
+
+	relationshipProperties = []string{
+		AuthenticationProperty,
+		AssertionMethodProperty,
+		KeyAgreementProperty,
+		DelegationKeyProperty,
+		InvocationKeyProperty,
+	}
+
+	verificationMaterialProperties = []string{
+		PublicKeyJwkProperty,
+		PublicKeyBase58Property,
+		PublicKeyMultibaseProperty,
+	}
+)
+
+// ValidationOption is DID document validation option.
+type ValidationOption func(opts *validationOptions)
+
+type validationOptions struct {
+	contextRegistry *ContextRegistry
+}
+
+// WithContextRegistry validates document contexts against context registry.
+func WithContextRegistry(registry *ContextRegistry) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.contextRegistry = registry
+	}
+}
+
+// ValidateDIDDocument validates (external) DID document against W3C DID Core structural rules:
+// - id is required and has to be a DID
+// - verification methods have id, type, controller and exactly one verification material property
+// - verification relationships either embed verification methods or reference existing verification methods
+// - services have id, type and service endpoint
+// - alsoKnownAs is a set of strings and controller is a DID or a set of DIDs
+// - contexts are allowed by context registry (if provided).
+func ValidateDIDDocument(doc DIDDocument, opts ...ValidationOption) error {
+	options := &validationOptions{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	// normalize document (e.g. typed arrays produced by document transformer) to JSON representation
+	normalized, err := normalize(doc)
+	if err != nil {
+		return err
+	}
+
+	id, ok := normalized[IDProperty].(string)
+	if !ok {
+		return errors.New("did document: id is required and must be a string")
+	}
+
+	if !isDID(id) {
+		return fmt.Errorf("did document: id '%s' is not a valid DID", id)
+	}
+
+	if err := validateContext(normalized, options.contextRegistry); err != nil {
+		return err
+	}
+
+	if err := validateDIDControllers(normalized); err != nil {
+		return err
+	}
+
+	if err := validateStringSet(normalized, AlsoKnownAs); err != nil {
+		return err
+	}
+
+	methodIDs, err := validateVerificationMethods(id, normalized)
+	if err != nil {
+		return err
+	}
+
+	for _, relationship := range relationshipProperties {
+		if err := validateRelationship(id, normalized, relationship, methodIDs); err != nil {
+			return err
+		}
+	}
+
+	return validateDIDServices(id, normalized)
+}
+
+func normalize(doc DIDDocument) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("did document: failed to marshal: %s", err.Error())
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return nil, fmt.Errorf("did document: failed to unmarshal: %s", err.Error())
+	}
+
+	return normalized, nil
+}
+
+func validateContext(doc map[string]interface{}, registry *ContextRegistry) error {
+	entry, ok := doc[ContextProperty]
+	if !ok {
+		return nil
+	}
+
+	var contexts []interface{}
+
+	switch ctx := entry.(type) {
+	case string:
+		contexts = []interface{}{ctx}
+	case []interface{}:
+		contexts = ctx
+	}
+
+	if len(contexts) == 0 || contexts[0] != DIDContext {
+		return fmt.Errorf("did document: first context must be '%s'", DIDContext)
+	}
+
+	if registry == nil {
+		return nil
+	}
+
+	if err := registry.Validate(contexts); err != nil {
+		return fmt.Errorf("did document: %s", err.Error())
+	}
+
+	return nil
+}
+
+func validateDIDControllers(doc map[string]interface{}) error {
+	entry, ok := doc[ControllerProperty]
+	if !ok {
+		return nil
+	}
+
+	var controllers []interface{}
+
+	switch c := entry.(type) {
+	case string:
+		controllers = []interface{}{c}
+	case []interface{}:
+		controllers = c
+	default:
+		return errors.New("did document: controller must be a string or a set of strings")
+	}
+
+	for _, c := range controllers {
+		controller, ok := c.(string)
+		if !ok || !isDID(controller) {
+			return fmt.Errorf("did document: controller '%v' is not a valid DID", c)
+		}
+	}
+
+	return nil
+}
+
+func validateStringSet(doc map[string]interface{}, property string) error {
+	entry, ok := doc[property]
+	if !ok {
+		return nil
+	}
+
+	values, ok := entry.([]interface{})
+	if !ok {
+		return fmt.Errorf("did document: %s must be a set of strings", property)
+	}
+
+	existing := make(map[string]bool)
+
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok || str == "" {
+			return fmt.Errorf("did document: %s must be a set of strings", property)
+		}
+
+		if existing[str] {
+			return fmt.Errorf("did document: duplicate value in %s: %s", property, str)
+		}
+
+		existing[str] = true
+	}
+
+	return nil
+}
+
+func validateVerificationMethods(did string, doc map[string]interface{}) (map[string]bool, error) {
+	methodIDs := make(map[string]bool)
+
+	entry, ok := doc[VerificationMethodProperty]
+	if !ok {
+		return methodIDs, nil
+	}
+
+	methods, ok := entry.([]interface{})
+	if !ok {
+		return nil, errors.New("did document: verificationMethod must be an array")
+	}
+
+	for _, m := range methods {
+		if err := addVerificationMethod(did, m, methodIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return methodIDs, nil
+}
+
+func addVerificationMethod(did string, entry interface{}, methodIDs map[string]bool) error {
+	method, ok := entry.(map[string]interface{})
+	if !ok {
+		return errors.New("did document: verification method must be an object")
+	}
+
+	id, err := validateVerificationMethod(did, method)
+	if err != nil {
+		return err
+	}
+
+	if methodIDs[id] {
+		return fmt.Errorf("did document: duplicate verification method id: %s", id)
+	}
+
+	methodIDs[id] = true
+
+	return nil
+}
+
+// validateVerificationMethod validates verification method and returns its absolute id.
+func validateVerificationMethod(did string, method map[string]interface{}) (string, error) {
+	id, ok := method[IDProperty].(string)
+	if !ok || id == "" {
+		return "", errors.New("did document: verification method id is required")
+	}
+
+	absoluteID, err := absoluteDIDURL(did, id)
+	if err != nil {
+		return "", fmt.Errorf("did document: verification method %s", err.Error())
+	}
+
+	if t, ok := method[TypeProperty].(string); !ok || t == "" {
+		return "", fmt.Errorf("did document: verification method '%s' type is required", id)
+	}
+
+	controller, ok := method[ControllerProperty].(string)
+	if !ok || !isDID(controller) {
+		return "", fmt.Errorf("did document: verification method '%s' controller is required and must be a DID", id)
+	}
+
+	count := 0
+
+	for _, property := range verificationMaterialProperties {
+		if _, ok := method[property]; ok {
+			count++
+		}
+	}
+
+	if count != 1 {
+		return "", fmt.Errorf("did document: verification method '%s' must have exactly one verification material property", id)
+	}
+
+	return absoluteID, nil
+}
+
+func validateRelationship(did string, doc map[string]interface{}, relationship string, methodIDs map[string]bool) error {
+	entry, ok := doc[relationship]
+	if !ok {
+		return nil
+	}
+
+	entries, ok := entry.([]interface{})
+	if !ok {
+		return fmt.Errorf("did document: %s must be an array", relationship)
+	}
+
+	for _, e := range entries {
+		switch value := e.(type) {
+		case string:
+			ref, err := absoluteDIDURL(did, value)
+			if err != nil {
+				return fmt.Errorf("did document: %s reference %s", relationship, err.Error())
+			}
+
+			if !methodIDs[ref] {
+				return fmt.Errorf("did document: %s reference '%s' does not match any verification method", relationship, value)
+			}
+		case map[string]interface{}:
+			if _, err := validateVerificationMethod(did, value); err != nil {
+				return fmt.Errorf("%s (%s)", err.Error(), relationship)
+			}
+		default:
+			return fmt.Errorf("did document: %s entry must be a reference or an embedded verification method", relationship)
+		}
+	}
+
+	return nil
+}
+
+func validateDIDServices(did string, doc map[string]interface{}) error {
+	entry, ok := doc[ServiceProperty]
+	if !ok {
+		return nil
+	}
+
+	services, ok := entry.([]interface{})
+	if !ok {
+		return errors.New("did document: service must be an array")
+	}
+
+	ids := make(map[string]bool)
+
+	for _, s := range services {
+		service, ok := s.(map[string]interface{})
+		if !ok {
+			return errors.New("did document: service must be an object")
+		}
+
+		id, ok := service[IDProperty].(string)
+		if !ok || id == "" {
+			return errors.New("did document: service id is required")
+		}
+
+		absoluteID, err := absoluteDIDURL(did, id)
+		if err != nil {
+			// service id may be any URI
+			absoluteID = id
+		}
+
+		if ids[absoluteID] {
+			return fmt.Errorf("did document: duplicate service id: %s", id)
+		}
+
+		ids[absoluteID] = true
+
+		if !isServiceType(service[TypeProperty]) {
+			return fmt.Errorf("did document: service '%s' type must be a string or a set of strings", id)
+		}
+
+		if service[ServiceEndpointProperty] == nil {
+			return fmt.Errorf("did document: service '%s' serviceEndpoint is required", id)
+		}
+	}
+
+	return nil
+}
+
+func isServiceType(entry interface{}) bool {
+	switch t := entry.(type) {
+	case string:
+		return t != ""
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); !ok || s == "" {
+				return false
+			}
+		}
+
+		return len(t) > 0
+	default:
+		return false
+	}
+}
+
+// absoluteDIDURL returns absolute DID URL for relative (fragment) or absolute DID URL.
+func absoluteDIDURL(did, id string) (string, error) {
+	if strings.HasPrefix(id, "#") && len(id) > 1 {
+		return did + id, nil
+	}
+
+	if strings.HasPrefix(id, didScheme) && strings.Contains(id, "#") && isDID(strings.SplitN(id, "#", 2)[0]) {
+		return id, nil
+	}
+
+	return "", fmt.Errorf("'%s' is not a DID URL", id)
+}
+
+func isDID(value string) bool {
+	return didRegex.MatchString(value)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDIDDocument(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(validDIDCoreDoc))
+		require.NoError(t, err)
+
+		require.NoError(t, ValidateDIDDocument(doc))
+	})
+
+	t.Run("success - minimal document", func(t *testing.T) {
+		require.NoError(t, ValidateDIDDocument(DIDDocument{IDProperty: "did:example:123"}))
+	})
+
+	t.Run("success - typed arrays", func(t *testing.T) {
+		doc := DIDDocument{
+			IDProperty: "did:example:123",
+			VerificationMethodProperty: []PublicKey{{
+				IDProperty:              "#key1",
+				TypeProperty:            "Ed25519VerificationKey2018",
+				ControllerProperty:      "did:example:123",
+				PublicKeyBase58Property: "abc",
+			}},
+			AuthenticationProperty: []string{"did:example:123#key1"},
+			ServiceProperty: []Service{{
+				IDProperty:              "#hub",
+				TypeProperty:            "IdentityHub",
+				ServiceEndpointProperty: "https://example.com/hub",
+			}},
+		}
+
+		require.NoError(t, ValidateDIDDocument(doc))
+	})
+
+	t.Run("success - context registry", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(validDIDCoreDoc))
+		require.NoError(t, err)
+
+		registry := NewContextRegistry(WithOfflineMode(true))
+		registry.Register("https://w3id.org/security/suites/jws-2020/v1")
+
+		require.NoError(t, ValidateDIDDocument(doc, WithContextRegistry(registry)))
+	})
+
+	t.Run("error - context not allowed by context registry", func(t *testing.T) {
+		doc, err := DidDocumentFromBytes([]byte(validDIDCoreDoc))
+		require.NoError(t, err)
+
+		err = ValidateDIDDocument(doc, WithContextRegistry(NewContextRegistry(WithOfflineMode(true))))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did document: unknown remote context")
+	})
+
+	t.Run("error - not a JSON document", func(t *testing.T) {
+		err := ValidateDIDDocument(DIDDocument{IDProperty: make(chan int)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to marshal")
+	})
+
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{"missing id", `{}`, "id is required"},
+		{"invalid id", `{"id": "example:123"}`, "is not a valid DID"},
+		{"invalid context", `{"id": "did:example:123", "@context": ["https://example.com"]}`, "first context must be"},
+		{"empty context", `{"id": "did:example:123", "@context": []}`, "first context must be"},
+		{"invalid controller", `{"id": "did:example:123", "controller": "example"}`, "controller 'example' is not a valid DID"},
+		{"invalid controller type", `{"id": "did:example:123", "controller": 1}`, "controller must be a string or a set of strings"},
+		{"invalid alsoKnownAs", `{"id": "did:example:123", "alsoKnownAs": "https://example.com"}`, "alsoKnownAs must be a set of strings"},
+		{"invalid alsoKnownAs value", `{"id": "did:example:123", "alsoKnownAs": [1]}`, "alsoKnownAs must be a set of strings"},
+		{"duplicate alsoKnownAs", `{"id": "did:example:123", "alsoKnownAs": ["a", "a"]}`, "duplicate value in alsoKnownAs"},
+		{"invalid verification methods", `{"id": "did:example:123", "verificationMethod": {}}`, "verificationMethod must be an array"},
+		{"invalid verification method", `{"id": "did:example:123", "verificationMethod": ["key"]}`, "verification method must be an object"},
+		{
+			"missing verification method id",
+			`{"id": "did:example:123", "verificationMethod": [{"type": "t"}]}`,
+			"verification method id is required",
+		},
+		{
+			"invalid verification method id",
+			`{"id": "did:example:123", "verificationMethod": [{"id": "key1"}]}`,
+			"'key1' is not a DID URL",
+		},
+		{
+			"missing verification method type",
+			`{"id": "did:example:123", "verificationMethod": [{"id": "#key1"}]}`,
+			"verification method '#key1' type is required",
+		},
+		{
+			"missing verification method controller",
+			`{"id": "did:example:123", "verificationMethod": [{"id": "#key1", "type": "t"}]}`,
+			"controller is required and must be a DID",
+		},
+		{
+			"missing verification material",
+			`{"id": "did:example:123", "verificationMethod": [{"id": "#key1", "type": "t", "controller": "did:example:123"}]}`,
+			"must have exactly one verification material property",
+		},
+		{
+			"multiple verification material properties",
+			`{"id": "did:example:123", "verificationMethod": [{"id": "#key1", "type": "t", "controller": "did:example:123",
+				"publicKeyBase58": "abc", "publicKeyMultibase": "zabc"}]}`,
+			"must have exactly one verification material property",
+		},
+		{
+			"duplicate verification method id",
+			`{"id": "did:example:123", "verificationMethod": [
+				{"id": "#key1", "type": "t", "controller": "did:example:123", "publicKeyBase58": "abc"},
+				{"id": "did:example:123#key1", "type": "t", "controller": "did:example:123", "publicKeyBase58": "abc"}]}`,
+			"duplicate verification method id: did:example:123#key1",
+		},
+		{"invalid relationship", `{"id": "did:example:123", "authentication": "#key1"}`, "authentication must be an array"},
+		{"invalid relationship entry", `{"id": "did:example:123", "keyAgreement": [1]}`, "keyAgreement entry must be a reference"},
+		{"invalid relationship reference", `{"id": "did:example:123", "assertionMethod": ["key1"]}`, "assertionMethod reference 'key1' is not a DID URL"},
+		{
+			"unresolved relationship reference",
+			`{"id": "did:example:123", "capabilityInvocation": ["#key1"]}`,
+			"capabilityInvocation reference '#key1' does not match any verification method",
+		},
+		{
+			"invalid embedded verification method",
+			`{"id": "did:example:123", "capabilityDelegation": [{"id": "#key1"}]}`,
+			"verification method '#key1' type is required (capabilityDelegation)",
+		},
+		{"invalid services", `{"id": "did:example:123", "service": {}}`, "service must be an array"},
+		{"invalid service", `{"id": "did:example:123", "service": ["hub"]}`, "service must be an object"},
+		{"missing service id", `{"id": "did:example:123", "service": [{}]}`, "service id is required"},
+		{
+			"duplicate service id",
+			`{"id": "did:example:123", "service": [
+				{"id": "#hub", "type": "t", "serviceEndpoint": "https://example.com"},
+				{"id": "did:example:123#hub", "type": "t", "serviceEndpoint": "https://example.com"}]}`,
+			"duplicate service id: did:example:123#hub",
+		},
+		{"missing service type", `{"id": "did:example:123", "service": [{"id": "#hub"}]}`, "service '#hub' type must be"},
+		{"invalid service type", `{"id": "did:example:123", "service": [{"id": "#hub", "type": ["t", 1]}]}`, "service '#hub' type must be"},
+		{"empty service types", `{"id": "did:example:123", "service": [{"id": "#hub", "type": []}]}`, "service '#hub' type must be"},
+		{
+			"missing service endpoint",
+			`{"id": "did:example:123", "service": [{"id": "#hub", "type": "t"}]}`,
+			"service '#hub' serviceEndpoint is required",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run("error - "+tc.name, func(t *testing.T) {
+			doc, err := DidDocumentFromBytes([]byte(tc.doc))
+			require.NoError(t, err)
+
+			err = ValidateDIDDocument(doc)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+const validDIDCoreDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"],
+  "id": "did:example:123",
+  "controller": ["did:example:456"],
+  "alsoKnownAs": ["https://example.com/alice"],
+  "verificationMethod": [
+    {
+      "id": "#key1",
+      "type": "JsonWebKey2020",
+      "controller": "did:example:123",
+      "publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "abc"}
+    },
+    {
+      "id": "did:example:123#key2",
+      "type": "Ed25519VerificationKey2020",
+      "controller": "did:example:456",
+      "publicKeyMultibase": "zabc"
+    }
+  ],
+  "authentication": ["#key1", "did:example:123#key2"],
+  "assertionMethod": ["did:example:123#key1"],
+  "keyAgreement": [
+    {
+      "id": "#key3",
+      "type": "X25519KeyAgreementKey2019",
+      "controller": "did:example:123",
+      "publicKeyBase58": "abc"
+    }
+  ],
+  "service": [
+    {
+      "id": "#hub",
+      "type": ["IdentityHub", "LinkedDomains"],
+      "serviceEndpoint": {"origins": ["https://example.com"]}
+    },
+    {
+      "id": "https://example.com/service",
+      "type": "Service",
+      "serviceEndpoint": "https://example.com"
+    }
+  ]
+}`
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	querySeparator    = "?"
+	fragmentSeparator = "#"
+
+	// namespace is 'did:<method>' optionally followed by ':<network>'.
+	namespaceNetworkPos = 2
+)
+
+// DID contains parts of parsed DID (or DID URL):
+// '<namespace>:<unique-suffix>[:<initial-state>][?<query>][#<fragment>]'.
+type DID struct {
+	// Namespace is DID namespace (e.g. did:sidetree or did:sidetree:test)
+	Namespace string
+
+	// Network is optional network part of namespace (e.g. test for did:sidetree:test)
+	Network string
+
+	// UniqueSuffix is DID unique suffix
+	UniqueSuffix string
+
+	// InitialState is encoded initial state of long-form DID (empty for short-form DID)
+	InitialState string
+
+	// Query is DID URL query (without '?')
+	Query string
+
+	// Fragment is DID URL fragment (without '#')
+	Fragment string
+}
+
+// ShortForm returns short-form DID (namespace and unique suffix).
+func (d *DID) ShortForm() string {
+	return d.Namespace + NamespaceDelimiter + d.UniqueSuffix
+}
+
+// IsLongForm returns true if DID contains initial state.
+func (d *DID) IsLongForm() bool {
+	return d.InitialState != ""
+}
+
+// ParseDID parses short or long-form DID (optionally with query and fragment) of the given namespace.
+// Unique suffix is validated to be non-empty and to contain only base64url characters; protocol specific
+// validation of unique suffix (see ValidateSuffix) and decoding of initial state are left to the caller.
+func ParseDID(namespace, id string) (*DID, error) {
+	prefix := namespace + NamespaceDelimiter
+
+	if namespace == "" || !strings.HasPrefix(id, prefix) {
+		return nil, errors.New("did must start with configured namespace")
+	}
+
+	did := &DID{
+		Namespace: namespace,
+		Network:   getNetwork(namespace),
+	}
+
+	remainder := strings.TrimPrefix(id, prefix)
+
+	if pos := strings.Index(remainder, fragmentSeparator); pos != -1 {
+		did.Fragment = remainder[pos+1:]
+		remainder = remainder[:pos]
+	}
+
+	if pos := strings.Index(remainder, querySeparator); pos != -1 {
+		did.Query = remainder[pos+1:]
+		remainder = remainder[:pos]
+	}
+
+	did.UniqueSuffix = remainder
+
+	if pos := strings.Index(remainder, NamespaceDelimiter); pos != -1 {
+		did.UniqueSuffix = remainder[:pos]
+		did.InitialState = remainder[pos+1:]
+
+		if did.InitialState == "" {
+			return nil, errors.New("initial state is empty")
+		}
+
+		if strings.Contains(did.InitialState, NamespaceDelimiter) {
+			return nil, errors.New("did must consist of namespace, unique suffix and optional initial state")
+		}
+	}
+
+	if did.UniqueSuffix == "" {
+		return nil, errors.New("did suffix is empty")
+	}
+
+	for _, c := range did.UniqueSuffix {
+		if !isBase64URLChar(c) {
+			return nil, errors.Errorf("did suffix contains invalid character '%c'", c)
+		}
+	}
+
+	return did, nil
+}
+
+func getNetwork(namespace string) string {
+	parts := strings.SplitN(namespace, NamespaceDelimiter, namespaceNetworkPos+1)
+	if len(parts) <= namespaceNetworkPos {
+		return ""
+	}
+
+	return parts[namespaceNetworkPos]
+}
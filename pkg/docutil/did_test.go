@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDID(t *testing.T) {
+	const (
+		ns           = "did:sidetree"
+		suffix       = "EiDOQXC2GnoVyHwIRbjhLx_cNc6vmZaS04SZjZdlLLAPRg"
+		initialState = "eyJkZWx0YSI6e30sInN1ZmZpeERhdGEiOnt9fQ"
+	)
+
+	t.Run("success - short-form DID", func(t *testing.T) {
+		did, err := ParseDID(ns, ns+":"+suffix)
+		require.NoError(t, err)
+		require.Equal(t, ns, did.Namespace)
+		require.Empty(t, did.Network)
+		require.Equal(t, suffix, did.UniqueSuffix)
+		require.Empty(t, did.InitialState)
+		require.Empty(t, did.Query)
+		require.Empty(t, did.Fragment)
+		require.False(t, did.IsLongForm())
+		require.Equal(t, ns+":"+suffix, did.ShortForm())
+	})
+
+	t.Run("success - long-form DID URL with network", func(t *testing.T) {
+		const nsWithNetwork = "did:bloc:trustbloc.dev"
+
+		did, err := ParseDID(nsWithNetwork, nsWithNetwork+":"+suffix+":"+initialState+"?versionId=1#key-1")
+		require.NoError(t, err)
+		require.Equal(t, nsWithNetwork, did.Namespace)
+		require.Equal(t, "trustbloc.dev", did.Network)
+		require.Equal(t, suffix, did.UniqueSuffix)
+		require.Equal(t, initialState, did.InitialState)
+		require.Equal(t, "versionId=1", did.Query)
+		require.Equal(t, "key-1", did.Fragment)
+		require.True(t, did.IsLongForm())
+		require.Equal(t, nsWithNetwork+":"+suffix, did.ShortForm())
+	})
+
+	t.Run("success - fragment only", func(t *testing.T) {
+		did, err := ParseDID(ns, ns+":"+suffix+"#key-1?x")
+		require.NoError(t, err)
+		require.Equal(t, suffix, did.UniqueSuffix)
+		require.Empty(t, did.Query)
+		require.Equal(t, "key-1?x", did.Fragment)
+	})
+
+	t.Run("error - namespace", func(t *testing.T) {
+		did, err := ParseDID(ns, "did:other:"+suffix)
+		require.Error(t, err)
+		require.Nil(t, did)
+		require.Contains(t, err.Error(), "did must start with configured namespace")
+
+		did, err = ParseDID("", ":"+suffix)
+		require.Error(t, err)
+		require.Nil(t, did)
+		require.Contains(t, err.Error(), "did must start with configured namespace")
+	})
+
+	t.Run("error - empty suffix", func(t *testing.T) {
+		for _, id := range []string{ns + ":", ns + ":#key-1", ns + "::" + initialState} {
+			did, err := ParseDID(ns, id)
+			require.Error(t, err)
+			require.Nil(t, did)
+			require.Contains(t, err.Error(), "did suffix is empty")
+		}
+	})
+
+	t.Run("error - invalid suffix", func(t *testing.T) {
+		did, err := ParseDID(ns, ns+":abc=")
+		require.Error(t, err)
+		require.Nil(t, did)
+		require.Contains(t, err.Error(), "did suffix contains invalid character '='")
+	})
+
+	t.Run("error - empty initial state", func(t *testing.T) {
+		did, err := ParseDID(ns, ns+":"+suffix+":")
+		require.Error(t, err)
+		require.Nil(t, did)
+		require.Contains(t, err.Error(), "initial state is empty")
+	})
+
+	t.Run("error - too many parts", func(t *testing.T) {
+		did, err := ParseDID(ns, ns+":"+suffix+":"+initialState+":other")
+		require.Error(t, err)
+		require.Nil(t, did)
+		require.Contains(t, err.Error(), "did must consist of namespace, unique suffix and optional initial state")
+	})
+}
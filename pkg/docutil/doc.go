@@ -11,6 +11,8 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 )
 
@@ -29,6 +31,109 @@ func CalculateID(namespace string, value interface{}, hashAlgorithmAsMultihashCo
 	return didID, nil
 }
 
+// SuffixDataHashing defines what is hashed to calculate DID unique suffix (differs between spec versions).
+type SuffixDataHashing int
+
+const (
+	// CanonicalSuffixData hashes JCS canonicalized suffix data object (current spec).
+	CanonicalSuffixData SuffixDataHashing = iota
+
+	// EncodedSuffixData hashes base64url encoded canonicalized suffix data (earlier spec versions).
+	EncodedSuffixData
+)
+
+// UniqueSuffixOption is an option for unique suffix calculation.
+type UniqueSuffixOption func(opts *uniqueSuffixOpts)
+
+type uniqueSuffixOpts struct {
+	hashing SuffixDataHashing
+	latest  bool
+}
+
+// WithSuffixDataHashing sets what is hashed to calculate unique suffix (defaults to canonical suffix data).
+func WithSuffixDataHashing(hashing SuffixDataHashing) UniqueSuffixOption {
+	return func(opts *uniqueSuffixOpts) {
+		opts.hashing = hashing
+	}
+}
+
+// WithLatestMultihashAlgorithm selects the latest (last) of supplied multihash algorithms for calculating unique
+// suffix instead of the first one.
+func WithLatestMultihashAlgorithm() UniqueSuffixOption {
+	return func(opts *uniqueSuffixOpts) {
+		opts.latest = true
+	}
+}
+
+// CalculateUniqueSuffix calculates DID unique suffix from suffix data object using one of multihash algorithms
+// (as selected by protocol version). By default multihash of canonicalized suffix data object is calculated
+// with the first algorithm.
+func CalculateUniqueSuffix(suffixData interface{}, multihashAlgorithms []uint, opts ...UniqueSuffixOption) (string, error) {
+	if len(multihashAlgorithms) == 0 {
+		return "", errors.New("algorithm not provided")
+	}
+
+	options := &uniqueSuffixOpts{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	alg := multihashAlgorithms[0]
+	if options.latest {
+		alg = multihashAlgorithms[len(multihashAlgorithms)-1]
+	}
+
+	switch options.hashing {
+	case CanonicalSuffixData:
+		return hashing.CalculateModelMultihash(suffixData, alg)
+	case EncodedSuffixData:
+		bytes, err := canonicalizer.MarshalCanonical(suffixData)
+		if err != nil {
+			return "", err
+		}
+
+		multihash, err := hashing.ComputeMultihash(alg, []byte(encoder.EncodeToString(bytes)))
+		if err != nil {
+			return "", err
+		}
+
+		return encoder.EncodeToString(multihash), nil
+	default:
+		return "", errors.Errorf("suffix data hashing [%d] is not supported", options.hashing)
+	}
+}
+
+// ValidateSuffix validates DID unique suffix: suffix has to consist of base64url characters only, its length
+// must not exceed maximum length (if specified) and it has to be multihash computed with one of supplied
+// multihash algorithms.
+func ValidateSuffix(suffix string, multihashAlgorithms []uint, maxLength uint) error {
+	if suffix == "" {
+		return errors.New("did suffix is empty")
+	}
+
+	if maxLength > 0 && len(suffix) > int(maxLength) {
+		return errors.Errorf("did suffix length[%d] exceeds maximum length[%d]", len(suffix), maxLength)
+	}
+
+	for _, c := range suffix {
+		if !isBase64URLChar(c) {
+			return errors.Errorf("did suffix contains invalid character '%c'", c)
+		}
+	}
+
+	if !hashing.IsComputedUsingMultihashAlgorithms(suffix, multihashAlgorithms) {
+		return errors.Errorf("did suffix is not computed with the required hash algorithms: %d", multihashAlgorithms)
+	}
+
+	return nil
+}
+
+func isBase64URLChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_'
+}
+
 // GetNamespaceFromID returns namespace from ID.
 func GetNamespaceFromID(id string) (string, error) {
 	pos := strings.LastIndex(id, ":")
@@ -10,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 )
 
 const (
@@ -46,6 +50,104 @@ func TestDidCalculationError(t *testing.T) {
 	require.Contains(t, err.Error(), "Expected '{'")
 }
 
+func TestCalculateUniqueSuffix(t *testing.T) {
+	const sha2_512 uint = 19
+
+	t.Run("success - canonical suffix data with first algorithm", func(t *testing.T) {
+		suffix, err := CalculateUniqueSuffix(suffixDataObject, []uint{sha2_256, sha2_512})
+		require.NoError(t, err)
+		require.Equal(t, expectedSuffixForSuffixObject, suffix)
+	})
+
+	t.Run("success - latest algorithm", func(t *testing.T) {
+		suffix, err := CalculateUniqueSuffix(suffixDataObject, []uint{sha2_256, sha2_512}, WithLatestMultihashAlgorithm())
+		require.NoError(t, err)
+
+		expected, err := hashing.CalculateModelMultihash(suffixDataObject, sha2_512)
+		require.NoError(t, err)
+		require.Equal(t, expected, suffix)
+	})
+
+	t.Run("success - encoded suffix data", func(t *testing.T) {
+		suffix, err := CalculateUniqueSuffix(suffixDataObject, []uint{sha2_256}, WithSuffixDataHashing(EncodedSuffixData))
+		require.NoError(t, err)
+		require.NotEqual(t, expectedSuffixForSuffixObject, suffix)
+
+		bytes, err := canonicalizer.MarshalCanonical(suffixDataObject)
+		require.NoError(t, err)
+
+		multihash, err := hashing.ComputeMultihash(sha2_256, []byte(encoder.EncodeToString(bytes)))
+		require.NoError(t, err)
+		require.Equal(t, encoder.EncodeToString(multihash), suffix)
+	})
+
+	t.Run("error - algorithm not provided", func(t *testing.T) {
+		suffix, err := CalculateUniqueSuffix(suffixDataObject, nil)
+		require.Error(t, err)
+		require.Empty(t, suffix)
+		require.Contains(t, err.Error(), "algorithm not provided")
+	})
+
+	t.Run("error - algorithm not supported", func(t *testing.T) {
+		for _, h := range []SuffixDataHashing{CanonicalSuffixData, EncodedSuffixData} {
+			suffix, err := CalculateUniqueSuffix(suffixDataObject, []uint{55}, WithSuffixDataHashing(h))
+			require.Error(t, err)
+			require.Empty(t, suffix)
+			require.Contains(t, err.Error(), "algorithm not supported")
+		}
+	})
+
+	t.Run("error - suffix data is not an object", func(t *testing.T) {
+		for _, h := range []SuffixDataHashing{CanonicalSuffixData, EncodedSuffixData} {
+			suffix, err := CalculateUniqueSuffix("!!!", []uint{sha2_256}, WithSuffixDataHashing(h))
+			require.Error(t, err)
+			require.Empty(t, suffix)
+		}
+	})
+
+	t.Run("error - suffix data hashing not supported", func(t *testing.T) {
+		suffix, err := CalculateUniqueSuffix(suffixDataObject, []uint{sha2_256}, WithSuffixDataHashing(10))
+		require.Error(t, err)
+		require.Empty(t, suffix)
+		require.Contains(t, err.Error(), "suffix data hashing [10] is not supported")
+	})
+}
+
+func TestValidateSuffix(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		err := ValidateSuffix(expectedSuffixForSuffixObject, []uint{sha2_256}, 46)
+		require.NoError(t, err)
+
+		err = ValidateSuffix(expectedSuffixForSuffixObject, []uint{sha2_256}, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - empty suffix", func(t *testing.T) {
+		err := ValidateSuffix("", []uint{sha2_256}, 0)
+		require.EqualError(t, err, "did suffix is empty")
+	})
+
+	t.Run("error - exceeds maximum length", func(t *testing.T) {
+		err := ValidateSuffix(expectedSuffixForSuffixObject, []uint{sha2_256}, 10)
+		require.EqualError(t, err, "did suffix length[46] exceeds maximum length[10]")
+	})
+
+	t.Run("error - invalid character", func(t *testing.T) {
+		err := ValidateSuffix("abc=", []uint{sha2_256}, 0)
+		require.EqualError(t, err, "did suffix contains invalid character '='")
+	})
+
+	t.Run("error - not multihash", func(t *testing.T) {
+		err := ValidateSuffix("abc", []uint{sha2_256}, 0)
+		require.EqualError(t, err, "did suffix is not computed with the required hash algorithms: [18]")
+	})
+
+	t.Run("error - multihash algorithm not supported", func(t *testing.T) {
+		err := ValidateSuffix(expectedSuffixForSuffixObject, []uint{19}, 0)
+		require.EqualError(t, err, "did suffix is not computed with the required hash algorithms: [19]")
+	})
+}
+
 func TestNamespaceFromID(t *testing.T) {
 	const namespace = "did:sidetree"
 	const suffix = "123456"
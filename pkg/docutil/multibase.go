@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/pkg/errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+)
+
+// MultibaseEncoding is multibase encoding (identified by its prefix character).
+// See https://tools.ietf.org/html/draft-multiformats-multibase.
+type MultibaseEncoding byte
+
+const (
+	// MultibaseBase58BTC is base58 bitcoin encoding (e.g. used for publicKeyMultibase).
+	MultibaseBase58BTC MultibaseEncoding = 'z'
+
+	// MultibaseBase64URL is base64url encoding without padding.
+	MultibaseBase64URL MultibaseEncoding = 'u'
+)
+
+const base58BTCAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// EncodeMultibase encodes data with the given multibase encoding (encoded value is prefixed with
+// encoding character).
+func EncodeMultibase(encoding MultibaseEncoding, data []byte) (string, error) {
+	switch encoding {
+	case MultibaseBase58BTC:
+		return string(encoding) + base58.Encode(data), nil
+	case MultibaseBase64URL:
+		return string(encoding) + encoder.EncodeToString(data), nil
+	default:
+		return "", errors.Errorf("multibase encoding '%c' is not supported", encoding)
+	}
+}
+
+// DecodeMultibase decodes multibase encoded value; encoding is determined by value prefix.
+func DecodeMultibase(value string) (MultibaseEncoding, []byte, error) {
+	if value == "" {
+		return 0, nil, errors.New("multibase value is empty")
+	}
+
+	encoding := MultibaseEncoding(value[0])
+	encoded := value[1:]
+
+	switch encoding {
+	case MultibaseBase58BTC:
+		for _, c := range encoded {
+			if !strings.ContainsRune(base58BTCAlphabet, c) {
+				return 0, nil, errors.Errorf("multibase value contains invalid base58btc character '%c'", c)
+			}
+		}
+
+		return encoding, base58.Decode(encoded), nil
+	case MultibaseBase64URL:
+		data, err := encoder.DecodeString(encoded)
+		if err != nil {
+			return 0, nil, errors.Errorf("multibase value is not valid base64url: %s", err.Error())
+		}
+
+		return encoding, data, nil
+	default:
+		return 0, nil, errors.Errorf("multibase encoding '%c' is not supported", encoding)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMultibase(t *testing.T) {
+	t.Run("success - base58btc", func(t *testing.T) {
+		encoded, err := EncodeMultibase(MultibaseBase58BTC, []byte("hello world"))
+		require.NoError(t, err)
+		require.Equal(t, "zStV1DL6CwTryKyV", encoded)
+	})
+
+	t.Run("success - base64url", func(t *testing.T) {
+		encoded, err := EncodeMultibase(MultibaseBase64URL, []byte("hello world"))
+		require.NoError(t, err)
+		require.Equal(t, "uaGVsbG8gd29ybGQ", encoded)
+	})
+
+	t.Run("error - encoding not supported", func(t *testing.T) {
+		encoded, err := EncodeMultibase('f', []byte("hello world"))
+		require.Error(t, err)
+		require.Empty(t, encoded)
+		require.Contains(t, err.Error(), "multibase encoding 'f' is not supported")
+	})
+}
+
+func TestDecodeMultibase(t *testing.T) {
+	t.Run("success - round trip", func(t *testing.T) {
+		data := []byte{0, 0, 1, 2, 3, 255}
+
+		for _, encoding := range []MultibaseEncoding{MultibaseBase58BTC, MultibaseBase64URL} {
+			encoded, err := EncodeMultibase(encoding, data)
+			require.NoError(t, err)
+
+			decodedEncoding, decoded, err := DecodeMultibase(encoded)
+			require.NoError(t, err)
+			require.Equal(t, encoding, decodedEncoding)
+			require.Equal(t, data, decoded)
+		}
+	})
+
+	t.Run("success - base58btc", func(t *testing.T) {
+		encoding, decoded, err := DecodeMultibase("zStV1DL6CwTryKyV")
+		require.NoError(t, err)
+		require.Equal(t, MultibaseBase58BTC, encoding)
+		require.Equal(t, "hello world", string(decoded))
+	})
+
+	t.Run("error - empty value", func(t *testing.T) {
+		_, decoded, err := DecodeMultibase("")
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "multibase value is empty")
+	})
+
+	t.Run("error - encoding not supported", func(t *testing.T) {
+		_, decoded, err := DecodeMultibase("f68656c6c6f")
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "multibase encoding 'f' is not supported")
+	})
+
+	t.Run("error - invalid base58btc", func(t *testing.T) {
+		_, decoded, err := DecodeMultibase("zStV1DL6CwTry0")
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "invalid base58btc character '0'")
+	})
+
+	t.Run("error - invalid base64url", func(t *testing.T) {
+		_, decoded, err := DecodeMultibase("uaGVsbG8+")
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "multibase value is not valid base64url")
+	})
+}
@@ -6,14 +6,108 @@ SPDX-License-Identifier: Apache-2.0
 
 package encoder
 
-import "encoding/base64"
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/bufferpool"
+)
+
+// DecodeOption is an option for decoding.
+type DecodeOption func(opts *decodeOptions)
+
+type decodeOptions struct {
+	strict         bool
+	maxDecodedSize uint
+}
+
+// WithStrict enables strict decoding: encoded content must be canonical base64url encoding without padding
+// (padding, new lines, other characters outside of base64url alphabet and non-zero trailing bits are rejected).
+func WithStrict() DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.strict = true
+	}
+}
+
+// WithMaxDecodedSize sets maximum size of decoded content; encoded content that would decode to more bytes
+// is rejected before decoding.
+func WithMaxDecodedSize(size uint) DecodeOption {
+	return func(opts *decodeOptions) {
+		opts.maxDecodedSize = size
+	}
+}
 
 // EncodeToString encodes the bytes to string.
 func EncodeToString(data []byte) string {
-	return base64.RawURLEncoding.EncodeToString(data)
+	// encoded bytes are only needed until they are converted to string
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	n := base64.RawURLEncoding.EncodedLen(len(data))
+	buf.Grow(n)
+
+	encoded := buf.Bytes()[:n]
+	base64.RawURLEncoding.Encode(encoded, data)
+
+	return string(encoded)
 }
 
 // DecodeString decodes the encoded content to Bytes.
-func DecodeString(encodedContent string) ([]byte, error) {
-	return base64.RawURLEncoding.DecodeString(encodedContent)
+func DecodeString(encodedContent string, opts ...DecodeOption) ([]byte, error) {
+	options := &decodeOptions{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	enc := base64.RawURLEncoding
+
+	if options.strict {
+		if err := checkAlphabet(encodedContent); err != nil {
+			return nil, err
+		}
+
+		enc = enc.Strict()
+	}
+
+	decodedSize := enc.DecodedLen(len(encodedContent))
+
+	if options.maxDecodedSize > 0 && uint(decodedSize) > options.maxDecodedSize {
+		return nil, fmt.Errorf("decoded size[%d] exceeds maximum size[%d]", decodedSize, options.maxDecodedSize)
+	}
+
+	// encoded content is copied into buffer from the pool (instead of new byte slice) for decoding
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	buf.WriteString(encodedContent)
+
+	decoded := make([]byte, decodedSize)
+
+	n, err := enc.Decode(decoded, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return decoded[:n], nil
+}
+
+// checkAlphabet checks that encoded content contains only characters from base64url alphabet (RFC 4648 section 5).
+func checkAlphabet(encodedContent string) error {
+	for i := 0; i < len(encodedContent); i++ {
+		c := encodedContent[i]
+
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_':
+			continue
+		case c == '=':
+			return errors.New("padding is not allowed")
+		default:
+			return fmt.Errorf("invalid base64url character at position %d", i)
+		}
+	}
+
+	return nil
 }
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package encoder
 
 import (
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -22,3 +23,75 @@ func TestEncodeAndDecodeAsString(t *testing.T) {
 	require.NotNil(t, decodedBytes)
 	require.EqualValues(t, "Hello World", decodedBytes)
 }
+
+func TestEncodeAndDecodeAsStringWithPooledBuffers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		for _, size := range []int{0, 1, 2, 3, 100, 1000, 100 * 1024} {
+			data := make([]byte, size)
+			for i := range data {
+				data[i] = byte(i)
+			}
+
+			encoded := EncodeToString(data)
+			require.Equal(t, base64.RawURLEncoding.EncodeToString(data), encoded)
+
+			decoded, err := DecodeString(encoded)
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		}
+	})
+
+	t.Run("error - invalid encoding", func(t *testing.T) {
+		decoded, err := DecodeString("abc=")
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "illegal base64 data")
+	})
+}
+
+func TestDecodeStringStrict(t *testing.T) {
+	encoded := EncodeToString([]byte("Hello World"))
+
+	t.Run("success", func(t *testing.T) {
+		decoded, err := DecodeString(encoded, WithStrict(), WithMaxDecodedSize(11))
+		require.NoError(t, err)
+		require.EqualValues(t, "Hello World", decoded)
+	})
+
+	t.Run("error - padding", func(t *testing.T) {
+		padded := base64.URLEncoding.EncodeToString([]byte("Hello World"))
+
+		decoded, err := DecodeString(padded, WithStrict())
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "padding is not allowed")
+	})
+
+	t.Run("error - invalid alphabet", func(t *testing.T) {
+		for _, invalid := range []string{"SGVsbG8+", "SGVsbG8/", "SGVs\nbG8", "SGVs bG8"} {
+			decoded, err := DecodeString(invalid, WithStrict())
+			require.Error(t, err)
+			require.Nil(t, decoded)
+			require.Contains(t, err.Error(), "invalid base64url character at position")
+		}
+
+		// new lines are ignored in non-strict mode
+		decoded, err := DecodeString("SGVs\nbG8")
+		require.NoError(t, err)
+		require.EqualValues(t, "Hello", decoded)
+	})
+
+	t.Run("error - non-zero trailing bits", func(t *testing.T) {
+		decoded, err := DecodeString("SGVsbG9", WithStrict())
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "illegal base64 data at input byte")
+	})
+
+	t.Run("error - decoded size exceeds maximum size", func(t *testing.T) {
+		decoded, err := DecodeString(encoded, WithMaxDecodedSize(10))
+		require.Error(t, err)
+		require.Nil(t, decoded)
+		require.Contains(t, err.Error(), "decoded size[11] exceeds maximum size[10]")
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpcapi implements Sidetree gRPC service defined in sidetree.proto (operation submission and resolution)
+// on top of document handler. Server is registered with gRPC server using RegisterSidetreeServer.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+//go:generate protoc --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. sidetree.proto
+
+var logger = log.New("sidetree-core-grpcapi")
+
+// DocumentHandler processes operations and resolves documents.
+type DocumentHandler interface {
+	ProcessOperation(operation []byte, protocolGenesisTime uint64) (*document.ResolutionResult, error)
+	ResolveDocument(idOrDocument string, opts ...document.ResolutionOption) (*document.ResolutionResult, error)
+}
+
+// Server implements Sidetree gRPC service.
+type Server struct {
+	UnimplementedSidetreeServer
+
+	handler  DocumentHandler
+	protocol protocol.Client
+}
+
+// NewServer returns a new Sidetree gRPC service implementation.
+func NewServer(handler DocumentHandler, pc protocol.Client) *Server {
+	return &Server{
+		handler:  handler,
+		protocol: pc,
+	}
+}
+
+// SubmitOperation validates operation and adds it to the batch.
+func (s *Server) SubmitOperation(_ context.Context, req *SubmitOperationRequest) (*SubmitOperationResponse, error) {
+	if len(req.Operation) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing operation")
+	}
+
+	pv, err := s.protocol.Current()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := s.handler.ProcessOperation(req.Operation, pv.Protocol().GenesisTime)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	response := &SubmitOperationResponse{}
+
+	if result != nil {
+		response.Result, err = json.Marshal(result)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal resolution result: %s", err.Error())
+		}
+	}
+
+	return response, nil
+}
+
+// Resolve resolves short or long form DID.
+func (s *Server) Resolve(_ context.Context, req *ResolveRequest) (*ResolveResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing id")
+	}
+
+	result, err := s.handler.ResolveDocument(req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal resolution result: %s", err.Error())
+	}
+
+	return &ResolveResponse{Result: bytes}, nil
+}
+
+// toStatusError converts error returned by document handler to gRPC status error.
+func toStatusError(err error) error {
+	code := statusCode(err)
+	if code == codes.Internal {
+		logger.Errorf("internal error: %s", err.Error())
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func statusCode(err error) codes.Code {
+	switch protocol.GetCode(err) {
+	case protocol.CodeBadRequest, protocol.CodeOperationExceedsMaximumSize, protocol.CodeDeltaExceedsMaximumSize:
+		return codes.InvalidArgument
+	case protocol.CodeDIDNotFound, protocol.CodeDIDDeactivated:
+		return codes.NotFound
+	}
+
+	switch {
+	case strings.Contains(err.Error(), "bad request"):
+		return codes.InvalidArgument
+	case strings.Contains(err.Error(), "not found"), strings.Contains(err.Error(), "was deactivated"):
+		return codes.NotFound
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+const id = "did:sidetree:abc"
+
+// document handler is used by the server.
+var _ DocumentHandler = (*dochandler.DocumentHandler)(nil)
+
+func TestServer_SubmitOperation(t *testing.T) {
+	t.Run("success - create", func(t *testing.T) {
+		handler := &mockHandler{result: &document.ResolutionResult{Document: document.Document{document.IDProperty: id}}}
+
+		client, stop := newClient(t, NewServer(handler, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.SubmitOperation(context.Background(), &SubmitOperationRequest{Operation: []byte("{}")})
+		require.NoError(t, err)
+
+		var result document.ResolutionResult
+		require.NoError(t, json.Unmarshal(response.Result, &result))
+		require.Equal(t, id, result.Document.ID())
+		require.Equal(t, "{}", string(handler.operation))
+	})
+
+	t.Run("success - update (no result)", func(t *testing.T) {
+		client, stop := newClient(t, NewServer(&mockHandler{}, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.SubmitOperation(context.Background(), &SubmitOperationRequest{Operation: []byte("{}")})
+		require.NoError(t, err)
+		require.Empty(t, response.Result)
+	})
+
+	t.Run("error - missing operation", func(t *testing.T) {
+		client, stop := newClient(t, NewServer(&mockHandler{}, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.SubmitOperation(context.Background(), &SubmitOperationRequest{})
+		require.Error(t, err)
+		require.Nil(t, response)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Contains(t, err.Error(), "missing operation")
+	})
+
+	t.Run("error - protocol error", func(t *testing.T) {
+		pc := mocks.NewMockProtocolClient()
+		pc.Err = errors.New("protocol error")
+
+		client, stop := newClient(t, NewServer(&mockHandler{}, pc))
+		defer stop()
+
+		response, err := client.SubmitOperation(context.Background(), &SubmitOperationRequest{Operation: []byte("{}")})
+		require.Error(t, err)
+		require.Nil(t, response)
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.Contains(t, err.Error(), "protocol error")
+	})
+
+	t.Run("error - handler error", func(t *testing.T) {
+		handler := &mockHandler{
+			err: protocol.NewCodedError(protocol.CodeDeltaExceedsMaximumSize, errors.New("bad request: delta size")),
+		}
+
+		client, stop := newClient(t, NewServer(handler, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.SubmitOperation(context.Background(), &SubmitOperationRequest{Operation: []byte("{}")})
+		require.Error(t, err)
+		require.Nil(t, response)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_Resolve(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler := &mockHandler{result: &document.ResolutionResult{Document: document.Document{document.IDProperty: id}}}
+
+		client, stop := newClient(t, NewServer(handler, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.Resolve(context.Background(), &ResolveRequest{Id: id})
+		require.NoError(t, err)
+
+		var result document.ResolutionResult
+		require.NoError(t, json.Unmarshal(response.Result, &result))
+		require.Equal(t, id, result.Document.ID())
+	})
+
+	t.Run("error - missing id", func(t *testing.T) {
+		client, stop := newClient(t, NewServer(&mockHandler{}, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.Resolve(context.Background(), &ResolveRequest{})
+		require.Error(t, err)
+		require.Nil(t, response)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		handler := &mockHandler{err: errors.New("uniqueSuffix not found in the store")}
+
+		client, stop := newClient(t, NewServer(handler, mocks.NewMockProtocolClient()))
+		defer stop()
+
+		response, err := client.Resolve(context.Background(), &ResolveRequest{Id: id})
+		require.Error(t, err)
+		require.Nil(t, response)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestStatusCode(t *testing.T) {
+	require.Equal(t, codes.InvalidArgument, statusCode(errors.New("bad request: invalid")))
+	require.Equal(t, codes.NotFound, statusCode(errors.New("document was deactivated")))
+	require.Equal(t, codes.NotFound,
+		statusCode(protocol.NewCodedError(protocol.CodeDIDDeactivated, errors.New("deactivated"))))
+	require.Equal(t, codes.Internal, statusCode(errors.New("other")))
+}
+
+// newClient starts gRPC server with the given Sidetree service (in-memory connection) and returns client for it
+// along with the function that closes the connection and stops the server.
+func newClient(t *testing.T, srv SidetreeServer) (SidetreeClient, func()) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	RegisterSidetreeServer(grpcServer, srv)
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			t.Logf("gRPC server stopped: %s", err.Error())
+		}
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+
+	return NewSidetreeClient(conn), func() {
+		require.NoError(t, conn.Close())
+		grpcServer.Stop()
+	}
+}
+
+type mockHandler struct {
+	operation []byte
+	result    *document.ResolutionResult
+	err       error
+}
+
+func (m *mockHandler) ProcessOperation(operation []byte, _ uint64) (*document.ResolutionResult, error) {
+	m.operation = operation
+
+	return m.result, m.err
+}
+
+func (m *mockHandler) ResolveDocument(string, ...document.ResolutionOption) (*document.ResolutionResult, error) {
+	return m.result, m.err
+}
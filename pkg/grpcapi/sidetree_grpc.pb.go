@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// SidetreeClient is the client API for Sidetree service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SidetreeClient interface {
+	// SubmitOperation validates operation and adds it to the batch.
+	SubmitOperation(ctx context.Context, in *SubmitOperationRequest, opts ...grpc.CallOption) (*SubmitOperationResponse, error)
+	// Resolve resolves short or long form DID.
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error)
+}
+
+type sidetreeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSidetreeClient(cc grpc.ClientConnInterface) SidetreeClient {
+	return &sidetreeClient{cc}
+}
+
+func (c *sidetreeClient) SubmitOperation(ctx context.Context, in *SubmitOperationRequest, opts ...grpc.CallOption) (*SubmitOperationResponse, error) {
+	out := new(SubmitOperationResponse)
+	err := c.cc.Invoke(ctx, "/sidetree.v1.Sidetree/SubmitOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidetreeClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error) {
+	out := new(ResolveResponse)
+	err := c.cc.Invoke(ctx, "/sidetree.v1.Sidetree/Resolve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SidetreeServer is the server API for Sidetree service.
+// All implementations must embed UnimplementedSidetreeServer
+// for forward compatibility
+type SidetreeServer interface {
+	// SubmitOperation validates operation and adds it to the batch.
+	SubmitOperation(context.Context, *SubmitOperationRequest) (*SubmitOperationResponse, error)
+	// Resolve resolves short or long form DID.
+	Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error)
+	mustEmbedUnimplementedSidetreeServer()
+}
+
+// UnimplementedSidetreeServer must be embedded to have forward compatible implementations.
+type UnimplementedSidetreeServer struct {
+}
+
+func (UnimplementedSidetreeServer) SubmitOperation(context.Context, *SubmitOperationRequest) (*SubmitOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOperation not implemented")
+}
+func (UnimplementedSidetreeServer) Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedSidetreeServer) mustEmbedUnimplementedSidetreeServer() {}
+
+// UnsafeSidetreeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SidetreeServer will
+// result in compilation errors.
+type UnsafeSidetreeServer interface {
+	mustEmbedUnimplementedSidetreeServer()
+}
+
+func RegisterSidetreeServer(s grpc.ServiceRegistrar, srv SidetreeServer) {
+	s.RegisterService(&_Sidetree_serviceDesc, srv)
+}
+
+func _Sidetree_SubmitOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidetreeServer).SubmitOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sidetree.v1.Sidetree/SubmitOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidetreeServer).SubmitOperation(ctx, req.(*SubmitOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidetree_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidetreeServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sidetree.v1.Sidetree/Resolve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidetreeServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sidetree_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sidetree.v1.Sidetree",
+	HandlerType: (*SidetreeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitOperation",
+			Handler:    _Sidetree_SubmitOperation_Handler,
+		},
+		{
+			MethodName: "Resolve",
+			Handler:    _Sidetree_Resolve_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sidetree.proto",
+}
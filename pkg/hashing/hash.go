@@ -10,11 +10,15 @@ import (
 	"crypto"
 	"errors"
 	"fmt"
+	stdhash "hash"
+	"sync"
 
 	"github.com/multiformats/go-multihash"
+	_ "golang.org/x/crypto/sha3" // registers SHA3 hash functions
 
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/bufferpool"
 )
 
 // ComputeMultihash will compute the hash for the supplied bytes using multihash code.
@@ -39,6 +43,12 @@ func GetHashFromMultihash(multihashCode uint) (h crypto.Hash, err error) {
 		h = crypto.SHA256
 	case multihash.SHA2_512:
 		h = crypto.SHA512
+	case multihash.SHA3_256:
+		h = crypto.SHA3_256
+	case multihash.SHA3_384:
+		h = crypto.SHA3_384
+	case multihash.SHA3_512:
+		h = crypto.SHA3_512
 	default:
 		err = fmt.Errorf("algorithm not supported, unable to compute hash")
 	}
@@ -113,12 +123,15 @@ func IsValidModelMultihash(model interface{}, modelMultihash string) error {
 
 // CalculateModelMultihash calculates model multihash.
 func CalculateModelMultihash(value interface{}, alg uint) (string, error) {
-	bytes, err := canonicalizer.MarshalCanonical(value)
-	if err != nil {
+	// canonical value is only needed for hashing so it is written into buffer from the pool
+	buf := bufferpool.Get()
+	defer bufferpool.Put(buf)
+
+	if err := canonicalizer.MarshalCanonicalTo(buf, value); err != nil {
 		return "", err
 	}
 
-	multiHashBytes, err := ComputeMultihash(alg, bytes)
+	multiHashBytes, err := ComputeMultihash(alg, buf.Bytes())
 	if err != nil {
 		return "", err
 	}
@@ -132,7 +145,8 @@ func GetHash(hash crypto.Hash, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("hash function not available for: %d", hash)
 	}
 
-	h := hash.New()
+	h := getHashFunc(hash)
+	defer putHashFunc(hash, h)
 
 	if _, hashErr := h.Write(data); hashErr != nil {
 		return nil, hashErr
@@ -142,3 +156,50 @@ func GetHash(hash crypto.Hash, data []byte) ([]byte, error) {
 
 	return result, nil
 }
+
+// hashFuncPools contains pools of hash function states per hash.
+// nolint:gochecknoglobals
+var (
+	hashFuncPools      = make(map[crypto.Hash]*sync.Pool)
+	hashFuncPoolsMutex sync.RWMutex
+)
+
+func getHashFuncPool(hash crypto.Hash) *sync.Pool {
+	hashFuncPoolsMutex.RLock()
+	p, ok := hashFuncPools[hash]
+	hashFuncPoolsMutex.RUnlock()
+
+	if ok {
+		return p
+	}
+
+	hashFuncPoolsMutex.Lock()
+	defer hashFuncPoolsMutex.Unlock()
+
+	p, ok = hashFuncPools[hash]
+	if !ok {
+		p = &sync.Pool{
+			New: func() interface{} {
+				return hash.New()
+			},
+		}
+
+		hashFuncPools[hash] = p
+	}
+
+	return p
+}
+
+func getHashFunc(hash crypto.Hash) stdhash.Hash {
+	h, ok := getHashFuncPool(hash).Get().(stdhash.Hash)
+	if !ok {
+		return hash.New()
+	}
+
+	return h
+}
+
+func putHashFunc(hash crypto.Hash, h stdhash.Hash) {
+	h.Reset()
+	getHashFuncPool(hash).Put(h)
+}
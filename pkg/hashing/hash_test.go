@@ -9,6 +9,8 @@ package hashing
 import (
 	"crypto"
 	"crypto/sha256"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,6 +23,9 @@ const (
 
 	sha2_256 = 18
 	sha2_512 = 19
+	sha3_256 = 22
+	sha3_384 = 21
+	sha3_512 = 20
 )
 
 var sample = []byte("test")
@@ -34,6 +39,20 @@ func TestGetHashFromMultihash(t *testing.T) {
 	hash, err = GetHashFromMultihash(sha2_256)
 	require.Nil(t, err)
 	require.NotNil(t, hash)
+
+	hashes := map[uint]crypto.Hash{
+		sha2_512: crypto.SHA512,
+		sha3_256: crypto.SHA3_256,
+		sha3_384: crypto.SHA3_384,
+		sha3_512: crypto.SHA3_512,
+	}
+
+	for code, expected := range hashes {
+		hash, err = GetHashFromMultihash(code)
+		require.NoError(t, err)
+		require.Equal(t, expected, hash)
+		require.True(t, hash.Available())
+	}
 }
 
 func TestComputeHash(t *testing.T) {
@@ -45,6 +64,16 @@ func TestComputeHash(t *testing.T) {
 	hash, err = ComputeMultihash(sha2_256, sample)
 	require.Nil(t, err)
 	require.NotNil(t, hash)
+
+	t.Run("success - SHA3", func(t *testing.T) {
+		hash, err := ComputeMultihash(sha3_256, sample)
+		require.NoError(t, err)
+
+		mh, err := GetMultihash(encoder.EncodeToString(hash))
+		require.NoError(t, err)
+		require.Equal(t, uint64(sha3_256), mh.Code)
+		require.Len(t, mh.Digest, 32)
+	})
 }
 
 func TestIsSupportedMultihash(t *testing.T) {
@@ -169,6 +198,31 @@ func TestHash(t *testing.T) {
 		require.Empty(t, h)
 		require.Contains(t, err.Error(), "hash function not available for: 55")
 	})
+
+	t.Run("success - concurrent hashing with pooled hash functions", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				test := []byte(fmt.Sprintf("hello world %d", i))
+
+				for _, hash := range []crypto.Hash{crypto.SHA256, crypto.SHA3_256} {
+					h, err := GetHash(hash, test)
+					require.NoError(t, err)
+
+					expected := hash.New()
+					expected.Write(test) //nolint:errcheck,gosec
+					require.Equal(t, expected.Sum(nil), h)
+				}
+			}(i)
+		}
+
+		wg.Wait()
+	})
 }
 
 var suffixDataObject = &struct {
@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bufferpool provides reusable buffers for canonicalization and encoding hot paths.
+package bufferpool
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxSize is the maximum capacity of buffer that is returned to the pool; larger buffers (e.g. for batch files)
+// are left to the garbage collector so that the pool doesn't pin large amounts of memory.
+const maxSize = 64 * 1024
+
+// nolint:gochecknoglobals
+var pool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// Get returns empty buffer from the pool.
+func Get() *bytes.Buffer {
+	buf, ok := pool.Get().(*bytes.Buffer)
+	if !ok {
+		return new(bytes.Buffer)
+	}
+
+	return buf
+}
+
+// Put returns buffer to the pool. Buffer (and bytes obtained from it) must not be used after Put.
+func Put(buf *bytes.Buffer) {
+	if buf.Cap() > maxSize {
+		return
+	}
+
+	buf.Reset()
+	pool.Put(buf)
+}
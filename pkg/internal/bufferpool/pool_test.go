@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bufferpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		buf := Get()
+		require.NotNil(t, buf)
+		require.Zero(t, buf.Len())
+
+		buf.WriteString("data")
+		Put(buf)
+
+		buf = Get()
+		require.Zero(t, buf.Len())
+		Put(buf)
+	})
+
+	t.Run("large buffer is not returned to the pool", func(t *testing.T) {
+		buf := Get()
+		buf.Grow(maxSize + 1)
+		buf.WriteString("data")
+
+		Put(buf)
+
+		// buffer is not reset since it is dropped
+		require.Equal(t, "data", buf.String())
+	})
+}
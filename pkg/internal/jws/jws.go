@@ -44,8 +44,17 @@ type Signer interface {
 }
 
 // NewJWS creates JSON Web Signature.
+// If 'b64' header is set to false then payload is not base64url encoded (https://tools.ietf.org/html/rfc7797)
+// and 'crit' header is set to contain 'b64' (if not provided).
 func NewJWS(protectedHeaders, unprotectedHeaders jws.Headers, payload []byte, signer Signer) (*JSONWebSignature, error) {
 	headers := mergeHeaders(protectedHeaders, signer.Headers())
+
+	if b64, err := headers.B64Payload(); err == nil && !b64 {
+		if _, ok := headers.Critical(); !ok {
+			headers[jws.HeaderCritical] = []string{jws.HeaderB64Payload}
+		}
+	}
+
 	jws := &JSONWebSignature{
 		ProtectedHeaders:   headers,
 		UnprotectedHeaders: unprotectedHeaders,
@@ -64,6 +73,7 @@ func NewJWS(protectedHeaders, unprotectedHeaders jws.Headers, payload []byte, si
 }
 
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
+// If 'b64' header is false then attached payload is not encoded (https://tools.ietf.org/html/rfc7797#section-5.2).
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 	byteHeaders, err := json.Marshal(s.joseHeaders)
 	if err != nil {
@@ -74,7 +84,10 @@ func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
 
 	b64Payload := ""
 	if !detached {
-		b64Payload = base64.RawURLEncoding.EncodeToString(s.Payload)
+		b64Payload, err = compactPayload(s.joseHeaders, s.Payload)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	b64Signature := base64.RawURLEncoding.EncodeToString(s.signature)
@@ -97,6 +110,24 @@ func (s JSONWebSignature) Signature() []byte {
 	return sCopy
 }
 
+func compactPayload(headers jws.Headers, payload []byte) (string, error) {
+	b64, err := headers.B64Payload()
+	if err != nil {
+		return "", err
+	}
+
+	if b64 {
+		return base64.RawURLEncoding.EncodeToString(payload), nil
+	}
+
+	// unencoded payload cannot contain '.' in compact serialization
+	if strings.Contains(string(payload), ".") {
+		return "", errors.New("unencoded payload cannot contain '.' character")
+	}
+
+	return string(payload), nil
+}
+
 func mergeHeaders(h1, h2 jws.Headers) jws.Headers {
 	h := make(jws.Headers, len(h1)+len(h2))
 
@@ -169,12 +200,25 @@ func VerifyJWS(jws string, jwk *jws.JWK, opts ...ParseOpt) (*JSONWebSignature, e
 		return nil, err
 	}
 
+	err = checkAlgorithm(parsedJWS.ProtectedHeaders, jwk)
+	if err != nil {
+		return nil, err
+	}
+
 	sInput, err := signingInput(parsedJWS.ProtectedHeaders, parsedJWS.Payload)
 	if err != nil {
 		return nil, fmt.Errorf("build signing input: %w", err)
 	}
 
-	err = VerifySignature(jwk, parsedJWS.signature, sInput)
+	if isRSA(jwk) {
+		// RSA key can be used with different algorithms
+		alg, _ := parsedJWS.ProtectedHeaders.Algorithm()
+
+		err = verifyRSASignature(jwk, alg, parsedJWS.signature, sInput)
+	} else {
+		err = VerifySignature(jwk, parsedJWS.signature, sInput)
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +244,7 @@ func parseCompacted(jwsCompact string, opts *jwsParseOpts) (*JSONWebSignature, e
 		return nil, err
 	}
 
-	payload, err := parseCompactedPayload(parts[jwsPayloadPart], opts)
+	payload, err := parseCompactedPayload(parts[jwsPayloadPart], joseHeaders, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -222,11 +266,24 @@ func parseCompacted(jwsCompact string, opts *jwsParseOpts) (*JSONWebSignature, e
 	}, nil
 }
 
-func parseCompactedPayload(jwsPayload string, opts *jwsParseOpts) ([]byte, error) {
+func parseCompactedPayload(jwsPayload string, headers jws.Headers, opts *jwsParseOpts) ([]byte, error) {
 	if len(opts.detachedPayload) > 0 {
 		return opts.detachedPayload, nil
 	}
 
+	b64, err := headers.B64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	if !b64 {
+		if jwsPayload == "" {
+			return nil, errors.New("compact jws payload is empty")
+		}
+
+		return []byte(jwsPayload), nil
+	}
+
 	payload, err := base64.RawURLEncoding.DecodeString(jwsPayload)
 	if err != nil {
 		return nil, fmt.Errorf("decode base64 payload: %w", err)
@@ -257,6 +314,16 @@ func parseCompactedHeaders(parts []string) (jws.Headers, error) {
 		return nil, err
 	}
 
+	err = checkCriticalHeaders(joseHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkB64Header(joseHeaders)
+	if err != nil {
+		return nil, err
+	}
+
 	return joseHeaders, nil
 }
 
@@ -266,12 +333,9 @@ func signingInput(headers jws.Headers, payload []byte) ([]byte, error) {
 		return nil, fmt.Errorf("serialize JWS headers: %w", err)
 	}
 
-	hBase64 := true
-
-	if b64, ok := headers[jws.HeaderB64Payload]; ok {
-		if hBase64, ok = b64.(bool); !ok {
-			return nil, errors.New("invalid b64 header")
-		}
+	hBase64, err := headers.B64Payload()
+	if err != nil {
+		return nil, err
 	}
 
 	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
@@ -287,6 +351,22 @@ func signingInput(headers jws.Headers, payload []byte) ([]byte, error) {
 	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
 }
 
+// checkAlgorithm checks that JWS algorithm matches the signing key (e.g. EdDSA has to be used with Ed25519 key).
+// Keys that are not supported for signing are rejected by signature verification.
+func checkAlgorithm(headers jws.Headers, jwk *jws.JWK) error {
+	expected, ok := jwk.Algorithm()
+	if !ok {
+		return nil
+	}
+
+	alg, _ := headers.Algorithm()
+	if alg != expected {
+		return fmt.Errorf("JWS algorithm '%s' doesn't match key type '%s' and curve '%s'", alg, jwk.Kty, jwk.Crv)
+	}
+
+	return nil
+}
+
 func checkJWSHeaders(headers jws.Headers) error {
 	if _, ok := headers[jws.HeaderAlgorithm]; !ok {
 		return fmt.Errorf("%s JWS header is not defined", jws.HeaderAlgorithm)
@@ -294,3 +374,75 @@ func checkJWSHeaders(headers jws.Headers) error {
 
 	return nil
 }
+
+// registeredHeaders are header names defined by JWS and JWA specifications; they cannot be listed in 'crit' header.
+var registeredHeaders = map[string]bool{
+	jws.HeaderAlgorithm:                   true,
+	jws.HeaderJWKSetURL:                   true,
+	"jwk":                                 true,
+	jws.HeaderKeyID:                       true,
+	jws.HeaderX509URL:                     true,
+	jws.HeaderX509CertificateChain:        true,
+	jws.HeaderX509CertificateDigestSha1:   true,
+	jws.HeaderX509CertificateDigestSha256: true,
+	jws.HeaderType:                        true,
+	jws.HeaderContentType:                 true,
+	jws.HeaderCritical:                    true,
+}
+
+// checkCriticalHeaders checks that 'crit' header (if present) is a non-empty list of extension header names
+// which are present in the headers (https://tools.ietf.org/html/rfc7515#section-4.1.11).
+// Whether extensions are understood is up to the caller.
+func checkCriticalHeaders(headers jws.Headers) error {
+	if _, ok := headers[jws.HeaderCritical]; !ok {
+		return nil
+	}
+
+	crit, ok := headers.Critical()
+	if !ok || len(crit) == 0 {
+		return fmt.Errorf("%s JWS header must be a non-empty list of header names", jws.HeaderCritical)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, name := range crit {
+		if seen[name] {
+			return fmt.Errorf("%s JWS header contains duplicate header '%s'", jws.HeaderCritical, name)
+		}
+
+		seen[name] = true
+
+		if registeredHeaders[name] {
+			return fmt.Errorf("%s JWS header cannot contain registered header '%s'", jws.HeaderCritical, name)
+		}
+
+		if _, ok := headers[name]; !ok {
+			return fmt.Errorf("critical JWS header '%s' is missing", name)
+		}
+	}
+
+	return nil
+}
+
+// checkB64Header checks that 'crit' header contains 'b64' if payload is not encoded
+// (https://tools.ietf.org/html/rfc7797#section-6).
+func checkB64Header(headers jws.Headers) error {
+	b64, err := headers.B64Payload()
+	if err != nil {
+		return err
+	}
+
+	if b64 {
+		return nil
+	}
+
+	crit, _ := headers.Critical()
+
+	for _, name := range crit {
+		if name == jws.HeaderB64Payload {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s JWS header must be listed in %s header", jws.HeaderB64Payload, jws.HeaderCritical)
+}
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package jws
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -232,6 +235,122 @@ func TestParseJWS(t *testing.T) {
 	require.Nil(t, parsedJWS)
 }
 
+func TestParseJWS_UnencodedPayload(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := getPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	signer := ecsigner.New(privateKey, "ES256", "key-1")
+	payload := []byte(`{"deltaHash":"EiA"}`)
+
+	jws, err := NewJWS(map[string]interface{}{"b64": false}, nil, payload, signer)
+	require.NoError(t, err)
+
+	crit, ok := jws.ProtectedHeaders.Critical()
+	require.True(t, ok)
+	require.Equal(t, []string{"b64"}, crit)
+
+	jwsCompact, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+	require.Equal(t, string(payload), strings.Split(jwsCompact, ".")[1])
+
+	parsedJWS, err := VerifyJWS(jwsCompact, jwk)
+	require.NoError(t, err)
+	require.Equal(t, payload, parsedJWS.Payload)
+
+	t.Run("success - detached payload", func(t *testing.T) {
+		jwsDetached, err := jws.SerializeCompact(true)
+		require.NoError(t, err)
+
+		parsedJWS, err := VerifyJWS(jwsDetached, jwk, WithJWSDetachedPayload(payload))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+
+		_, err = VerifyJWS(jwsDetached, jwk)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "compact jws payload is empty")
+	})
+
+	t.Run("error - b64 not listed in crit header", func(t *testing.T) {
+		headers := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","b64":false}`))
+		parts := strings.Split(jwsCompact, ".")
+
+		parsedJWS, err := VerifyJWS(fmt.Sprintf("%s.%s.%s", headers, parts[1], parts[2]), jwk)
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "b64 JWS header must be listed in crit header")
+	})
+
+	t.Run("error - invalid b64 header", func(t *testing.T) {
+		headers := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","b64":"false"}`))
+		parts := strings.Split(jwsCompact, ".")
+
+		parsedJWS, err := VerifyJWS(fmt.Sprintf("%s.%s.%s", headers, parts[1], parts[2]), jwk)
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "invalid b64 header")
+	})
+
+	t.Run("error - payload with '.' cannot be serialized", func(t *testing.T) {
+		jws, err := NewJWS(map[string]interface{}{"b64": false}, nil, []byte("a.b"), signer)
+		require.NoError(t, err)
+
+		jwsCompact, err := jws.SerializeCompact(false)
+		require.Error(t, err)
+		require.Empty(t, jwsCompact)
+		require.Contains(t, err.Error(), "unencoded payload cannot contain '.' character")
+
+		jwsCompact, err = jws.SerializeCompact(true)
+		require.NoError(t, err)
+		require.NotEmpty(t, jwsCompact)
+	})
+}
+
+func TestCheckCriticalHeaders(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, checkCriticalHeaders(map[string]interface{}{"alg": "ES256"}))
+		require.NoError(t, checkCriticalHeaders(map[string]interface{}{"alg": "ES256", "exp": 1, "crit": []interface{}{"exp"}}))
+	})
+
+	t.Run("error - invalid crit", func(t *testing.T) {
+		for _, crit := range []interface{}{"exp", []interface{}{}, []interface{}{1}} {
+			err := checkCriticalHeaders(map[string]interface{}{"alg": "ES256", "exp": 1, "crit": crit})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "crit JWS header must be a non-empty list of header names")
+		}
+	})
+
+	t.Run("error - duplicate header", func(t *testing.T) {
+		err := checkCriticalHeaders(map[string]interface{}{"alg": "ES256", "exp": 1, "crit": []interface{}{"exp", "exp"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "crit JWS header contains duplicate header 'exp'")
+	})
+
+	t.Run("error - registered header", func(t *testing.T) {
+		err := checkCriticalHeaders(map[string]interface{}{"alg": "ES256", "crit": []interface{}{"alg"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "crit JWS header cannot contain registered header 'alg'")
+	})
+
+	t.Run("error - missing critical header", func(t *testing.T) {
+		err := checkCriticalHeaders(map[string]interface{}{"alg": "ES256", "crit": []interface{}{"exp"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "critical JWS header 'exp' is missing")
+	})
+
+	t.Run("error - parse JWS", func(t *testing.T) {
+		headers := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","crit":["exp"]}`))
+		payload := base64.RawURLEncoding.EncodeToString([]byte("payload"))
+
+		parsedJWS, err := ParseJWS(fmt.Sprintf("%s.%s.%s", headers, payload, payload))
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "critical JWS header 'exp' is missing")
+	})
+}
+
 func TestParseJWS_ED25519(t *testing.T) {
 	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
 	require.NoError(t, err)
@@ -251,6 +370,86 @@ func TestParseJWS_ED25519(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, parsedJWS)
 	require.Equal(t, jws, parsedJWS)
+
+	t.Run("error - algorithm doesn't match key", func(t *testing.T) {
+		signer := testSigner{
+			headers:   map[string]interface{}{"alg": "ES256"},
+			signature: ed25519.Sign(privateKey, []byte("payload")),
+		}
+
+		es256JWS, err := NewJWS(signer.Headers(), nil, []byte("payload"), signer)
+		require.NoError(t, err)
+
+		es256Compact, err := es256JWS.SerializeCompact(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := VerifyJWS(es256Compact, jwk)
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "JWS algorithm 'ES256' doesn't match key type 'OKP' and curve 'Ed25519'")
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		otherJWK, err := getPublicKeyJWK(otherPublicKey)
+		require.NoError(t, err)
+
+		parsedJWS, err := VerifyJWS(jwsCompact, otherJWK)
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+	})
+}
+
+func TestParseJWS_RSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk, err := getPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	signer := &rsaSigner{privateKey: privateKey}
+
+	jws, err := NewJWS(map[string]interface{}{"alg": "PS256"}, nil, []byte("payload"), signer)
+	require.NoError(t, err)
+
+	jwsCompact, err := jws.SerializeCompact(false)
+	require.NoError(t, err)
+
+	parsedJWS, err := VerifyJWS(jwsCompact, jwk)
+	require.NoError(t, err)
+	require.Equal(t, jws, parsedJWS)
+
+	t.Run("error - algorithm doesn't match signature", func(t *testing.T) {
+		rs256JWS, err := NewJWS(map[string]interface{}{"alg": "RS256"}, nil, []byte("payload"), signer)
+		require.NoError(t, err)
+
+		rs256Compact, err := rs256JWS.SerializeCompact(false)
+		require.NoError(t, err)
+
+		parsedJWS, err := VerifyJWS(rs256Compact, jwk)
+		require.Error(t, err)
+		require.Nil(t, parsedJWS)
+		require.Contains(t, err.Error(), "rsa: invalid signature")
+	})
+}
+
+// rsaSigner creates PS256 signatures.
+type rsaSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+func (s *rsaSigner) Sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+
+	return rsa.SignPSS(rand.Reader, s.privateKey, crypto.SHA256, hash[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+func (s *rsaSigner) Headers() jws.Headers {
+	return jws.Headers{"alg": "PS256"}
 }
 
 func TestIsCompactJWS(t *testing.T) {
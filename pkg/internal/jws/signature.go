@@ -11,6 +11,8 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,14 +28,16 @@ const (
 	p384KeySize      = 48
 	p521KeySize      = 66
 	secp256k1KeySize = 32
+
+	minRSAKeySize = 2048
 )
 
 // VerifySignature verifies signature against public key in JWK format.
 func VerifySignature(jwk *jws.JWK, signature, msg []byte) error {
 	switch jwk.Kty {
-	case "EC":
+	case jws.KeyTypeEC:
 		return verifyECSignature(jwk, signature, msg)
-	case "OKP":
+	case jws.KeyTypeOKP:
 		return verifyEd25519Signature(jwk, signature, msg)
 	default:
 		return fmt.Errorf("'%s' key type is not supported for verifying signature", jwk.Kty)
@@ -122,6 +126,10 @@ func verifyECSignature(jwk *jws.JWK, signature, msg []byte) error {
 	r := big.NewInt(0).SetBytes(signature[:ec.keySize])
 	s := big.NewInt(0).SetBytes(signature[ec.keySize:])
 
+	if isSecp256k1(jwk.Kty, jwk.Crv) {
+		return verifySecp256k1Signature(ecdsaPubKey, hash, r, s)
+	}
+
 	verified := ecdsa.Verify(ecdsaPubKey, hash, r, s)
 	if !verified {
 		return errors.New("ecdsa: invalid signature")
@@ -130,6 +138,89 @@ func verifyECSignature(jwk *jws.JWK, signature, msg []byte) error {
 	return nil
 }
 
+// verifySecp256k1Signature verifies ES256K signature (R || S) using secp256k1 implementation.
+// High-S signatures are accepted since JWS (RFC 8812) doesn't require signature normalization.
+func verifySecp256k1Signature(pubKey *ecdsa.PublicKey, hash []byte, r, s *big.Int) error {
+	curve := btcec.S256()
+
+	if pubKey.Curve != curve {
+		return errors.New("ecdsa: public key is not on secp256k1 curve")
+	}
+
+	// parsing public key validates that the point is on the curve
+	btcecPubKey, err := btcec.ParsePubKey((*btcec.PublicKey)(pubKey).SerializeUncompressed(), curve)
+	if err != nil {
+		return fmt.Errorf("ecdsa: invalid secp256k1 public key: %s", err.Error())
+	}
+
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(curve.N) >= 0 || s.Cmp(curve.N) >= 0 {
+		return errors.New("ecdsa: invalid signature")
+	}
+
+	sig := &btcec.Signature{R: r, S: s}
+
+	if !sig.Verify(hash, btcecPubKey) {
+		return errors.New("ecdsa: invalid signature")
+	}
+
+	return nil
+}
+
+func isRSA(jwk *jws.JWK) bool {
+	return jwk.Kty == jws.KeyTypeRSA
+}
+
+// verifyRSASignature verifies RSA signature for the given JWS algorithm (PS256 or RS256).
+func verifyRSASignature(jwk *jws.JWK, alg string, signature, msg []byte) error {
+	pubKey, err := GetRSAPublicKey(jwk)
+	if err != nil {
+		return err
+	}
+
+	if pubKey.N.BitLen() < minRSAKeySize {
+		return fmt.Errorf("rsa: key size must be at least %d bits", minRSAKeySize)
+	}
+
+	hash := sha256.Sum256(msg)
+
+	switch alg {
+	case jws.AlgorithmPS256:
+		err = rsa.VerifyPSS(pubKey, crypto.SHA256, hash[:], signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case jws.AlgorithmRS256:
+		err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, hash[:], signature)
+	default:
+		return fmt.Errorf("rsa: algorithm '%s' is not supported", alg)
+	}
+
+	if err != nil {
+		return errors.New("rsa: invalid signature")
+	}
+
+	return nil
+}
+
+// GetRSAPublicKey returns RSA public key.
+func GetRSAPublicKey(jwk *jws.JWK) (*rsa.PublicKey, error) {
+	jsonBytes, err := json.Marshal(jwk)
+	if err != nil {
+		return nil, err
+	}
+
+	var internalJWK JWK
+
+	err = internalJWK.UnmarshalJSON(jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, ok := internalJWK.Key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("unexpected public key type for rsa")
+	}
+
+	return pubKey, nil
+}
+
 type ellipticCurve struct {
 	curve   elliptic.Curve
 	keySize int
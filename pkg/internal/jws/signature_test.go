@@ -12,7 +12,10 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -158,6 +161,135 @@ func TestVerifyECSignature(t *testing.T) {
 	})
 }
 
+func TestVerifySecp256k1Signature(t *testing.T) {
+	privateKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	jwk, err := getPublicKeyJWK(privateKey.PubKey().ToECDSA())
+	require.NoError(t, err)
+
+	payload := []byte("test")
+	hash := sha256.Sum256(payload)
+
+	sig, err := privateKey.Sign(hash[:])
+	require.NoError(t, err)
+
+	t.Run("success - btcec signature", func(t *testing.T) {
+		err := VerifySignature(jwk, getSecp256k1Signature(sig.R, sig.S), payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("success - high S signature", func(t *testing.T) {
+		highS := new(big.Int).Sub(btcec.S256().N, sig.S)
+
+		err := VerifySignature(jwk, getSecp256k1Signature(sig.R, highS), payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - different payload", func(t *testing.T) {
+		err := VerifySignature(jwk, getSecp256k1Signature(sig.R, sig.S), []byte("different"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ecdsa: invalid signature")
+	})
+
+	t.Run("error - signature values out of range", func(t *testing.T) {
+		err := VerifySignature(jwk, getSecp256k1Signature(big.NewInt(0), sig.S), payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ecdsa: invalid signature")
+
+		err = VerifySignature(jwk, getSecp256k1Signature(sig.R, btcec.S256().N), payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ecdsa: invalid signature")
+	})
+
+	t.Run("error - public key is not on secp256k1 curve", func(t *testing.T) {
+		p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		err = verifySecp256k1Signature(&p256Key.PublicKey, hash[:], sig.R, sig.S)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key is not on secp256k1 curve")
+	})
+
+	t.Run("error - invalid public key", func(t *testing.T) {
+		pubKey := &ecdsa.PublicKey{Curve: btcec.S256(), X: big.NewInt(1), Y: big.NewInt(1)}
+
+		err := verifySecp256k1Signature(pubKey, hash[:], sig.R, sig.S)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid secp256k1 public key")
+	})
+}
+
+func TestVerifyRSASignature(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk, err := getPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	payload := []byte("test")
+	hash := sha256.Sum256(payload)
+
+	pssSignature, err := rsa.SignPSS(rand.Reader, privateKey, crypto.SHA256, hash[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	require.NoError(t, err)
+
+	pkcs1Signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	t.Run("success - PS256", func(t *testing.T) {
+		err := verifyRSASignature(jwk, "PS256", pssSignature, payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("success - RS256", func(t *testing.T) {
+		err := verifyRSASignature(jwk, "RS256", pkcs1Signature, payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - signature created with different algorithm", func(t *testing.T) {
+		err := verifyRSASignature(jwk, "PS256", pkcs1Signature, payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rsa: invalid signature")
+	})
+
+	t.Run("error - different payload", func(t *testing.T) {
+		err := verifyRSASignature(jwk, "RS256", pkcs1Signature, []byte("different"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rsa: invalid signature")
+	})
+
+	t.Run("error - algorithm not supported", func(t *testing.T) {
+		err := verifyRSASignature(jwk, "RS512", pkcs1Signature, payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rsa: algorithm 'RS512' is not supported")
+	})
+
+	t.Run("error - key too small", func(t *testing.T) {
+		smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+
+		smallJWK, err := getPublicKeyJWK(&smallKey.PublicKey)
+		require.NoError(t, err)
+
+		err = verifyRSASignature(smallJWK, "RS256", pkcs1Signature, payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rsa: key size must be at least 2048 bits")
+	})
+
+	t.Run("error - not an RSA key", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		ecJWK, err := getPublicKeyJWK(&ecKey.PublicKey)
+		require.NoError(t, err)
+
+		err = verifyRSASignature(ecJWK, "RS256", pkcs1Signature, payload)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected public key type for rsa")
+	})
+}
+
 func TestVerifyED25519Signature(t *testing.T) {
 	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
 	require.NoError(t, err)
@@ -218,6 +350,18 @@ func getECSignatureSHA256(privateKey *ecdsa.PrivateKey, payload []byte) []byte {
 	return getECSignature(privateKey, payload, crypto.SHA256)
 }
 
+func getSecp256k1Signature(r, s *big.Int) []byte {
+	signature := make([]byte, 2*secp256k1KeySize)
+
+	rBytes := r.Bytes()
+	sBytes := s.Bytes()
+
+	copy(signature[secp256k1KeySize-len(rBytes):secp256k1KeySize], rBytes)
+	copy(signature[2*secp256k1KeySize-len(sBytes):], sBytes)
+
+	return signature
+}
+
 func getECSignature(privKey *ecdsa.PrivateKey, payload []byte, hash crypto.Hash) []byte {
 	hasher := hash.New()
 
@@ -257,7 +401,7 @@ func getPublicKeyJWK(pubKey interface{}) (*jws.JWK, error) {
 	}
 
 	switch key := pubKey.(type) {
-	case ed25519.PublicKey:
+	case ed25519.PublicKey, *rsa.PublicKey:
 		// handled automatically by gojose
 	case *ecdsa.PublicKey:
 		ecdsaPubKey := pubKey.(*ecdsa.PublicKey)
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jws
+
+// JWS algorithms (https://tools.ietf.org/html/rfc7518#section-3.1, https://tools.ietf.org/html/rfc8037#section-3.1
+// and https://tools.ietf.org/html/rfc8812#section-3.2).
+const (
+	// AlgorithmEdDSA is EdDSA signature algorithm (used with Ed25519 keys).
+	AlgorithmEdDSA = "EdDSA"
+
+	// AlgorithmES256 is ECDSA using P-256 and SHA-256.
+	AlgorithmES256 = "ES256"
+
+	// AlgorithmES384 is ECDSA using P-384 and SHA-384.
+	AlgorithmES384 = "ES384"
+
+	// AlgorithmES512 is ECDSA using P-521 and SHA-512.
+	AlgorithmES512 = "ES512"
+
+	// AlgorithmES256K is ECDSA using secp256k1 and SHA-256.
+	AlgorithmES256K = "ES256K"
+
+	// AlgorithmRS256 is RSASSA-PKCS1-v1_5 using SHA-256.
+	AlgorithmRS256 = "RS256"
+
+	// AlgorithmPS256 is RSASSA-PSS using SHA-256 and MGF1 with SHA-256.
+	AlgorithmPS256 = "PS256"
+)
+
+// JWK key types and curves.
+const (
+	// KeyTypeEC is elliptic curve key type.
+	KeyTypeEC = "EC"
+
+	// KeyTypeOKP is octet key pair key type (https://tools.ietf.org/html/rfc8037#section-2).
+	KeyTypeOKP = "OKP"
+
+	// KeyTypeRSA is RSA key type.
+	KeyTypeRSA = "RSA"
+
+	// CurveEd25519 is Ed25519 curve (OKP key type).
+	CurveEd25519 = "Ed25519"
+
+	// CurveP256 is P-256 curve (EC key type).
+	CurveP256 = "P-256"
+
+	// CurveP384 is P-384 curve (EC key type).
+	CurveP384 = "P-384"
+
+	// CurveP521 is P-521 curve (EC key type).
+	CurveP521 = "P-521"
+
+	// CurveSecp256k1 is secp256k1 curve (EC key type).
+	CurveSecp256k1 = "secp256k1"
+)
+
+var ecAlgorithms = map[string]string{
+	CurveP256:      AlgorithmES256,
+	CurveP384:      AlgorithmES384,
+	CurveP521:      AlgorithmES512,
+	CurveSecp256k1: AlgorithmES256K,
+}
+
+// Algorithm returns JWS algorithm for signing key; false is returned if key type/curve is not supported for signing
+// or if more than one algorithm can be used with the key (RSA).
+func (jwk *JWK) Algorithm() (string, bool) {
+	switch jwk.Kty {
+	case KeyTypeOKP:
+		if jwk.Crv == CurveEd25519 {
+			return AlgorithmEdDSA, true
+		}
+
+		return "", false
+	case KeyTypeEC:
+		alg, ok := ecAlgorithms[jwk.Crv]
+
+		return alg, ok
+	default:
+		return "", false
+	}
+}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package jws
 
+import "errors"
+
 // IANA registered JOSE headers (https://tools.ietf.org/html/rfc7515#section-4.1)
 const (
 	// HeaderAlgorithm identifies:
@@ -86,6 +88,50 @@ func (h Headers) Algorithm() (string, bool) {
 	return h.stringValue(HeaderAlgorithm)
 }
 
+// Critical gets critical header parameter names ('crit') from JOSE headers.
+func (h Headers) Critical() ([]string, bool) {
+	raw, ok := h[HeaderCritical]
+	if !ok {
+		return nil, false
+	}
+
+	switch values := raw.(type) {
+	case []string:
+		return values, true
+	case []interface{}:
+		names := make([]string, len(values))
+
+		for i, v := range values {
+			name, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+
+			names[i] = name
+		}
+
+		return names, true
+	default:
+		return nil, false
+	}
+}
+
+// B64Payload returns false if payload is not base64url encoded (RFC 7797 'b64' header is set to false).
+// An error is returned if 'b64' header is not boolean.
+func (h Headers) B64Payload() (bool, error) {
+	raw, ok := h[HeaderB64Payload]
+	if !ok {
+		return true, nil
+	}
+
+	b64, ok := raw.(bool)
+	if !ok {
+		return false, errors.New("invalid b64 header")
+	}
+
+	return b64, nil
+}
+
 func (h Headers) stringValue(key string) (string, bool) {
 	kRaw, ok := h[key]
 	if !ok {
@@ -36,3 +36,40 @@ func TestHeader(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "kid", kid)
 }
+
+func TestHeader_Critical(t *testing.T) {
+	crit, ok := Headers{}.Critical()
+	require.False(t, ok)
+	require.Nil(t, crit)
+
+	crit, ok = Headers{"crit": []string{"b64"}}.Critical()
+	require.True(t, ok)
+	require.Equal(t, []string{"b64"}, crit)
+
+	crit, ok = Headers{"crit": []interface{}{"b64", "exp"}}.Critical()
+	require.True(t, ok)
+	require.Equal(t, []string{"b64", "exp"}, crit)
+
+	crit, ok = Headers{"crit": []interface{}{"b64", 1}}.Critical()
+	require.False(t, ok)
+	require.Nil(t, crit)
+
+	crit, ok = Headers{"crit": "b64"}.Critical()
+	require.False(t, ok)
+	require.Nil(t, crit)
+}
+
+func TestHeader_B64Payload(t *testing.T) {
+	b64, err := Headers{}.B64Payload()
+	require.NoError(t, err)
+	require.True(t, b64)
+
+	b64, err = Headers{"b64": false}.B64Payload()
+	require.NoError(t, err)
+	require.False(t, b64)
+
+	b64, err = Headers{"b64": "false"}.B64Payload()
+	require.Error(t, err)
+	require.False(t, b64)
+	require.Contains(t, err.Error(), "invalid b64 header")
+}
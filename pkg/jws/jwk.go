@@ -14,10 +14,16 @@ type JWK struct {
 	Crv string `json:"crv"`
 	X   string `json:"x"`
 	Y   string `json:"y"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
 }
 
 // Validate validates JWK.
 func (jwk *JWK) Validate() error {
+	if jwk.Kty == KeyTypeRSA {
+		return jwk.validateRSA()
+	}
+
 	if jwk.Crv == "" {
 		return errors.New("JWK crv is missing")
 	}
@@ -30,5 +36,31 @@ func (jwk *JWK) Validate() error {
 		return errors.New("JWK x is missing")
 	}
 
+	// octet key pair has only x coordinate (https://tools.ietf.org/html/rfc8037#section-2)
+	if jwk.Kty == KeyTypeOKP && jwk.Y != "" {
+		return errors.New("JWK y is not allowed for OKP key type")
+	}
+
 	return nil
 }
+
+func (jwk *JWK) validateRSA() error {
+	if jwk.N == "" {
+		return errors.New("JWK n is missing")
+	}
+
+	if jwk.E == "" {
+		return errors.New("JWK e is missing")
+	}
+
+	return nil
+}
+
+// KeyAlgorithm returns key algorithm as used in protocol key algorithms (curve or 'RSA' for RSA keys).
+func (jwk *JWK) KeyAlgorithm() string {
+	if jwk.Kty == KeyTypeRSA {
+		return KeyTypeRSA
+	}
+
+	return jwk.Crv
+}
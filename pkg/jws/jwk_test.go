@@ -51,4 +51,87 @@ func TestValidate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "x is missing")
 	})
+	t.Run("success RSA", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "RSA",
+			N:   "n",
+			E:   "e",
+		}
+
+		err := jwk.Validate()
+		require.NoError(t, err)
+	})
+
+	t.Run("RSA - missing n", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "RSA",
+			E:   "e",
+		}
+
+		err := jwk.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "n is missing")
+	})
+
+	t.Run("RSA - missing e", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "RSA",
+			N:   "n",
+		}
+
+		err := jwk.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "e is missing")
+	})
+
+	t.Run("y not allowed for OKP", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   "x",
+			Y:   "y",
+		}
+
+		err := jwk.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "y is not allowed for OKP key type")
+	})
+}
+
+func TestAlgorithm(t *testing.T) {
+	tests := []struct {
+		kty string
+		crv string
+		alg string
+	}{
+		{kty: "OKP", crv: "Ed25519", alg: "EdDSA"},
+		{kty: "EC", crv: "P-256", alg: "ES256"},
+		{kty: "EC", crv: "P-384", alg: "ES384"},
+		{kty: "EC", crv: "P-521", alg: "ES512"},
+		{kty: "EC", crv: "secp256k1", alg: "ES256K"},
+	}
+
+	for _, tc := range tests {
+		alg, ok := (&JWK{Kty: tc.kty, Crv: tc.crv}).Algorithm()
+		require.True(t, ok)
+		require.Equal(t, tc.alg, alg)
+	}
+
+	t.Run("not supported", func(t *testing.T) {
+		for _, jwk := range []*JWK{
+			{Kty: "OKP", Crv: "X25519"},
+			{Kty: "EC", Crv: "Ed25519"},
+			{Kty: "RSA", N: "n", E: "e"},
+		} {
+			alg, ok := jwk.Algorithm()
+			require.False(t, ok)
+			require.Empty(t, alg)
+		}
+	})
+}
+
+func TestKeyAlgorithm(t *testing.T) {
+	require.Equal(t, "P-256", (&JWK{Kty: "EC", Crv: "P-256"}).KeyAlgorithm())
+	require.Equal(t, "Ed25519", (&JWK{Kty: "OKP", Crv: "Ed25519"}).KeyAlgorithm())
+	require.Equal(t, "RSA", (&JWK{Kty: "RSA", N: "n", E: "e"}).KeyAlgorithm())
 }
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics provides in-memory metrics provider that exposes metrics in Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	requestsMetric = "sidetree_http_requests_total"
+	durationMetric = "sidetree_http_request_duration_seconds"
+)
+
+type requestKey struct {
+	handler string
+	status  int
+}
+
+type duration struct {
+	sum   float64
+	count uint64
+}
+
+// Registry is in-memory metrics provider.
+type Registry struct {
+	mutex     sync.RWMutex
+	requests  map[requestKey]uint64
+	durations map[string]*duration
+}
+
+// NewRegistry returns a new metrics registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[string]*duration),
+	}
+}
+
+// HTTPRequest records HTTP request processed by the given handler.
+func (r *Registry) HTTPRequest(handler string, status int, d time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests[requestKey{handler: handler, status: status}]++
+
+	entry, ok := r.durations[handler]
+	if !ok {
+		entry = &duration{}
+		r.durations[handler] = entry
+	}
+
+	entry.sum += d.Seconds()
+	entry.count++
+}
+
+// RequestCount returns number of requests processed by the handler with the given status code.
+func (r *Registry) RequestCount(handler string, status int) uint64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.requests[requestKey{handler: handler, status: status}]
+}
+
+// WriteMetrics writes metrics in Prometheus text exposition format.
+func (r *Registry) WriteMetrics(w io.Writer) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# HELP %s Total number of HTTP requests.\n", requestsMetric)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", requestsMetric)
+
+	for _, key := range r.sortedRequestKeys() {
+		fmt.Fprintf(bw, "%s{handler=%s,code=\"%d\"} %d\n",
+			requestsMetric, quote(key.handler), key.status, r.requests[key])
+	}
+
+	fmt.Fprintf(bw, "# HELP %s HTTP request duration in seconds.\n", durationMetric)
+	fmt.Fprintf(bw, "# TYPE %s summary\n", durationMetric)
+
+	for _, handler := range r.sortedHandlers() {
+		d := r.durations[handler]
+
+		fmt.Fprintf(bw, "%s_sum{handler=%s} %s\n",
+			durationMetric, quote(handler), strconv.FormatFloat(d.sum, 'f', -1, 64))
+		fmt.Fprintf(bw, "%s_count{handler=%s} %d\n", durationMetric, quote(handler), d.count)
+	}
+
+	return bw.Flush()
+}
+
+func (r *Registry) sortedRequestKeys() []requestKey {
+	keys := make([]requestKey, 0, len(r.requests))
+	for key := range r.requests {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].handler == keys[j].handler {
+			return keys[i].status < keys[j].status
+		}
+
+		return keys[i].handler < keys[j].handler
+	})
+
+	return keys
+}
+
+func (r *Registry) sortedHandlers() []string {
+	handlers := make([]string, 0, len(r.durations))
+	for handler := range r.durations {
+		handlers = append(handlers, handler)
+	}
+
+	sort.Strings(handlers)
+
+	return handlers
+}
+
+// quote quotes label value (backslash, double-quote and line feed are escaped).
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+
+	return `"` + value + `"`
+}
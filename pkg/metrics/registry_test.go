@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r := NewRegistry()
+
+		r.HTTPRequest("POST /operations", http.StatusOK, 2*time.Second)
+		r.HTTPRequest("GET /identifiers/{id}", http.StatusNotFound, time.Second)
+		r.HTTPRequest("GET /identifiers/{id}", http.StatusOK, 500*time.Millisecond)
+		r.HTTPRequest("GET /identifiers/{id}", http.StatusOK, 500*time.Millisecond)
+
+		require.Equal(t, uint64(2), r.RequestCount("GET /identifiers/{id}", http.StatusOK))
+		require.Equal(t, uint64(1), r.RequestCount("GET /identifiers/{id}", http.StatusNotFound))
+		require.Equal(t, uint64(0), r.RequestCount("GET /identifiers/{id}", http.StatusGone))
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, r.WriteMetrics(buf))
+
+		expected := `# HELP sidetree_http_requests_total Total number of HTTP requests.
+# TYPE sidetree_http_requests_total counter
+sidetree_http_requests_total{handler="GET /identifiers/{id}",code="200"} 2
+sidetree_http_requests_total{handler="GET /identifiers/{id}",code="404"} 1
+sidetree_http_requests_total{handler="POST /operations",code="200"} 1
+# HELP sidetree_http_request_duration_seconds HTTP request duration in seconds.
+# TYPE sidetree_http_request_duration_seconds summary
+sidetree_http_request_duration_seconds_sum{handler="GET /identifiers/{id}"} 2
+sidetree_http_request_duration_seconds_count{handler="GET /identifiers/{id}"} 3
+sidetree_http_request_duration_seconds_sum{handler="POST /operations"} 2
+sidetree_http_request_duration_seconds_count{handler="POST /operations"} 1
+`
+		require.Equal(t, expected, buf.String())
+	})
+
+	t.Run("label value is escaped", func(t *testing.T) {
+		r := NewRegistry()
+		r.HTTPRequest("a\"b\\c\nd", http.StatusOK, time.Second)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, r.WriteMetrics(buf))
+		require.Contains(t, buf.String(), `handler="a\"b\\c\nd"`)
+	})
+
+	t.Run("error - write error", func(t *testing.T) {
+		r := NewRegistry()
+
+		err := r.WriteMetrics(&failingWriter{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "write error")
+	})
+}
+
+type failingWriter struct{}
+
+func (w *failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migration migrates anchored operations in the operation store when operation request encoding
+// (e.g. property names) changes between protocol versions. Each operation is re-encoded by the migration,
+// re-validated with the protocol version that applies to the operation and replaced in the store, so that
+// upgrades don't require wiping (and re-observing) the store.
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+var logger = log.New("sidetree-core-migration")
+
+// Store provides access to anchored operations.
+type Store interface {
+	// UniqueSuffixes returns unique suffixes of all documents in the store
+	UniqueSuffixes() ([]string, error)
+	// Get retrieves all operations related to document
+	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
+	// Replace replaces all operations related to document
+	Replace(uniqueSuffix string, ops []*operation.AnchoredOperation) error
+}
+
+// Migration re-encodes operation request; it returns new operation request and true if the request
+// has been changed.
+type Migration func(op *operation.AnchoredOperation) ([]byte, bool, error)
+
+// Progress contains progress of the migration.
+type Progress struct {
+	Documents      int `json:"documents"`
+	TotalDocuments int `json:"totalDocuments"`
+	Operations     int `json:"operations"`
+	Migrated       int `json:"migrated"`
+	Failed         int `json:"failed"`
+}
+
+// FailedOperation describes operation that could not be migrated (operation is not changed in the store).
+type FailedOperation struct {
+	UniqueSuffix      string `json:"uniqueSuffix"`
+	TransactionTime   uint64 `json:"transactionTime"`
+	TransactionNumber uint64 `json:"transactionNumber"`
+	Error             string `json:"error"`
+}
+
+// Report contains result of the migration.
+type Report struct {
+	Progress
+
+	DryRun           bool               `json:"dryRun"`
+	FailedOperations []*FailedOperation `json:"failedOperations,omitempty"`
+}
+
+// Option is an option for migrator.
+type Option func(opts *Migrator)
+
+// WithDryRun enables dry run: operations are migrated and validated but the store is not changed.
+func WithDryRun() Option {
+	return func(opts *Migrator) {
+		opts.dryRun = true
+	}
+}
+
+// WithProgress sets function that is invoked with migration progress after each document.
+func WithProgress(progress func(Progress)) Option {
+	return func(opts *Migrator) {
+		opts.progress = progress
+	}
+}
+
+// Migrator migrates anchored operations in the store.
+type Migrator struct {
+	namespace string
+	store     Store
+	pc        protocol.Client
+	migration Migration
+
+	dryRun   bool
+	progress func(Progress)
+}
+
+// New returns migrator for operations of the given namespace.
+func New(namespace string, store Store, pc protocol.Client, migration Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		namespace: namespace,
+		store:     store,
+		pc:        pc,
+		migration: migration,
+		progress:  func(Progress) {},
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Run migrates operations of all documents in the store. Operations that fail migration or validation
+// are reported and left unchanged (remaining operations of the document are migrated). An error is
+// returned if the store cannot be accessed.
+func (m *Migrator) Run() (*Report, error) {
+	suffixes, err := m.store.UniqueSuffixes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unique suffixes: %s", err.Error())
+	}
+
+	report := &Report{DryRun: m.dryRun}
+	report.TotalDocuments = len(suffixes)
+
+	logger.Infof("Migrating operations of %d documents (dry run: %t)", len(suffixes), m.dryRun)
+
+	for _, suffix := range suffixes {
+		if err := m.migrateDocument(suffix, report); err != nil {
+			return report, err
+		}
+
+		report.Documents++
+
+		m.progress(report.Progress)
+	}
+
+	logger.Infof("Migrated %d of %d operations (failed: %d, dry run: %t)",
+		report.Migrated, report.Operations, report.Failed, m.dryRun)
+
+	return report, nil
+}
+
+func (m *Migrator) migrateDocument(suffix string, report *Report) error {
+	ops, err := m.store.Get(suffix)
+	if err != nil {
+		return fmt.Errorf("failed to get operations for document [%s]: %s", suffix, err.Error())
+	}
+
+	migrated := make([]*operation.AnchoredOperation, len(ops))
+	changed := false
+
+	for i, op := range ops {
+		report.Operations++
+
+		migrated[i] = op
+
+		migratedOp, ok, err := m.migrateOperation(op)
+		if err != nil {
+			logger.Warnf("Failed to migrate operation of document [%s] anchored at [%d:%d]: %s",
+				suffix, op.TransactionTime, op.TransactionNumber, err.Error())
+
+			report.Failed++
+			report.FailedOperations = append(report.FailedOperations, &FailedOperation{
+				UniqueSuffix:      suffix,
+				TransactionTime:   op.TransactionTime,
+				TransactionNumber: op.TransactionNumber,
+				Error:             err.Error(),
+			})
+
+			continue
+		}
+
+		if ok {
+			migrated[i] = migratedOp
+			changed = true
+
+			report.Migrated++
+		}
+	}
+
+	if !changed || m.dryRun {
+		return nil
+	}
+
+	if err := m.store.Replace(suffix, migrated); err != nil {
+		return fmt.Errorf("failed to replace operations for document [%s]: %s", suffix, err.Error())
+	}
+
+	return nil
+}
+
+func (m *Migrator) migrateOperation(op *operation.AnchoredOperation) (*operation.AnchoredOperation, bool, error) {
+	buffer, ok, err := m.migration(op)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ok {
+		return op, false, nil
+	}
+
+	migrated := *op
+	migrated.OperationBuffer = buffer
+
+	if err := m.validate(&migrated); err != nil {
+		return nil, false, err
+	}
+
+	return &migrated, true, nil
+}
+
+// validate validates migrated operation with the protocol version that applies to the operation.
+func (m *Migrator) validate(op *operation.AnchoredOperation) error {
+	v, err := m.pc.Get(op.ProtocolGenesisTime)
+	if err != nil {
+		return fmt.Errorf("failed to get protocol version for genesis time [%d]: %s", op.ProtocolGenesisTime, err.Error())
+	}
+
+	parsed, err := v.OperationParser().Parse(m.namespace, op.OperationBuffer)
+	if err != nil {
+		return fmt.Errorf("migrated operation is not valid: %s", err.Error())
+	}
+
+	if parsed.UniqueSuffix != op.UniqueSuffix || parsed.Type != op.Type {
+		return errors.New("migrated operation has different unique suffix or type")
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+const namespace = "did:sidetree"
+
+var migration = RenameProperties(map[string]string{"suffix_data": "suffixData"})
+
+func TestMigrator_Run(t *testing.T) {
+	pc := newProtocolClient()
+
+	t.Run("success", func(t *testing.T) {
+		store := newStore()
+
+		var progress []Progress
+
+		report, err := New(namespace, store, pc, migration, WithProgress(func(p Progress) {
+			progress = append(progress, p)
+		})).Run()
+		require.NoError(t, err)
+		require.False(t, report.DryRun)
+		require.Equal(t, 2, report.TotalDocuments)
+		require.Equal(t, 2, report.Documents)
+		require.Equal(t, 3, report.Operations)
+		require.Equal(t, 2, report.Migrated)
+		require.Equal(t, 0, report.Failed)
+		require.Empty(t, report.FailedOperations)
+
+		require.Len(t, progress, 2)
+		require.Equal(t, 1, progress[0].Documents)
+		require.Equal(t, 2, progress[1].Documents)
+
+		require.Equal(t, `{"suffix":"suffix1","suffixData":"data","type":"create"}`,
+			string(store.ops["suffix1"][0].OperationBuffer))
+		require.Equal(t, `{"suffix":"suffix1","suffixData":"data","type":"update"}`,
+			string(store.ops["suffix1"][1].OperationBuffer))
+		require.Equal(t, uint64(2), store.ops["suffix1"][1].TransactionTime)
+		require.Equal(t, []string{"suffix1"}, store.replaced)
+
+		// migration is idempotent
+		report, err = New(namespace, store, pc, migration).Run()
+		require.NoError(t, err)
+		require.Equal(t, 0, report.Migrated)
+		require.Equal(t, []string{"suffix1"}, store.replaced)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		store := newStore()
+
+		report, err := New(namespace, store, pc, migration, WithDryRun()).Run()
+		require.NoError(t, err)
+		require.True(t, report.DryRun)
+		require.Equal(t, 2, report.Migrated)
+		require.Empty(t, store.replaced)
+		require.Equal(t, `{"suffix_data":"data","suffix":"suffix1","type":"create"}`,
+			string(store.ops["suffix1"][0].OperationBuffer))
+	})
+
+	t.Run("failed operations are reported and not migrated", func(t *testing.T) {
+		store := newStore()
+		store.ops["suffix2"] = append(store.ops["suffix2"],
+			newOperation("suffix2", operation.TypeUpdate, `{"suffix_data":"data","suffix":"other","type":"update"}`),
+			newOperation("suffix2", operation.TypeUpdate, `{"suffix_data":"invalid","suffix":"suffix2","type":"update"}`),
+			newOperation("suffix2", operation.TypeUpdate, `[]`),
+			newOperation("suffix2", operation.TypeUpdate, `{"suffix_data":"data","suffix":"suffix2","type":"update"}`),
+		)
+
+		report, err := New(namespace, store, pc, migration).Run()
+		require.NoError(t, err)
+		require.Equal(t, 7, report.Operations)
+		require.Equal(t, 3, report.Migrated)
+		require.Equal(t, 3, report.Failed)
+		require.Len(t, report.FailedOperations, 3)
+		require.Equal(t, "suffix2", report.FailedOperations[0].UniqueSuffix)
+		require.Equal(t, "migrated operation has different unique suffix or type", report.FailedOperations[0].Error)
+		require.Equal(t, "migrated operation is not valid: invalid suffix data", report.FailedOperations[1].Error)
+		require.Contains(t, report.FailedOperations[2].Error, "failed to unmarshal operation request")
+
+		require.Equal(t, []string{"suffix1", "suffix2"}, store.replaced)
+		require.Equal(t, `{"suffix_data":"data","suffix":"other","type":"update"}`,
+			string(store.ops["suffix2"][1].OperationBuffer))
+		require.Equal(t, `{"suffix":"suffix2","suffixData":"data","type":"update"}`,
+			string(store.ops["suffix2"][4].OperationBuffer))
+	})
+
+	t.Run("error - protocol version", func(t *testing.T) {
+		pc := newProtocolClient()
+		pc.Err = errors.New("protocol error")
+
+		report, err := New(namespace, newStore(), pc, migration).Run()
+		require.NoError(t, err)
+		require.Equal(t, 2, report.Failed)
+		require.Equal(t, "failed to get protocol version for genesis time [0]: protocol error",
+			report.FailedOperations[0].Error)
+	})
+
+	t.Run("error - store", func(t *testing.T) {
+		store := newStore()
+		store.suffixesErr = errors.New("suffixes error")
+
+		report, err := New(namespace, store, pc, migration).Run()
+		require.EqualError(t, err, "failed to get unique suffixes: suffixes error")
+		require.Nil(t, report)
+
+		store = newStore()
+		store.getErr = errors.New("get error")
+
+		report, err = New(namespace, store, pc, migration).Run()
+		require.EqualError(t, err, "failed to get operations for document [suffix1]: get error")
+		require.Equal(t, 0, report.Documents)
+
+		store = newStore()
+		store.replaceErr = errors.New("replace error")
+
+		_, err = New(namespace, store, pc, migration).Run()
+		require.EqualError(t, err, "failed to replace operations for document [suffix1]: replace error")
+	})
+}
+
+func newProtocolClient() *mocks.MockProtocolClient {
+	parser := &mocks.OperationParser{}
+	parser.ParseStub = func(_ string, buffer []byte) (*operation.Operation, error) {
+		var request map[string]string
+		if err := json.Unmarshal(buffer, &request); err != nil {
+			return nil, err
+		}
+
+		if request["suffixData"] != "data" {
+			return nil, fmt.Errorf("invalid suffix data")
+		}
+
+		return &operation.Operation{
+			UniqueSuffix: request["suffix"],
+			Type:         operation.Type(request["type"]),
+		}, nil
+	}
+
+	pc := mocks.NewMockProtocolClient()
+	pc.Versions[0].OperationParserReturns(parser)
+
+	return pc
+}
+
+func newOperation(suffix string, opType operation.Type, request string) *operation.AnchoredOperation {
+	return &operation.AnchoredOperation{
+		Type:            opType,
+		UniqueSuffix:    suffix,
+		OperationBuffer: []byte(request),
+	}
+}
+
+type mockStore struct {
+	suffixes []string
+	ops      map[string][]*operation.AnchoredOperation
+	replaced []string
+
+	suffixesErr error
+	getErr      error
+	replaceErr  error
+}
+
+func newStore() *mockStore {
+	update := newOperation("suffix1", operation.TypeUpdate, `{"suffix_data":"data","suffix":"suffix1","type":"update"}`)
+	update.TransactionTime = 2
+
+	return &mockStore{
+		suffixes: []string{"suffix1", "suffix2"},
+		ops: map[string][]*operation.AnchoredOperation{
+			"suffix1": {
+				newOperation("suffix1", operation.TypeCreate, `{"suffix_data":"data","suffix":"suffix1","type":"create"}`),
+				update,
+			},
+			"suffix2": {
+				newOperation("suffix2", operation.TypeCreate, `{"suffix":"suffix2","suffixData":"data","type":"create"}`),
+			},
+		},
+	}
+}
+
+func (m *mockStore) UniqueSuffixes() ([]string, error) {
+	return m.suffixes, m.suffixesErr
+}
+
+func (m *mockStore) Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error) {
+	return m.ops[uniqueSuffix], m.getErr
+}
+
+func (m *mockStore) Replace(uniqueSuffix string, ops []*operation.AnchoredOperation) error {
+	if m.replaceErr != nil {
+		return m.replaceErr
+	}
+
+	m.ops[uniqueSuffix] = ops
+	m.replaced = append(m.replaced, uniqueSuffix)
+
+	return nil
+}
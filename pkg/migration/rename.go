@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+)
+
+// RenameProperties returns migration that renames properties of operation request (at any level, e.g.
+// {"recovery_commitment": "recoveryCommitment"}); migrated request is canonicalized. Note that signed
+// data (compact JWS) is not changed.
+func RenameProperties(names map[string]string) Migration {
+	return func(op *operation.AnchoredOperation) ([]byte, bool, error) {
+		var request map[string]interface{}
+		if err := json.Unmarshal(op.OperationBuffer, &request); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal operation request: %s", err.Error())
+		}
+
+		renamed, changed := rename(request, names)
+		if !changed {
+			return nil, false, nil
+		}
+
+		buffer, err := canonicalizer.MarshalCanonical(renamed)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to marshal migrated operation request: %s", err.Error())
+		}
+
+		return buffer, true, nil
+	}
+}
+
+func rename(value interface{}, names map[string]string) (interface{}, bool) {
+	changed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(v))
+
+		for key, val := range v {
+			if newKey, ok := names[key]; ok {
+				key = newKey
+				changed = true
+			}
+
+			renamed, ok := rename(val, names)
+			changed = changed || ok
+
+			obj[key] = renamed
+		}
+
+		return obj, changed
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+
+		for i, val := range v {
+			renamed, ok := rename(val, names)
+			changed = changed || ok
+
+			arr[i] = renamed
+		}
+
+		return arr, changed
+	default:
+		return v, false
+	}
+}
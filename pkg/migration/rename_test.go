@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+func TestRenameProperties(t *testing.T) {
+	migrate := RenameProperties(map[string]string{
+		"recovery_commitment": "recoveryCommitment",
+		"public_keys":         "publicKeys",
+	})
+
+	t.Run("success - nested properties", func(t *testing.T) {
+		op := &operation.AnchoredOperation{
+			OperationBuffer: []byte(`{"type":"create","suffixData":{"recovery_commitment":"abc"},` +
+				`"delta":{"patches":[{"document":{"public_keys":[{"id":"key1"}]}}]}}`),
+		}
+
+		buffer, ok, err := migrate(op)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, `{"delta":{"patches":[{"document":{"publicKeys":[{"id":"key1"}]}}]},`+
+			`"suffixData":{"recoveryCommitment":"abc"},"type":"create"}`, string(buffer))
+	})
+
+	t.Run("success - not changed", func(t *testing.T) {
+		op := &operation.AnchoredOperation{
+			OperationBuffer: []byte(`{"type":"create","suffixData":{"recoveryCommitment":"abc"}}`),
+		}
+
+		buffer, ok, err := migrate(op)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Nil(t, buffer)
+	})
+
+	t.Run("error - invalid request", func(t *testing.T) {
+		buffer, ok, err := migrate(&operation.AnchoredOperation{OperationBuffer: []byte("invalid")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal operation request")
+		require.False(t, ok)
+		require.Nil(t, buffer)
+	})
+}
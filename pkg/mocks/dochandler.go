@@ -136,11 +136,27 @@ func (m *MockDocumentHandler) ProcessOperation(operationBuffer []byte, _ uint64)
 }
 
 // ResolveDocument mocks resolve document.
-func (m *MockDocumentHandler) ResolveDocument(didOrDocument string) (*document.ResolutionResult, error) {
+func (m *MockDocumentHandler) ResolveDocument(didOrDocument string, opts ...document.ResolutionOption) (*document.ResolutionResult, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 
+	result, err := m.resolveDocument(didOrDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.GetResolutionOptions(opts...).ContentType == document.ContentTypeDIDJSON {
+		doc := result.Document.Copy()
+		delete(doc, document.ContextProperty)
+
+		result.Document = doc
+	}
+
+	return result, nil
+}
+
+func (m *MockDocumentHandler) resolveDocument(didOrDocument string) (*document.ResolutionResult, error) {
 	const badRequest = "bad request"
 	if !strings.HasPrefix(didOrDocument, m.namespace) {
 		return nil, fmt.Errorf("%s: must start with supported namespace", badRequest)
@@ -131,6 +131,7 @@ func GetProtocolVersion(p protocol.Protocol) *ProtocolVersion {
 	v.DocumentTransformerReturns(&DocumentTransformer{})
 
 	v.ProtocolReturns(p)
+	v.CapabilitiesReturns(p.Capabilities())
 
 	return v
 }
@@ -153,6 +154,6 @@ func GetDefaultProtocolParameters() protocol.Protocol {
 		MaxProofFileSize:            MaxBatchFileSize,
 		SignatureAlgorithms:         []string{"EdDSA", "ES256"},
 		KeyAlgorithms:               []string{"Ed25519", "P-256"},
-		Patches:                     []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
+		Patches:                     []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch", "add-also-known-as", "remove-also-known-as", "json-merge-patch", "replace-services", "add-verification-relationships", "remove-verification-relationships", "set-controller"},
 	}
 }
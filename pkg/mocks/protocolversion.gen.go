@@ -8,6 +8,16 @@ import (
 )
 
 type ProtocolVersion struct {
+	CapabilitiesStub        func() protocol.Capabilities
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+	}
+	capabilitiesReturns struct {
+		result1 protocol.Capabilities
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 protocol.Capabilities
+	}
 	DocumentComposerStub        func() protocol.DocumentComposer
 	documentComposerMutex       sync.RWMutex
 	documentComposerArgsForCall []struct {
@@ -112,6 +122,58 @@ type ProtocolVersion struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ProtocolVersion) Capabilities() protocol.Capabilities {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Capabilities", []interface{}{})
+	fake.capabilitiesMutex.Unlock()
+	if fake.CapabilitiesStub != nil {
+		return fake.CapabilitiesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.capabilitiesReturns
+	return fakeReturns.result1
+}
+
+func (fake *ProtocolVersion) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *ProtocolVersion) CapabilitiesCalls(stub func() protocol.Capabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = stub
+}
+
+func (fake *ProtocolVersion) CapabilitiesReturns(result1 protocol.Capabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesReturns = struct {
+		result1 protocol.Capabilities
+	}{result1}
+}
+
+func (fake *ProtocolVersion) CapabilitiesReturnsOnCall(i int, result1 protocol.Capabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 protocol.Capabilities
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 protocol.Capabilities
+	}{result1}
+}
+
 func (fake *ProtocolVersion) DocumentComposer() protocol.DocumentComposer {
 	fake.documentComposerMutex.Lock()
 	ret, specificReturn := fake.documentComposerReturnsOnCall[len(fake.documentComposerArgsForCall)]
@@ -635,6 +697,8 @@ func (fake *ProtocolVersion) VersionReturnsOnCall(i int, result1 string) {
 func (fake *ProtocolVersion) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
 	fake.documentComposerMutex.RLock()
 	defer fake.documentComposerMutex.RUnlock()
 	fake.documentTransformerMutex.RLock()
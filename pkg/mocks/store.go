@@ -8,6 +8,7 @@ package mocks
 
 import (
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -65,3 +66,36 @@ func (m *MockOperationStore) Get(uniqueSuffix string) ([]*operation.AnchoredOper
 
 	return nil, errors.New("uniqueSuffix not found in the store")
 }
+
+// GetPage mocks retrieving page of operations (ordered by transaction time and number) anchored after the given position.
+func (m *MockOperationStore) GetPage(uniqueSuffix string, after *operation.Position, limit int) ([]*operation.AnchoredOperation, error) {
+	ops, err := m.Get(uniqueSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*operation.AnchoredOperation, len(ops))
+	copy(sorted, ops)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TransactionTime == sorted[j].TransactionTime {
+			return sorted[i].TransactionNumber < sorted[j].TransactionNumber
+		}
+
+		return sorted[i].TransactionTime < sorted[j].TransactionTime
+	})
+
+	var page []*operation.AnchoredOperation
+
+	for _, op := range sorted {
+		if len(page) == limit {
+			break
+		}
+
+		if after == nil || after.Before(op) {
+			page = append(page, op)
+		}
+	}
+
+	return page, nil
+}
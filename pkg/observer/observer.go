@@ -7,6 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package observer
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -31,24 +36,58 @@ type OperationFilter interface {
 	Filter(uniqueSuffix string, ops []*operation.AnchoredOperation) ([]*operation.AnchoredOperation, error)
 }
 
+// AnchorListener is notified when a Sidetree transaction has been processed (e.g. to update
+// status of operations submitted to the batch writer).
+type AnchorListener interface {
+	Anchored(anchorString string, txnTime, txnNumber uint64)
+}
+
+// UnsupportedTxnStore stores transactions that declare protocol version that is not supported by the node
+// (e.g. transactions anchored by upgraded nodes), so that they can be reprocessed after the node is upgraded.
+type UnsupportedTxnStore interface {
+	Put(txn txn.SidetreeTxn) error
+	Get() ([]txn.SidetreeTxn, error)
+	Delete(txn txn.SidetreeTxn) error
+}
+
 // Providers contains all of the providers required by the TxnProcessor.
 type Providers struct {
 	Ledger                 Ledger
 	ProtocolClientProvider protocol.ClientProvider
+	// AnchorListener is optional
+	AnchorListener AnchorListener
+	// UnsupportedTxnStore is optional (transactions are kept in memory by default)
+	UnsupportedTxnStore UnsupportedTxnStore
 }
 
+// errUnsupportedVersion indicates that transaction declares protocol version that is not supported.
+var errUnsupportedVersion = errors.New("protocol version is not supported")
+
 // Observer receives transactions over a channel and processes them by storing them to an operation store.
 type Observer struct {
 	*Providers
 
-	stopCh chan struct{}
+	stopCh      chan struct{}
+	stopped     uint32
+	lastTxnTime uint64
+
+	unsupportedTxns UnsupportedTxnStore
+
+	// serializes processing of new and reprocessed transactions
+	mutex sync.Mutex
 }
 
 // New returns a new observer.
 func New(providers *Providers) *Observer {
+	unsupportedTxns := providers.UnsupportedTxnStore
+	if unsupportedTxns == nil {
+		unsupportedTxns = NewMemUnsupportedTxnStore()
+	}
+
 	return &Observer{
-		Providers: providers,
-		stopCh:    make(chan struct{}, 1),
+		Providers:       providers,
+		stopCh:          make(chan struct{}, 1),
+		unsupportedTxns: unsupportedTxns,
 	}
 }
 
@@ -62,7 +101,21 @@ func (o *Observer) Stop() {
 	o.stopCh <- struct{}{}
 }
 
+// Stopped returns true if the observer is no longer listening for transactions (observer was stopped
+// or notification channel was closed).
+func (o *Observer) Stopped() bool {
+	return atomic.LoadUint32(&o.stopped) == 1
+}
+
+// LastTransactionTime returns transaction time of the latest transaction handled by the observer
+// (e.g. used to check how far behind the chain head the observer is).
+func (o *Observer) LastTransactionTime() uint64 {
+	return atomic.LoadUint64(&o.lastTxnTime)
+}
+
 func (o *Observer) listen(txnsCh <-chan []txn.SidetreeTxn) {
+	defer atomic.StoreUint32(&o.stopped, 1)
+
 	for {
 		select {
 		case <-o.stopCh:
@@ -82,29 +135,112 @@ func (o *Observer) listen(txnsCh <-chan []txn.SidetreeTxn) {
 	}
 }
 
-func (o *Observer) process(txns []txn.SidetreeTxn) {
-	for _, txn := range txns {
-		pc, err := o.ProtocolClientProvider.ForNamespace(txn.Namespace)
-		if err != nil {
-			logger.Warnf("Failed to get protocol client for namespace [%s]: %s", txn.Namespace, err.Error())
+// Reprocess processes transactions that were recorded because they declared unsupported protocol
+// version (e.g. after the node was upgraded with the new protocol version). Transactions that are
+// still not supported remain recorded. The number of processed transactions is returned.
+func (o *Observer) Reprocess() (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	txns, err := o.unsupportedTxns.Get()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unsupported transactions: %s", err.Error())
+	}
+
+	processed := 0
+
+	for _, t := range txns {
+		if err := o.processTxn(t); errors.Is(err, errUnsupportedVersion) {
+			logger.Debugf("Transaction [%s] still declares unsupported protocol version: %s", t.AnchorString, err.Error())
 
 			continue
 		}
 
-		v, err := pc.Get(txn.ProtocolGenesisTime)
-		if err != nil {
-			logger.Warnf("Failed to get processor for transaction time [%d]: %s", txn.ProtocolGenesisTime, err.Error())
+		// transaction was handled (failures other than unsupported version are not retried)
+		if err := o.unsupportedTxns.Delete(t); err != nil {
+			return processed, fmt.Errorf("failed to delete unsupported transaction: %s", err.Error())
+		}
+
+		processed++
+	}
+
+	logger.Infof("Reprocessed %d of %d transactions with unsupported protocol version", processed, len(txns))
+
+	return processed, nil
+}
+
+func (o *Observer) process(txns []txn.SidetreeTxn) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, t := range txns {
+		o.updateLastTransactionTime(t.TransactionTime)
 
+		err := o.processTxn(t)
+		if !errors.Is(err, errUnsupportedVersion) {
 			continue
 		}
 
-		err = v.TransactionProcessor().Process(txn)
-		if err != nil {
-			logger.Warnf("Failed to process anchor[%s]: %s", txn.AnchorString, err.Error())
+		logger.Warnf("Recording anchor[%s] for reprocessing: %s", t.AnchorString, err.Error())
 
-			continue
+		if err := o.unsupportedTxns.Put(t); err != nil {
+			logger.Errorf("Failed to record anchor[%s] with unsupported protocol version: %s", t.AnchorString, err.Error())
 		}
+	}
+}
+
+// processTxn processes transaction; errUnsupportedVersion is returned (wrapped) if transaction declares
+// protocol version that is not supported, other failures are logged.
+func (o *Observer) processTxn(t txn.SidetreeTxn) error {
+	pc, err := o.ProtocolClientProvider.ForNamespace(t.Namespace)
+	if err != nil {
+		logger.Warnf("Failed to get protocol client for namespace [%s]: %s", t.Namespace, err.Error())
+
+		return err
+	}
+
+	v, err := getVersion(pc, t.ProtocolGenesisTime)
+	if err != nil {
+		return err
+	}
+
+	err = v.TransactionProcessor().Process(t)
+	if err != nil {
+		logger.Warnf("Failed to process anchor[%s]: %s", t.AnchorString, err.Error())
+
+		return err
+	}
+
+	logger.Debugf("Successfully processed anchor[%s]", t.AnchorString)
 
-		logger.Debugf("Successfully processed anchor[%s]", txn.AnchorString)
+	if o.AnchorListener != nil {
+		o.AnchorListener.Anchored(t.AnchorString, t.TransactionTime, t.TransactionNumber)
+	}
+
+	return nil
+}
+
+// getVersion returns protocol version with the given genesis time.
+func getVersion(pc protocol.Client, genesisTime uint64) (protocol.Version, error) {
+	v, err := pc.Get(genesisTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: genesis time [%d]: %s", errUnsupportedVersion, genesisTime, err.Error())
+	}
+
+	// version that applies at the given time has different genesis time if the declared version is unknown
+	if v.Protocol().GenesisTime != genesisTime {
+		return nil, fmt.Errorf("%w: genesis time [%d], closest supported version [%s] has genesis time [%d]",
+			errUnsupportedVersion, genesisTime, v.Version(), v.Protocol().GenesisTime)
+	}
+
+	return v, nil
+}
+
+func (o *Observer) updateLastTransactionTime(txnTime uint64) {
+	for {
+		last := atomic.LoadUint64(&o.lastTxnTime)
+		if txnTime <= last || atomic.CompareAndSwapUint64(&o.lastTxnTime, last, txnTime) {
+			return
+		}
 	}
 }
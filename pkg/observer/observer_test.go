@@ -8,6 +8,7 @@ package observer
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -38,8 +39,12 @@ func TestStartObserver(t *testing.T) {
 		o.Start()
 		defer o.Stop()
 
+		require.False(t, o.Stopped())
+
 		close(sidetreeTxnCh)
 		time.Sleep(200 * time.Millisecond)
+
+		require.True(t, o.Stopped())
 	})
 
 	t.Run("test success", func(t *testing.T) {
@@ -52,9 +57,12 @@ func TestStartObserver(t *testing.T) {
 		pc.Versions[0].TransactionProcessorReturns(tp)
 		pc.Versions[0].ProtocolReturns(pc.Protocol)
 
+		listener := &mockAnchorListener{}
+
 		providers := &Providers{
 			Ledger:                 mockLedger{registerForSidetreeTxnValue: sidetreeTxnCh},
 			ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace1, pc),
+			AnchorListener:         listener,
 		}
 
 		o := New(providers)
@@ -65,15 +73,124 @@ func TestStartObserver(t *testing.T) {
 
 		sidetreeTxnCh <- []txn.SidetreeTxn{
 			{Namespace: namespace1, ProtocolGenesisTime: 0, TransactionTime: 10, TransactionNumber: 0, AnchorString: "1.address"},
-			{Namespace: namespace1, ProtocolGenesisTime: 20, TransactionTime: 21, TransactionNumber: 2, AnchorString: "1.address"},
+			{Namespace: namespace1, ProtocolGenesisTime: 1, TransactionTime: 21, TransactionNumber: 2, AnchorString: "1.address"},
 			{Namespace: namespace2, ProtocolGenesisTime: 100, TransactionTime: 200, TransactionNumber: 2, AnchorString: "2.address"},
 		}
 		time.Sleep(200 * time.Millisecond)
 
 		require.Equal(t, 1, tp.ProcessCallCount())
+		require.Equal(t, uint64(200), o.LastTransactionTime())
+		require.Equal(t, []string{"1.address"}, listener.getAnchors())
 	})
 }
 
+func TestObserver_UnsupportedVersion(t *testing.T) {
+	const namespace = "ns1"
+
+	tp := &mocks.TxnProcessor{}
+
+	pc := mocks.NewMockProtocolClient()
+	pc.Protocol.GenesisTime = 1
+	pc.Versions[0].TransactionProcessorReturns(tp)
+	pc.Versions[0].ProtocolReturns(pc.Protocol)
+
+	listener := &mockAnchorListener{}
+
+	o := New(&Providers{
+		ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace, pc),
+		AnchorListener:         listener,
+	})
+
+	o.process([]txn.SidetreeTxn{
+		{Namespace: namespace, ProtocolGenesisTime: 0, TransactionTime: 5, AnchorString: "0.address"},
+		{Namespace: namespace, ProtocolGenesisTime: 1, TransactionTime: 10, AnchorString: "1.address"},
+		{Namespace: namespace, ProtocolGenesisTime: 500, TransactionTime: 600, AnchorString: "2.address"},
+	})
+
+	require.Equal(t, 1, tp.ProcessCallCount())
+	require.Equal(t, uint64(600), o.LastTransactionTime())
+
+	unsupported, err := o.unsupportedTxns.Get()
+	require.NoError(t, err)
+	require.Len(t, unsupported, 2)
+	require.Equal(t, "0.address", unsupported[0].AnchorString)
+	require.Equal(t, "2.address", unsupported[1].AnchorString)
+
+	// node is not upgraded yet
+	processed, err := o.Reprocess()
+	require.NoError(t, err)
+	require.Equal(t, 0, processed)
+
+	// upgrade node with the new protocol version
+	tpUpgrade := &mocks.TxnProcessor{}
+
+	upgrade := mocks.GetDefaultProtocolParameters()
+	upgrade.GenesisTime = 500
+
+	v := mocks.GetProtocolVersion(upgrade)
+	v.TransactionProcessorReturns(tpUpgrade)
+
+	pc.Versions = append(pc.Versions, v)
+
+	processed, err = o.Reprocess()
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	require.Equal(t, 1, tpUpgrade.ProcessCallCount())
+	require.Equal(t, []string{"1.address", "2.address"}, listener.getAnchors())
+
+	unsupported, err = o.unsupportedTxns.Get()
+	require.NoError(t, err)
+	require.Len(t, unsupported, 1)
+	require.Equal(t, "0.address", unsupported[0].AnchorString)
+
+	t.Run("error - store", func(t *testing.T) {
+		store := &mockUnsupportedTxnStore{getErr: fmt.Errorf("get error")}
+
+		o := New(&Providers{
+			ProtocolClientProvider: mocks.NewMockProtocolClientProvider().WithProtocolClient(namespace, pc),
+			UnsupportedTxnStore:    store,
+		})
+
+		processed, err := o.Reprocess()
+		require.EqualError(t, err, "failed to get unsupported transactions: get error")
+		require.Equal(t, 0, processed)
+
+		store.getErr = nil
+		store.putErr = fmt.Errorf("put error")
+		store.deleteErr = fmt.Errorf("delete error")
+		store.txns = []txn.SidetreeTxn{{Namespace: namespace, ProtocolGenesisTime: 500}}
+
+		// put error is logged
+		o.process([]txn.SidetreeTxn{{Namespace: namespace, ProtocolGenesisTime: 1000}})
+
+		processed, err = o.Reprocess()
+		require.EqualError(t, err, "failed to delete unsupported transaction: delete error")
+		require.Equal(t, 0, processed)
+	})
+}
+
+func TestMemUnsupportedTxnStore(t *testing.T) {
+	s := NewMemUnsupportedTxnStore()
+
+	txn1 := txn.SidetreeTxn{AnchorString: "1.address", TransactionNumber: 1}
+	txn2 := txn.SidetreeTxn{AnchorString: "2.address", TransactionNumber: 2}
+
+	require.NoError(t, s.Put(txn1))
+	require.NoError(t, s.Put(txn2))
+	require.NoError(t, s.Put(txn1))
+
+	txns, err := s.Get()
+	require.NoError(t, err)
+	require.Equal(t, []txn.SidetreeTxn{txn1, txn2}, txns)
+
+	require.NoError(t, s.Delete(txn1))
+	require.NoError(t, s.Delete(txn1))
+
+	txns, err = s.Get()
+	require.NoError(t, err)
+	require.Equal(t, []txn.SidetreeTxn{txn2}, txns)
+}
+
 func TestTxnProcessor_Process(t *testing.T) {
 	t.Run("test error from txn operations provider", func(t *testing.T) {
 		errExpected := fmt.Errorf("txn operations provider error")
@@ -102,6 +219,25 @@ func (m mockLedger) RegisterForSidetreeTxn() <-chan []txn.SidetreeTxn {
 	return m.registerForSidetreeTxnValue
 }
 
+type mockAnchorListener struct {
+	mutex   sync.Mutex
+	anchors []string
+}
+
+func (m *mockAnchorListener) Anchored(anchorString string, _, _ uint64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.anchors = append(m.anchors, anchorString)
+}
+
+func (m *mockAnchorListener) getAnchors() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.anchors
+}
+
 type mockOperationStore struct {
 	putFunc func(ops []*operation.AnchoredOperation) error
 	getFunc func(suffix string) ([]*operation.AnchoredOperation, error)
@@ -138,3 +274,22 @@ func (m *mockTxnOpsProvider) GetTxnOperations(txn *txn.SidetreeTxn) ([]*operatio
 
 	return []*operation.AnchoredOperation{op}, nil
 }
+
+type mockUnsupportedTxnStore struct {
+	txns      []txn.SidetreeTxn
+	putErr    error
+	getErr    error
+	deleteErr error
+}
+
+func (m *mockUnsupportedTxnStore) Put(t txn.SidetreeTxn) error {
+	return m.putErr
+}
+
+func (m *mockUnsupportedTxnStore) Get() ([]txn.SidetreeTxn, error) {
+	return m.txns, m.getErr
+}
+
+func (m *mockUnsupportedTxnStore) Delete(t txn.SidetreeTxn) error {
+	return m.deleteErr
+}
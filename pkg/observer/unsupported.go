@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observer
+
+import (
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/txn"
+)
+
+// MemUnsupportedTxnStore keeps transactions with unsupported protocol version in memory.
+type MemUnsupportedTxnStore struct {
+	mutex sync.RWMutex
+	txns  []txn.SidetreeTxn
+}
+
+// NewMemUnsupportedTxnStore returns a new in-memory store of transactions with unsupported protocol version.
+func NewMemUnsupportedTxnStore() *MemUnsupportedTxnStore {
+	return &MemUnsupportedTxnStore{}
+}
+
+// Put records transaction (transaction that is already recorded is ignored).
+func (s *MemUnsupportedTxnStore) Put(t txn.SidetreeTxn) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, existing := range s.txns {
+		if existing == t {
+			return nil
+		}
+	}
+
+	s.txns = append(s.txns, t)
+
+	return nil
+}
+
+// Get returns recorded transactions (in the order they were recorded).
+func (s *MemUnsupportedTxnStore) Get() ([]txn.SidetreeTxn, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	txns := make([]txn.SidetreeTxn, len(s.txns))
+	copy(txns, s.txns)
+
+	return txns, nil
+}
+
+// Delete deletes recorded transaction.
+func (s *MemUnsupportedTxnStore) Delete(t txn.SidetreeTxn) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, existing := range s.txns {
+		if existing == t {
+			s.txns = append(s.txns[:i], s.txns[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return nil
+}
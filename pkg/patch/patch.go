@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
@@ -40,6 +41,27 @@ const (
 
 	// JSONPatch captures enum value "json-patch".
 	JSONPatch Action = "ietf-json-patch"
+
+	// AddAlsoKnownAs captures "add-also-known-as".
+	AddAlsoKnownAs Action = "add-also-known-as"
+
+	// RemoveAlsoKnownAs captures "remove-also-known-as".
+	RemoveAlsoKnownAs Action = "remove-also-known-as"
+
+	// ReplaceServiceEndpoints captures "replace-services".
+	ReplaceServiceEndpoints Action = "replace-services"
+
+	// AddVerificationRelationships captures "add-verification-relationships".
+	AddVerificationRelationships Action = "add-verification-relationships"
+
+	// RemoveVerificationRelationships captures "remove-verification-relationships".
+	RemoveVerificationRelationships Action = "remove-verification-relationships"
+
+	// JSONMergePatch captures enum value "json-merge-patch" (RFC 7386).
+	JSONMergePatch Action = "json-merge-patch"
+
+	// SetController captures "set-controller".
+	SetController Action = "set-controller"
 )
 
 // Key defines key that will be used to get document patch information.
@@ -64,20 +86,108 @@ const (
 
 	// ActionKey captures "action" key.
 	ActionKey Key = "action"
+
+	// UrisKey captures "uris" key.
+	UrisKey Key = "uris"
+
+	// PatchKey captures "patch" key.
+	PatchKey Key = "patch"
+
+	// RelationshipsKey captures "relationships" key.
+	RelationshipsKey Key = "relationships"
+
+	// ControllerKey captures "controller" key.
+	ControllerKey Key = "controller"
 )
 
+// nolint:gochecknoglobals
+var actionMutex sync.RWMutex
+
 var actionConfig = map[Action]Key{
-	AddPublicKeys:          PublicKeys,
-	RemovePublicKeys:       IdsKey,
-	AddServiceEndpoints:    ServicesKey,
-	RemoveServiceEndpoints: IdsKey,
-	JSONPatch:              PatchesKey,
-	Replace:                DocumentKey,
+	AddPublicKeys:                   PublicKeys,
+	RemovePublicKeys:                IdsKey,
+	AddServiceEndpoints:             ServicesKey,
+	RemoveServiceEndpoints:          IdsKey,
+	JSONPatch:                       PatchesKey,
+	Replace:                         DocumentKey,
+	AddAlsoKnownAs:                  UrisKey,
+	RemoveAlsoKnownAs:               UrisKey,
+	JSONMergePatch:                  PatchKey,
+	ReplaceServiceEndpoints:         ServicesKey,
+	AddVerificationRelationships:    RelationshipsKey,
+	RemoveVerificationRelationships: RelationshipsKey,
+	SetController:                   ControllerKey,
+}
+
+// idempotentActions are actions that produce the same result when applied repeatedly;
+// consecutive duplicates of these patches can be safely removed.
+// nolint:gochecknoglobals
+var idempotentActions = map[Action]bool{
+	Replace:                         true,
+	AddPublicKeys:                   true,
+	RemovePublicKeys:                true,
+	AddServiceEndpoints:             true,
+	RemoveServiceEndpoints:          true,
+	AddAlsoKnownAs:                  true,
+	RemoveAlsoKnownAs:               true,
+	JSONMergePatch:                  true,
+	ReplaceServiceEndpoints:         true,
+	AddVerificationRelationships:    true,
+	RemoveVerificationRelationships: true,
+	SetController:                   true,
 }
 
 // Patch defines generic patch structure.
 type Patch map[Key]interface{}
 
+// RegisterAction registers custom patch action. Value key is the patch key that holds patch value
+// (e.g. "uris" for "add-also-known-as"). Standard actions cannot be overridden.
+func RegisterAction(action Action, valueKey Key) error {
+	if action == "" {
+		return errors.New("missing action")
+	}
+
+	if valueKey == "" || valueKey == ActionKey {
+		return fmt.Errorf("invalid value key '%s' for action '%s'", valueKey, action)
+	}
+
+	actionMutex.Lock()
+	defer actionMutex.Unlock()
+
+	if _, ok := actionConfig[action]; ok {
+		return fmt.Errorf("action '%s' is already registered", action)
+	}
+
+	actionConfig[action] = valueKey
+
+	return nil
+}
+
+// IsRegistered returns true if the action is standard action or registered custom action.
+func IsRegistered(action Action) bool {
+	_, ok := getValueKey(action)
+
+	return ok
+}
+
+// NewPatch creates new patch for registered action.
+func NewPatch(action Action, value interface{}) (Patch, error) {
+	valueKey, ok := getValueKey(action)
+	if !ok {
+		return nil, fmt.Errorf("action '%s' is not supported", action)
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("missing value for action '%s'", action)
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = action
+	patch[valueKey] = value
+
+	return patch, nil
+}
+
 // PatchesFromDocument creates patches from opaque document.
 func PatchesFromDocument(doc string) ([]Patch, error) { //nolint:gocyclo
 	parsed, err := document.FromBytes([]byte(doc))
@@ -104,6 +214,10 @@ func PatchesFromDocument(doc string) ([]Patch, error) { //nolint:gocyclo
 			docPatch, err = NewAddPublicKeysPatch(string(jsonBytes))
 		case document.ServiceProperty:
 			docPatch, err = NewAddServiceEndpointsPatch(string(jsonBytes))
+		case document.AlsoKnownAs:
+			docPatch, err = NewAddAlsoKnownAs(string(jsonBytes))
+		case document.ControllerProperty:
+			docPatch, err = NewSetControllerPatch(string(jsonBytes))
 		default:
 			jsonPatches = append(jsonPatches, fmt.Sprintf(jsonPatchAddTemplate, key, string(jsonBytes)))
 		}
@@ -162,6 +276,25 @@ func NewJSONPatch(patches string) (Patch, error) {
 	return patch, nil
 }
 
+// NewJSONMergePatch creates new JSON merge patch (RFC 7386); merge patch has to be JSON object.
+func NewJSONMergePatch(mergePatch string) (Patch, error) {
+	var generic map[string]interface{}
+	err := json.Unmarshal([]byte(mergePatch), &generic)
+	if err != nil {
+		return nil, fmt.Errorf("merge patch is not JSON object: %s", err.Error())
+	}
+
+	if len(generic) == 0 {
+		return nil, errors.New("missing merge patch")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = JSONMergePatch
+	patch[PatchKey] = generic
+
+	return patch, nil
+}
+
 // NewAddPublicKeysPatch creates new patch for adding public keys.
 func NewAddPublicKeysPatch(publicKeys string) (Patch, error) {
 	pubKeys, err := getPublicKeys(publicKeys)
@@ -194,6 +327,36 @@ func NewRemovePublicKeysPatch(publicKeyIds string) (Patch, error) {
 	return patch, nil
 }
 
+// NewAddVerificationRelationshipsPatch creates new patch for adding purposes (verification relationships)
+// to existing public keys, e.g. [{"id": "key1", "purposes": ["authentication"]}].
+func NewAddVerificationRelationshipsPatch(relationships string) (Patch, error) {
+	return newVerificationRelationshipsPatch(AddVerificationRelationships, relationships)
+}
+
+// NewRemoveVerificationRelationshipsPatch creates new patch for removing purposes (verification relationships)
+// from existing public keys, e.g. [{"id": "key1", "purposes": ["authentication"]}].
+func NewRemoveVerificationRelationshipsPatch(relationships string) (Patch, error) {
+	return newVerificationRelationshipsPatch(RemoveVerificationRelationships, relationships)
+}
+
+func newVerificationRelationshipsPatch(action Action, relationships string) (Patch, error) {
+	var values []interface{}
+	err := json.Unmarshal([]byte(relationships), &values)
+	if err != nil {
+		return nil, fmt.Errorf("verification relationships invalid: %s", err.Error())
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("missing verification relationships")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = action
+	patch[RelationshipsKey] = values
+
+	return patch, nil
+}
+
 // NewAddServiceEndpointsPatch creates new patch for adding service endpoints.
 func NewAddServiceEndpointsPatch(serviceEndpoints string) (Patch, error) {
 	services, err := getServices(serviceEndpoints)
@@ -208,6 +371,25 @@ func NewAddServiceEndpointsPatch(serviceEndpoints string) (Patch, error) {
 	return patch, nil
 }
 
+// NewReplaceServiceEndpointsPatch creates new patch for replacing all existing service endpoints
+// (empty array removes all services).
+func NewReplaceServiceEndpointsPatch(serviceEndpoints string) (Patch, error) {
+	services, err := getServices(serviceEndpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	if services == nil {
+		return nil, errors.New("missing services")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = ReplaceServiceEndpoints
+	patch[ServicesKey] = services
+
+	return patch, nil
+}
+
 // NewRemoveServiceEndpointsPatch creates new patch for removing service endpoints.
 func NewRemoveServiceEndpointsPatch(serviceEndpointIds string) (Patch, error) {
 	ids, err := getStringArray(serviceEndpointIds)
@@ -226,6 +408,89 @@ func NewRemoveServiceEndpointsPatch(serviceEndpointIds string) (Patch, error) {
 	return patch, nil
 }
 
+// NewAddAlsoKnownAs creates new patch for adding also-known-as property.
+func NewAddAlsoKnownAs(uris string) (Patch, error) {
+	urisToAdd, err := getStringArray(uris)
+	if err != nil {
+		return nil, fmt.Errorf("also known as uris is not string array: %s", err.Error())
+	}
+
+	if len(urisToAdd) == 0 {
+		return nil, errors.New("missing also known as uris")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = AddAlsoKnownAs
+	patch[UrisKey] = getGenericArray(urisToAdd)
+
+	return patch, nil
+}
+
+// NewRemoveAlsoKnownAs creates new patch for removing also-known-as URI.
+func NewRemoveAlsoKnownAs(uris string) (Patch, error) {
+	urisToRemove, err := getStringArray(uris)
+	if err != nil {
+		return nil, fmt.Errorf("also known as uris is not string array: %s", err.Error())
+	}
+
+	if len(urisToRemove) == 0 {
+		return nil, errors.New("missing also known as uris")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = RemoveAlsoKnownAs
+	patch[UrisKey] = getGenericArray(urisToRemove)
+
+	return patch, nil
+}
+
+// NewSetControllerPatch creates new patch for setting controller property; controller is either
+// a single value (e.g. "did:example:123") or a set (e.g. ["did:example:123", "did:example:456"]).
+// Empty set removes controller property from the document.
+func NewSetControllerPatch(controller string) (Patch, error) {
+	var value interface{}
+	err := json.Unmarshal([]byte(controller), &value)
+	if err != nil {
+		return nil, fmt.Errorf("controller invalid: %s", err.Error())
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, errors.New("missing controller")
+		}
+	case []interface{}:
+		for _, entry := range v {
+			if _, ok := entry.(string); !ok {
+				return nil, errors.New("controller is not string array")
+			}
+		}
+	default:
+		return nil, errors.New("controller must be a string or string array")
+	}
+
+	patch := make(Patch)
+	patch[ActionKey] = SetController
+	patch[ControllerKey] = value
+
+	return patch, nil
+}
+
+// GetValueKey returns key of the patch value (e.g. publicKeys for add-public-keys patch).
+func (p Patch) GetValueKey() (Key, error) {
+	action, err := p.GetAction()
+	if err != nil {
+		return "", err
+	}
+
+	valueKey, ok := getValueKey(action)
+	if !ok {
+		return "", fmt.Errorf("action '%s' is not supported", action)
+	}
+
+	return valueKey, nil
+}
+
 // GetValue returns patch value.
 func (p Patch) GetValue() (interface{}, error) {
 	action, err := p.GetAction()
@@ -233,7 +498,7 @@ func (p Patch) GetValue() (interface{}, error) {
 		return nil, err
 	}
 
-	valueKey, ok := actionConfig[action]
+	valueKey, ok := getValueKey(action)
 	if !ok {
 		return nil, fmt.Errorf("action '%s' is not supported", action)
 	}
@@ -263,7 +528,7 @@ func (p Patch) GetAction() (Action, error) {
 		return "", fmt.Errorf("action type not supported: %s", v)
 	}
 
-	_, ok = actionConfig[action]
+	_, ok = getValueKey(action)
 	if !ok {
 		return "", fmt.Errorf("action '%s' is not supported", action)
 	}
@@ -302,6 +567,51 @@ func FromBytes(data []byte) (Patch, error) {
 	return patch, nil
 }
 
+// Normalize returns normalized patches: each patch is re-created from its canonical (JCS) representation and
+// consecutive exact duplicates of idempotent patches are removed. Patch order is preserved since it is significant
+// for patch application. Equivalent patches will therefore produce identical deltas (and delta hashes).
+func Normalize(patches []Patch) ([]Patch, error) {
+	var result []Patch
+
+	var previous string
+
+	for _, p := range patches {
+		canonicalBytes, err := p.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to canonicalize patch: %s", err.Error())
+		}
+
+		normalized, err := FromBytes(canonicalBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize patch: %s", err.Error())
+		}
+
+		action, err := normalized.GetAction()
+		if err != nil {
+			return nil, err
+		}
+
+		if idempotentActions[action] && string(canonicalBytes) == previous {
+			continue
+		}
+
+		previous = string(canonicalBytes)
+
+		result = append(result, normalized)
+	}
+
+	return result, nil
+}
+
+func getValueKey(action Action) (Key, bool) {
+	actionMutex.RLock()
+	defer actionMutex.RUnlock()
+
+	valueKey, ok := actionConfig[action]
+
+	return valueKey, ok
+}
+
 func stringEntry(entry interface{}) string {
 	if entry == nil {
 		return ""
@@ -315,7 +625,8 @@ func stringEntry(entry interface{}) string {
 }
 
 func validateReplaceDocument(doc document.ReplaceDocument) error {
-	allowedKeys := []string{document.ReplaceServiceProperty, document.ReplacePublicKeyProperty}
+	allowedKeys := []string{document.ReplaceServiceProperty, document.ReplacePublicKeyProperty,
+		document.ReplaceAlsoKnownAsProperty, document.ReplaceControllerProperty}
 
 	for key := range doc {
 		if !contains(allowedKeys, key) {
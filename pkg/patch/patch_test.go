@@ -179,6 +179,52 @@ func TestIETFPatch(t *testing.T) {
 	})
 }
 
+func TestJSONMergePatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "json-merge-patch", "patch": {"name": "value"}}`))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, JSONMergePatch)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, value, p[PatchKey])
+	})
+	t.Run("missing patch", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "json-merge-patch"}`))
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "json-merge-patch patch is missing key: patch")
+	})
+	t.Run("success from new", func(t *testing.T) {
+		p, err := NewJSONMergePatch(`{"name": "value", "other": null}`)
+		require.NoError(t, err)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"name": "value", "other": nil}, value)
+
+		bytes, err := p.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, `{"action":"json-merge-patch","patch":{"name":"value","other":null}}`, string(bytes))
+	})
+	t.Run("error - not JSON object", func(t *testing.T) {
+		p, err := NewJSONMergePatch(`[]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "merge patch is not JSON object")
+	})
+	t.Run("error - empty merge patch", func(t *testing.T) {
+		p, err := NewJSONMergePatch(`{}`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing merge patch")
+	})
+}
+
 func TestAddPublicKeysPatch(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		patch, err := FromBytes([]byte(addPublicKeysPatch))
@@ -317,6 +363,46 @@ func TestAddServiceEndpointsPatch(t *testing.T) {
 	})
 }
 
+func TestReplaceServiceEndpointsPatch(t *testing.T) {
+	t.Run("success from new", func(t *testing.T) {
+		p, err := NewReplaceServiceEndpointsPatch(testAddServiceEndpoints)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, ReplaceServiceEndpoints)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.NotEmpty(t, value)
+		require.Equal(t, value, p[ServicesKey])
+	})
+	t.Run("success - empty services", func(t *testing.T) {
+		p, err := NewReplaceServiceEndpointsPatch(`[]`)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{}, p[ServicesKey])
+	})
+	t.Run("missing services", func(t *testing.T) {
+		patch, err := FromBytes([]byte(`{"action": "replace-services"}`))
+		require.Error(t, err)
+		require.Nil(t, patch)
+		require.Contains(t, err.Error(), "replace-services patch is missing key: services")
+	})
+	t.Run("error - services is null", func(t *testing.T) {
+		p, err := NewReplaceServiceEndpointsPatch(`null`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing services")
+	})
+	t.Run("error - not json", func(t *testing.T) {
+		p, err := NewReplaceServiceEndpointsPatch("not json")
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "services invalid")
+	})
+}
+
 func TestRemoveServiceEndpointsPatch(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		p, err := FromBytes([]byte(removeServiceEndpoints))
@@ -369,6 +455,339 @@ func TestRemoveServiceEndpointsPatch(t *testing.T) {
 	})
 }
 
+func TestAddAlsoKnownAsPatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := FromBytes([]byte(addAlsoKnownAs))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, AddAlsoKnownAs)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.NotEmpty(t, value)
+		require.Equal(t, value, p[UrisKey])
+	})
+	t.Run("missing uris", func(t *testing.T) {
+		patch, err := FromBytes([]byte(`{"action": "add-also-known-as"}`))
+		require.Error(t, err)
+		require.Nil(t, patch)
+		require.Contains(t, err.Error(), "add-also-known-as patch is missing key: uris")
+	})
+	t.Run("success from new", func(t *testing.T) {
+		const uris = `["did:domain.com", "did:other.com"]`
+		p, err := NewAddAlsoKnownAs(uris)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, AddAlsoKnownAs)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"did:domain.com", "did:other.com"}, value)
+	})
+	t.Run("empty uris", func(t *testing.T) {
+		p, err := NewAddAlsoKnownAs(`[]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing also known as uris")
+	})
+	t.Run("error - uris not string array", func(t *testing.T) {
+		p, err := NewAddAlsoKnownAs(`[0, 1]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "cannot unmarshal")
+	})
+}
+
+func TestSetControllerPatch(t *testing.T) {
+	t.Run("success - single value", func(t *testing.T) {
+		p, err := NewSetControllerPatch(`"did:example:123"`)
+		require.NoError(t, err)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, SetController, action)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", value)
+	})
+	t.Run("success - set", func(t *testing.T) {
+		p, err := NewSetControllerPatch(`["did:example:123", "did:example:456"]`)
+		require.NoError(t, err)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"did:example:123", "did:example:456"}, value)
+	})
+	t.Run("success - empty set", func(t *testing.T) {
+		p, err := NewSetControllerPatch(`[]`)
+		require.NoError(t, err)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Empty(t, value)
+	})
+	t.Run("success from bytes", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "set-controller", "controller": "did:example:123"}`))
+		require.NoError(t, err)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", value)
+	})
+	t.Run("error - missing controller", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "set-controller"}`))
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "set-controller patch is missing key: controller")
+
+		p, err = NewSetControllerPatch(`""`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing controller")
+	})
+	t.Run("error - invalid json", func(t *testing.T) {
+		p, err := NewSetControllerPatch(`invalid`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "controller invalid")
+	})
+	t.Run("error - invalid type", func(t *testing.T) {
+		p, err := NewSetControllerPatch(`{"id": "did:example:123"}`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "controller must be a string or string array")
+
+		p, err = NewSetControllerPatch(`["did:example:123", 1]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "controller is not string array")
+	})
+	t.Run("success - patches from document", func(t *testing.T) {
+		patches, err := PatchesFromDocument(`{"controller": "did:example:123"}`)
+		require.NoError(t, err)
+		require.Len(t, patches, 1)
+
+		action, err := patches[0].GetAction()
+		require.NoError(t, err)
+		require.Equal(t, SetController, action)
+	})
+	t.Run("success - replace patch", func(t *testing.T) {
+		p, err := NewReplacePatch(`{"controller": ["did:example:123"]}`)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+	})
+}
+
+func TestRemoveAlsoKnownAsPatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := FromBytes([]byte(removeAlsoKnownAs))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, RemoveAlsoKnownAs)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.NotEmpty(t, value)
+		require.Equal(t, value, p[UrisKey])
+	})
+	t.Run("success from new", func(t *testing.T) {
+		p, err := NewRemoveAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, RemoveAlsoKnownAs)
+	})
+	t.Run("empty uris", func(t *testing.T) {
+		p, err := NewRemoveAlsoKnownAs(`[]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing also known as uris")
+	})
+	t.Run("error - uris not string array", func(t *testing.T) {
+		p, err := NewRemoveAlsoKnownAs(`"did:domain.com"`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "cannot unmarshal")
+	})
+}
+
+func TestVerificationRelationshipsPatch(t *testing.T) {
+	const relationships = `[{"id": "key1", "purposes": ["authentication", "assertionMethod"]}]`
+
+	t.Run("success - add", func(t *testing.T) {
+		p, err := NewAddVerificationRelationshipsPatch(relationships)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, AddVerificationRelationships)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, value, p[RelationshipsKey])
+		require.Len(t, value, 1)
+	})
+	t.Run("success - remove", func(t *testing.T) {
+		p, err := NewRemoveVerificationRelationshipsPatch(relationships)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, RemoveVerificationRelationships)
+	})
+	t.Run("success - from bytes", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "add-verification-relationships", "relationships": ` + relationships + `}`))
+		require.NoError(t, err)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, action, AddVerificationRelationships)
+	})
+	t.Run("missing relationships", func(t *testing.T) {
+		p, err := FromBytes([]byte(`{"action": "remove-verification-relationships"}`))
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "remove-verification-relationships patch is missing key: relationships")
+	})
+	t.Run("empty relationships", func(t *testing.T) {
+		p, err := NewAddVerificationRelationshipsPatch(`[]`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing verification relationships")
+	})
+	t.Run("error - relationships not array", func(t *testing.T) {
+		p, err := NewAddVerificationRelationshipsPatch(`{}`)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "verification relationships invalid")
+	})
+}
+
+func TestRegisterAction(t *testing.T) {
+	const customAction Action = "add-custom-data"
+	const customKey Key = "data"
+
+	t.Run("success", func(t *testing.T) {
+		require.False(t, IsRegistered(customAction))
+		require.True(t, IsRegistered(AddPublicKeys))
+
+		err := RegisterAction(customAction, customKey)
+		require.NoError(t, err)
+		require.True(t, IsRegistered(customAction))
+
+		p, err := NewPatch(customAction, map[string]interface{}{"key": "value"})
+		require.NoError(t, err)
+
+		action, err := p.GetAction()
+		require.NoError(t, err)
+		require.Equal(t, customAction, action)
+
+		value, err := p.GetValue()
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"key": "value"}, value)
+
+		p, err = FromBytes([]byte(`{"action": "add-custom-data", "data": {"key": "value"}}`))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		p, err = FromBytes([]byte(`{"action": "add-custom-data"}`))
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "add-custom-data patch is missing key: data")
+	})
+	t.Run("error - action already registered", func(t *testing.T) {
+		err := RegisterAction(AddPublicKeys, customKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "action 'add-public-keys' is already registered")
+	})
+	t.Run("error - missing action", func(t *testing.T) {
+		err := RegisterAction("", customKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing action")
+	})
+	t.Run("error - invalid value key", func(t *testing.T) {
+		err := RegisterAction("other", ActionKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid value key 'action' for action 'other'")
+	})
+	t.Run("error - new patch for action that is not registered", func(t *testing.T) {
+		p, err := NewPatch("not-registered", "value")
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "action 'not-registered' is not supported")
+	})
+	t.Run("error - new patch without value", func(t *testing.T) {
+		p, err := NewPatch(AddPublicKeys, nil)
+		require.Error(t, err)
+		require.Nil(t, p)
+		require.Contains(t, err.Error(), "missing value for action 'add-public-keys'")
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("success - consecutive duplicates of idempotent patches are removed", func(t *testing.T) {
+		p1, err := FromBytes([]byte(`{"action": "remove-public-keys", "ids": ["key1"]}`))
+		require.NoError(t, err)
+
+		p2, err := FromBytes([]byte(`{ "ids": ["key1"],  "action": "remove-public-keys" }`))
+		require.NoError(t, err)
+
+		p3, err := NewRemoveServiceEndpointsPatch(`["svc1"]`)
+		require.NoError(t, err)
+
+		normalized, err := Normalize([]Patch{p1, p2, p3, p1})
+		require.NoError(t, err)
+		require.Len(t, normalized, 3)
+
+		action, err := normalized[1].GetAction()
+		require.NoError(t, err)
+		require.Equal(t, RemoveServiceEndpoints, action)
+	})
+
+	t.Run("success - duplicates of non-idempotent patches are kept", func(t *testing.T) {
+		p, err := NewJSONPatch(`[{"op": "add", "path": "/values/-", "value": "value"}]`)
+		require.NoError(t, err)
+
+		normalized, err := Normalize([]Patch{p, p})
+		require.NoError(t, err)
+		require.Len(t, normalized, 2)
+	})
+
+	t.Run("success - no patches", func(t *testing.T) {
+		normalized, err := Normalize(nil)
+		require.NoError(t, err)
+		require.Empty(t, normalized)
+	})
+
+	t.Run("error - invalid patch", func(t *testing.T) {
+		normalized, err := Normalize([]Patch{{ActionKey: "invalid"}})
+		require.Error(t, err)
+		require.Nil(t, normalized)
+		require.Contains(t, err.Error(), "failed to normalize patch")
+	})
+
+	t.Run("error - patch cannot be canonicalized", func(t *testing.T) {
+		normalized, err := Normalize([]Patch{{ActionKey: AddAlsoKnownAs, UrisKey: make(chan int)}})
+		require.Error(t, err)
+		require.Nil(t, normalized)
+		require.Contains(t, err.Error(), "failed to canonicalize patch")
+	})
+}
+
 func TestBytes(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		original, err := FromBytes([]byte(addPublicKeysPatch))
@@ -551,3 +970,13 @@ const replaceDoc = `{
 		"serviceEndpoint": "http://hub.my-personal-server.com"
 	}]
 }`
+
+const addAlsoKnownAs = `{
+	"action": "add-also-known-as",
+	"uris": ["did:domain.com"]
+}`
+
+const removeAlsoKnownAs = `{
+	"action": "remove-also-known-as",
+	"uris": ["did:domain.com"]
+}`
@@ -0,0 +1,240 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+)
+
+// AuditStatus is the result of commitment chain verification for an operation.
+type AuditStatus string
+
+const (
+	// AuditStatusValid means that the operation reveals the current commitment and has been applied.
+	AuditStatusValid AuditStatus = "valid"
+
+	// AuditStatusInvalid means that the operation could not be parsed or applied.
+	AuditStatusInvalid AuditStatus = "invalid"
+
+	// AuditStatusCommitmentMismatch means that the operation reveal value doesn't match the current commitment.
+	AuditStatusCommitmentMismatch AuditStatus = "commitment-mismatch"
+
+	// AuditStatusCommitmentReused means that the operation next commitment has already been used.
+	AuditStatusCommitmentReused AuditStatus = "commitment-reused"
+
+	// AuditStatusIgnored means that the operation is not part of the commitment chains (e.g. operation
+	// anchored after document was deactivated or additional create operation).
+	AuditStatusIgnored AuditStatus = "ignored"
+)
+
+// AuditedOperation contains result of commitment chain verification for an anchored operation.
+type AuditedOperation struct {
+	Type                operation.Type `json:"type"`
+	TransactionTime     uint64         `json:"transactionTime"`
+	TransactionNumber   uint64         `json:"transactionNumber"`
+	ProtocolGenesisTime uint64         `json:"protocolGenesisTime"`
+	Status              AuditStatus    `json:"status"`
+	// Commitment is the commitment the reveal value was verified against
+	Commitment  string `json:"commitment,omitempty"`
+	RevealValue string `json:"revealValue,omitempty"`
+	// NextCommitment is the commitment for the next operation of the same chain (update or recovery)
+	NextCommitment string `json:"nextCommitment,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// AuditReport contains result of commitment chain verification for a document.
+type AuditReport struct {
+	UniqueSuffix string `json:"uniqueSuffix"`
+	// Valid is true if all anchored operations are part of verified commitment chains
+	Valid              bool                `json:"valid"`
+	UpdateCommitment   string              `json:"updateCommitment,omitempty"`
+	RecoveryCommitment string              `json:"recoveryCommitment,omitempty"`
+	Deactivated        bool                `json:"deactivated"`
+	Operations         []*AuditedOperation `json:"operations"`
+}
+
+// Audit walks anchored operations of the document (in anchoring order) and verifies update and recovery
+// commitment chains: each reveal value has to match the current commitment of its chain and next commitments
+// cannot re-use previous commitments. An error is returned only if the operations cannot be retrieved or
+// protocol version for an operation is not available; verification failures are recorded in the report.
+func (s *OperationProcessor) Audit(uniqueSuffix string) (*AuditReport, error) {
+	ops, err := s.store.Get(uniqueSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	sortOperations(ops)
+
+	a := &auditor{
+		OperationProcessor: s,
+		report:             &AuditReport{UniqueSuffix: uniqueSuffix, Valid: true},
+		commitments:        make(map[string]bool),
+	}
+
+	for _, op := range ops {
+		if _, err := s.pc.Get(op.ProtocolGenesisTime); err != nil {
+			return nil, fmt.Errorf("audit '%s' operation: %s", op.Type, err.Error())
+		}
+
+		result := &AuditedOperation{
+			Type:                op.Type,
+			TransactionTime:     op.TransactionTime,
+			TransactionNumber:   op.TransactionNumber,
+			ProtocolGenesisTime: op.ProtocolGenesisTime,
+		}
+
+		if err := a.audit(op, result); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Status = AuditStatusValid
+		}
+
+		if result.Status != AuditStatusValid {
+			logger.Infof("[%s] Audit of operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d} failed [%s]: %s",
+				s.name, uniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, result.Status, result.Error)
+
+			a.report.Valid = false
+		}
+
+		a.report.Operations = append(a.report.Operations, result)
+	}
+
+	if a.rm == nil {
+		a.report.Valid = false
+	} else {
+		a.report.UpdateCommitment = a.rm.UpdateCommitment
+		a.report.RecoveryCommitment = a.rm.RecoveryCommitment
+		a.report.Deactivated = a.rm.Deactivated
+	}
+
+	return a.report, nil
+}
+
+type auditor struct {
+	*OperationProcessor
+
+	report *AuditReport
+	rm     *protocol.ResolutionModel
+
+	// commitments contains all commitments of the document (revealed and current)
+	commitments map[string]bool
+}
+
+func (a *auditor) audit(op *operation.AnchoredOperation, result *AuditedOperation) error {
+	if op.Type == operation.TypeCreate {
+		return a.auditCreate(op, result)
+	}
+
+	if a.rm == nil {
+		result.Status = AuditStatusIgnored
+
+		return errors.New("document has not been created")
+	}
+
+	if a.rm.Deactivated {
+		result.Status = AuditStatusIgnored
+
+		return errors.New("document has been deactivated")
+	}
+
+	current := a.rm.RecoveryCommitment
+	if op.Type == operation.TypeUpdate {
+		current = a.rm.UpdateCommitment
+	}
+
+	result.Commitment = current
+
+	rv, err := a.getRevealValue(op)
+	if err != nil {
+		result.Status = AuditStatusInvalid
+
+		return err
+	}
+
+	result.RevealValue = rv
+
+	if err := commitment.VerifyCommitment(rv, current); err != nil {
+		result.Status = AuditStatusCommitmentMismatch
+
+		return err
+	}
+
+	next, err := a.getCommitment(op)
+	if err != nil {
+		result.Status = AuditStatusInvalid
+
+		return err
+	}
+
+	result.NextCommitment = next
+
+	if next != "" && a.commitments[next] {
+		result.Status = AuditStatusCommitmentReused
+
+		return errors.New("next commitment has already been used")
+	}
+
+	state, err := a.applyOperation(op, a.rm)
+	if err != nil {
+		result.Status = AuditStatusInvalid
+
+		return err
+	}
+
+	// recover operation starts new update commitment chain as well
+	if op.Type == operation.TypeRecover && a.commitments[state.UpdateCommitment] {
+		result.Status = AuditStatusCommitmentReused
+
+		return errors.New("update commitment has already been used")
+	}
+
+	a.setState(state)
+
+	return nil
+}
+
+func (a *auditor) auditCreate(op *operation.AnchoredOperation, result *AuditedOperation) error {
+	if a.rm != nil {
+		result.Status = AuditStatusIgnored
+
+		return errors.New("document has already been created")
+	}
+
+	state, err := a.applyOperation(op, &protocol.ResolutionModel{})
+	if err != nil {
+		result.Status = AuditStatusInvalid
+
+		return err
+	}
+
+	if state.UpdateCommitment == state.RecoveryCommitment {
+		result.Status = AuditStatusCommitmentReused
+
+		return errors.New("update and recovery commitments are equal")
+	}
+
+	result.NextCommitment = state.UpdateCommitment
+
+	a.setState(state)
+
+	return nil
+}
+
+func (a *auditor) setState(state *protocol.ResolutionModel) {
+	for _, c := range []string{state.UpdateCommitment, state.RecoveryCommitment} {
+		if c != "" {
+			a.commitments[c] = true
+		}
+	}
+
+	a.rm = state
+}
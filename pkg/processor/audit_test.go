@@ -0,0 +1,189 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestAudit(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pc := newMockProtocolClient()
+
+	t.Run("success - valid commitment chains", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		updateOp, nextUpdateKey, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		updateOp, _, err = getAnchoredUpdateOperation(nextUpdateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		recoveredUpdateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, recoveredUpdateKey, uniqueSuffix, 3)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(recoverOp))
+
+		updateOp, _, err = getAnchoredUpdateOperation(recoveredUpdateKey, uniqueSuffix, 4)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		deactivateOp, err := getDeactivateOperation(nextRecoveryKey, uniqueSuffix)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(getAnchoredOperation(deactivateOp, 5)))
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.NoError(t, err)
+		require.True(t, report.Valid)
+		require.True(t, report.Deactivated)
+		require.Equal(t, uniqueSuffix, report.UniqueSuffix)
+		require.Len(t, report.Operations, 6)
+
+		expectedTypes := []operation.Type{
+			operation.TypeCreate, operation.TypeUpdate, operation.TypeUpdate,
+			operation.TypeRecover, operation.TypeUpdate, operation.TypeDeactivate,
+		}
+
+		for i, op := range report.Operations {
+			require.Equal(t, AuditStatusValid, op.Status, op.Error)
+			require.Equal(t, expectedTypes[i], op.Type)
+			require.Equal(t, uint64(i), op.TransactionTime)
+		}
+
+		require.Equal(t, report.Operations[0].NextCommitment, report.Operations[1].Commitment)
+		require.Equal(t, report.Operations[1].NextCommitment, report.Operations[2].Commitment)
+		require.Equal(t, report.Operations[3].NextCommitment, report.Operations[5].Commitment)
+		require.NotEmpty(t, report.Operations[1].RevealValue)
+	})
+
+	t.Run("reveal value doesn't match commitment", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		// update key has already been revealed
+		updateOp, _, err = getAnchoredUpdateOperation(updateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Len(t, report.Operations, 3)
+		require.Equal(t, AuditStatusValid, report.Operations[1].Status)
+		require.Equal(t, AuditStatusCommitmentMismatch, report.Operations[2].Status)
+		require.Equal(t, "reveal value doesn't match commitment", report.Operations[2].Error)
+		require.Equal(t, report.Operations[1].NextCommitment, report.UpdateCommitment)
+	})
+
+	t.Run("commitment re-used", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		// recovery sets update commitment that has already been used
+		recoverOp, _, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(recoverOp))
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, AuditStatusCommitmentReused, report.Operations[2].Status)
+		require.Equal(t, "update commitment has already been used", report.Operations[2].Error)
+	})
+
+	t.Run("operations after deactivation are ignored", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		deactivateOp, err := getDeactivateOperation(recoveryKey, uniqueSuffix)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(getAnchoredOperation(deactivateOp, 1)))
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.True(t, report.Deactivated)
+		require.Len(t, report.Operations, 3)
+		require.Equal(t, AuditStatusValid, report.Operations[1].Status)
+		require.Equal(t, AuditStatusIgnored, report.Operations[2].Status)
+		require.Equal(t, "document has been deactivated", report.Operations[2].Error)
+	})
+
+	t.Run("missing create operation", func(t *testing.T) {
+		store := mocks.NewMockOperationStore(nil)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, "suffix", 1)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(updateOp))
+
+		report, err := New("test", store, pc).Audit("suffix")
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, AuditStatusIgnored, report.Operations[0].Status)
+		require.Equal(t, "document has not been created", report.Operations[0].Error)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		updateOp.OperationBuffer = []byte("{}")
+		require.NoError(t, store.Put(updateOp))
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.NoError(t, err)
+		require.False(t, report.Valid)
+		require.Equal(t, AuditStatusInvalid, report.Operations[1].Status)
+		require.Contains(t, report.Operations[1].Error, "get operation reveal value")
+	})
+
+	t.Run("error - store", func(t *testing.T) {
+		report, err := New("test", mocks.NewMockOperationStore(errors.New("store error")), pc).Audit("suffix")
+		require.EqualError(t, err, "store error")
+		require.Nil(t, report)
+	})
+
+	t.Run("error - protocol", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		pc := newMockProtocolClient()
+		pc.Err = errors.New("protocol error")
+
+		report, err := New("test", store, pc).Audit(uniqueSuffix)
+		require.EqualError(t, err, "audit 'create' operation: protocol error")
+		require.Nil(t, report)
+	})
+}
@@ -26,6 +26,19 @@ type OperationProcessor struct {
 	name  string
 	store OperationStoreClient
 	pc    protocol.Client
+
+	tombstone bool
+}
+
+// Option is an operation processor instance option.
+type Option func(opts *OperationProcessor)
+
+// WithDeactivationTombstone enables returning resolution model for deactivated document (instead of an error);
+// resolution model contains final document (before deactivation) and deactivate transaction reference.
+func WithDeactivationTombstone() Option {
+	return func(opts *OperationProcessor) {
+		opts.tombstone = true
+	}
 }
 
 // OperationStoreClient defines interface for retrieving all operations related to document.
@@ -35,8 +48,15 @@ type OperationStoreClient interface {
 }
 
 // New returns new operation processor with the given name. (Note that name is only used for logging.)
-func New(name string, store OperationStoreClient, pc protocol.Client) *OperationProcessor {
-	return &OperationProcessor{name: name, store: store, pc: pc}
+func New(name string, store OperationStoreClient, pc protocol.Client, opts ...Option) *OperationProcessor {
+	processor := &OperationProcessor{name: name, store: store, pc: pc}
+
+	// apply options
+	for _, opt := range opts {
+		opt(processor)
+	}
+
+	return processor
 }
 
 // Resolve document based on the given unique suffix.
@@ -61,18 +81,30 @@ func (s *OperationProcessor) Resolve(uniqueSuffix string) (*protocol.ResolutionM
 	}
 
 	// apply 'create' operations first
-	rm = s.applyFirstValidCreateOperation(createOps, rm)
+	rm, err = s.applyFirstValidCreateOperation(createOps, rm)
+	if err != nil {
+		return nil, err
+	}
+
 	if rm == nil {
-		return nil, errors.New("valid create operation not found")
+		return nil, protocol.NewCodedError(protocol.CodeDIDNotFound, errors.New("valid create operation not found"))
 	}
 
 	// apply 'full' operations first
 	if len(fullOps) > 0 {
 		logger.Debugf("[%s] Applying %d full operations for unique suffix [%s]", s.name, len(fullOps), uniqueSuffix)
 
-		rm = s.applyOperations(fullOps, rm, getRecoveryCommitment)
+		rm, err = s.applyOperations(fullOps, rm, getRecoveryCommitment)
+		if err != nil {
+			return nil, err
+		}
+
 		if rm.Doc == nil {
-			return nil, errors.New("document was deactivated")
+			if s.tombstone && rm.Deactivated {
+				return rm, nil
+			}
+
+			return nil, protocol.NewCodedError(protocol.CodeDIDDeactivated, errors.New("document was deactivated"))
 		}
 	}
 
@@ -80,7 +112,10 @@ func (s *OperationProcessor) Resolve(uniqueSuffix string) (*protocol.ResolutionM
 	filteredUpdateOps := getOpsWithTxnGreaterThan(updateOps, rm.LastOperationTransactionTime, rm.LastOperationTransactionNumber)
 	if len(filteredUpdateOps) > 0 {
 		logger.Debugf("[%s] Applying %d update operations after last full operation for unique suffix [%s]", s.name, len(filteredUpdateOps), uniqueSuffix)
-		rm = s.applyOperations(filteredUpdateOps, rm, getUpdateCommitment)
+		rm, err = s.applyOperations(filteredUpdateOps, rm, getUpdateCommitment)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return rm, nil
@@ -146,7 +181,9 @@ func getOpsWithTxnGreaterThan(ops []*operation.AnchoredOperation, txnTime, txnNu
 	return nil
 }
 
-func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, commitmentFnc fnc) *protocol.ResolutionModel {
+// applyOperations applies operations in commitment order; operations that are rejected are skipped
+// and internal error (transient failure) fails the whole resolution.
+func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, commitmentFnc fnc) (*protocol.ResolutionModel, error) {
 	// suffix for logging
 	uniqueSuffix := ops[0].UniqueSuffix
 
@@ -164,7 +201,10 @@ func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation,
 	for ok {
 		logger.Debugf("[%s] Found %d operation(s) for commitment '%s' {UniqueSuffix: %s}", s.name, len(commitmentOps), c, uniqueSuffix)
 
-		newState := s.applyFirstValidOperation(commitmentOps, state, c, commitmentMap)
+		newState, err := s.applyFirstValidOperation(commitmentOps, state, c, commitmentMap)
+		if err != nil {
+			return nil, err
+		}
 
 		// can't find a valid operation to apply
 		if newState == nil {
@@ -186,7 +226,7 @@ func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation,
 
 		// stop if there is no next commitment
 		if c == "" {
-			return state
+			return state, nil
 		}
 
 		commitmentOps, ok = opMap[c]
@@ -196,7 +236,7 @@ func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation,
 		logger.Infof("[%s] Number of commitments applied '%d' doesn't match number of operations '%d' {UniqueSuffix: %s}", s.name, len(commitmentMap), len(ops), uniqueSuffix)
 	}
 
-	return state
+	return state, nil
 }
 
 type fnc func(rm *protocol.ResolutionModel) string
@@ -209,12 +249,16 @@ func getRecoveryCommitment(rm *protocol.ResolutionModel) string {
 	return rm.RecoveryCommitment
 }
 
-func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operation.AnchoredOperation, rm *protocol.ResolutionModel) *protocol.ResolutionModel {
+func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	for _, op := range createOps {
 		var state *protocol.ResolutionModel
 		var err error
 
 		if state, err = s.applyOperation(op, rm); err != nil {
+			if protocol.IsInternalError(err) {
+				return nil, err
+			}
+
 			logger.Infof("[%s] Skipped bad operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
 			continue
@@ -222,14 +266,14 @@ func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operati
 
 		logger.Debugf("[%s] After applying create op %+v, recover commitment[%s], update commitment[%s], New doc: %s", s.name, op, state.RecoveryCommitment, state.UpdateCommitment, state.Doc)
 
-		return state
+		return state, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 // this function should be used for update, recover and deactivate operations (create is handled differently).
-func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, currCommitment string, processedCommitments map[string]bool) *protocol.ResolutionModel {
+func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, currCommitment string, processedCommitments map[string]bool) (*protocol.ResolutionModel, error) {
 	for _, op := range ops {
 		var state *protocol.ResolutionModel
 		var err error
@@ -258,6 +302,10 @@ func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredO
 		}
 
 		if state, err = s.applyOperation(op, rm); err != nil {
+			if protocol.IsInternalError(err) {
+				return nil, err
+			}
+
 			logger.Infof("[%s] Skipped bad operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
 			continue
@@ -265,16 +313,16 @@ func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredO
 
 		logger.Debugf("[%s] After applying op %+v, recover commitment[%s], update commitment[%s], New doc: %s", s.name, op, state.RecoveryCommitment, state.UpdateCommitment, state.Doc)
 
-		return state
+		return state, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (s *OperationProcessor) applyOperation(op *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	p, err := s.pc.Get(op.ProtocolGenesisTime)
 	if err != nil {
-		return nil, fmt.Errorf("apply '%s' operation: %s", op.Type, err.Error())
+		return nil, protocol.NewInternalError(fmt.Errorf("apply '%s' operation: %s", op.Type, err.Error()))
 	}
 
 	return p.OperationApplier().Apply(op, rm)
@@ -97,7 +97,15 @@ func TestResolve(t *testing.T) {
 		doc, err := op.applyOperation(createOp, &protocol.ResolutionModel{})
 		require.Nil(t, doc)
 		require.Error(t, err)
+		require.True(t, protocol.IsInternalError(err))
 		require.Contains(t, err.Error(), "apply 'create' operation: protocol parameters are not defined for blockchain time")
+
+		// internal error fails resolution (instead of skipping operation)
+		doc, err = op.Resolve(createOp.UniqueSuffix)
+		require.Nil(t, doc)
+		require.Error(t, err)
+		require.True(t, protocol.IsInternalError(err))
+		require.Contains(t, err.Error(), "protocol parameters are not defined for blockchain time")
 	})
 
 	t.Run("resolution error", func(t *testing.T) {
@@ -116,6 +124,7 @@ func TestResolve(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, doc)
 		require.Contains(t, err.Error(), "valid create operation not found")
+		require.Equal(t, protocol.CodeDIDNotFound, protocol.GetCode(err))
 	})
 }
 
@@ -426,8 +435,30 @@ func TestDeactivate(t *testing.T) {
 		doc, err := p.Resolve(uniqueSuffix)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "document was deactivated")
+		require.Equal(t, protocol.CodeDIDDeactivated, protocol.GetCode(err))
 		require.Nil(t, doc)
 	})
+
+	t.Run("success - deactivation tombstone", func(t *testing.T) {
+		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+		createResult, err := New("test", store, pc).Resolve(uniqueSuffix)
+		require.NoError(t, err)
+
+		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, uniqueSuffix)
+		require.NoError(t, err)
+
+		err = store.Put(deactivateOp)
+		require.Nil(t, err)
+
+		p := New("test", store, pc, WithDeactivationTombstone())
+		rm, err := p.Resolve(uniqueSuffix)
+		require.NoError(t, err)
+		require.True(t, rm.Deactivated)
+		require.Nil(t, rm.Doc)
+		require.Equal(t, createResult.Doc, rm.FinalDoc)
+		require.Equal(t, deactivateOp.TransactionTime, rm.LastOperationTransactionTime)
+	})
 }
 
 func TestRecover(t *testing.T) {
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package protocolclient provides protocol client that maintains an ordered set of protocol versions
+// and returns the version that applies at the given (logical blockchain) transaction time.
+package protocolclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// BlockchainTime returns current logical blockchain time.
+type BlockchainTime func() (uint64, error)
+
+// Option is an option for protocol client.
+type Option func(opts *Client)
+
+// WithBlockchainTime sets provider of the current blockchain time. If set, Current returns the version
+// that applies at the current blockchain time (versions with future genesis time are scheduled but not
+// current); otherwise Current returns the version with the latest genesis time.
+func WithBlockchainTime(blockchainTime BlockchainTime) Option {
+	return func(opts *Client) {
+		opts.blockchainTime = blockchainTime
+	}
+}
+
+// Client is protocol client that returns protocol versions based on their genesis time.
+type Client struct {
+	mutex    sync.RWMutex
+	versions []protocol.Version // sorted by genesis time
+
+	blockchainTime BlockchainTime
+}
+
+// New returns protocol client for the given versions (in any order); genesis times of the versions
+// must be unique.
+func New(versions []protocol.Version, opts ...Option) (*Client, error) {
+	if len(versions) == 0 {
+		return nil, errors.New("missing protocol versions")
+	}
+
+	c := &Client{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for _, v := range versions {
+		if err := c.Add(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Add adds protocol version (e.g. scheduled protocol upgrade); an error is returned if there's already
+// a version with the same genesis time.
+func (c *Client) Add(v protocol.Version) error {
+	if v == nil {
+		return errors.New("missing protocol version")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	genesisTime := v.Protocol().GenesisTime
+
+	i := sort.Search(len(c.versions), func(i int) bool {
+		return c.versions[i].Protocol().GenesisTime >= genesisTime
+	})
+
+	if i < len(c.versions) && c.versions[i].Protocol().GenesisTime == genesisTime {
+		return fmt.Errorf("protocol version [%s] and version [%s] have the same genesis time: %d",
+			v.Version(), c.versions[i].Version(), genesisTime)
+	}
+
+	c.versions = append(c.versions, nil)
+	copy(c.versions[i+1:], c.versions[i:])
+	c.versions[i] = v
+
+	return nil
+}
+
+// Current returns current version of protocol.
+func (c *Client) Current() (protocol.Version, error) {
+	if c.blockchainTime != nil {
+		t, err := c.blockchainTime()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blockchain time: %s", err.Error())
+		}
+
+		return c.Get(t)
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.versions) == 0 {
+		return nil, errors.New("protocol versions are not defined")
+	}
+
+	return c.versions[len(c.versions)-1], nil
+}
+
+// Get returns the version of protocol at the given transaction time, i.e. the version with
+// the latest genesis time that is not after the transaction time.
+func (c *Client) Get(transactionTime uint64) (protocol.Version, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	i := sort.Search(len(c.versions), func(i int) bool {
+		return c.versions[i].Protocol().GenesisTime > transactionTime
+	})
+
+	if i == 0 {
+		return nil, fmt.Errorf("protocol parameters are not defined for blockchain time: %d", transactionTime)
+	}
+
+	return c.versions[i-1], nil
+}
+
+// Versions returns all protocol versions ordered by genesis time.
+func (c *Client) Versions() []protocol.Version {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	versions := make([]protocol.Version, len(c.versions))
+	copy(versions, c.versions)
+
+	return versions
+}
@@ -0,0 +1,147 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New([]protocol.Version{newVersion("1.0", 500), newVersion("0.1", 0), newVersion("0.5", 100)})
+		require.NoError(t, err)
+
+		versions := c.Versions()
+		require.Len(t, versions, 3)
+		require.Equal(t, "0.1", versions[0].Version())
+		require.Equal(t, "0.5", versions[1].Version())
+		require.Equal(t, "1.0", versions[2].Version())
+	})
+
+	t.Run("error - no versions", func(t *testing.T) {
+		c, err := New(nil)
+		require.EqualError(t, err, "missing protocol versions")
+		require.Nil(t, c)
+	})
+
+	t.Run("error - duplicate genesis time", func(t *testing.T) {
+		c, err := New([]protocol.Version{newVersion("0.1", 100), newVersion("1.0", 100)})
+		require.EqualError(t, err, "protocol version [1.0] and version [0.1] have the same genesis time: 100")
+		require.Nil(t, c)
+	})
+
+	t.Run("error - nil version", func(t *testing.T) {
+		c, err := New([]protocol.Version{nil})
+		require.EqualError(t, err, "missing protocol version")
+		require.Nil(t, c)
+	})
+}
+
+func TestClient_Get(t *testing.T) {
+	c, err := New([]protocol.Version{newVersion("0.5", 100), newVersion("1.0", 500)})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		v, err := c.Get(100)
+		require.NoError(t, err)
+		require.Equal(t, "0.5", v.Version())
+
+		v, err = c.Get(499)
+		require.NoError(t, err)
+		require.Equal(t, "0.5", v.Version())
+
+		v, err = c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+
+		v, err = c.Get(10000)
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+	})
+
+	t.Run("error - before first genesis time", func(t *testing.T) {
+		v, err := c.Get(99)
+		require.EqualError(t, err, "protocol parameters are not defined for blockchain time: 99")
+		require.Nil(t, v)
+	})
+}
+
+func TestClient_Current(t *testing.T) {
+	t.Run("latest version", func(t *testing.T) {
+		c, err := New([]protocol.Version{newVersion("0.1", 0), newVersion("1.0", 500)})
+		require.NoError(t, err)
+
+		v, err := c.Current()
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+	})
+
+	t.Run("version at blockchain time", func(t *testing.T) {
+		var blockchainTime uint64 = 100
+
+		c, err := New([]protocol.Version{newVersion("0.1", 0), newVersion("1.0", 500)},
+			WithBlockchainTime(func() (uint64, error) { return blockchainTime, nil }))
+		require.NoError(t, err)
+
+		v, err := c.Current()
+		require.NoError(t, err)
+		require.Equal(t, "0.1", v.Version())
+
+		blockchainTime = 500
+
+		v, err = c.Current()
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+	})
+
+	t.Run("error - blockchain time", func(t *testing.T) {
+		c, err := New([]protocol.Version{newVersion("0.1", 0)},
+			WithBlockchainTime(func() (uint64, error) { return 0, errors.New("blockchain error") }))
+		require.NoError(t, err)
+
+		v, err := c.Current()
+		require.EqualError(t, err, "failed to get blockchain time: blockchain error")
+		require.Nil(t, v)
+	})
+
+	t.Run("error - no versions", func(t *testing.T) {
+		v, err := (&Client{}).Current()
+		require.EqualError(t, err, "protocol versions are not defined")
+		require.Nil(t, v)
+	})
+}
+
+func TestClient_Add(t *testing.T) {
+	c, err := New([]protocol.Version{newVersion("0.1", 0)})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Add(newVersion("1.0", 500)))
+
+	v, err := c.Get(500)
+	require.NoError(t, err)
+	require.Equal(t, "1.0", v.Version())
+
+	err = c.Add(newVersion("1.1", 500))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "have the same genesis time: 500")
+}
+
+func newVersion(version string, genesisTime uint64) protocol.Version {
+	p := mocks.GetDefaultProtocolParameters()
+	p.GenesisTime = genesisTime
+
+	v := mocks.GetProtocolVersion(p)
+	v.VersionReturns(version)
+
+	return v
+}
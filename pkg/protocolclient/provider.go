@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// ProviderOption is an option for protocol client provider.
+type ProviderOption func(opts *Provider)
+
+// WithDefaultClient sets protocol client that is returned for namespaces without registered client.
+// If not set, an error is returned for such namespaces.
+func WithDefaultClient(pc protocol.Client) ProviderOption {
+	return func(opts *Provider) {
+		opts.defaultClient = pc
+	}
+}
+
+// Provider returns protocol clients registered for namespaces, so that namespaces (DID methods) served
+// by the same process can use different protocol parameters and version schedules.
+type Provider struct {
+	mutex         sync.RWMutex
+	clients       map[string]protocol.Client
+	defaultClient protocol.Client
+}
+
+// NewProvider returns a new protocol client provider.
+func NewProvider(opts ...ProviderOption) *Provider {
+	p := &Provider{
+		clients: make(map[string]protocol.Client),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Add registers protocol client for the namespace.
+func (p *Provider) Add(namespace string, pc protocol.Client) error {
+	if namespace == "" {
+		return errors.New("missing namespace")
+	}
+
+	if pc == nil {
+		return errors.New("missing protocol client")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.clients[namespace]; ok {
+		return fmt.Errorf("protocol client for namespace [%s] is already registered", namespace)
+	}
+
+	p.clients[namespace] = pc
+
+	return nil
+}
+
+// ForNamespace returns protocol client for the namespace.
+func (p *Provider) ForNamespace(namespace string) (protocol.Client, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	pc, ok := p.clients[namespace]
+	if ok {
+		return pc, nil
+	}
+
+	if p.defaultClient != nil {
+		return p.defaultClient, nil
+	}
+
+	return nil, fmt.Errorf("protocol client not found for namespace [%s]", namespace)
+}
+
+// Namespaces returns (sorted) namespaces with registered protocol clients.
+func (p *Provider) Namespaces() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	namespaces := make([]string, 0, len(p.clients))
+	for ns := range p.clients {
+		namespaces = append(namespaces, ns)
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces
+}
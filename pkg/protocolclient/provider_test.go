@@ -0,0 +1,77 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+const (
+	ns1 = "did:ns1"
+	ns2 = "did:ns2"
+)
+
+func TestProvider(t *testing.T) {
+	pc1, err := New([]protocol.Version{newVersion("0.1", 0)})
+	require.NoError(t, err)
+
+	pc2, err := New([]protocol.Version{newVersion("0.1", 0), newVersion("1.0", 500)})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		p := NewProvider()
+		require.NoError(t, p.Add(ns1, pc1))
+		require.NoError(t, p.Add(ns2, pc2))
+		require.Equal(t, []string{ns1, ns2}, p.Namespaces())
+
+		pc, err := p.ForNamespace(ns1)
+		require.NoError(t, err)
+
+		v, err := pc.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, "0.1", v.Version())
+
+		pc, err = p.ForNamespace(ns2)
+		require.NoError(t, err)
+
+		v, err = pc.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+	})
+
+	t.Run("default client", func(t *testing.T) {
+		p := NewProvider(WithDefaultClient(pc1))
+		require.NoError(t, p.Add(ns2, pc2))
+
+		pc, err := p.ForNamespace("did:other")
+		require.NoError(t, err)
+		require.Equal(t, pc1, pc)
+
+		pc, err = p.ForNamespace(ns2)
+		require.NoError(t, err)
+		require.Equal(t, pc2, pc)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		pc, err := NewProvider().ForNamespace(ns1)
+		require.EqualError(t, err, "protocol client not found for namespace [did:ns1]")
+		require.Nil(t, pc)
+	})
+
+	t.Run("error - add", func(t *testing.T) {
+		p := NewProvider()
+		require.EqualError(t, p.Add("", pc1), "missing namespace")
+		require.EqualError(t, p.Add(ns1, nil), "missing protocol client")
+
+		require.NoError(t, p.Add(ns1, pc1))
+		require.EqualError(t, p.Add(ns1, pc2), "protocol client for namespace [did:ns1] is already registered")
+	})
+}
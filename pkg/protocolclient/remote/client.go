@@ -0,0 +1,315 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package remote provides protocol client that discovers protocol parameters from a remote endpoint.
+// The endpoint returns configurations of protocol versions (see versions.ParseConfig) as payload of compact
+// JWS signed by the parameters publisher; the configurations are cached and periodically refreshed so that
+// parameter changes (e.g. scheduled protocol upgrades) can be rolled out without redeploying nodes.
+//
+// The payload may contain a sequence number next to the versions (e.g. {"sequence": 2, "versions": [...]});
+// payloads with a lower sequence than the cached payload are rejected so that previously signed parameters
+// cannot be replayed to roll back a protocol upgrade.
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/protocolclient"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions"
+)
+
+var logger = log.New("sidetree-core-protocolclient-remote")
+
+const (
+	defaultCacheExpiry = 10 * time.Minute
+	defaultRetryAfter  = 30 * time.Second
+	defaultHTTPTimeout = 20 * time.Second
+
+	maxResponseSize = 1 << 20
+)
+
+// Option is an option for remote protocol client.
+type Option func(opts *Client)
+
+// WithHTTPClient sets HTTP client used to fetch protocol parameters (default client has 20s timeout).
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(opts *Client) {
+		opts.httpClient = httpClient
+	}
+}
+
+// WithCacheExpiry sets how long fetched protocol parameters are used before they're refreshed (default is 10m).
+func WithCacheExpiry(expiry time.Duration) Option {
+	return func(opts *Client) {
+		opts.cacheExpiry = expiry
+	}
+}
+
+// WithRetryAfter sets how long cached (expired) protocol parameters are used after refresh failed
+// before the next refresh is attempted (default is 30s).
+func WithRetryAfter(retryAfter time.Duration) Option {
+	return func(opts *Client) {
+		opts.retryAfter = retryAfter
+	}
+}
+
+// WithClientOptions sets options for protocol client created from fetched parameters.
+func WithClientOptions(clientOpts ...protocolclient.Option) Option {
+	return func(opts *Client) {
+		opts.clientOpts = append(opts.clientOpts, clientOpts...)
+	}
+}
+
+// Client is protocol client that fetches protocol parameters from remote endpoint.
+type Client struct {
+	url       string
+	publicKey *jws.JWK
+	deps      *versions.Dependencies
+
+	httpClient  *http.Client
+	cacheExpiry time.Duration
+	retryAfter  time.Duration
+	clientOpts  []protocolclient.Option
+
+	mutex    sync.Mutex
+	client   *protocolclient.Client
+	payload  []byte
+	sequence uint64
+	expiry   time.Time
+	inFlight *refreshCall
+}
+
+// refreshCall is refresh in progress; concurrent callers wait for its result instead of fetching again.
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// New returns protocol client that fetches protocol parameters from the given URL; parameters
+// have to be signed by the given public key. Protocol versions are created using registered
+// version factories (see versions.Register).
+func New(url string, publicKey *jws.JWK, deps *versions.Dependencies, opts ...Option) (*Client, error) {
+	if url == "" {
+		return nil, errors.New("missing protocol parameters URL")
+	}
+
+	if publicKey == nil {
+		return nil, errors.New("missing public key for protocol parameters signature verification")
+	}
+
+	c := &Client{
+		url:         url,
+		publicKey:   publicKey,
+		deps:        deps,
+		httpClient:  &http.Client{Timeout: defaultHTTPTimeout},
+		cacheExpiry: defaultCacheExpiry,
+		retryAfter:  defaultRetryAfter,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Current returns latest version of protocol.
+func (c *Client) Current() (protocol.Version, error) {
+	pc, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.Current()
+}
+
+// Get returns the version at the given transaction time.
+func (c *Client) Get(transactionTime uint64) (protocol.Version, error) {
+	pc, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return pc.Get(transactionTime)
+}
+
+// Refresh fetches protocol parameters regardless of cache expiry.
+func (c *Client) Refresh() error {
+	return c.refresh()
+}
+
+func (c *Client) get() (*protocolclient.Client, error) {
+	c.mutex.Lock()
+
+	// cached parameters are used while they're refreshed
+	if c.client != nil && (time.Now().Before(c.expiry) || c.inFlight != nil) {
+		defer c.mutex.Unlock()
+
+		return c.client, nil
+	}
+
+	c.mutex.Unlock()
+
+	err := c.refresh()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		if c.client == nil {
+			return nil, err
+		}
+
+		// remote endpoint may be temporarily unavailable, keep using cached parameters
+		logger.Warnf("Failed to refresh protocol parameters from [%s], using cached parameters: %s", c.url, err.Error())
+
+		c.expiry = time.Now().Add(c.retryAfter)
+	}
+
+	return c.client, nil
+}
+
+// refresh fetches protocol parameters without holding the lock (so that cached parameters remain available
+// while the remote endpoint responds); only one fetch is in progress at a time.
+func (c *Client) refresh() error {
+	c.mutex.Lock()
+
+	if call := c.inFlight; call != nil {
+		c.mutex.Unlock()
+
+		<-call.done
+
+		return call.err
+	}
+
+	call := &refreshCall{done: make(chan struct{})}
+	c.inFlight = call
+
+	c.mutex.Unlock()
+
+	call.err = c.load()
+
+	c.mutex.Lock()
+	c.inFlight = nil
+	c.mutex.Unlock()
+
+	close(call.done)
+
+	return call.err
+}
+
+func (c *Client) load() error {
+	payload, err := c.fetch()
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	cached := c.client != nil && bytes.Equal(payload, c.payload)
+	cachedSequence := c.sequence
+
+	if cached {
+		c.expiry = time.Now().Add(c.cacheExpiry)
+	}
+
+	c.mutex.Unlock()
+
+	if cached {
+		return nil
+	}
+
+	sequence, err := getSequence(payload)
+	if err != nil {
+		return err
+	}
+
+	if sequence < cachedSequence {
+		return fmt.Errorf("protocol parameters sequence[%d] is lower than sequence of cached parameters[%d]",
+			sequence, cachedSequence)
+	}
+
+	configs, err := versions.ParseConfig(payload, versions.FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	pc, err := versions.NewClient(configs, c.deps, c.clientOpts...)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Loaded %d protocol version(s) from [%s]", len(configs), c.url)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.client = pc
+	c.payload = payload
+	c.sequence = sequence
+	c.expiry = time.Now().Add(c.cacheExpiry)
+
+	return nil
+}
+
+func getSequence(payload []byte) (uint64, error) {
+	var p struct {
+		Sequence uint64 `json:"sequence"`
+	}
+
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return 0, fmt.Errorf("failed to parse protocol parameters sequence: %s", err.Error())
+	}
+
+	return p.Sequence, nil
+}
+
+// fetch fetches signed protocol parameters and returns verified payload.
+func (c *Client) fetch() ([]byte, error) {
+	resp, err := c.httpClient.Get(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protocol parameters: %s", err.Error())
+	}
+
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			logger.Warnf("Failed to close response body: %s", closeErr.Error())
+		}
+	}()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol parameters: %s", err.Error())
+	}
+
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("protocol parameters exceed maximum size[%d]", maxResponseSize)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch protocol parameters: status code %d: %s", resp.StatusCode, body)
+	}
+
+	signed, err := internaljws.VerifyJWS(string(bytes.TrimSpace(body)), c.publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protocol parameters signature: %s", err.Error())
+	}
+
+	return signed.Payload, nil
+}
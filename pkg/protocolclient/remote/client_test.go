@@ -0,0 +1,414 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions"
+)
+
+const (
+	v1 = "remote-0.1"
+	v2 = "remote-1.0"
+)
+
+func TestNew(t *testing.T) {
+	publicKey, _ := newKeys(t)
+
+	c, err := New("https://example.com/protocol", publicKey, &versions.Dependencies{},
+		WithHTTPClient(&http.Client{}), WithCacheExpiry(time.Minute), WithRetryAfter(time.Second),
+		WithClientOptions())
+	require.NoError(t, err)
+	require.NotNil(t, c)
+
+	t.Run("success - default HTTP timeout", func(t *testing.T) {
+		c, err := New("https://example.com/protocol", publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+		require.Equal(t, defaultHTTPTimeout, c.httpClient.Timeout)
+	})
+
+	t.Run("error - missing URL", func(t *testing.T) {
+		c, err := New("", publicKey, &versions.Dependencies{})
+		require.EqualError(t, err, "missing protocol parameters URL")
+		require.Nil(t, c)
+	})
+
+	t.Run("error - missing public key", func(t *testing.T) {
+		c, err := New("https://example.com/protocol", nil, &versions.Dependencies{})
+		require.EqualError(t, err, "missing public key for protocol parameters signature verification")
+		require.Nil(t, c)
+	})
+}
+
+func TestClient(t *testing.T) {
+	require.NoError(t, versions.Register(v1, &mockFactory{}))
+	require.NoError(t, versions.Register(v2, &mockFactory{}))
+
+	publicKey, privateKey := newKeys(t)
+
+	t.Run("success", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		v, err := c.Current()
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+
+		v, err = c.Get(100)
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+
+		// parameters are cached
+		require.Equal(t, 1, server.requests())
+	})
+
+	t.Run("success - refresh after cache expiry", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{}, WithCacheExpiry(10*time.Millisecond))
+		require.NoError(t, err)
+
+		v, err := c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+
+		server.setPayload(config(v1, 0) + "," + config(v2, 500))
+
+		time.Sleep(20 * time.Millisecond)
+
+		v, err = c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v2, v.Version())
+		require.Equal(t, 2, server.requests())
+	})
+
+	t.Run("success - cached parameters are used if refresh fails", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{},
+			WithCacheExpiry(10*time.Millisecond), WithRetryAfter(time.Minute))
+		require.NoError(t, err)
+
+		_, err = c.Current()
+		require.NoError(t, err)
+
+		server.setStatus(http.StatusInternalServerError)
+
+		time.Sleep(20 * time.Millisecond)
+
+		v, err := c.Current()
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+
+		// next refresh is attempted after retry interval
+		_, err = c.Current()
+		require.NoError(t, err)
+		require.Equal(t, 2, server.requests())
+
+		err = c.Refresh()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status code 500")
+	})
+
+	t.Run("success - single fetch for concurrent requests", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		release := server.block()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+
+		errs := make(chan error, 10)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := c.Current()
+				errs <- err
+			}()
+		}
+
+		require.Eventually(t, func() bool { return server.requests() == 1 }, time.Second, time.Millisecond)
+
+		close(release)
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, server.requests())
+	})
+
+	t.Run("success - cached parameters are used while refresh is in progress", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{}, WithCacheExpiry(10*time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = c.Current()
+		require.NoError(t, err)
+
+		server.setPayload(config(v1, 0) + "," + config(v2, 500))
+
+		release := server.block()
+
+		time.Sleep(20 * time.Millisecond)
+
+		refreshErr := make(chan error)
+
+		go func() {
+			refreshErr <- c.Refresh()
+		}()
+
+		require.Eventually(t, func() bool { return server.requests() == 2 }, time.Second, time.Millisecond)
+
+		// fetch doesn't block callers
+		v, err := c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+
+		close(release)
+		require.NoError(t, <-refreshErr)
+
+		v, err = c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v2, v.Version())
+	})
+
+	t.Run("error - rollback to lower sequence", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		server.setSequencedPayload(2, config(v1, 0)+","+config(v2, 500))
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{}, WithCacheExpiry(10*time.Millisecond),
+			WithRetryAfter(time.Minute))
+		require.NoError(t, err)
+
+		v, err := c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v2, v.Version())
+
+		// previously signed parameters are replayed
+		server.setSequencedPayload(1, config(v1, 0))
+
+		err = c.Refresh()
+		require.EqualError(t, err,
+			"protocol parameters sequence[1] is lower than sequence of cached parameters[2]")
+
+		server.setPayload(config(v1, 0))
+
+		err = c.Refresh()
+		require.EqualError(t, err,
+			"protocol parameters sequence[0] is lower than sequence of cached parameters[2]")
+
+		time.Sleep(20 * time.Millisecond)
+
+		// cached parameters are used
+		v, err = c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v2, v.Version())
+
+		server.setSequencedPayload(3, config(v1, 0))
+
+		require.NoError(t, c.Refresh())
+
+		v, err = c.Get(500)
+		require.NoError(t, err)
+		require.Equal(t, v1, v.Version())
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		_, otherKey := newKeys(t)
+
+		server := newServer(t, otherKey, config(v1, 0))
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		v, err := c.Current()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify protocol parameters signature")
+		require.Nil(t, v)
+
+		v, err = c.Get(0)
+		require.Error(t, err)
+		require.Nil(t, v)
+	})
+
+	t.Run("error - invalid configuration", func(t *testing.T) {
+		server := newServer(t, privateKey, `{"version":"unknown"}`)
+		defer server.Close()
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		err = c.Refresh()
+		require.EqualError(t, err, "factory for version 'unknown' is not registered")
+
+		server.setPayload(`{}`)
+
+		err = c.Refresh()
+		require.EqualError(t, err, "invalid protocol version[0] configuration: missing version")
+	})
+
+	t.Run("error - fetch", func(t *testing.T) {
+		c, err := New("http://localhost:0/protocol", publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		err = c.Refresh()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to fetch protocol parameters")
+	})
+
+	t.Run("error - response too large", func(t *testing.T) {
+		server := newServer(t, privateKey, config(v1, 0))
+		defer server.Close()
+
+		server.body = make([]byte, maxResponseSize+1)
+
+		c, err := New(server.URL, publicKey, &versions.Dependencies{})
+		require.NoError(t, err)
+
+		err = c.Refresh()
+		require.EqualError(t, err, "protocol parameters exceed maximum size[1048576]")
+	})
+}
+
+type server struct {
+	*httptest.Server
+
+	t          *testing.T
+	privateKey ed25519.PrivateKey
+
+	mutex  sync.Mutex
+	body   []byte
+	status int
+	count  int
+	gate   chan struct{}
+}
+
+func newServer(t *testing.T, privateKey ed25519.PrivateKey, versionConfigs string) *server {
+	s := &server{t: t, privateKey: privateKey, status: http.StatusOK}
+	s.setPayload(versionConfigs)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		s.mutex.Lock()
+		s.count++
+		gate := s.gate
+		s.mutex.Unlock()
+
+		if gate != nil {
+			<-gate
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		rw.WriteHeader(s.status)
+		_, err := rw.Write(s.body)
+		require.NoError(t, err)
+	}))
+
+	return s
+}
+
+func (s *server) setPayload(versionConfigs string) {
+	s.sign(`{"versions":[` + versionConfigs + `]}`)
+}
+
+func (s *server) setSequencedPayload(sequence uint64, versionConfigs string) {
+	s.sign(fmt.Sprintf(`{"sequence":%d,"versions":[%s]}`, sequence, versionConfigs))
+}
+
+func (s *server) sign(payload string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	signed, err := signutil.SignPayload([]byte(payload), edsigner.New(s.privateKey, "EdDSA", "key1"))
+	require.NoError(s.t, err)
+
+	s.body = []byte(signed)
+}
+
+// block blocks responses until the returned channel is closed.
+func (s *server) block() chan struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.gate = make(chan struct{})
+
+	return s.gate
+}
+
+func (s *server) setStatus(status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.status = status
+}
+
+func (s *server) requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.count
+}
+
+func config(version string, genesisTime uint64) string {
+	return fmt.Sprintf(`{"version":"%s","protocol":{"genesisTime":%d}}`, version, genesisTime)
+}
+
+func newKeys(t *testing.T) (*jws.JWK, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+	require.NoError(t, err)
+
+	return jwk, privateKey
+}
+
+type mockFactory struct{}
+
+func (m *mockFactory) Create(version string, p protocol.Protocol, _ *versions.Dependencies) (protocol.Version, error) {
+	v := mocks.GetProtocolVersion(p)
+	v.VersionReturns(version)
+
+	return v, nil
+}
@@ -0,0 +1,303 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package adminhandler provides administrative endpoints for the batching subsystem (queue depth, pending
+// operations, forced batch cut, pausing/resuming the batch writer and dead-letter inspection). All endpoints
+// require one of the configured bearer tokens.
+package adminhandler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/batch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+var logger = log.New("sidetree-core-restapi-adminhandler")
+
+const (
+	// DefaultBasePath is default base path of admin endpoints.
+	DefaultBasePath = "/admin"
+
+	// DefaultPendingLimit is default number of pending operations returned.
+	DefaultPendingLimit = 100
+
+	// MaxPendingLimit is maximum number of pending operations returned.
+	MaxPendingLimit = 1000
+
+	limitParam  = "limit"
+	contentType = "application/json"
+)
+
+// BatchWriter is implemented by the batch writer.
+type BatchWriter interface {
+	QueueDepth() uint
+	PendingOperations(num uint) ([]*operation.QueuedOperationAtTime, error)
+	Flush() error
+	Pause()
+	Resume() error
+	Paused() bool
+	Stopped() bool
+}
+
+// DeadLetterProvider returns batches of operations that failed processing.
+type DeadLetterProvider interface {
+	DeadLetters() []*batch.FailedBatch
+}
+
+// Option is an option for admin handlers.
+type Option func(opts *options)
+
+type options struct {
+	basePath    string
+	deadLetters DeadLetterProvider
+	middleware  []common.Middleware
+}
+
+// WithBasePath sets base path of admin endpoints (default is /admin).
+func WithBasePath(basePath string) Option {
+	return func(opts *options) {
+		opts.basePath = basePath
+	}
+}
+
+// WithDeadLetters enables dead-letter inspection endpoint.
+func WithDeadLetters(provider DeadLetterProvider) Option {
+	return func(opts *options) {
+		opts.deadLetters = provider
+	}
+}
+
+// WithMiddleware adds middleware that is applied (after authorization) to admin endpoints.
+func WithMiddleware(middleware ...common.Middleware) Option {
+	return func(opts *options) {
+		opts.middleware = append(opts.middleware, middleware...)
+	}
+}
+
+// QueueResponse contains state of the operation queue and the batch writer.
+type QueueResponse struct {
+	Depth   uint `json:"depth"`
+	Paused  bool `json:"paused"`
+	Stopped bool `json:"stopped"`
+}
+
+// OperationInfo describes a queued operation (operation request is not included).
+type OperationInfo struct {
+	UniqueSuffix        string `json:"uniqueSuffix"`
+	Namespace           string `json:"namespace"`
+	ProtocolGenesisTime uint64 `json:"protocolGenesisTime"`
+}
+
+// PendingResponse contains operations from the head of the queue.
+type PendingResponse struct {
+	Depth      uint             `json:"depth"`
+	Operations []*OperationInfo `json:"operations"`
+}
+
+// DeadLetter describes a batch of operations that failed processing.
+type DeadLetter struct {
+	Operations []*OperationInfo `json:"operations"`
+	Attempts   int              `json:"attempts"`
+	Error      string           `json:"error"`
+	Failed     int64            `json:"failed"`
+}
+
+// DeadLettersResponse contains batches of operations that failed processing (oldest first).
+type DeadLettersResponse struct {
+	DeadLetters []*DeadLetter `json:"deadLetters"`
+}
+
+// New returns admin handlers; requests without one of the given bearer tokens are rejected (if no tokens
+// are provided all requests are rejected).
+func New(writer BatchWriter, tokens []string, opts ...Option) []common.HTTPHandler {
+	o := &options{basePath: DefaultBasePath}
+
+	// apply options
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	h := &admin{writer: writer, deadLetters: o.deadLetters}
+
+	handlers := []common.HTTPHandler{
+		newHandler(o.basePath+"/queue", http.MethodGet, h.queue),
+		newHandler(o.basePath+"/queue/operations", http.MethodGet, h.pending),
+		newHandler(o.basePath+"/batch/cut", http.MethodPost, h.cut),
+		newHandler(o.basePath+"/writer/pause", http.MethodPost, h.pause),
+		newHandler(o.basePath+"/writer/resume", http.MethodPost, h.resume),
+	}
+
+	if o.deadLetters != nil {
+		handlers = append(handlers, newHandler(o.basePath+"/deadletters", http.MethodGet, h.listDeadLetters))
+	}
+
+	middleware := append([]common.Middleware{common.BearerTokenAuth(tokens...)}, o.middleware...)
+
+	return common.WithMiddleware(handlers, middleware...)
+}
+
+type admin struct {
+	writer      BatchWriter
+	deadLetters DeadLetterProvider
+}
+
+func (h *admin) queue(rw http.ResponseWriter, _ *http.Request) {
+	h.writeQueue(rw)
+}
+
+func (h *admin) pending(rw http.ResponseWriter, req *http.Request) {
+	limit, err := getLimit(req)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, err)
+
+		return
+	}
+
+	ops, err := h.writer.PendingOperations(limit)
+	if err != nil {
+		common.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	response := &PendingResponse{
+		Depth:      h.writer.QueueDepth(),
+		Operations: make([]*OperationInfo, len(ops)),
+	}
+
+	for i, op := range ops {
+		response.Operations[i] = getOperationInfo(&op.QueuedOperation, op.ProtocolGenesisTime)
+	}
+
+	common.WriteResponseWithContentType(rw, http.StatusOK, contentType, response)
+}
+
+func (h *admin) cut(rw http.ResponseWriter, _ *http.Request) {
+	logger.Infof("Forcing batch cut")
+
+	if err := h.writer.Flush(); err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case h.writer.Stopped():
+			status = http.StatusServiceUnavailable
+		case h.writer.Paused():
+			status = http.StatusConflict
+		}
+
+		common.WriteError(rw, status, err)
+
+		return
+	}
+
+	h.writeQueue(rw)
+}
+
+func (h *admin) pause(rw http.ResponseWriter, _ *http.Request) {
+	logger.Infof("Pausing batch writer")
+
+	h.writer.Pause()
+
+	h.writeQueue(rw)
+}
+
+func (h *admin) resume(rw http.ResponseWriter, _ *http.Request) {
+	logger.Infof("Resuming batch writer")
+
+	if err := h.writer.Resume(); err != nil {
+		common.WriteError(rw, http.StatusServiceUnavailable, err)
+
+		return
+	}
+
+	h.writeQueue(rw)
+}
+
+func (h *admin) listDeadLetters(rw http.ResponseWriter, _ *http.Request) {
+	batches := h.deadLetters.DeadLetters()
+
+	response := &DeadLettersResponse{
+		DeadLetters: make([]*DeadLetter, len(batches)),
+	}
+
+	for i, b := range batches {
+		dl := &DeadLetter{
+			Operations: make([]*OperationInfo, len(b.Operations)),
+			Attempts:   b.Attempts,
+			Error:      b.Error,
+			Failed:     b.Failed,
+		}
+
+		for j, op := range b.Operations {
+			dl.Operations[j] = getOperationInfo(op, b.ProtocolGenesisTime)
+		}
+
+		response.DeadLetters[i] = dl
+	}
+
+	common.WriteResponseWithContentType(rw, http.StatusOK, contentType, response)
+}
+
+func (h *admin) writeQueue(rw http.ResponseWriter) {
+	common.WriteResponseWithContentType(rw, http.StatusOK, contentType, &QueueResponse{
+		Depth:   h.writer.QueueDepth(),
+		Paused:  h.writer.Paused(),
+		Stopped: h.writer.Stopped(),
+	})
+}
+
+func getOperationInfo(op *operation.QueuedOperation, protocolGenesisTime uint64) *OperationInfo {
+	return &OperationInfo{
+		UniqueSuffix:        op.UniqueSuffix,
+		Namespace:           op.Namespace,
+		ProtocolGenesisTime: protocolGenesisTime,
+	}
+}
+
+func getLimit(req *http.Request) (uint, error) {
+	value := req.URL.Query().Get(limitParam)
+	if value == "" {
+		return DefaultPendingLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxPendingLimit {
+		return 0, fmt.Errorf("'%s' query parameter must be a number between 1 and %d", limitParam, MaxPendingLimit)
+	}
+
+	return uint(limit), nil
+}
+
+type handler struct {
+	path    string
+	method  string
+	handler common.HTTPRequestHandler
+}
+
+func newHandler(path, method string, h common.HTTPRequestHandler) *handler {
+	return &handler{path: path, method: method, handler: h}
+}
+
+// Path returns the context path.
+func (h *handler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *handler) Method() string {
+	return h.method
+}
+
+// Handler returns the handler.
+func (h *handler) Handler() common.HTTPRequestHandler {
+	return h.handler
+}
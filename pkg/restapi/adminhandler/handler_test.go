@@ -0,0 +1,264 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package adminhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/batch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const token = "admin-token"
+
+// batch writer and in-memory dead-letter queue are used by admin handlers.
+var (
+	_ BatchWriter        = (*batch.Writer)(nil)
+	_ DeadLetterProvider = (*batch.MemDeadLetterQueue)(nil)
+)
+
+func TestNew(t *testing.T) {
+	handlers := New(&mockWriter{}, []string{token})
+	require.Len(t, handlers, 5)
+
+	handlers = New(&mockWriter{}, []string{token}, WithBasePath("/ops"), WithDeadLetters(batch.NewMemDeadLetterQueue()))
+	require.Len(t, handlers, 6)
+	require.Equal(t, "/ops/deadletters", handlers[5].Path())
+}
+
+func TestAuthorization(t *testing.T) {
+	handlers := New(&mockWriter{}, []string{token})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		rw := serve(getHandler(t, handlers, "/admin/queue"), "", "Bearer other")
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("no tokens configured", func(t *testing.T) {
+		handlers := New(&mockWriter{}, nil)
+
+		rw := serve(getHandler(t, handlers, "/admin/queue"), "", "Bearer ")
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+}
+
+func TestQueue(t *testing.T) {
+	writer := &mockWriter{depth: 3, paused: true}
+
+	rw := serve(getHandler(t, New(writer, []string{token}), "/admin/queue"), "", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var response QueueResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+	require.Equal(t, uint(3), response.Depth)
+	require.True(t, response.Paused)
+	require.False(t, response.Stopped)
+}
+
+func TestPendingOperations(t *testing.T) {
+	writer := &mockWriter{
+		depth: 2,
+		ops: []*operation.QueuedOperationAtTime{
+			{QueuedOperation: operation.QueuedOperation{UniqueSuffix: "suffix1", Namespace: "did:sidetree"}},
+			{QueuedOperation: operation.QueuedOperation{UniqueSuffix: "suffix2", Namespace: "did:sidetree"}, ProtocolGenesisTime: 100},
+		},
+	}
+
+	h := getHandler(t, New(writer, []string{token}), "/admin/queue/operations")
+
+	t.Run("success", func(t *testing.T) {
+		rw := serve(h, "?limit=10", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, uint(10), writer.peeked)
+
+		var response PendingResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, uint(2), response.Depth)
+		require.Len(t, response.Operations, 2)
+		require.Equal(t, "suffix2", response.Operations[1].UniqueSuffix)
+		require.Equal(t, uint64(100), response.Operations[1].ProtocolGenesisTime)
+		require.NotContains(t, rw.Body.String(), "OperationBuffer")
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		rw := serve(h, "", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, uint(DefaultPendingLimit), writer.peeked)
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		rw := serve(h, "?limit=0", "")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "'limit' query parameter must be a number between 1 and 1000")
+	})
+
+	t.Run("error - queue", func(t *testing.T) {
+		h := getHandler(t, New(&mockWriter{err: errors.New("queue error")}, []string{token}), "/admin/queue/operations")
+
+		rw := serve(h, "", "")
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.Contains(t, rw.Body.String(), "queue error")
+	})
+}
+
+func TestCut(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		writer := &mockWriter{}
+
+		rw := serve(getHandler(t, New(writer, []string{token}), "/admin/batch/cut"), "", "")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.True(t, writer.flushed)
+	})
+
+	t.Run("error - paused", func(t *testing.T) {
+		writer := &mockWriter{paused: true, err: errors.New("writer is paused")}
+
+		rw := serve(getHandler(t, New(writer, []string{token}), "/admin/batch/cut"), "", "")
+		require.Equal(t, http.StatusConflict, rw.Code)
+	})
+
+	t.Run("error - stopped", func(t *testing.T) {
+		writer := &mockWriter{stopped: true, err: errors.New("writer is stopped")}
+
+		rw := serve(getHandler(t, New(writer, []string{token}), "/admin/batch/cut"), "", "")
+		require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	})
+
+	t.Run("error - operations pending", func(t *testing.T) {
+		writer := &mockWriter{err: errors.New("1 operations are still pending")}
+
+		rw := serve(getHandler(t, New(writer, []string{token}), "/admin/batch/cut"), "", "")
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+	})
+}
+
+func TestPauseResume(t *testing.T) {
+	writer := &mockWriter{}
+	handlers := New(writer, []string{token})
+
+	rw := serve(getHandler(t, handlers, "/admin/writer/pause"), "", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), `"paused":true`)
+
+	rw = serve(getHandler(t, handlers, "/admin/writer/resume"), "", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), `"paused":false`)
+
+	t.Run("error - stopped", func(t *testing.T) {
+		writer.err = errors.New("writer is stopped")
+
+		rw := serve(getHandler(t, handlers, "/admin/writer/resume"), "", "")
+		require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	})
+}
+
+func TestDeadLetters(t *testing.T) {
+	deadLetters := batch.NewMemDeadLetterQueue()
+	deadLetters.Add(&batch.FailedBatch{
+		Operations:          []*operation.QueuedOperation{{UniqueSuffix: "suffix1", OperationBuffer: []byte("{}")}},
+		ProtocolGenesisTime: 100,
+		Attempts:            3,
+		Error:               "CAS error",
+	})
+
+	h := getHandler(t, New(&mockWriter{}, []string{token}, WithDeadLetters(deadLetters)), "/admin/deadletters")
+
+	rw := serve(h, "", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var response DeadLettersResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+	require.Len(t, response.DeadLetters, 1)
+	require.Equal(t, 3, response.DeadLetters[0].Attempts)
+	require.Equal(t, "CAS error", response.DeadLetters[0].Error)
+	require.Equal(t, "suffix1", response.DeadLetters[0].Operations[0].UniqueSuffix)
+	require.Equal(t, uint64(100), response.DeadLetters[0].Operations[0].ProtocolGenesisTime)
+}
+
+func getHandler(t *testing.T, handlers []common.HTTPHandler, path string) common.HTTPHandler {
+	t.Helper()
+
+	for _, h := range handlers {
+		if h.Path() == path {
+			return h
+		}
+	}
+
+	t.Fatalf("handler not found: %s", path)
+
+	return nil
+}
+
+func serve(h common.HTTPHandler, query, auth string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(h.Method(), h.Path()+query, nil)
+
+	if auth == "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.Header.Set("Authorization", auth)
+	}
+
+	rw := httptest.NewRecorder()
+	h.Handler()(rw, req)
+
+	return rw
+}
+
+type mockWriter struct {
+	depth   uint
+	ops     []*operation.QueuedOperationAtTime
+	peeked  uint
+	paused  bool
+	stopped bool
+	flushed bool
+	err     error
+}
+
+func (m *mockWriter) QueueDepth() uint {
+	return m.depth
+}
+
+func (m *mockWriter) PendingOperations(num uint) ([]*operation.QueuedOperationAtTime, error) {
+	m.peeked = num
+
+	return m.ops, m.err
+}
+
+func (m *mockWriter) Flush() error {
+	m.flushed = m.err == nil
+
+	return m.err
+}
+
+func (m *mockWriter) Pause() {
+	m.paused = true
+}
+
+func (m *mockWriter) Resume() error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.paused = false
+
+	return nil
+}
+
+func (m *mockWriter) Paused() bool {
+	return m.paused
+}
+
+func (m *mockWriter) Stopped() bool {
+	return m.stopped
+}
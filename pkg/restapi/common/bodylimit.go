@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// OperationSizeLimit returns middleware that rejects (413) request bodies larger than maximum operation size
+// of the current protocol version. Declared content length is checked before reading the body and at most
+// maximum operation size (plus one byte) is read otherwise, so that large uploads are not buffered.
+func OperationSizeLimit(pc protocol.Client) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			pv, err := pc.Current()
+			if err != nil {
+				WriteError(rw, http.StatusInternalServerError, err)
+
+				return
+			}
+
+			maxSize := int64(pv.Protocol().MaxOperationSize)
+
+			if req.ContentLength > maxSize {
+				writeTooLarge(rw, req.ContentLength, maxSize)
+
+				return
+			}
+
+			body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+			if err != nil {
+				WriteError(rw, http.StatusBadRequest, err)
+
+				return
+			}
+
+			if int64(len(body)) > maxSize {
+				writeTooLarge(rw, int64(len(body)), maxSize)
+
+				return
+			}
+
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			next(rw, req)
+		}
+	}
+}
+
+func writeTooLarge(rw http.ResponseWriter, size, maxSize int64) {
+	WriteError(rw, http.StatusRequestEntityTooLarge,
+		protocol.NewCodedError(protocol.CodeOperationExceedsMaximumSize,
+			fmt.Errorf("request body size[%d] exceeds maximum operation size[%d]", size, maxSize)))
+}
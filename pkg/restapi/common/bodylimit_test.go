@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestOperationSizeLimit(t *testing.T) {
+	var received []byte
+
+	h := &mockHandler{path: "/operations", method: http.MethodPost, handle: func(rw http.ResponseWriter, req *http.Request) {
+		var err error
+		received, err = ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		rw.WriteHeader(http.StatusOK)
+	}}
+
+	pc := mocks.NewMockProtocolClient()
+	pc.CurrentVersion.ProtocolReturns(protocol.Protocol{MaxOperationSize: 10})
+
+	handle := WithMiddleware([]HTTPHandler{h}, OperationSizeLimit(pc))[0].Handler()
+
+	t.Run("success", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handle(rw, httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader("0123456789")))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "0123456789", string(received))
+	})
+
+	t.Run("error - content length exceeds maximum size", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handle(rw, httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader("01234567890")))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, protocol.CodeOperationExceedsMaximumSize, response.Code)
+		require.Equal(t, "request body size[11] exceeds maximum operation size[10]", response.Message)
+	})
+
+	t.Run("error - body exceeds maximum size (unknown content length)", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader("0123456789012345"))
+		req.ContentLength = -1
+
+		rw := httptest.NewRecorder()
+		handle(rw, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+		require.Contains(t, rw.Body.String(), "request body size[11] exceeds maximum operation size[10]")
+	})
+
+	t.Run("error - read error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", &failingReader{})
+		req.ContentLength = -1
+
+		rw := httptest.NewRecorder()
+		handle(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "read error")
+	})
+
+	t.Run("error - protocol error", func(t *testing.T) {
+		pcErr := mocks.NewMockProtocolClient()
+		pcErr.Err = errors.New("protocol error")
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, OperationSizeLimit(pcErr))[0].Handler()(rw,
+			httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader("0123")))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.Contains(t, rw.Body.String(), "protocol error")
+	})
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
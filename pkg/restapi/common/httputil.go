@@ -9,11 +9,27 @@ package common
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 )
 
+// ErrorResponse is error response with machine-readable error code (e.g. did_not_found) and human-readable message.
+// For invalid requests pointer identifies the invalid field of the request (e.g. /delta/patches/0/publicKeys/1/purposes).
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Pointer string `json:"pointer,omitempty"`
+}
+
 // WriteResponse writes a response to the response writer.
 func WriteResponse(rw http.ResponseWriter, status int, v interface{}) {
-	rw.Header().Set("Content-Type", "application/did+ld+json")
+	WriteResponseWithContentType(rw, status, "application/did+ld+json", v)
+}
+
+// WriteResponseWithContentType writes a response with the given content type to the response writer.
+func WriteResponseWithContentType(rw http.ResponseWriter, status int, contentType string, v interface{}) {
+	rw.Header().Set("Content-Type", contentType)
 	rw.WriteHeader(status)
 	err := json.NewEncoder(rw).Encode(v)
 	if err != nil {
@@ -21,14 +37,42 @@ func WriteResponse(rw http.ResponseWriter, status int, v interface{}) {
 	}
 }
 
-// WriteError writes an error to the response writer.
+// WriteError writes an error response (JSON) to the response writer. Error code is taken from the error (if it
+// has one) or derived from the status code.
 func WriteError(rw http.ResponseWriter, status int, err error) {
 	logger.Warnf("returning error status: %d, message: %s", status, err.Error())
 
-	rw.Header().Set("Content-Type", "text/plain")
+	rw.Header().Set("Content-Type", "application/json")
 	rw.WriteHeader(status)
-	_, e := rw.Write([]byte(err.Error()))
+
+	encoder := json.NewEncoder(rw)
+	encoder.SetEscapeHTML(false)
+
+	e := encoder.Encode(NewErrorResponse(status, err))
 	if e != nil {
 		logger.Errorf("Unable to write response: %s", e)
 	}
 }
+
+// NewErrorResponse returns error response for the error; error code is taken from the error (if it has one)
+// or derived from the status code.
+func NewErrorResponse(status int, err error) *ErrorResponse {
+	code := protocol.GetCode(err)
+	if code == "" {
+		code = statusCode(status)
+	}
+
+	return &ErrorResponse{Code: code, Message: err.Error(), Pointer: protocol.GetPointer(err)}
+}
+
+// statusCode returns error code for the given HTTP status (e.g. not_found for 404).
+func statusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return protocol.CodeBadRequest
+	case http.StatusInternalServerError:
+		return protocol.CodeInternalError
+	default:
+		return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	}
+}
@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 )
 
 func TestWriteResponse(t *testing.T) {
@@ -23,10 +26,64 @@ func TestWriteResponse(t *testing.T) {
 	require.Equal(t, "application/did+ld+json", rw.Header().Get("content-type"))
 }
 
-func TestWriteError(t *testing.T) {
+func TestWriteResponseWithContentType(t *testing.T) {
 	rw := httptest.NewRecorder()
-	errExpected := errors.New("some error")
-	WriteError(rw, http.StatusBadRequest, errExpected)
-	require.Equal(t, http.StatusBadRequest, rw.Code)
-	require.Equal(t, errExpected.Error(), rw.Body.String())
+	WriteResponseWithContentType(rw, http.StatusOK, "application/json", "content")
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Equal(t, "\"content\"\n", rw.Body.String())
+	require.Equal(t, "application/json", rw.Header().Get("content-type"))
+}
+
+func TestWriteError(t *testing.T) {
+	t.Run("code from status", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		errExpected := errors.New("some error")
+		WriteError(rw, http.StatusBadRequest, errExpected)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Equal(t, "application/json", rw.Header().Get("content-type"))
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, protocol.CodeBadRequest, response.Code)
+		require.Equal(t, errExpected.Error(), response.Message)
+
+		rw = httptest.NewRecorder()
+		WriteError(rw, http.StatusInternalServerError, errExpected)
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, protocol.CodeInternalError, response.Code)
+
+		rw = httptest.NewRecorder()
+		WriteError(rw, http.StatusNotFound, errExpected)
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, "not_found", response.Code)
+	})
+
+	t.Run("code from error", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		err := NewHTTPError(http.StatusBadRequest,
+			protocol.NewCodedError(protocol.CodeDeltaExceedsMaximumSize, errors.New("delta size[2] exceeds maximum delta size[1]")))
+		WriteError(rw, err.Status(), err)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, protocol.CodeDeltaExceedsMaximumSize, response.Code)
+		require.Equal(t, "delta size[2] exceeds maximum delta size[1]", response.Message)
+		require.Empty(t, response.Pointer)
+		require.NotContains(t, rw.Body.String(), "pointer")
+	})
+	t.Run("pointer from error", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		err := NewHTTPError(http.StatusBadRequest,
+			protocol.NewCodedError(protocol.CodeBadRequest,
+				protocol.NewFieldError("/delta/patches/0/publicKeys/1/purposes", errors.New("invalid purpose"))))
+		WriteError(rw, err.Status(), err)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, protocol.CodeBadRequest, response.Code)
+		require.Equal(t, "invalid purpose", response.Message)
+		require.Equal(t, "/delta/patches/0/publicKeys/1/purposes", response.Pointer)
+	})
 }
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/metrics"
+)
+
+// Instrument returns handlers that record request count, status code and duration of each request
+// with the metrics provider (handler is identified by method and path, e.g. "GET /identifiers/{id}").
+func Instrument(provider metrics.Provider, handlers ...HTTPHandler) []HTTPHandler {
+	return WithMiddleware(handlers, Metrics(provider))
+}
+
+// statusWriter captures response status code.
+type statusWriter struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
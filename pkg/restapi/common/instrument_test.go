@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrument(t *testing.T) {
+	provider := &mockMetricsProvider{}
+
+	handlers := Instrument(provider,
+		&mockHandler{path: "/ok", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			WriteResponse(rw, http.StatusOK, "ok")
+		}},
+		&mockHandler{path: "/implicit", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			_, err := rw.Write([]byte("ok"))
+			require.NoError(t, err)
+		}},
+		&mockHandler{path: "/error", method: http.MethodPost, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			WriteError(rw, http.StatusBadRequest, errors.New("bad request"))
+		}},
+	)
+	require.Len(t, handlers, 3)
+
+	require.Equal(t, "/ok", handlers[0].Path())
+	require.Equal(t, http.MethodGet, handlers[0].Method())
+
+	for _, h := range handlers {
+		rw := httptest.NewRecorder()
+		h.Handler()(rw, httptest.NewRequest(h.Method(), h.Path(), nil))
+	}
+
+	require.Len(t, provider.requests, 3)
+	require.Equal(t, request{handler: "GET /ok", status: http.StatusOK}, provider.requests[0])
+	require.Equal(t, request{handler: "GET /implicit", status: http.StatusOK}, provider.requests[1])
+	require.Equal(t, request{handler: "POST /error", status: http.StatusBadRequest}, provider.requests[2])
+}
+
+type request struct {
+	handler string
+	status  int
+}
+
+type mockMetricsProvider struct {
+	requests []request
+}
+
+func (m *mockMetricsProvider) HTTPRequest(handler string, status int, _ time.Duration) {
+	m.requests = append(m.requests, request{handler: handler, status: status})
+}
+
+type mockHandler struct {
+	path   string
+	method string
+	handle HTTPRequestHandler
+}
+
+func (h *mockHandler) Path() string {
+	return h.path
+}
+
+func (h *mockHandler) Method() string {
+	return h.method
+}
+
+func (h *mockHandler) Handler() HTTPRequestHandler {
+	return h.handle
+}
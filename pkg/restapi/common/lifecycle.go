@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const defaultShutdownRetryAfter = 30 * time.Second
+
+// ShutdownHook is invoked during shutdown after in-flight requests have completed (e.g. batch writer
+// flush, so that operations accepted before shutdown are anchored).
+type ShutdownHook func() error
+
+// LifecycleOption is an option for lifecycle.
+type LifecycleOption func(opts *Lifecycle)
+
+// WithRetryAfter sets Retry-After value returned with requests rejected during shutdown (default is 30s).
+func WithRetryAfter(retryAfter time.Duration) LifecycleOption {
+	return func(opts *Lifecycle) {
+		opts.retryAfter = retryAfter
+	}
+}
+
+// WithShutdownHook adds hook that is invoked during shutdown (hooks are invoked in the order they were added).
+func WithShutdownHook(hook ShutdownHook) LifecycleOption {
+	return func(opts *Lifecycle) {
+		opts.hooks = append(opts.hooks, hook)
+	}
+}
+
+// Lifecycle coordinates graceful shutdown of REST handlers: once shutdown starts handlers wrapped with
+// RejectOnShutdown middleware (operation submissions) respond with 503, while requests that are already
+// in-flight complete and handlers wrapped with TrackRequests middleware (e.g. resolutions) keep serving.
+type Lifecycle struct {
+	mutex      sync.Mutex
+	stopped    bool
+	inFlight   int
+	idle       chan struct{}
+	retryAfter time.Duration
+	hooks      []ShutdownHook
+}
+
+// NewLifecycle returns a new lifecycle.
+func NewLifecycle(opts ...LifecycleOption) *Lifecycle {
+	l := &Lifecycle{
+		retryAfter: defaultShutdownRetryAfter,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Stopped returns true if shutdown has started (e.g. used by readiness check).
+func (l *Lifecycle) Stopped() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.stopped
+}
+
+// Shutdown stops accepting new operation submissions, waits for requests that are in-flight when shutdown
+// starts to complete and invokes shutdown hooks. An error is returned if the context is done before in-flight requests
+// have completed or if any of the hooks fails.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.mutex.Lock()
+
+	if l.stopped {
+		l.mutex.Unlock()
+
+		return errors.New("shutdown already started")
+	}
+
+	l.stopped = true
+
+	idle := make(chan struct{})
+	if l.inFlight == 0 {
+		close(idle)
+	} else {
+		l.idle = idle
+	}
+
+	l.mutex.Unlock()
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for in-flight requests: %s", ctx.Err().Error())
+	}
+
+	for _, hook := range l.hooks {
+		if err := hook(); err != nil {
+			return fmt.Errorf("shutdown hook: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// RejectOnShutdown returns middleware that rejects requests with 503 (and Retry-After header) once
+// shutdown has started; accepted requests are tracked as in-flight.
+func (l *Lifecycle) RejectOnShutdown() Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			if !l.begin() {
+				rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(l.retryAfter.Seconds()))))
+
+				WriteError(rw, http.StatusServiceUnavailable,
+					fmt.Errorf("service is shutting down, [%s %s] is not available", handler.Method(), handler.Path()))
+
+				return
+			}
+
+			defer l.end()
+
+			next(rw, req)
+		}
+	}
+}
+
+// TrackRequests returns middleware that tracks requests as in-flight (shutdown waits for them to complete)
+// without rejecting requests during shutdown. Requests that arrive after shutdown has started are served
+// but not tracked, so that shutdown completes under continuous load.
+func (l *Lifecycle) TrackRequests() Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			if l.begin() {
+				defer l.end()
+			}
+
+			next(rw, req)
+		}
+	}
+}
+
+// begin tracks request as in-flight; false is returned (and request is not tracked) once shutdown has started.
+func (l *Lifecycle) begin() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.stopped {
+		return false
+	}
+
+	l.inFlight++
+
+	return true
+}
+
+func (l *Lifecycle) end() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inFlight--
+
+	if l.inFlight == 0 && l.idle != nil {
+		close(l.idle)
+		l.idle = nil
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var flushed bool
+
+		l := NewLifecycle(WithRetryAfter(10*time.Second), WithShutdownHook(func() error {
+			flushed = true
+
+			return nil
+		}))
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		submit := WithMiddleware([]HTTPHandler{&mockHandler{path: "/operations", method: http.MethodPost,
+			handle: func(rw http.ResponseWriter, _ *http.Request) {
+				close(started)
+				<-release
+				rw.WriteHeader(http.StatusOK)
+			}}}, l.RejectOnShutdown())[0].Handler()
+
+		resolve := WithMiddleware([]HTTPHandler{&mockHandler{path: "/identifiers/{id}", method: http.MethodGet,
+			handle: func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}}}, l.TrackRequests())[0].Handler()
+
+		inFlight := httptest.NewRecorder()
+
+		go submit(inFlight, httptest.NewRequest(http.MethodPost, "/operations", nil))
+
+		<-started
+
+		shutdownErr := make(chan error)
+
+		go func() {
+			shutdownErr <- l.Shutdown(context.Background())
+		}()
+
+		require.Eventually(t, l.Stopped, time.Second, 10*time.Millisecond)
+
+		// new submissions are rejected
+		rw := httptest.NewRecorder()
+		submit(rw, httptest.NewRequest(http.MethodPost, "/operations", nil))
+		require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+		require.Equal(t, "10", rw.Header().Get("Retry-After"))
+		require.Contains(t, rw.Body.String(), `"code":"service_unavailable"`)
+
+		// resolutions are still served
+		rw = httptest.NewRecorder()
+		resolve(rw, httptest.NewRequest(http.MethodGet, "/identifiers/abc", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		select {
+		case <-shutdownErr:
+			t.Fatal("shutdown completed before in-flight request")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+
+		require.NoError(t, <-shutdownErr)
+		require.Equal(t, http.StatusOK, inFlight.Code)
+		require.True(t, flushed)
+
+		err := l.Shutdown(context.Background())
+		require.EqualError(t, err, "shutdown already started")
+	})
+
+	t.Run("success - shutdown completes under continuous load", func(t *testing.T) {
+		var flushed int32
+
+		l := NewLifecycle(WithShutdownHook(func() error {
+			atomic.StoreInt32(&flushed, 1)
+
+			return nil
+		}))
+
+		resolve := WithMiddleware([]HTTPHandler{&mockHandler{path: "/identifiers/{id}", method: http.MethodGet,
+			handle: func(rw http.ResponseWriter, _ *http.Request) {
+				time.Sleep(time.Millisecond)
+				rw.WriteHeader(http.StatusOK)
+			}}}, l.TrackRequests())[0].Handler()
+
+		done := make(chan struct{})
+		defer close(done)
+
+		// overlapping requests keep number of requests in-flight above zero
+		for i := 0; i < 10; i++ {
+			go func() {
+				for {
+					select {
+					case <-done:
+						return
+					default:
+						resolve(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/identifiers/abc", nil))
+					}
+				}
+			}()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		require.NoError(t, l.Shutdown(ctx))
+		require.Equal(t, int32(1), atomic.LoadInt32(&flushed))
+
+		// requests are still served after shutdown
+		rw := httptest.NewRecorder()
+		resolve(rw, httptest.NewRequest(http.MethodGet, "/identifiers/abc", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("error - context done", func(t *testing.T) {
+		l := NewLifecycle()
+		require.True(t, l.begin())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := l.Shutdown(ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "waiting for in-flight requests")
+	})
+
+	t.Run("error - hook", func(t *testing.T) {
+		l := NewLifecycle(WithShutdownHook(func() error { return errors.New("flush error") }))
+
+		err := l.Shutdown(context.Background())
+		require.EqualError(t, err, "shutdown hook: flush error")
+	})
+}
@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/metrics"
+)
+
+// Middleware wraps request handler (e.g. authorization, CORS, request logging, panic recovery). Handler
+// descriptor gives middleware access to handler metadata (method and path).
+type Middleware func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler
+
+// Chain applies middleware to the request handler of the given handler descriptor; the first middleware
+// is the outermost one (it is invoked first).
+func Chain(handler HTTPHandler, next HTTPRequestHandler, middleware ...Middleware) HTTPRequestHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		next = middleware[i](handler, next)
+	}
+
+	return next
+}
+
+// WithMiddleware returns handlers with middleware applied (used for handlers that don't accept
+// middleware options).
+func WithMiddleware(handlers []HTTPHandler, middleware ...Middleware) []HTTPHandler {
+	wrapped := make([]HTTPHandler, len(handlers))
+
+	for i, h := range handlers {
+		wrapped[i] = &middlewareHandler{HTTPHandler: h, middleware: middleware}
+	}
+
+	return wrapped
+}
+
+type middlewareHandler struct {
+	HTTPHandler
+
+	middleware []Middleware
+}
+
+// Handler returns the request handler with middleware applied.
+func (h *middlewareHandler) Handler() HTTPRequestHandler {
+	return Chain(h.HTTPHandler, h.HTTPHandler.Handler(), h.middleware...)
+}
+
+// Recovery returns middleware that recovers from panics in the request handler and responds with 500.
+func Recovery() Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Errorf("recovered from panic in [%s %s]: %v", handler.Method(), handler.Path(), r)
+
+					WriteError(rw, http.StatusInternalServerError, errors.New("internal server error"))
+				}
+			}()
+
+			next(rw, req)
+		}
+	}
+}
+
+// RequestLogging returns middleware that logs method, path, response status and duration of each request.
+func RequestLogging() Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+
+			sw := &statusWriter{ResponseWriter: rw, status: http.StatusOK}
+
+			next(sw, req)
+
+			logger.Infof("[%s %s] %s: status %d in %s",
+				handler.Method(), handler.Path(), req.URL.RequestURI(), sw.status, time.Since(start))
+		}
+	}
+}
+
+// CORS returns middleware that sets CORS headers for requests from allowed origins ("*" allows any origin).
+func CORS(allowedOrigins ...string) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+
+			if origin != "" && isOriginAllowed(allowedOrigins, origin) {
+				rw.Header().Set("Access-Control-Allow-Origin", origin)
+				rw.Header().Set("Access-Control-Allow-Methods", handler.Method())
+				rw.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				rw.Header().Add("Vary", "Origin")
+			}
+
+			next(rw, req)
+		}
+	}
+}
+
+// BearerTokenAuth returns middleware that rejects (401) requests without one of the given bearer tokens
+// in Authorization header.
+func BearerTokenAuth(tokens ...string) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			if !isAuthorized(req.Header.Get("Authorization"), tokens) {
+				WriteError(rw, http.StatusUnauthorized,
+					fmt.Errorf("unauthorized request to [%s %s]", handler.Method(), handler.Path()))
+
+				return
+			}
+
+			next(rw, req)
+		}
+	}
+}
+
+// Metrics returns middleware that records request status code and duration with the metrics provider
+// (handler is identified by method and path, e.g. "GET /identifiers/{id}").
+func Metrics(provider metrics.Provider) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		name := handler.Method() + " " + handler.Path()
+
+		return func(rw http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+
+			sw := &statusWriter{ResponseWriter: rw, status: http.StatusOK}
+
+			next(sw, req)
+
+			provider.HTTPRequest(name, sw.status, time.Since(start))
+		}
+	}
+}
+
+// CacheControl returns middleware that sets Cache-Control header of successful (200) and not modified (304)
+// responses (e.g. "public, max-age=60" allows HTTP caches to serve resolution responses without revalidation).
+func CacheControl(value string) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			next(&cacheControlWriter{ResponseWriter: rw, value: value}, req)
+		}
+	}
+}
+
+type cacheControlWriter struct {
+	http.ResponseWriter
+
+	value       string
+	wroteHeader bool
+}
+
+// WriteHeader sets Cache-Control header before writing the status.
+func (w *cacheControlWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+
+	if status == http.StatusOK || status == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.value)
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes the status (200 if not written yet) and the data.
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func isOriginAllowed(allowedOrigins []string, origin string) bool {
+	for _, allowed := range allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAuthorized(authHeader string, tokens []string) bool {
+	const prefix = "Bearer "
+
+	if !strings.HasPrefix(authHeader, prefix) {
+		return false
+	}
+
+	actual := []byte(strings.TrimPrefix(authHeader, prefix))
+
+	for _, token := range tokens {
+		if subtle.ConstantTimeCompare(actual, []byte(token)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
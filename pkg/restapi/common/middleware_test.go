@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+
+	record := func(name string) Middleware {
+		return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+			return func(rw http.ResponseWriter, req *http.Request) {
+				calls = append(calls, name+":"+handler.Path())
+				next(rw, req)
+			}
+		}
+	}
+
+	h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+		calls = append(calls, "handler")
+	}}
+
+	handlers := WithMiddleware([]HTTPHandler{h}, record("first"), record("second"))
+	require.Len(t, handlers, 1)
+	require.Equal(t, "/test", handlers[0].Path())
+	require.Equal(t, http.MethodGet, handlers[0].Method())
+
+	handlers[0].Handler()(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.Equal(t, []string{"first:/test", "second:/test", "handler"}, calls)
+}
+
+func TestRecovery(t *testing.T) {
+	h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(http.ResponseWriter, *http.Request) {
+		panic("unexpected")
+	}}
+
+	rw := httptest.NewRecorder()
+	WithMiddleware([]HTTPHandler{h}, Recovery())[0].Handler()(rw, httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.Equal(t, http.StatusInternalServerError, rw.Code)
+	require.Contains(t, rw.Body.String(), "internal server error")
+}
+
+func TestRequestLogging(t *testing.T) {
+	h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}}
+
+	rw := httptest.NewRecorder()
+	WithMiddleware([]HTTPHandler{h}, RequestLogging())[0].Handler()(rw, httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.Equal(t, http.StatusNotFound, rw.Code)
+}
+
+func TestCORS(t *testing.T) {
+	h := &mockHandler{path: "/test", method: http.MethodPost, handle: func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Origin", "https://example.com")
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CORS("https://example.com"))[0].Handler()(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "https://example.com", rw.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, http.MethodPost, rw.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("any origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Origin", "https://other.com")
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CORS("*"))[0].Handler()(rw, req)
+		require.Equal(t, "https://other.com", rw.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("origin not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Origin", "https://other.com")
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CORS("https://example.com"))[0].Handler()(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Empty(t, rw.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestBearerTokenAuth(t *testing.T) {
+	h := &mockHandler{path: "/test", method: http.MethodPost, handle: func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}}
+
+	handle := WithMiddleware([]HTTPHandler{h}, BearerTokenAuth("token1", "token2"))[0].Handler()
+
+	t.Run("authorized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Authorization", "Bearer token2")
+
+		rw := httptest.NewRecorder()
+		handle(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("error - invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Authorization", "Bearer token3")
+
+		rw := httptest.NewRecorder()
+		handle(rw, req)
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+		require.Contains(t, rw.Body.String(), "unauthorized request to [POST /test]")
+	})
+
+	t.Run("error - missing token", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handle(rw, httptest.NewRequest(http.MethodPost, "/test", nil))
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+}
+
+func TestCacheControl(t *testing.T) {
+	t.Run("success response", func(t *testing.T) {
+		h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header().Set("Cache-Control", "no-cache")
+			_, err := rw.Write([]byte("data"))
+			require.NoError(t, err)
+		}}
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CacheControl("public, max-age=60"))[0].Handler()(rw, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "public, max-age=60", rw.Header().Get("Cache-Control"))
+		require.Equal(t, "data", rw.Body.String())
+	})
+
+	t.Run("not modified response", func(t *testing.T) {
+		h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusNotModified)
+		}}
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CacheControl("public, max-age=60"))[0].Handler()(rw, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusNotModified, rw.Code)
+		require.Equal(t, "public, max-age=60", rw.Header().Get("Cache-Control"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		h := &mockHandler{path: "/test", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+			_, err := rw.Write([]byte("not found"))
+			require.NoError(t, err)
+		}}
+
+		rw := httptest.NewRecorder()
+		WithMiddleware([]HTTPHandler{h}, CacheControl("public, max-age=60"))[0].Handler()(rw, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		require.Empty(t, rw.Header().Get("Cache-Control"))
+	})
+}
@@ -0,0 +1,299 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultMaxRateLimitKeys = 10000
+
+	// maxOperationKeyBodySize is maximum size of request body that is read in order to get DID suffix
+	// of the operation; larger requests are not limited by DID (they are rejected by OperationSizeLimit).
+	maxOperationKeyBodySize = 1 << 20
+)
+
+// KeyFunc returns the key that requests are rate limited by (e.g. client IP or DID); requests with
+// empty key are not limited.
+type KeyFunc func(req *http.Request) string
+
+// ByIP returns client IP (from remote address of the request).
+func ByIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// ByForwardedIP returns client IP from X-Forwarded-For header (first address) or from remote address
+// of the request if the header is not set; it should only be used if the node is behind a trusted proxy.
+func ByForwardedIP(req *http.Request) string {
+	if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	return ByIP(req)
+}
+
+// ByDID returns DID from 'id' path variable or 'did' query parameter (see ByOperationDID for operation requests,
+// which contain DID suffix in the request body).
+func ByDID(req *http.Request) string {
+	if id := mux.Vars(req)["id"]; id != "" {
+		return id
+	}
+
+	return req.URL.Query().Get("did")
+}
+
+// ByOperationDID returns DID suffix of update, recover and deactivate operation from the request body
+// ('didSuffix' property); create operations (and requests that are not operations) are not limited by DID.
+// The body is restored so that it can be read by the next handler.
+func ByOperationDID(req *http.Request) string {
+	if req.Body == nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxOperationKeyBodySize+1))
+
+	req.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+
+	if err != nil || len(body) > maxOperationKeyBodySize {
+		return ""
+	}
+
+	var op struct {
+		DidSuffix string `json:"didSuffix"`
+	}
+
+	if err := json.Unmarshal(body, &op); err != nil {
+		return ""
+	}
+
+	return op.DidSuffix
+}
+
+// RateLimiterOption is an option for rate limiter.
+type RateLimiterOption func(opts *RateLimiter)
+
+// WithMaxKeys sets maximum number of tracked keys (least recently used key is evicted once the maximum is reached).
+func WithMaxKeys(max int) RateLimiterOption {
+	return func(opts *RateLimiter) {
+		opts.maxKeys = max
+	}
+}
+
+// RateLimiter limits rate of requests per key using token bucket algorithm: each key may make burst
+// requests at once and the bucket is refilled with the given number of requests per second.
+type RateLimiter struct {
+	mutex   sync.Mutex
+	name    string
+	rate    float64
+	burst   float64
+	key     KeyFunc
+	buckets map[string]*list.Element
+	lru     *list.List
+	maxKeys int
+	now     func() time.Time
+}
+
+type bucket struct {
+	key     string
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter returns a new rate limiter (name is used in error messages, e.g. "ip" or "did").
+func NewRateLimiter(name string, requestsPerSecond float64, burst int, key KeyFunc,
+	opts ...RateLimiterOption) (*RateLimiter, error) {
+	l := &RateLimiter{
+		name:    name,
+		rate:    requestsPerSecond,
+		burst:   float64(burst),
+		key:     key,
+		buckets: make(map[string]*list.Element),
+		lru:     list.New(),
+		maxKeys: defaultMaxRateLimitKeys,
+		now:     time.Now,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if !(l.rate > 0) || math.IsInf(l.rate, 1) {
+		return nil, fmt.Errorf("rate limiter %s: requests per second must be greater than zero", name)
+	}
+
+	if burst < 1 {
+		return nil, fmt.Errorf("rate limiter %s: burst must be at least one", name)
+	}
+
+	if l.maxKeys < 1 {
+		return nil, fmt.Errorf("rate limiter %s: maximum number of keys must be at least one", name)
+	}
+
+	return l, nil
+}
+
+// Allow takes a token from the bucket of the given key; if the bucket is empty false is returned
+// along with the time until the next token is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	b := l.refill(key)
+
+	if b.tokens < 1 {
+		return false, l.retryAfter(b)
+	}
+
+	b.tokens--
+
+	return true, 0
+}
+
+// check returns whether a token is available in the bucket of the given key without taking it.
+func (l *RateLimiter) check(key string) (bool, time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	b := l.refill(key)
+
+	if b.tokens < 1 {
+		return false, l.retryAfter(b)
+	}
+
+	return true, 0
+}
+
+// refund returns a token taken by Allow to the bucket of the given key.
+func (l *RateLimiter) refund(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if e, ok := l.buckets[key]; ok {
+		if b, ok := e.Value.(*bucket); ok {
+			b.tokens = math.Min(l.burst, b.tokens+1)
+		}
+	}
+}
+
+// refill returns the bucket of the given key (creating it, and evicting the least recently used bucket
+// if the maximum number of keys is reached) with tokens refilled up to now.
+func (l *RateLimiter) refill(key string) *bucket {
+	now := l.now()
+
+	var b *bucket
+
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+
+		if v, ok := e.Value.(*bucket); ok {
+			b = v
+		}
+	}
+
+	if b == nil {
+		for l.lru.Len() >= l.maxKeys {
+			l.evict()
+		}
+
+		b = &bucket{key: key, tokens: l.burst, updated: now}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	return b
+}
+
+// evict removes the least recently used bucket.
+func (l *RateLimiter) evict() {
+	e := l.lru.Back()
+	l.lru.Remove(e)
+
+	if b, ok := e.Value.(*bucket); ok {
+		delete(l.buckets, b.key)
+	}
+}
+
+func (l *RateLimiter) retryAfter(b *bucket) time.Duration {
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// RateLimit returns middleware that rejects (429) requests exceeding the rate of any of the given
+// limiters; Retry-After header contains number of seconds until the request may be retried. All limiters
+// are checked before a token is taken from any of them, so a rejected request doesn't consume tokens.
+func RateLimit(limiters ...*RateLimiter) Middleware {
+	return func(handler HTTPHandler, next HTTPRequestHandler) HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			keys := make([]string, len(limiters))
+
+			for i, l := range limiters {
+				keys[i] = l.key(req)
+				if keys[i] == "" {
+					continue
+				}
+
+				if allowed, retryAfter := l.check(keys[i]); !allowed {
+					writeRateLimitExceeded(rw, handler, l, keys[i], retryAfter)
+
+					return
+				}
+			}
+
+			for i, l := range limiters {
+				if keys[i] == "" {
+					continue
+				}
+
+				// token may have been taken by concurrent request since the check
+				if allowed, retryAfter := l.Allow(keys[i]); !allowed {
+					for j := 0; j < i; j++ {
+						if keys[j] != "" {
+							limiters[j].refund(keys[j])
+						}
+					}
+
+					writeRateLimitExceeded(rw, handler, l, keys[i], retryAfter)
+
+					return
+				}
+			}
+
+			next(rw, req)
+		}
+	}
+}
+
+func writeRateLimitExceeded(rw http.ResponseWriter, handler HTTPHandler, l *RateLimiter, key string,
+	retryAfter time.Duration) {
+	logger.Debugf("rate limit exceeded for %s [%s] on [%s %s]", l.name, key, handler.Method(), handler.Path())
+
+	rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	WriteError(rw, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded for %s", l.name))
+}
@@ -0,0 +1,282 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Now()
+
+	l, err := NewRateLimiter("ip", 2, 2, ByIP)
+	require.NoError(t, err)
+
+	l.now = func() time.Time { return now }
+
+	allowed, _ := l.Allow("key1")
+	require.True(t, allowed)
+
+	allowed, _ = l.Allow("key1")
+	require.True(t, allowed)
+
+	allowed, retryAfter := l.Allow("key1")
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// other keys have their own bucket
+	allowed, _ = l.Allow("key2")
+	require.True(t, allowed)
+
+	// bucket is refilled over time
+	now = now.Add(500 * time.Millisecond)
+
+	allowed, _ = l.Allow("key1")
+	require.True(t, allowed)
+
+	allowed, _ = l.Allow("key1")
+	require.False(t, allowed)
+}
+
+func TestRateLimiter_MaxKeys(t *testing.T) {
+	now := time.Now()
+
+	l, err := NewRateLimiter("ip", 1, 1, ByIP, WithMaxKeys(2))
+	require.NoError(t, err)
+
+	l.now = func() time.Time { return now }
+
+	l.Allow("key1")
+	l.Allow("key2")
+
+	// key1 is used more recently than key2
+	allowed, _ := l.Allow("key1")
+	require.False(t, allowed)
+
+	// least recently used key is evicted (even if its bucket is not refilled)
+	allowed, _ = l.Allow("key3")
+	require.True(t, allowed)
+	require.Len(t, l.buckets, 2)
+	require.Contains(t, l.buckets, "key1")
+	require.Contains(t, l.buckets, "key3")
+
+	// number of keys never exceeds maximum
+	for i := 0; i < 100; i++ {
+		l.Allow(fmt.Sprintf("other-%d", i))
+		require.Len(t, l.buckets, 2)
+		require.Equal(t, 2, l.lru.Len())
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Run("error - invalid rate", func(t *testing.T) {
+		for _, rate := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+			l, err := NewRateLimiter("ip", rate, 1, ByIP)
+			require.Error(t, err)
+			require.Nil(t, l)
+			require.Contains(t, err.Error(), "rate limiter ip: requests per second must be greater than zero")
+		}
+	})
+
+	t.Run("error - invalid burst", func(t *testing.T) {
+		l, err := NewRateLimiter("ip", 1, 0, ByIP)
+		require.Error(t, err)
+		require.Nil(t, l)
+		require.Contains(t, err.Error(), "rate limiter ip: burst must be at least one")
+	})
+
+	t.Run("error - invalid maximum number of keys", func(t *testing.T) {
+		l, err := NewRateLimiter("ip", 1, 1, ByIP, WithMaxKeys(0))
+		require.Error(t, err)
+		require.Nil(t, l)
+		require.Contains(t, err.Error(), "rate limiter ip: maximum number of keys must be at least one")
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	h := &mockHandler{path: "/test/{id}", method: http.MethodGet, handle: func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}}
+
+	byIP, err := NewRateLimiter("ip", 0.5, 1, ByIP)
+	require.NoError(t, err)
+
+	byDID, err := NewRateLimiter("did", 0.5, 1, ByDID)
+	require.NoError(t, err)
+
+	handler := WithMiddleware([]HTTPHandler{h}, RateLimit(byIP, byDID))[0].Handler()
+
+	newRequest := func(remoteAddr, id string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/test/"+id, nil)
+		req.RemoteAddr = remoteAddr
+
+		return mux.SetURLVars(req, map[string]string{"id": id})
+	}
+
+	rw := httptest.NewRecorder()
+	handler(rw, newRequest("10.0.0.1:1234", "did:sidetree:abc"))
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	t.Run("limited by ip", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handler(rw, newRequest("10.0.0.1:1235", "did:sidetree:def"))
+		require.Equal(t, http.StatusTooManyRequests, rw.Code)
+		require.Equal(t, "2", rw.Header().Get("Retry-After"))
+		require.Contains(t, rw.Body.String(), `"code":"too_many_requests"`)
+		require.Contains(t, rw.Body.String(), "rate limit exceeded for ip")
+	})
+
+	t.Run("limited by did", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handler(rw, newRequest("10.0.0.2:1234", "did:sidetree:abc"))
+		require.Equal(t, http.StatusTooManyRequests, rw.Code)
+		require.Contains(t, rw.Body.String(), "rate limit exceeded for did")
+
+		// request rejected by did limiter doesn't consume ip token
+		rw = httptest.NewRecorder()
+		handler(rw, newRequest("10.0.0.2:1234", "did:sidetree:ghi"))
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("token refunded if taken concurrently", func(t *testing.T) {
+		now := time.Now()
+
+		first, err := NewRateLimiter("first", 1, 1, ByIP)
+		require.NoError(t, err)
+
+		first.now = func() time.Time { return now }
+
+		second, err := NewRateLimiter("second", 1, 1, ByIP)
+		require.NoError(t, err)
+
+		// token of the second limiter is taken (by concurrent request) after it has been checked
+		second.now = func() time.Time {
+			if e, ok := second.buckets["10.0.0.4"]; ok {
+				if b, ok := e.Value.(*bucket); ok {
+					b.tokens = 0
+				}
+			}
+
+			return now
+		}
+
+		handler := WithMiddleware([]HTTPHandler{h}, RateLimit(first, second))[0].Handler()
+
+		rw := httptest.NewRecorder()
+		handler(rw, newRequest("10.0.0.4:1234", ""))
+		require.Equal(t, http.StatusTooManyRequests, rw.Code)
+		require.Contains(t, rw.Body.String(), "rate limit exceeded for second")
+
+		allowed, _ := first.Allow("10.0.0.4")
+		require.True(t, allowed)
+	})
+
+	t.Run("not limited", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handler(rw, newRequest("10.0.0.3:1234", ""))
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+}
+
+func TestKeyFuncs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?did=did:sidetree:abc", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+
+	require.Equal(t, "10.0.0.1", ByIP(req))
+	require.Equal(t, "10.0.0.1", ByForwardedIP(req))
+	require.Equal(t, "did:sidetree:abc", ByDID(req))
+
+	req.Header.Set("X-Forwarded-For", "192.168.1.1, 10.0.0.1")
+	require.Equal(t, "192.168.1.1", ByForwardedIP(req))
+
+	req.RemoteAddr = "invalid"
+	require.Equal(t, "invalid", ByIP(req))
+}
+
+func TestByOperationDID(t *testing.T) {
+	t.Run("success - update operation", func(t *testing.T) {
+		body := `{"type": "update", "didSuffix": "abc", "delta": {}}`
+
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(body))
+		require.Equal(t, "abc", ByOperationDID(req))
+
+		// body is restored for the next handler
+		restored, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(restored))
+	})
+
+	t.Run("not limited - create operation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(`{"type": "create"}`))
+		require.Empty(t, ByOperationDID(req))
+	})
+
+	t.Run("not limited - invalid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader("invalid"))
+		require.Empty(t, ByOperationDID(req))
+
+		req = httptest.NewRequest(http.MethodGet, "/operations", nil)
+		req.Body = nil
+		require.Empty(t, ByOperationDID(req))
+	})
+
+	t.Run("not limited - request body too large", func(t *testing.T) {
+		body := `{"didSuffix": "abc", "padding": "` + strings.Repeat("a", maxOperationKeyBodySize) + `"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(body))
+		require.Empty(t, ByOperationDID(req))
+
+		restored, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, string(restored))
+	})
+
+	t.Run("limited by did", func(t *testing.T) {
+		byDID, err := NewRateLimiter("did", 0.5, 1, ByOperationDID)
+		require.NoError(t, err)
+
+		var received []string
+
+		h := &mockHandler{path: "/operations", method: http.MethodPost,
+			handle: func(rw http.ResponseWriter, req *http.Request) {
+				body, err := ioutil.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				received = append(received, string(body))
+			}}
+
+		handler := WithMiddleware([]HTTPHandler{h}, RateLimit(byDID))[0].Handler()
+
+		body := `{"type": "update", "didSuffix": "abc"}`
+
+		rw := httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, []string{body}, received)
+
+		rw = httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/operations",
+			strings.NewReader(`{"type": "deactivate", "didSuffix": "abc"}`)))
+		require.Equal(t, http.StatusTooManyRequests, rw.Code)
+		require.Contains(t, rw.Body.String(), "rate limit exceeded for did")
+
+		rw = httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/operations",
+			strings.NewReader(`{"type": "update", "didSuffix": "def"}`)))
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+}
@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+// BulkUpdateHandler handles bulk submission of DID document operations.
+type BulkUpdateHandler struct {
+	*handler
+}
+
+// NewBulkUpdateHandler returns a new DID document bulk update handler.
+func NewBulkUpdateHandler(basePath string, processor dochandler.Processor, pc protocol.Client,
+	bulkOpts []dochandler.BulkOption, opts ...Option) *BulkUpdateHandler {
+	return &BulkUpdateHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/operations/bulk", basePath),
+			http.MethodPost,
+			dochandler.NewBulkUpdateHandler(processor, pc, bulkOpts...).BulkUpdate,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "submit-operations",
+			Summary:     "Submits multiple operations; each operation is processed independently.",
+			Request:     []interface{}{[]map[string]interface{}{}},
+			Responses: []*openapi.ResponseSpec{
+				{Status: http.StatusOK, Description: "per-operation results", Body: &dochandler.BulkResponse{}},
+			},
+		}),
+	}
+}
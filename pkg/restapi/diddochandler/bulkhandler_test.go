@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+func TestNewBulkUpdateHandler(t *testing.T) {
+	pc := newMockProtocolClient()
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	handler := NewBulkUpdateHandler(basePath, docHandler, pc, []dochandler.BulkOption{dochandler.WithMaxBulkOperations(1)})
+	require.Equal(t, basePath+"/operations/bulk", handler.Path())
+	require.Equal(t, http.MethodPost, handler.Method())
+	require.NotNil(t, handler.OpenAPISpec())
+
+	rw := httptest.NewRecorder()
+	handler.Handler()(rw, httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("[{}, {}]")))
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+}
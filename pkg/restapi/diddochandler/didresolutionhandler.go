@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+// DIDResolutionHandler resolves DIDs according to DID Resolution HTTP(S) binding.
+type DIDResolutionHandler struct {
+	*handler
+}
+
+// NewDIDResolutionHandler returns a new DID resolution handler (GET {basePath}/1.0/identifiers/{did}).
+func NewDIDResolutionHandler(basePath string, resolver dochandler.Resolver, opts ...Option) *DIDResolutionHandler {
+	return &DIDResolutionHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/1.0/identifiers/{id}", basePath),
+			http.MethodGet,
+			dochandler.NewDIDResolutionHandler(resolver).Resolve,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "did-resolution",
+			Summary:     "Resolves DID according to DID Resolution HTTP(S) binding.",
+			Responses: []*openapi.ResponseSpec{
+				{
+					Status:      http.StatusOK,
+					Description: "resolution result or DID document (application/did+ld+json)",
+					Body:        &document.ResolutionResult{},
+					ContentType: document.ContentTypeDIDResolution,
+				},
+				{
+					Status:      http.StatusGone,
+					Description: "DID document was deactivated",
+					Body:        &document.ResolutionResult{},
+					ContentType: document.ContentTypeDIDResolution,
+				},
+			},
+		}),
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestNewDIDResolutionHandler(t *testing.T) {
+	handler := NewDIDResolutionHandler(basePath, mocks.NewMockDocumentHandler().WithNamespace(namespace))
+	require.Equal(t, basePath+"/1.0/identifiers/{id}", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+}
@@ -35,6 +35,38 @@ package diddochandler
 //    default: error
 //        200: response
 
+// Operations swagger:route GET /operations get-did-operations operationsParams
+// Returns operation history of a DID document.
+// Responses:
+//    default: error
+//        200: response
+
+// Status swagger:route GET /operations/status get-operation-status statusParams
+// Returns status (queued, written, anchored or failed) of submitted operations.
+// Responses:
+//    default: error
+//        200: response
+
+// LongFormDID swagger:route POST /long-form-did compute-long-form-did request
+// Returns short-form DID, long-form DID and initial state for create request (operation is not submitted).
+// Responses:
+//    default: error
+//        200: response
+
+// Versions swagger:route GET /versions get-protocol-versions
+// Returns supported protocol versions and their parameters.
+// Responses:
+//    default: error
+//        200: response
+
+// DIDResolution swagger:route GET /1.0/identifiers/{id} did-resolution resolveDocParams
+// Resolves DID according to DID Resolution HTTP(S) binding.
+// Responses:
+//    default: error
+//        200: response
+//        406: error
+//        410: response
+
 // Contains the request.
 //swagger:parameters request
 //nolint:deadcode,unused
@@ -80,3 +112,53 @@ type resolveDocumentParams struct {
 	// required: true
 	ID string `json:"id"`
 }
+
+// operationsParams model
+// This is used for getting operation history of DID document
+//
+//swagger:parameters operationsParams
+//nolint:deadcode,unused
+type operationsParams struct {
+	// The DID.
+	//
+	// in: query
+	// required: true
+	DID string `json:"did"`
+
+	// Maximum number of operations in the page.
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// Cursor of the page (returned as nextCursor with the previous page).
+	//
+	// in: query
+	Cursor string `json:"cursor"`
+}
+
+// statusParams model
+// This is used for getting status of submitted operations
+//
+//swagger:parameters statusParams
+//nolint:deadcode,unused
+type statusParams struct {
+	// The DID (status of the latest operation submitted for the DID).
+	//
+	// in: query
+	DID string `json:"did"`
+
+	// The anchor string (status of operations in the batch).
+	//
+	// in: query
+	Anchor string `json:"anchor"`
+
+	// Maximum number of batch operations in the page.
+	//
+	// in: query
+	Limit int `json:"limit"`
+
+	// Cursor of the page (returned as nextCursor with the previous page).
+	//
+	// in: query
+	Cursor string `json:"cursor"`
+}
@@ -8,21 +8,49 @@ package diddochandler
 
 import (
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
 )
 
+// Option is an option for DID document handlers.
+type Option func(opts *handler)
+
+// WithMiddleware sets middleware chain (e.g. authorization, CORS, request logging, panic recovery) that
+// is applied to the request handler; the first middleware is the outermost one.
+func WithMiddleware(middleware ...common.Middleware) Option {
+	return func(opts *handler) {
+		opts.middleware = append(opts.middleware, middleware...)
+	}
+}
+
 // handler resolves DID documents.
 type handler struct {
 	path       string
 	method     string
 	reqHandler common.HTTPRequestHandler
+	middleware []common.Middleware
+	spec       *openapi.Spec
 }
 
-func newHandler(path, method string, reqHandler common.HTTPRequestHandler) *handler {
-	return &handler{
-		path:       path,
-		method:     method,
-		reqHandler: reqHandler,
+func newHandler(path, method string, reqHandler common.HTTPRequestHandler, opts ...Option) *handler {
+	h := &handler{
+		path:   path,
+		method: method,
 	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.reqHandler = common.Chain(h, reqHandler, h.middleware...)
+
+	return h
+}
+
+func (h *handler) withSpec(spec *openapi.Spec) *handler {
+	h.spec = spec
+
+	return h
 }
 
 // Path returns the context path.
@@ -39,3 +67,8 @@ func (h *handler) Method() string {
 func (h *handler) Handler() common.HTTPRequestHandler {
 	return h.reqHandler
 }
+
+// OpenAPISpec returns OpenAPI operation details.
+func (h *handler) OpenAPISpec() *openapi.Spec {
+	return h.spec
+}
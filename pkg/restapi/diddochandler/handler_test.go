@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var paths []string
+
+	record := func(handler common.HTTPHandler, next common.HTTPRequestHandler) common.HTTPRequestHandler {
+		return func(rw http.ResponseWriter, req *http.Request) {
+			paths = append(paths, handler.Method()+" "+handler.Path())
+			next(rw, req)
+		}
+	}
+
+	handler := NewOperationsHandler(basePath, namespace, mocks.NewMockOperationStore(nil),
+		WithMiddleware(record, common.BearerTokenAuth("token")))
+
+	rw := httptest.NewRecorder()
+	handler.Handler()(rw, httptest.NewRequest(http.MethodGet, "/document/operations?did="+namespace+":abc", nil))
+	require.Equal(t, http.StatusUnauthorized, rw.Code)
+	require.Equal(t, []string{"GET " + basePath + "/operations"}, paths)
+
+	req := httptest.NewRequest(http.MethodGet, "/document/operations?did="+namespace+":abc", nil)
+	req.Header.Set("Authorization", "Bearer token")
+
+	rw = httptest.NewRecorder()
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusNotFound, rw.Code)
+	require.Len(t, paths, 2)
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	pc := newMockProtocolClient()
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	doc := openapi.Generate(openapi.Info{Title: "DID document API", Version: "0.1.0"},
+		NewUpdateHandler(basePath, docHandler, pc),
+		NewResolveHandler(basePath, docHandler),
+		NewDIDResolutionHandler(basePath, docHandler),
+		NewOperationsHandler(basePath, namespace, mocks.NewMockOperationStore(nil)),
+		NewVersionsHandler(basePath, namespace, nil),
+	)
+
+	require.Len(t, doc.Paths, 4)
+
+	update := doc.Paths[basePath+"/operations"]["post"]
+	require.Equal(t, "submit-operation", update.OperationID)
+	require.Len(t, update.RequestBody.Content[document.ContentTypeDIDLDJSON].Schema.OneOf, 4)
+
+	operations := doc.Paths[basePath+"/operations"]["get"]
+	require.Equal(t, "get-did-operations", operations.OperationID)
+	require.Equal(t, "did", operations.Parameters[0].Name)
+
+	resolve := doc.Paths[basePath+"/1.0/identifiers/{id}"]["get"]
+	require.Equal(t, "did-resolution", resolve.OperationID)
+	require.Contains(t, resolve.Responses["200"].Content, document.ContentTypeDIDResolution)
+
+	require.Contains(t, doc.Components.Schemas, "CreateRequest")
+	require.Contains(t, doc.Components.Schemas, "ResolutionResult")
+	require.Contains(t, doc.Components.Schemas, "VersionsResponse")
+}
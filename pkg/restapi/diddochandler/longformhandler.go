@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
+)
+
+// LongFormDIDHandler computes DIDs from create request without submitting the operation.
+type LongFormDIDHandler struct {
+	*handler
+}
+
+// NewLongFormDIDHandler returns a new long-form DID handler.
+func NewLongFormDIDHandler(basePath, namespace string, pc protocol.Client, opts ...Option) *LongFormDIDHandler {
+	return &LongFormDIDHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/long-form-did", basePath),
+			http.MethodPost,
+			dochandler.NewLongFormDIDHandler(namespace, pc).Compute,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "compute-long-form-did",
+			Summary:     "Returns short-form DID, long-form DID and initial state for create request (operation is not submitted).",
+			Request:     []interface{}{&model.CreateRequest{}},
+			Responses: []*openapi.ResponseSpec{
+				{
+					Status:      http.StatusOK,
+					Description: "computed identifiers",
+					Body:        &dochandler.LongFormDIDResponse{},
+					ContentType: "application/json",
+				},
+			},
+		}),
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongFormDIDHandler_Compute(t *testing.T) {
+	handler := NewLongFormDIDHandler(basePath, namespace, newMockProtocolClient())
+	require.Equal(t, basePath+"/long-form-did", handler.Path())
+	require.Equal(t, http.MethodPost, handler.Method())
+	require.NotNil(t, handler.Handler())
+	require.NotNil(t, handler.OpenAPISpec())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, basePath+"/long-form-did", bytes.NewReader([]byte("{}")))
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+// OperationsHandler returns operation history of DID documents.
+type OperationsHandler struct {
+	*handler
+}
+
+// NewOperationsHandler returns a new DID document operations handler.
+func NewOperationsHandler(basePath, namespace string, store dochandler.OperationStore, opts ...Option) *OperationsHandler {
+	return &OperationsHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/operations", basePath),
+			http.MethodGet,
+			dochandler.NewOperationsHandler(namespace, store).GetOperations,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "get-did-operations",
+			Summary:     "Returns operation history of a DID document.",
+			Query: []*openapi.QueryParam{
+				{Name: "did", Description: "short or long form DID", Required: true},
+				{Name: "limit", Description: "maximum number of operations in the page"},
+				{Name: "cursor", Description: "cursor of the page (returned as 'nextCursor' with the previous page)"},
+			},
+			Responses: []*openapi.ResponseSpec{
+				{Status: http.StatusOK, Description: "operation history", Body: &dochandler.OperationsResponse{}},
+			},
+		}),
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestOperationsHandler_GetOperations(t *testing.T) {
+	handler := NewOperationsHandler(basePath, namespace, mocks.NewMockOperationStore(nil))
+	require.Equal(t, basePath+"/operations", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/document/operations?did="+namespace+":abc", nil)
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusNotFound, rw.Code)
+}
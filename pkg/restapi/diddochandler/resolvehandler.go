@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
 )
 
 // ResolveHandler resolves DID documents.
@@ -19,12 +21,20 @@ type ResolveHandler struct {
 }
 
 // NewResolveHandler returns a new DID document resolve handler.
-func NewResolveHandler(basePath string, resolver dochandler.Resolver) *ResolveHandler {
+func NewResolveHandler(basePath string, resolver dochandler.Resolver, opts ...Option) *ResolveHandler {
 	return &ResolveHandler{
 		handler: newHandler(
 			fmt.Sprintf("%s/identifiers/{id}", basePath),
 			http.MethodGet,
 			dochandler.NewResolveHandler(resolver).Resolve,
-		),
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "resolve-did-document",
+			Summary:     "Resolves a DID document by ID or by ID and initial value (long form DID).",
+			Responses: []*openapi.ResponseSpec{
+				{Status: http.StatusOK, Description: "resolution result", Body: &document.ResolutionResult{}},
+				{Status: http.StatusNotModified, Description: "document matches entity tag in If-None-Match header"},
+			},
+		}),
 	}
 }
@@ -0,0 +1,49 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+// StatusHandler returns anchoring status of submitted operations.
+type StatusHandler struct {
+	*handler
+}
+
+// NewStatusHandler returns a new operation status handler.
+func NewStatusHandler(basePath, namespace string, provider dochandler.OperationStatusProvider, opts ...Option) *StatusHandler {
+	return &StatusHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/operations/status", basePath),
+			http.MethodGet,
+			dochandler.NewStatusHandler(namespace, provider).GetStatus,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "get-operation-status",
+			Summary:     "Returns status (queued, written, anchored or failed) of submitted operations.",
+			Query: []*openapi.QueryParam{
+				{Name: "did", Description: "short or long form DID (status of the latest operation for the DID)"},
+				{Name: "anchor", Description: "anchor string (status of operations in the batch)"},
+				{Name: "limit", Description: "maximum number of batch operations in the page"},
+				{Name: "cursor", Description: "cursor of the page (returned as 'nextCursor' with the previous page)"},
+			},
+			Responses: []*openapi.ResponseSpec{
+				{
+					Status:      http.StatusOK,
+					Description: "operation status",
+					Body:        &dochandler.StatusResponse{},
+					ContentType: "application/json",
+				},
+			},
+		}),
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/batch"
+)
+
+func TestStatusHandler_GetStatus(t *testing.T) {
+	tracker := batch.NewOperationStatusTracker()
+	tracker.Queued(&operation.QueuedOperation{UniqueSuffix: "abc"})
+
+	handler := NewStatusHandler(basePath, namespace, tracker)
+	require.Equal(t, basePath+"/operations/status", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+	require.NotNil(t, handler.OpenAPISpec())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/document/operations/status?did="+namespace+":abc", nil)
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), `"status":"queued"`)
+}
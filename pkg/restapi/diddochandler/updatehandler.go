@@ -11,7 +11,10 @@ import (
 	"net/http"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 // UpdateHandler handles the creation and update of DID documents.
@@ -20,12 +23,22 @@ type UpdateHandler struct {
 }
 
 // NewUpdateHandler returns a new DID document update handler.
-func NewUpdateHandler(basePath string, processor dochandler.Processor, pc protocol.Client) *UpdateHandler {
+func NewUpdateHandler(basePath string, processor dochandler.Processor, pc protocol.Client, opts ...Option) *UpdateHandler {
 	return &UpdateHandler{
 		handler: newHandler(
 			fmt.Sprintf("%s/operations", basePath),
 			http.MethodPost,
 			dochandler.NewUpdateHandler(processor, pc).Update,
-		),
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "submit-operation",
+			Summary:     "Creates, updates, recovers or deactivates a DID document.",
+			Request: []interface{}{
+				&model.CreateRequest{}, &model.UpdateRequest{}, &model.RecoverRequest{}, &model.DeactivateRequest{},
+			},
+			Responses: []*openapi.ResponseSpec{
+				{Status: http.StatusOK, Description: "resolution result (returned for create operation)", Body: &document.ResolutionResult{}},
+			},
+		}),
 	}
 }
@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/openapi"
+)
+
+// VersionsHandler returns protocol versions (their parameters and capabilities) supported by the node.
+type VersionsHandler struct {
+	*handler
+}
+
+// NewVersionsHandler returns a new DID document protocol versions handler.
+func NewVersionsHandler(basePath, namespace string, versions []protocol.Version, opts ...Option) *VersionsHandler {
+	return &VersionsHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/versions", basePath),
+			http.MethodGet,
+			dochandler.NewVersionsHandler(namespace, versions).GetVersions,
+			opts...,
+		).withSpec(&openapi.Spec{
+			OperationID: "get-protocol-versions",
+			Summary:     "Returns supported protocol versions, their parameters and capabilities.",
+			Responses: []*openapi.ResponseSpec{
+				{Status: http.StatusOK, Description: "protocol versions", Body: &dochandler.VersionsResponse{}},
+			},
+		}),
+	}
+}
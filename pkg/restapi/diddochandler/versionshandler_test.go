@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestVersionsHandler_GetVersions(t *testing.T) {
+	v := mocks.GetProtocolVersion(mocks.GetDefaultProtocolParameters())
+
+	handler := NewVersionsHandler(basePath, namespace, []protocol.Version{v})
+	require.Equal(t, basePath+"/versions", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/document/versions", nil)
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), `"maxOperationSize"`)
+}
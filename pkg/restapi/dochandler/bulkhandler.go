@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	defaultMaxBulkOperations = 100
+
+	// bulkOperationOverhead is allowance per operation for array envelope (separators and white space).
+	bulkOperationOverhead = 64
+)
+
+// BulkOption is an option for bulk update handler.
+type BulkOption func(opts *BulkUpdateHandler)
+
+// WithMaxBulkOperations sets maximum number of operations in bulk request (default is 100).
+func WithMaxBulkOperations(max int) BulkOption {
+	return func(opts *BulkUpdateHandler) {
+		opts.maxOperations = max
+	}
+}
+
+// BulkResult is result of single operation in bulk request.
+type BulkResult struct {
+	Index  int                        `json:"index"`
+	Status int                        `json:"status"`
+	Result *document.ResolutionResult `json:"result,omitempty"`
+	Error  *common.ErrorResponse      `json:"error,omitempty"`
+}
+
+// BulkResponse contains results of operations (in request order).
+type BulkResponse struct {
+	Results []*BulkResult `json:"results"`
+}
+
+// BulkUpdateHandler handles array of operation requests; each operation is validated and processed
+// independently and per-operation results are returned.
+type BulkUpdateHandler struct {
+	*UpdateHandler
+
+	maxOperations int
+}
+
+// NewBulkUpdateHandler returns a new bulk document update handler.
+func NewBulkUpdateHandler(processor Processor, pc protocol.Client, opts ...BulkOption) *BulkUpdateHandler {
+	h := &BulkUpdateHandler{
+		UpdateHandler: NewUpdateHandler(processor, pc),
+		maxOperations: defaultMaxBulkOperations,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// BulkUpdate processes array of operation requests. Request body is limited to maximum number of operations
+// of maximum operation size (plus envelope overhead) and operations larger than maximum operation size
+// are rejected before they are parsed.
+func (h *BulkUpdateHandler) BulkUpdate(rw http.ResponseWriter, req *http.Request) {
+	pv, err := h.protocol.Current()
+	if err != nil {
+		common.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	maxOperationSize := int64(pv.Protocol().MaxOperationSize)
+	maxBodySize := int64(h.maxOperations) * (maxOperationSize + bulkOperationOverhead)
+
+	if req.ContentLength > maxBodySize {
+		writeTooLarge(rw, fmt.Errorf("request body size[%d] exceeds maximum size[%d]", req.ContentLength, maxBodySize))
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(rw, req.Body, maxBodySize))
+	if err != nil {
+		if int64(len(body)) >= maxBodySize {
+			writeTooLarge(rw, fmt.Errorf("request body exceeds maximum size[%d]", maxBodySize))
+
+			return
+		}
+
+		common.WriteError(rw, http.StatusBadRequest, err)
+
+		return
+	}
+
+	var operations []json.RawMessage
+	if err := json.Unmarshal(body, &operations); err != nil {
+		common.WriteError(rw, http.StatusBadRequest,
+			fmt.Errorf("bad request: request must be an array of operations: %s", err.Error()))
+
+		return
+	}
+
+	if len(operations) == 0 {
+		common.WriteError(rw, http.StatusBadRequest, fmt.Errorf("bad request: no operations provided"))
+
+		return
+	}
+
+	if len(operations) > h.maxOperations {
+		common.WriteError(rw, http.StatusBadRequest,
+			fmt.Errorf("bad request: number of operations[%d] exceeds maximum[%d]", len(operations), h.maxOperations))
+
+		return
+	}
+
+	response := &BulkResponse{Results: make([]*BulkResult, len(operations))}
+
+	for i, op := range operations {
+		if int64(len(op)) > maxOperationSize {
+			response.Results[i] = &BulkResult{
+				Index:  i,
+				Status: http.StatusRequestEntityTooLarge,
+				Error: common.NewErrorResponse(http.StatusRequestEntityTooLarge,
+					protocol.NewCodedError(protocol.CodeOperationExceedsMaximumSize,
+						fmt.Errorf("operation size[%d] exceeds maximum operation size[%d]", len(op), maxOperationSize))),
+			}
+
+			continue
+		}
+
+		response.Results[i] = h.process(i, op)
+	}
+
+	common.WriteResponse(rw, http.StatusOK, response)
+}
+
+func (h *BulkUpdateHandler) process(index int, op []byte) *BulkResult {
+	result, err := h.doUpdate(op)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if httpErr, ok := err.(*common.HTTPError); ok {
+			status = httpErr.Status()
+		}
+
+		return &BulkResult{
+			Index:  index,
+			Status: status,
+			Error:  common.NewErrorResponse(status, err),
+		}
+	}
+
+	return &BulkResult{
+		Index:  index,
+		Status: http.StatusOK,
+		Result: result,
+	}
+}
+
+func writeTooLarge(rw http.ResponseWriter, err error) {
+	common.WriteError(rw, http.StatusRequestEntityTooLarge,
+		protocol.NewCodedError(protocol.CodeOperationExceedsMaximumSize, err))
+}
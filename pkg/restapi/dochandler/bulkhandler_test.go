@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+)
+
+func TestBulkUpdateHandler_BulkUpdate(t *testing.T) {
+	pc := newMockProtocolClient()
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	info, err := getCreateRequestInfo()
+	require.NoError(t, err)
+
+	create, err := client.NewCreateRequest(info)
+	require.NoError(t, err)
+
+	t.Run("success - per operation results", func(t *testing.T) {
+		handler := NewBulkUpdateHandler(docHandler, pc)
+
+		body := "[" + string(create) + `, {"type": "other"}]`
+
+		rw := httptest.NewRecorder()
+		handler.BulkUpdate(rw, httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader(body)))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response BulkResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Results, 2)
+
+		require.Equal(t, 0, response.Results[0].Index)
+		require.Equal(t, http.StatusOK, response.Results[0].Status)
+		require.NotNil(t, response.Results[0].Result)
+		require.NotEmpty(t, response.Results[0].Result.Document.ID())
+		require.Nil(t, response.Results[0].Error)
+
+		require.Equal(t, 1, response.Results[1].Index)
+		require.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+		require.Nil(t, response.Results[1].Result)
+		require.Equal(t, protocol.CodeBadRequest, response.Results[1].Error.Code)
+		require.Contains(t, response.Results[1].Error.Message, "operation type [other] not supported")
+	})
+
+	t.Run("error - internal error for single operation", func(t *testing.T) {
+		handler := NewBulkUpdateHandler(mocks.NewMockDocumentHandler().WithNamespace(namespace).
+			WithProtocolClient(pc).WithError(errors.New("processor error")), pc)
+
+		rw := httptest.NewRecorder()
+		handler.BulkUpdate(rw, httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("["+string(create)+"]")))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response BulkResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Results, 1)
+		require.Equal(t, http.StatusInternalServerError, response.Results[0].Status)
+		require.Equal(t, protocol.CodeInternalError, response.Results[0].Error.Code)
+	})
+
+	t.Run("error - protocol error", func(t *testing.T) {
+		pcErr := newMockProtocolClient()
+		pcErr.Err = errors.New("protocol error")
+
+		handler := NewBulkUpdateHandler(docHandler, pcErr)
+
+		rw := httptest.NewRecorder()
+		handler.BulkUpdate(rw, httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("["+string(create)+"]")))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.Contains(t, rw.Body.String(), "protocol error")
+	})
+
+	t.Run("error - request body too large (content length)", func(t *testing.T) {
+		body := "[" + strings.Repeat(" ", mocks.MaxOperationByteSize+bulkOperationOverhead) + "{}]"
+
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc, WithMaxBulkOperations(1)).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader(body)))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+		require.Contains(t, rw.Body.String(), protocol.CodeOperationExceedsMaximumSize)
+		require.Contains(t, rw.Body.String(), "exceeds maximum size[2064]")
+	})
+
+	t.Run("error - request body too large (unknown content length)", func(t *testing.T) {
+		body := "[" + strings.Repeat(" ", mocks.MaxOperationByteSize+bulkOperationOverhead) + "{}]"
+
+		req := httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader(body))
+		req.ContentLength = -1
+
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc, WithMaxBulkOperations(1)).BulkUpdate(rw, req)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+		require.Contains(t, rw.Body.String(), protocol.CodeOperationExceedsMaximumSize)
+		require.Contains(t, rw.Body.String(), "request body exceeds maximum size[2064]")
+	})
+
+	t.Run("error - operation too large", func(t *testing.T) {
+		large := `{"type": "` + strings.Repeat("x", mocks.MaxOperationByteSize) + `"}`
+
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("["+string(create)+","+large+"]")))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response BulkResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Results, 2)
+		require.Equal(t, http.StatusOK, response.Results[0].Status)
+
+		require.Equal(t, 1, response.Results[1].Index)
+		require.Equal(t, http.StatusRequestEntityTooLarge, response.Results[1].Status)
+		require.Nil(t, response.Results[1].Result)
+		require.Equal(t, protocol.CodeOperationExceedsMaximumSize, response.Results[1].Error.Code)
+		require.Contains(t, response.Results[1].Error.Message, "exceeds maximum operation size[2000]")
+	})
+
+	t.Run("error - not an array", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader(string(create))))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "request must be an array of operations")
+	})
+
+	t.Run("error - empty array", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("[]")))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "no operations provided")
+	})
+
+	t.Run("error - too many operations", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc, WithMaxBulkOperations(1)).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", strings.NewReader("[{}, {}]")))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "number of operations[2] exceeds maximum[1]")
+	})
+
+	t.Run("error - read error", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		NewBulkUpdateHandler(docHandler, pc).BulkUpdate(rw,
+			httptest.NewRequest(http.MethodPost, "/operations/bulk", &failingReader{}))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
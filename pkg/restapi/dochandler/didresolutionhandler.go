@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	mediaTypeLDJSON   = "application/ld+json"
+	mediaTypeAny      = "*/*"
+	mediaTypeAnyApp   = "application/*"
+	profileParam      = "profile"
+	qualityParam      = "q"
+	acceptHeader      = "Accept"
+	deactivatedErrMsg = "was deactivated"
+)
+
+// representation is resolution response representation selected by content negotiation.
+type representation int
+
+const (
+	notAcceptable representation = iota
+	resolutionResult
+	didDocument
+	didJSONDocument
+)
+
+// DIDResolutionHandler implements DID Resolution HTTP(S) binding: depending on Accept header it returns either
+// resolution result (document and metadata) or DID document only; resolution errors are returned as resolution
+// result with error code in resolution metadata.
+type DIDResolutionHandler struct {
+	resolver Resolver
+}
+
+// NewDIDResolutionHandler returns a new DID resolution handler.
+func NewDIDResolutionHandler(resolver Resolver) *DIDResolutionHandler {
+	return &DIDResolutionHandler{
+		resolver: resolver,
+	}
+}
+
+// Resolve resolves DID and writes resolution result or DID document (as requested by Accept header).
+func (h *DIDResolutionHandler) Resolve(rw http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+
+	id := getID(req)
+	logger.Debugf("Resolving DID [%s]", id)
+
+	repr := negotiate(req.Header.Get(acceptHeader))
+	if repr == notAcceptable {
+		writeResolutionError(rw, http.StatusNotAcceptable, document.ResolutionErrorRepresentationNotSupported, start)
+
+		return
+	}
+
+	var opts []document.ResolutionOption
+	if repr == didJSONDocument {
+		opts = append(opts, document.WithContentType(document.ContentTypeDIDJSON))
+	}
+
+	result, err := h.resolver.ResolveDocument(id, opts...)
+	if err != nil {
+		h.handleError(rw, err, start)
+
+		return
+	}
+
+	status := http.StatusOK
+	if deactivated, ok := result.DocumentMetadata[document.DeactivatedProperty].(bool); ok && deactivated {
+		status = http.StatusGone
+	}
+
+	switch repr {
+	case didDocument:
+		common.WriteResponseWithContentType(rw, status, document.ContentTypeDIDLDJSON, result.Document)
+
+		return
+	case didJSONDocument:
+		common.WriteResponseWithContentType(rw, status, document.ContentTypeDIDJSON, result.Document)
+
+		return
+	}
+
+	result.ResolutionMetadata = document.NewResolutionMetadata(document.ContentTypeDIDLDJSON, time.Since(start))
+
+	common.WriteResponseWithContentType(rw, status, document.ContentTypeDIDResolution, result)
+}
+
+func (h *DIDResolutionHandler) handleError(rw http.ResponseWriter, err error, start time.Time) {
+	switch {
+	case strings.Contains(err.Error(), "bad request"):
+		writeResolutionError(rw, http.StatusBadRequest, document.ResolutionErrorInvalidDID, start)
+	case strings.Contains(err.Error(), "not found"):
+		writeResolutionError(rw, http.StatusNotFound, document.ResolutionErrorNotFound, start)
+	case strings.Contains(err.Error(), deactivatedErrMsg):
+		common.WriteResponseWithContentType(rw, http.StatusGone, document.ContentTypeDIDResolution,
+			&document.ResolutionResult{
+				DocumentMetadata:   document.Metadata{document.DeactivatedProperty: true},
+				ResolutionMetadata: document.NewResolutionMetadata(document.ContentTypeDIDLDJSON, time.Since(start)),
+			})
+	default:
+		logger.Errorf("internal server error:  %s", err.Error())
+
+		writeResolutionError(rw, http.StatusInternalServerError, document.ResolutionErrorInternal, start)
+	}
+}
+
+func writeResolutionError(rw http.ResponseWriter, status int, code string, start time.Time) {
+	logger.Warnf("returning error status: %d, resolution error: %s", status, code)
+
+	common.WriteResponseWithContentType(rw, status, document.ContentTypeDIDResolution,
+		&document.ResolutionResult{
+			ResolutionMetadata: document.NewResolutionErrorMetadata(code, time.Since(start)),
+		})
+}
+
+type mediaRange struct {
+	mediaType string
+	profile   string
+	quality   float64
+}
+
+// negotiate selects representation for the given Accept header; resolution result is returned
+// if Accept header is missing.
+func negotiate(accept string) representation {
+	if strings.TrimSpace(accept) == "" {
+		return resolutionResult
+	}
+
+	for _, r := range parseAccept(accept) {
+		switch {
+		case r.mediaType == document.ContentTypeDIDLDJSON:
+			return didDocument
+		case r.mediaType == document.ContentTypeDIDJSON:
+			return didJSONDocument
+		case r.mediaType == mediaTypeLDJSON && r.profile == document.DIDResolutionProfile:
+			return resolutionResult
+		case r.mediaType == mediaTypeAny || r.mediaType == mediaTypeAnyApp:
+			return resolutionResult
+		}
+	}
+
+	return notAcceptable
+}
+
+// parseAccept parses Accept header into media ranges ordered by quality (ranges with zero quality are omitted).
+func parseAccept(accept string) []*mediaRange {
+	var ranges []*mediaRange
+
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			logger.Debugf("ignoring invalid media range [%s]: %s", value, err.Error())
+
+			continue
+		}
+
+		r := &mediaRange{
+			mediaType: mediaType,
+			profile:   params[profileParam],
+			quality:   1,
+		}
+
+		if q, ok := params[qualityParam]; ok {
+			quality, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+
+			r.quality = quality
+		}
+
+		if r.quality > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	return ranges
+}
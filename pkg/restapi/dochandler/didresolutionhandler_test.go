@@ -0,0 +1,187 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestDIDResolutionHandler_Resolve(t *testing.T) {
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
+
+	create, err := getCreateRequest()
+	require.NoError(t, err)
+
+	bytes, err := canonicalizer.MarshalCanonical(create)
+	require.NoError(t, err)
+
+	result, err := docHandler.ProcessOperation(bytes, 0)
+	require.NoError(t, err)
+
+	getID = func(req *http.Request) string { return result.Document.ID() }
+
+	handler := NewDIDResolutionHandler(docHandler)
+
+	t.Run("resolution result (no accept header)", func(t *testing.T) {
+		rw := resolve(handler, "")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDResolution, rw.Header().Get("content-type"))
+
+		var rr document.ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+		require.Equal(t, result.Document.ID(), rr.Document.ID())
+		require.Equal(t, document.ContentTypeDIDLDJSON, rr.ResolutionMetadata[document.ContentTypeProperty])
+	})
+
+	t.Run("resolution result (profile)", func(t *testing.T) {
+		rw := resolve(handler, `application/ld+json;profile="https://w3id.org/did-resolution";charset=utf-8`)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDResolution, rw.Header().Get("content-type"))
+	})
+
+	t.Run("resolution result (any)", func(t *testing.T) {
+		rw := resolve(handler, "text/html, */*;q=0.8")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDResolution, rw.Header().Get("content-type"))
+	})
+
+	t.Run("did document", func(t *testing.T) {
+		rw := resolve(handler, "application/did+ld+json")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDLDJSON, rw.Header().Get("content-type"))
+
+		doc, err := document.FromBytes(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, result.Document.ID(), doc.ID())
+		require.NotContains(t, doc, "didResolutionMetadata")
+	})
+
+	t.Run("did document (plain JSON)", func(t *testing.T) {
+		rw := resolve(handler, "application/did+json")
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDJSON, rw.Header().Get("content-type"))
+
+		doc, err := document.FromBytes(rw.Body.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, result.Document.ID(), doc.ID())
+		require.NotContains(t, doc, document.ContextProperty)
+	})
+
+	t.Run("did document preferred by quality", func(t *testing.T) {
+		rw := resolve(handler, `application/ld+json;profile="https://w3id.org/did-resolution";q=0.5, application/did+ld+json`)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDLDJSON, rw.Header().Get("content-type"))
+	})
+
+	t.Run("error - not acceptable", func(t *testing.T) {
+		for _, accept := range []string{"text/html", "application/ld+json", "application/did+ld+json;q=0", "invalid;;"} {
+			rw := resolve(handler, accept)
+			require.Equal(t, http.StatusNotAcceptable, rw.Code, accept)
+			requireResolutionError(t, rw, document.ResolutionErrorRepresentationNotSupported)
+		}
+	})
+
+	t.Run("error - invalid did", func(t *testing.T) {
+		getID = func(req *http.Request) string { return "did:other:abc" }
+
+		rw := resolve(handler, "")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireResolutionError(t, rw, document.ResolutionErrorInvalidDID)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		getID = func(req *http.Request) string { return namespace + docutil.NamespaceDelimiter + "someid" }
+
+		rw := resolve(handler, "")
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		requireResolutionError(t, rw, document.ResolutionErrorNotFound)
+	})
+
+	t.Run("error - internal", func(t *testing.T) {
+		h := NewDIDResolutionHandler(mocks.NewMockDocumentHandler().WithNamespace(namespace).WithError(errors.New("db error")))
+
+		rw := resolve(h, "")
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		requireResolutionError(t, rw, document.ResolutionErrorInternal)
+		require.NotContains(t, rw.Body.String(), "db error")
+	})
+
+	t.Run("deactivated (error)", func(t *testing.T) {
+		h := NewDIDResolutionHandler(mocks.NewMockDocumentHandler().WithNamespace(namespace).WithError(errors.New("document was deactivated")))
+
+		rw := resolve(h, "")
+		require.Equal(t, http.StatusGone, rw.Code)
+
+		var rr document.ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+		require.Equal(t, true, rr.DocumentMetadata[document.DeactivatedProperty])
+	})
+
+	t.Run("deactivated (tombstone)", func(t *testing.T) {
+		tombstone := &document.ResolutionResult{
+			Document:         document.Document{document.IDProperty: "did:sidetree:abc"},
+			DocumentMetadata: document.Metadata{document.DeactivatedProperty: true},
+		}
+
+		h := NewDIDResolutionHandler(&tombstoneResolver{result: tombstone})
+
+		rw := resolve(h, "")
+		require.Equal(t, http.StatusGone, rw.Code)
+		require.Equal(t, document.ContentTypeDIDResolution, rw.Header().Get("content-type"))
+
+		var rr document.ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+		require.Equal(t, true, rr.DocumentMetadata[document.DeactivatedProperty])
+
+		rw = resolve(h, "application/did+ld+json")
+		require.Equal(t, http.StatusGone, rw.Code)
+		require.Equal(t, document.ContentTypeDIDLDJSON, rw.Header().Get("content-type"))
+	})
+}
+
+func resolve(handler *DIDResolutionHandler, accept string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+
+	req := httptest.NewRequest(http.MethodGet, "/1.0/identifiers", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	handler.Resolve(rw, req)
+
+	return rw
+}
+
+func requireResolutionError(t *testing.T, rw *httptest.ResponseRecorder, code string) {
+	t.Helper()
+
+	require.Equal(t, document.ContentTypeDIDResolution, rw.Header().Get("content-type"))
+
+	var rr document.ResolutionResult
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+	require.Nil(t, rr.Document)
+	require.Equal(t, code, rr.ResolutionMetadata[document.ErrorProperty])
+}
+
+type tombstoneResolver struct {
+	result *document.ResolutionResult
+}
+
+func (r *tombstoneResolver) ResolveDocument(string, ...document.ResolutionOption) (*document.ResolutionResult, error) {
+	return r.result, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const operationTypeProperty = "type"
+
+// LongFormDIDResponse contains identifiers computed from create request.
+type LongFormDIDResponse struct {
+	// DID is short-form DID (namespace and unique suffix)
+	DID string `json:"did"`
+
+	// LongFormDID is '<namespace>:<unique-suffix>:<initial-state>'
+	LongFormDID string `json:"longFormDID"`
+
+	// InitialState is Base64url(JCS({suffixData, delta}))
+	InitialState string `json:"initialState"`
+}
+
+// LongFormDIDHandler computes short-form and long-form DID from create request without
+// submitting the operation (e.g. wallets can preview identifiers before anchoring).
+type LongFormDIDHandler struct {
+	namespace string
+	protocol  protocol.Client
+}
+
+// NewLongFormDIDHandler returns a new long-form DID handler.
+func NewLongFormDIDHandler(namespace string, pc protocol.Client) *LongFormDIDHandler {
+	return &LongFormDIDHandler{
+		namespace: namespace,
+		protocol:  pc,
+	}
+}
+
+// Compute validates create request and returns short-form DID, long-form DID and initial state.
+func (h *LongFormDIDHandler) Compute(rw http.ResponseWriter, req *http.Request) {
+	request, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, err)
+
+		return
+	}
+
+	response, err := h.compute(request)
+	if err != nil {
+		common.WriteError(rw, err.(*common.HTTPError).Status(), err)
+
+		return
+	}
+
+	common.WriteResponseWithContentType(rw, http.StatusOK, "application/json", response)
+}
+
+func (h *LongFormDIDHandler) compute(request []byte) (*LongFormDIDResponse, error) {
+	pv, err := h.protocol.Current()
+	if err != nil {
+		logger.Errorf("internal server error:  %s", err.Error())
+
+		return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	op, err := pv.OperationParser().Parse(h.namespace, request)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	if op.Type != operation.TypeCreate {
+		return nil, common.NewHTTPError(http.StatusBadRequest,
+			fmt.Errorf("operation type [%s] not supported, expecting create", op.Type))
+	}
+
+	initialState, err := getInitialState(request)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	longFormDID := op.ID + docutil.NamespaceDelimiter + initialState
+
+	// verify that long-form DID can be resolved by the current protocol version
+	did, _, err := pv.OperationParser().ParseDID(h.namespace, longFormDID)
+	if err != nil || did != op.ID {
+		logger.Errorf("failed to parse computed long-form DID [%s]: %v", longFormDID, err)
+
+		return nil, common.NewHTTPError(http.StatusInternalServerError,
+			fmt.Errorf("failed to compute long-form DID for [%s]", op.ID))
+	}
+
+	return &LongFormDIDResponse{
+		DID:          op.ID,
+		LongFormDID:  longFormDID,
+		InitialState: initialState,
+	}, nil
+}
+
+// getInitialState returns Base64url(JCS) of create request without operation type.
+func getInitialState(request []byte) (string, error) {
+	var fields map[string]interface{}
+
+	err := json.Unmarshal(request, &fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal create request: %s", err.Error())
+	}
+
+	delete(fields, operationTypeProperty)
+
+	jcs, err := canonicalizer.MarshalCanonical(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize initial state: %s", err.Error())
+	}
+
+	return encoder.EncodeToString(jcs), nil
+}
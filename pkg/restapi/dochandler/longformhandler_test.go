@@ -0,0 +1,92 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+func TestLongFormDIDHandler_Compute(t *testing.T) {
+	pc := newMockProtocolClient()
+	handler := NewLongFormDIDHandler(namespace, pc)
+
+	info, err := getCreateRequestInfo()
+	require.NoError(t, err)
+
+	create, err := client.NewCreateRequest(info)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		rw := compute(handler, create)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "application/json", rw.Header().Get("content-type"))
+
+		var response LongFormDIDResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, response.DID+":"+response.InitialState, response.LongFormDID)
+
+		longFormDID, err := operationparser.ParseLongFormDID(namespace, response.LongFormDID)
+		require.NoError(t, err)
+		require.Equal(t, response.DID, longFormDID.DID)
+
+		// long-form DID is resolvable without anchoring
+		docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+		result, err := docHandler.ResolveDocument(response.LongFormDID)
+		require.NoError(t, err)
+		require.Contains(t, result.Document.ID(), response.DID)
+	})
+
+	t.Run("error - invalid request", func(t *testing.T) {
+		rw := compute(handler, []byte("{}"))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "parse operation: operation type [] not supported")
+	})
+
+	t.Run("error - not create operation", func(t *testing.T) {
+		update, err := client.NewUpdateRequest(getUpdateRequestInfo("abc"))
+		require.NoError(t, err)
+
+		rw := compute(handler, update)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "operation type [update] not supported, expecting create")
+	})
+
+	t.Run("error - read body", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		handler.Compute(rw, httptest.NewRequest(http.MethodPost, "/long-form-did", &failingReader{}))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("error - protocol", func(t *testing.T) {
+		errPC := newMockProtocolClient()
+		errPC.Err = errors.New("protocol error")
+
+		rw := compute(NewLongFormDIDHandler(namespace, errPC), create)
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		requireErrorResponse(t, rw, "internal_error", "protocol error")
+	})
+}
+
+func compute(handler *LongFormDIDHandler, request []byte) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+
+	handler.Compute(rw, httptest.NewRequest(http.MethodPost, "/long-form-did", bytes.NewReader(request)))
+
+	return rw
+}
@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	didParam = "did"
+
+	// StatusAnchored is status of operations that were anchored (and stored by the observer).
+	StatusAnchored = "anchored"
+)
+
+// OperationStore retrieves anchored operations of a document.
+type OperationStore interface {
+	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
+}
+
+// PagedOperationStore is implemented by operation stores that support paged queries (operation history
+// is retrieved from the store one page at a time instead of loading all operations of the document).
+type PagedOperationStore interface {
+	// GetPage returns up to limit operations (ordered by transaction time and number) anchored after
+	// the given position (from the beginning if position is nil).
+	GetPage(uniqueSuffix string, after *operation.Position, limit int) ([]*operation.AnchoredOperation, error)
+}
+
+// OperationInfo describes an operation in document's operation history.
+type OperationInfo struct {
+	Type                operation.Type `json:"type"`
+	TransactionTime     uint64         `json:"transactionTime"`
+	TransactionNumber   uint64         `json:"transactionNumber"`
+	ProtocolGenesisTime uint64         `json:"protocolGenesisTime"`
+	Status              string         `json:"status"`
+}
+
+// OperationsResponse contains document's operation history.
+type OperationsResponse struct {
+	ID         string           `json:"id"`
+	Operations []*OperationInfo `json:"operations"`
+	// NextCursor is cursor for the next page (empty if this is the last page)
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// OperationsHandler returns operation history of a document.
+type OperationsHandler struct {
+	namespace string
+	store     OperationStore
+}
+
+// NewOperationsHandler returns a new operations handler.
+func NewOperationsHandler(namespace string, store OperationStore) *OperationsHandler {
+	return &OperationsHandler{
+		namespace: namespace,
+		store:     store,
+	}
+}
+
+// GetOperations returns operation history (ordered by anchoring time) of the document specified
+// by 'did' query parameter. Operation history is paged: 'limit' query parameter specifies page size
+// and 'cursor' query parameter specifies the page (next cursor is returned with each page).
+func (h *OperationsHandler) GetOperations(rw http.ResponseWriter, req *http.Request) {
+	response, err := h.getOperations(req.URL.Query())
+	if err != nil {
+		common.WriteError(rw, err.(*common.HTTPError).Status(), err)
+
+		return
+	}
+
+	common.WriteResponse(rw, http.StatusOK, response)
+}
+
+func (h *OperationsHandler) getOperations(query url.Values) (*OperationsResponse, error) {
+	id := query.Get(didParam)
+	if id == "" {
+		return nil, common.NewHTTPError(http.StatusBadRequest, fmt.Errorf("missing '%s' query parameter", didParam))
+	}
+
+	uniqueSuffix, err := getSuffix(h.namespace, id)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	limit, err := getPageLimit(query)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	var after *operation.Position
+
+	if cursor := query.Get(cursorParam); cursor != "" {
+		after = &operation.Position{}
+
+		err = decodeCursor(cursor, after)
+		if err != nil {
+			return nil, common.NewHTTPError(http.StatusBadRequest, err)
+		}
+	}
+
+	// one more operation is requested to find out whether there is a next page
+	ops, err := h.getPage(uniqueSuffix, after, limit+1)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, common.NewHTTPError(http.StatusNotFound,
+				protocol.NewCodedError(protocol.CodeDIDNotFound, errors.New("document not found")))
+		}
+
+		logger.Errorf("internal server error:  %s", err.Error())
+
+		return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	response := &OperationsResponse{
+		ID:         h.namespace + docutil.NamespaceDelimiter + uniqueSuffix,
+		Operations: []*OperationInfo{},
+	}
+
+	if len(ops) > limit {
+		ops = ops[:limit]
+
+		last := ops[limit-1]
+
+		response.NextCursor, err = encodeCursor(&operation.Position{
+			TransactionTime:   last.TransactionTime,
+			TransactionNumber: last.TransactionNumber,
+		})
+		if err != nil {
+			return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+		}
+	}
+
+	for _, op := range ops {
+		response.Operations = append(response.Operations, &OperationInfo{
+			Type:                op.Type,
+			TransactionTime:     op.TransactionTime,
+			TransactionNumber:   op.TransactionNumber,
+			ProtocolGenesisTime: op.ProtocolGenesisTime,
+			Status:              StatusAnchored,
+		})
+	}
+
+	return response, nil
+}
+
+// getPage returns page of operations from the store; if the store doesn't support paged queries
+// all operations are retrieved and the page is selected in memory.
+func (h *OperationsHandler) getPage(uniqueSuffix string, after *operation.Position, limit int) ([]*operation.AnchoredOperation, error) {
+	if s, ok := h.store.(PagedOperationStore); ok {
+		return s.GetPage(uniqueSuffix, after, limit)
+	}
+
+	ops, err := h.store.Get(uniqueSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*operation.AnchoredOperation, len(ops))
+	copy(sorted, ops)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].TransactionTime == sorted[j].TransactionTime {
+			return sorted[i].TransactionNumber < sorted[j].TransactionNumber
+		}
+
+		return sorted[i].TransactionTime < sorted[j].TransactionTime
+	})
+
+	var page []*operation.AnchoredOperation
+
+	for _, op := range sorted {
+		if len(page) == limit {
+			break
+		}
+
+		if after == nil || after.Before(op) {
+			page = append(page, op)
+		}
+	}
+
+	return page, nil
+}
+
+// getSuffix returns unique suffix of short or long form DID.
+func getSuffix(namespace, id string) (string, error) {
+	did, err := docutil.ParseDID(namespace, id)
+	if err != nil {
+		return "", err
+	}
+
+	return did.UniqueSuffix, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+func TestOperationsHandler_GetOperations(t *testing.T) {
+	const suffix = "EiDOQXC2GnoVyHwIRbjhLx_cNc6vmZaS04SZjZdlLLAPRg"
+
+	store := mocks.NewMockOperationStore(nil)
+
+	for _, op := range []*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: suffix, TransactionTime: 1, TransactionNumber: 1},
+		{Type: operation.TypeRecover, UniqueSuffix: suffix, TransactionTime: 3, TransactionNumber: 5},
+		{Type: operation.TypeUpdate, UniqueSuffix: suffix, TransactionTime: 2, TransactionNumber: 2},
+	} {
+		require.NoError(t, store.Put(op))
+	}
+
+	handler := NewOperationsHandler(namespace, store)
+
+	t.Run("success", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did="+namespace+":"+suffix, nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response OperationsResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, namespace+":"+suffix, response.ID)
+		require.Len(t, response.Operations, 3)
+
+		require.Equal(t, operation.TypeCreate, response.Operations[0].Type)
+		require.Equal(t, operation.TypeUpdate, response.Operations[1].Type)
+		require.Equal(t, operation.TypeRecover, response.Operations[2].Type)
+		require.Equal(t, uint64(3), response.Operations[2].TransactionTime)
+		require.Equal(t, uint64(5), response.Operations[2].TransactionNumber)
+		require.Equal(t, StatusAnchored, response.Operations[2].Status)
+	})
+
+	t.Run("success - long form DID", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did="+namespace+":"+suffix+":initialState", nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("success - paged", func(t *testing.T) {
+		for _, s := range []OperationStore{store, &nonPagedStore{store: store}} {
+			rw := httptest.NewRecorder()
+			handler := NewOperationsHandler(namespace, s)
+			handler.GetOperations(rw, httptest.NewRequest(http.MethodGet, "/operations?limit=2&did="+namespace+":"+suffix, nil))
+			require.Equal(t, http.StatusOK, rw.Code)
+
+			var response OperationsResponse
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+			require.Len(t, response.Operations, 2)
+			require.Equal(t, operation.TypeCreate, response.Operations[0].Type)
+			require.Equal(t, operation.TypeUpdate, response.Operations[1].Type)
+			require.NotEmpty(t, response.NextCursor)
+
+			rw = httptest.NewRecorder()
+			handler.GetOperations(rw, httptest.NewRequest(http.MethodGet,
+				"/operations?limit=2&did="+namespace+":"+suffix+"&cursor="+response.NextCursor, nil))
+			require.Equal(t, http.StatusOK, rw.Code)
+
+			response = OperationsResponse{}
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+			require.Len(t, response.Operations, 1)
+			require.Equal(t, operation.TypeRecover, response.Operations[0].Type)
+			require.Empty(t, response.NextCursor)
+		}
+	})
+
+	t.Run("error - invalid limit", func(t *testing.T) {
+		for _, limit := range []string{"0", "abc", "1001"} {
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/operations?limit="+limit+"&did="+namespace+":"+suffix, nil)
+
+			handler.GetOperations(rw, req)
+			require.Equal(t, http.StatusBadRequest, rw.Code)
+			requireErrorResponse(t, rw, protocol.CodeBadRequest, "'limit' query parameter must be a number between 1 and 1000")
+		}
+	})
+
+	t.Run("error - invalid cursor", func(t *testing.T) {
+		for _, cursor := range []string{"!!", "YWJj"} {
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/operations?cursor="+cursor+"&did="+namespace+":"+suffix, nil)
+
+			handler.GetOperations(rw, req)
+			require.Equal(t, http.StatusBadRequest, rw.Code)
+			requireErrorResponse(t, rw, protocol.CodeBadRequest, "invalid cursor")
+		}
+	})
+
+	t.Run("error - missing did", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations", nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeBadRequest, "missing 'did' query parameter")
+	})
+
+	t.Run("error - invalid namespace", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did=did:other:"+suffix, nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeBadRequest, "did must start with configured namespace")
+	})
+
+	t.Run("error - empty suffix", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did="+namespace+":", nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeBadRequest, "did suffix is empty")
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did="+namespace+":unknown", nil)
+
+		handler.GetOperations(rw, req)
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeDIDNotFound, "document not found")
+	})
+
+	t.Run("error - store error", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/operations?did="+namespace+":"+suffix, nil)
+
+		NewOperationsHandler(namespace, mocks.NewMockOperationStore(errors.New("store error"))).GetOperations(rw, req)
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeInternalError, "store error")
+	})
+}
+
+type nonPagedStore struct {
+	store OperationStore
+}
+
+func (s *nonPagedStore) Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error) {
+	return s.store.Get(uniqueSuffix)
+}
+
+func requireErrorResponse(t *testing.T, rw *httptest.ResponseRecorder, code, message string) {
+	t.Helper()
+
+	var response common.ErrorResponse
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+	require.Equal(t, code, response.Code)
+	require.Equal(t, message, response.Message)
+}
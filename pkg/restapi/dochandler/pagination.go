@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+)
+
+const (
+	limitParam  = "limit"
+	cursorParam = "cursor"
+
+	// DefaultPageLimit is number of items returned by listing endpoints if limit is not specified.
+	DefaultPageLimit = 100
+
+	// MaxPageLimit is maximum number of items returned by listing endpoints.
+	MaxPageLimit = 1000
+)
+
+// getPageLimit returns page limit from 'limit' query parameter.
+func getPageLimit(query url.Values) (int, error) {
+	value := query.Get(limitParam)
+	if value == "" {
+		return DefaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxPageLimit {
+		return 0, fmt.Errorf("'%s' query parameter must be a number between 1 and %d", limitParam, MaxPageLimit)
+	}
+
+	return limit, nil
+}
+
+// encodeCursor returns opaque cursor (Base64url encoded JSON) for the given position.
+func encodeCursor(position interface{}) (string, error) {
+	bytes, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %s", err.Error())
+	}
+
+	return encoder.EncodeToString(bytes), nil
+}
+
+// decodeCursor decodes opaque cursor into position.
+func decodeCursor(cursor string, position interface{}) error {
+	bytes, err := encoder.DecodeString(cursor)
+	if err != nil {
+		return errors.New("invalid cursor")
+	}
+
+	err = json.Unmarshal(bytes, position)
+	if err != nil {
+		return errors.New("invalid cursor")
+	}
+
+	return nil
+}
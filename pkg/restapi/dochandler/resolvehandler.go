@@ -7,22 +7,32 @@ SPDX-License-Identifier: Apache-2.0
 package dochandler
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/trustbloc/edge-core/pkg/log"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 )
 
 var logger = log.New("sidetree-core-restapi-dochandler")
 
+// defaultCacheControl allows caches to store resolution responses but requires revalidation (using entity tag)
+// since document may be updated at any time; use common.CacheControl middleware to allow caches to serve
+// stored responses without revalidation.
+const defaultCacheControl = "no-cache"
+
 // Resolver resolves documents.
 type Resolver interface {
-	ResolveDocument(idOrDocument string) (*document.ResolutionResult, error)
+	ResolveDocument(idOrDocument string, opts ...document.ResolutionOption) (*document.ResolutionResult, error)
 }
 
 // ResolveHandler resolves generic documents.
@@ -39,29 +49,51 @@ func NewResolveHandler(resolver Resolver) *ResolveHandler {
 
 // Resolve resolves a document.
 func (o *ResolveHandler) Resolve(rw http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+
 	id := getID(req)
-	logger.Debugf("Resolving DID document for ID [%s]", id)
-	response, err := o.doResolve(id)
+	contentType := documentContentType(req.Header.Get(acceptHeader))
+
+	// representation depends on Accept header so caches must not serve it for other Accept values
+	rw.Header().Set("Vary", acceptHeader)
+
+	logger.Debugf("Resolving DID document for ID [%s] as [%s]", id, contentType)
+	response, err := o.doResolve(id, contentType)
 	if err != nil {
 		common.WriteError(rw, err.(*common.HTTPError).Status(), err)
 
 		return
 	}
+
+	etag := computeETag(response, contentType)
+	rw.Header().Set("ETag", etag)
+	rw.Header().Set("Cache-Control", defaultCacheControl)
+
+	if etagMatches(req.Header.Get("If-None-Match"), etag) {
+		logger.Debugf("... DID document for ID [%s] not modified", id)
+		rw.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	response.ResolutionMetadata = document.NewResolutionMetadata(contentType, time.Since(start))
 	logger.Debugf("... resolved DID document for ID [%s]: %s", id, response.Document)
-	common.WriteResponse(rw, http.StatusOK, response)
+	common.WriteResponseWithContentType(rw, http.StatusOK, contentType, response)
 }
 
-func (o *ResolveHandler) doResolve(id string) (*document.ResolutionResult, error) {
-	doc, err := o.resolver.ResolveDocument(id)
+func (o *ResolveHandler) doResolve(id, contentType string) (*document.ResolutionResult, error) {
+	doc, err := o.resolver.ResolveDocument(id, document.WithContentType(contentType))
 	if err != nil {
 		if strings.Contains(err.Error(), "bad request") {
 			return nil, common.NewHTTPError(http.StatusBadRequest, err)
 		}
 		if strings.Contains(err.Error(), "not found") {
-			return nil, common.NewHTTPError(http.StatusNotFound, errors.New("document not found"))
+			return nil, common.NewHTTPError(http.StatusNotFound,
+				protocol.NewCodedError(protocol.CodeDIDNotFound, errors.New("document not found")))
 		}
 		if strings.Contains(err.Error(), "was deactivated") {
-			return nil, common.NewHTTPError(http.StatusGone, errors.New("document is no longer available"))
+			return nil, common.NewHTTPError(http.StatusGone,
+				protocol.NewCodedError(protocol.CodeDIDDeactivated, errors.New("document is no longer available")))
 		}
 
 		logger.Errorf("internal server error:  %s", err.Error())
@@ -72,6 +104,51 @@ func (o *ResolveHandler) doResolve(id string) (*document.ResolutionResult, error
 	return doc, nil
 }
 
+// documentContentType returns DID document representation requested by Accept header: plain JSON
+// (application/did+json) if it is preferred over JSON-LD, otherwise JSON-LD (application/did+ld+json).
+func documentContentType(accept string) string {
+	for _, r := range parseAccept(accept) {
+		switch r.mediaType {
+		case document.ContentTypeDIDJSON:
+			return document.ContentTypeDIDJSON
+		case document.ContentTypeDIDLDJSON:
+			return document.ContentTypeDIDLDJSON
+		}
+	}
+
+	return document.ContentTypeDIDLDJSON
+}
+
+// computeETag returns strong entity tag derived from the last operation applied to the document (transaction
+// time and number of the create and the latest operation) and the published flag, so the tag changes exactly
+// when the document state changes. Content type is included since representations differ (see Vary header).
+func computeETag(result *document.ResolutionResult, contentType string) string {
+	state := fmt.Sprintf("%s|%v|%v.%v|%v.%v", contentType,
+		result.MethodMetadata[document.PublishedProperty],
+		result.DocumentMetadata[document.CreatedTransactionTimeProperty],
+		result.DocumentMetadata[document.CreatedTransactionNumberProperty],
+		result.DocumentMetadata[document.UpdatedTransactionTimeProperty],
+		result.DocumentMetadata[document.UpdatedTransactionNumberProperty])
+
+	hash := sha256.Sum256([]byte(state))
+
+	return `"` + encoder.EncodeToString(hash[:]) + `"`
+}
+
+// etagMatches returns true if If-None-Match header value ('*' or list of entity tags) matches the entity tag
+// (weak comparison is used as defined for If-None-Match).
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
 var getID = func(req *http.Request) string {
 	return mux.Vars(req)["id"]
 }
@@ -7,16 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package dochandler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
@@ -48,6 +52,94 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		require.Equal(t, http.StatusOK, rw.Code)
 		fmt.Printf("Response: %s\n", rw.Body.String())
 		require.Equal(t, "application/did+ld+json", rw.Header().Get("content-type"))
+
+		var rr document.ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+		require.Equal(t, result.Document.ID(), rr.Document.ID())
+		require.Equal(t, document.ContentTypeDIDLDJSON, rr.ResolutionMetadata[document.ContentTypeProperty])
+		require.Contains(t, rr.ResolutionMetadata, document.DurationProperty)
+	})
+	t.Run("Success - plain JSON", func(t *testing.T) {
+		docHandler := mocks.NewMockDocumentHandler().
+			WithNamespace(namespace)
+
+		create, err := getCreateRequest()
+		require.NoError(t, err)
+
+		bytes, err := canonicalizer.MarshalCanonical(create)
+		require.NoError(t, err)
+
+		result, err := docHandler.ProcessOperation(bytes, 0)
+		require.NoError(t, err)
+
+		getID = func(req *http.Request) string { return result.Document.ID() }
+		handler := NewResolveHandler(docHandler)
+
+		req := httptest.NewRequest(http.MethodGet, "/document", nil)
+		req.Header.Set("Accept", "application/did+ld+json;q=0.5, application/did+json")
+
+		rw := httptest.NewRecorder()
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDJSON, rw.Header().Get("content-type"))
+		require.Equal(t, "Accept", rw.Header().Get("Vary"))
+
+		var rr document.ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &rr))
+		require.Equal(t, result.Document.ID(), rr.Document.ID())
+		require.NotContains(t, rr.Document, document.ContextProperty)
+		require.Equal(t, document.ContentTypeDIDJSON, rr.ResolutionMetadata[document.ContentTypeProperty])
+
+		// JSON-LD is preferred
+		req.Header.Set("Accept", "application/did+ld+json, application/did+json;q=0.5")
+
+		rw = httptest.NewRecorder()
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, document.ContentTypeDIDLDJSON, rw.Header().Get("content-type"))
+	})
+	t.Run("Success - caching headers", func(t *testing.T) {
+		docHandler := mocks.NewMockDocumentHandler().
+			WithNamespace(namespace)
+
+		create, err := getCreateRequest()
+		require.NoError(t, err)
+
+		bytes, err := canonicalizer.MarshalCanonical(create)
+		require.NoError(t, err)
+
+		result, err := docHandler.ProcessOperation(bytes, 0)
+		require.NoError(t, err)
+
+		getID = func(req *http.Request) string { return result.Document.ID() }
+		handler := NewResolveHandler(docHandler)
+
+		rw := httptest.NewRecorder()
+		handler.Resolve(rw, httptest.NewRequest(http.MethodGet, "/document", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "no-cache", rw.Header().Get("Cache-Control"))
+
+		etag := rw.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		for _, ifNoneMatch := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+			rw = httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/document", nil)
+			req.Header.Set("If-None-Match", ifNoneMatch)
+
+			handler.Resolve(rw, req)
+			require.Equal(t, http.StatusNotModified, rw.Code, ifNoneMatch)
+			require.Equal(t, etag, rw.Header().Get("ETag"))
+			require.Empty(t, rw.Body.String())
+		}
+
+		rw = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/document", nil)
+		req.Header.Set("If-None-Match", `"other"`)
+
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, etag, rw.Header().Get("ETag"))
 	})
 	t.Run("Success with initial value", func(t *testing.T) {
 		docHandler := mocks.NewMockDocumentHandler().
@@ -95,6 +187,7 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusNotFound, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeDIDNotFound, "document not found")
 	})
 	t.Run("Error", func(t *testing.T) {
 		getID = func(req *http.Request) string {
@@ -143,9 +236,54 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusGone, rw.Code)
+		requireErrorResponse(t, rw, protocol.CodeDIDDeactivated, "document is no longer available")
 	})
 }
 
+func TestComputeETag(t *testing.T) {
+	result := &document.ResolutionResult{
+		Document:       document.Document{"id": "did:sidetree:abc"},
+		MethodMetadata: document.Metadata{document.PublishedProperty: true},
+		DocumentMetadata: document.Metadata{
+			document.CreatedTransactionTimeProperty:   uint64(10),
+			document.CreatedTransactionNumberProperty: uint64(1),
+		},
+	}
+
+	etag := computeETag(result, document.ContentTypeDIDLDJSON)
+	require.NotEmpty(t, etag)
+
+	// resolution metadata and re-marshalled document content don't affect entity tag
+	result.ResolutionMetadata = document.NewResolutionMetadata(document.ContentTypeDIDLDJSON, time.Second)
+	result.Document["service"] = []interface{}{}
+	require.Equal(t, etag, computeETag(result, document.ContentTypeDIDLDJSON))
+
+	// representation is different for other content type
+	require.NotEqual(t, etag, computeETag(result, document.ContentTypeDIDJSON))
+
+	// updated transaction time and number are set by every applied operation
+	result.DocumentMetadata[document.UpdatedTransactionTimeProperty] = uint64(11)
+	result.DocumentMetadata[document.UpdatedTransactionNumberProperty] = uint64(2)
+
+	etag2 := computeETag(result, document.ContentTypeDIDLDJSON)
+	require.NotEqual(t, etag, etag2)
+
+	result.DocumentMetadata[document.UpdatedTransactionNumberProperty] = uint64(3)
+	require.NotEqual(t, etag2, computeETag(result, document.ContentTypeDIDLDJSON))
+
+	// unpublished document
+	unpublished := &document.ResolutionResult{
+		Document:       document.Document{"id": "did:sidetree:abc"},
+		MethodMetadata: document.Metadata{document.PublishedProperty: false},
+	}
+
+	etag3 := computeETag(unpublished, document.ContentTypeDIDLDJSON)
+	require.NotEqual(t, etag, etag3)
+
+	unpublished.MethodMetadata[document.PublishedProperty] = true
+	require.NotEqual(t, etag3, computeETag(unpublished, document.ContentTypeDIDLDJSON))
+}
+
 func getCreateRequest() (*model.CreateRequest, error) {
 	delta, err := getDelta()
 	if err != nil {
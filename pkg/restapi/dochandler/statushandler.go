@@ -0,0 +1,156 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/batch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const anchorParam = "anchor"
+
+// OperationStatusProvider returns status of operations submitted to the batch writer.
+type OperationStatusProvider interface {
+	Get(uniqueSuffix string) (*batch.OperationStatus, error)
+	GetByAnchor(anchorString string) ([]*batch.OperationStatus, error)
+}
+
+// StatusResponse contains status of the latest operation submitted for a document (or status
+// of all operations in a batch).
+type StatusResponse struct {
+	Operations []*batch.OperationStatus `json:"operations"`
+	// NextCursor is cursor for the next page of batch operations (empty if this is the last page)
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// statusPosition is position of operation status in the batch (statuses are ordered by unique suffix).
+type statusPosition struct {
+	UniqueSuffix string `json:"uniqueSuffix"`
+}
+
+// StatusHandler returns status of submitted operations (queued, written, anchored or failed).
+type StatusHandler struct {
+	namespace string
+	provider  OperationStatusProvider
+}
+
+// NewStatusHandler returns a new operation status handler.
+func NewStatusHandler(namespace string, provider OperationStatusProvider) *StatusHandler {
+	return &StatusHandler{
+		namespace: namespace,
+		provider:  provider,
+	}
+}
+
+// GetStatus returns status of the latest operation submitted for the document specified by 'did' query
+// parameter or status of operations in the batch specified by 'anchor' query parameter (batch operations
+// are paged using 'limit' and 'cursor' query parameters).
+func (h *StatusHandler) GetStatus(rw http.ResponseWriter, req *http.Request) {
+	response, err := h.getStatus(req.URL.Query())
+	if err != nil {
+		common.WriteError(rw, err.(*common.HTTPError).Status(), err)
+
+		return
+	}
+
+	common.WriteResponseWithContentType(rw, http.StatusOK, "application/json", response)
+}
+
+func (h *StatusHandler) getStatus(query url.Values) (*StatusResponse, error) {
+	id := query.Get(didParam)
+	anchorString := query.Get(anchorParam)
+
+	switch {
+	case id != "" && anchorString != "":
+		return nil, common.NewHTTPError(http.StatusBadRequest,
+			errors.New("only one of 'did' and 'anchor' query parameters may be specified"))
+	case anchorString != "":
+		return h.getBatchStatus(anchorString, query)
+	case id != "":
+		uniqueSuffix, err := getSuffix(h.namespace, id)
+		if err != nil {
+			return nil, common.NewHTTPError(http.StatusBadRequest, err)
+		}
+
+		status, err := h.provider.Get(uniqueSuffix)
+		if err != nil {
+			return nil, newStatusError(err)
+		}
+
+		return &StatusResponse{Operations: []*batch.OperationStatus{status}}, nil
+	default:
+		return nil, common.NewHTTPError(http.StatusBadRequest,
+			errors.New("missing 'did' or 'anchor' query parameter"))
+	}
+}
+
+func (h *StatusHandler) getBatchStatus(anchorString string, query url.Values) (*StatusResponse, error) {
+	limit, err := getPageLimit(query)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	after := &statusPosition{}
+
+	if cursor := query.Get(cursorParam); cursor != "" {
+		err = decodeCursor(cursor, after)
+		if err != nil {
+			return nil, common.NewHTTPError(http.StatusBadRequest, err)
+		}
+	}
+
+	statuses, err := h.provider.GetByAnchor(anchorString)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	sorted := make([]*batch.OperationStatus, len(statuses))
+	copy(sorted, statuses)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UniqueSuffix < sorted[j].UniqueSuffix
+	})
+
+	response := &StatusResponse{Operations: []*batch.OperationStatus{}}
+
+	for _, status := range sorted {
+		if status.UniqueSuffix <= after.UniqueSuffix {
+			continue
+		}
+
+		if len(response.Operations) == limit {
+			response.NextCursor, err = encodeCursor(&statusPosition{
+				UniqueSuffix: response.Operations[limit-1].UniqueSuffix,
+			})
+			if err != nil {
+				return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+			}
+
+			break
+		}
+
+		response.Operations = append(response.Operations, status)
+	}
+
+	return response, nil
+}
+
+func newStatusError(err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return common.NewHTTPError(http.StatusNotFound, errors.New("operation status not found"))
+	}
+
+	logger.Errorf("internal server error:  %s", err.Error())
+
+	return common.NewHTTPError(http.StatusInternalServerError, err)
+}
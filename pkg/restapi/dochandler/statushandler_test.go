@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/batch"
+)
+
+func TestStatusHandler_GetStatus(t *testing.T) {
+	const suffix = "EiDOQXC2GnoVyHwIRbjhLx_cNc6vmZaS04SZjZdlLLAPRg"
+
+	tracker := batch.NewOperationStatusTracker()
+	tracker.Queued(&operation.QueuedOperation{UniqueSuffix: suffix})
+	tracker.Queued(&operation.QueuedOperation{UniqueSuffix: "suffix2"})
+	tracker.Written("anchor", []*operation.QueuedOperation{{UniqueSuffix: "suffix2"}})
+	tracker.Anchored("anchor", 10, 1)
+
+	handler := NewStatusHandler(namespace, tracker)
+
+	t.Run("success - did", func(t *testing.T) {
+		rw := getStatus(handler, "did="+namespace+":"+suffix)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "application/json", rw.Header().Get("content-type"))
+
+		var response StatusResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Operations, 1)
+		require.Equal(t, suffix, response.Operations[0].UniqueSuffix)
+		require.Equal(t, batch.StatusQueued, response.Operations[0].Status)
+	})
+
+	t.Run("success - anchor", func(t *testing.T) {
+		rw := getStatus(handler, "anchor=anchor")
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response StatusResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Operations, 1)
+		require.Equal(t, batch.StatusAnchored, response.Operations[0].Status)
+		require.Equal(t, uint64(10), response.Operations[0].TransactionTime)
+	})
+
+	t.Run("success - anchor (paged)", func(t *testing.T) {
+		tracker := batch.NewOperationStatusTracker()
+		tracker.Written("anchor", []*operation.QueuedOperation{
+			{UniqueSuffix: "suffix3"}, {UniqueSuffix: "suffix1"}, {UniqueSuffix: "suffix2"},
+		})
+
+		handler := NewStatusHandler(namespace, tracker)
+
+		rw := getStatus(handler, "anchor=anchor&limit=2")
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response StatusResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Operations, 2)
+		require.Equal(t, "suffix1", response.Operations[0].UniqueSuffix)
+		require.Equal(t, "suffix2", response.Operations[1].UniqueSuffix)
+		require.NotEmpty(t, response.NextCursor)
+
+		rw = getStatus(handler, "anchor=anchor&limit=2&cursor="+response.NextCursor)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		response = StatusResponse{}
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Operations, 1)
+		require.Equal(t, "suffix3", response.Operations[0].UniqueSuffix)
+		require.Empty(t, response.NextCursor)
+
+		rw = getStatus(handler, "anchor=anchor&limit=-1")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		rw = getStatus(handler, "anchor=anchor&cursor=invalid")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "invalid cursor")
+	})
+
+	t.Run("error - missing query parameter", func(t *testing.T) {
+		rw := getStatus(handler, "")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "missing 'did' or 'anchor' query parameter")
+	})
+
+	t.Run("error - both query parameters", func(t *testing.T) {
+		rw := getStatus(handler, "did="+namespace+":"+suffix+"&anchor=anchor")
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "only one of 'did' and 'anchor' query parameters may be specified")
+	})
+
+	t.Run("error - invalid did", func(t *testing.T) {
+		rw := getStatus(handler, "did=did:other:"+suffix)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		requireErrorResponse(t, rw, "bad_request", "did must start with configured namespace")
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		rw := getStatus(handler, "did="+namespace+":other")
+		require.Equal(t, http.StatusNotFound, rw.Code)
+		requireErrorResponse(t, rw, "not_found", "operation status not found")
+
+		rw = getStatus(handler, "anchor=other")
+		require.Equal(t, http.StatusNotFound, rw.Code)
+	})
+
+	t.Run("error - internal", func(t *testing.T) {
+		h := NewStatusHandler(namespace, &mockStatusProvider{err: errors.New("store error")})
+
+		rw := getStatus(h, "did="+namespace+":"+suffix)
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		requireErrorResponse(t, rw, "internal_error", "store error")
+	})
+}
+
+func getStatus(handler *StatusHandler, query string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+
+	handler.GetStatus(rw, httptest.NewRequest(http.MethodGet, "/operations/status?"+query, nil))
+
+	return rw
+}
+
+type mockStatusProvider struct {
+	err error
+}
+
+func (m *mockStatusProvider) Get(string) (*batch.OperationStatus, error) {
+	return nil, m.err
+}
+
+func (m *mockStatusProvider) GetByAnchor(string) ([]*batch.OperationStatus, error) {
+	return nil, m.err
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+// VersionInfo contains protocol version, its parameters and supported features.
+type VersionInfo struct {
+	Version      string                `json:"version"`
+	Protocol     protocol.Protocol     `json:"protocol"`
+	Capabilities protocol.Capabilities `json:"capabilities"`
+}
+
+// VersionsResponse contains protocol versions supported by the node.
+type VersionsResponse struct {
+	Namespace string         `json:"namespace"`
+	Current   string         `json:"current,omitempty"`
+	Versions  []*VersionInfo `json:"versions"`
+}
+
+// VersionsHandler returns protocol versions (their parameters and capabilities) supported by the node so that
+// clients can check limits (e.g. max operation size) and features (e.g. allowed patches, signature algorithms)
+// before constructing operations.
+type VersionsHandler struct {
+	response *VersionsResponse
+}
+
+// NewVersionsHandler returns a new versions handler for the given protocol versions.
+func NewVersionsHandler(namespace string, versions []protocol.Version) *VersionsHandler {
+	sorted := make([]protocol.Version, len(versions))
+	copy(sorted, versions)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Protocol().GenesisTime < sorted[j].Protocol().GenesisTime
+	})
+
+	response := &VersionsResponse{
+		Namespace: namespace,
+		Versions:  make([]*VersionInfo, len(sorted)),
+	}
+
+	for i, v := range sorted {
+		response.Versions[i] = &VersionInfo{
+			Version:      v.Version(),
+			Protocol:     v.Protocol(),
+			Capabilities: v.Capabilities(),
+		}
+	}
+
+	if len(sorted) > 0 {
+		// current version is the version with the latest genesis time
+		response.Current = sorted[len(sorted)-1].Version()
+	}
+
+	return &VersionsHandler{response: response}
+}
+
+// GetVersions returns protocol versions (ordered by genesis time) and their parameters.
+func (h *VersionsHandler) GetVersions(rw http.ResponseWriter, _ *http.Request) {
+	common.WriteResponse(rw, http.StatusOK, h.response)
+}
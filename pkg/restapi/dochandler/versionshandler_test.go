@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestVersionsHandler_GetVersions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p1 := mocks.GetDefaultProtocolParameters()
+
+		p2 := mocks.GetDefaultProtocolParameters()
+		p2.GenesisTime = 100
+		p2.MaxOperationSize = 5000
+
+		v1 := mocks.GetProtocolVersion(p1)
+
+		v2 := mocks.GetProtocolVersion(p2)
+		v2.VersionReturns("1.0")
+
+		handler := NewVersionsHandler(namespace, []protocol.Version{v2, v1})
+
+		rw := httptest.NewRecorder()
+		handler.GetVersions(rw, httptest.NewRequest(http.MethodGet, "/versions", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "application/did+ld+json", rw.Header().Get("content-type"))
+
+		var response VersionsResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Equal(t, namespace, response.Namespace)
+		require.Equal(t, "1.0", response.Current)
+		require.Len(t, response.Versions, 2)
+
+		require.Equal(t, mocks.CurrentVersion, response.Versions[0].Version)
+		require.Equal(t, p1.MaxOperationSize, response.Versions[0].Protocol.MaxOperationSize)
+		require.Equal(t, p1.MultihashAlgorithms, response.Versions[0].Protocol.MultihashAlgorithms)
+		require.Equal(t, p1.Patches, response.Versions[0].Protocol.Patches)
+		require.Equal(t, p1.Patches, response.Versions[0].Capabilities.PatchActions)
+		require.Equal(t, p1.SignatureAlgorithms, response.Versions[0].Capabilities.SignatureAlgorithms)
+		require.Equal(t, p1.MultihashAlgorithms, response.Versions[0].Capabilities.MultihashAlgorithms)
+
+		require.Equal(t, "1.0", response.Versions[1].Version)
+		require.Equal(t, uint64(100), response.Versions[1].Protocol.GenesisTime)
+		require.Equal(t, uint(5000), response.Versions[1].Protocol.MaxOperationSize)
+	})
+
+	t.Run("no versions", func(t *testing.T) {
+		handler := NewVersionsHandler(namespace, nil)
+
+		rw := httptest.NewRecorder()
+		handler.GetVersions(rw, httptest.NewRequest(http.MethodGet, "/versions", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response VersionsResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Empty(t, response.Current)
+		require.Empty(t, response.Versions)
+	})
+}
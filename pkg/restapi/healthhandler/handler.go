@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package healthhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+var logger = log.New("sidetree-core-restapi-healthhandler")
+
+const (
+	// StatusOK is reported for successful checks (and overall status if all checks succeeded).
+	StatusOK = "ok"
+
+	// StatusUnavailable is reported as overall status if any of the checks failed.
+	StatusUnavailable = "unavailable"
+
+	// HealthPath is default path of liveness endpoint.
+	HealthPath = "/healthz"
+
+	// ReadyPath is default path of readiness endpoint.
+	ReadyPath = "/readyz"
+)
+
+// CheckFunc checks state of a subsystem (e.g. operation store, CAS client, observer); error is returned
+// if the subsystem is not healthy (or not ready).
+type CheckFunc func() error
+
+// Option is an option for health handler.
+type Option func(opts *HealthHandler)
+
+// WithCheck adds named check to the handler (checks are executed in the order they were added).
+func WithCheck(name string, check CheckFunc) Option {
+	return func(opts *HealthHandler) {
+		opts.checks = append(opts.checks, &namedCheck{name: name, check: check})
+	}
+}
+
+// Response contains overall status and status of each check.
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// HealthHandler aggregates state of subsystems; 200 is returned if all checks succeed and 503 otherwise.
+// The same handler is used for liveness (/healthz) and readiness (/readyz) with different checks.
+type HealthHandler struct {
+	path   string
+	checks []*namedCheck
+}
+
+// New returns a new health handler.
+func New(path string, opts ...Option) *HealthHandler {
+	h := &HealthHandler{path: path}
+
+	// apply options
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Path returns the context path.
+func (h *HealthHandler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *HealthHandler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *HealthHandler) Handler() common.HTTPRequestHandler {
+	return h.check
+}
+
+func (h *HealthHandler) check(rw http.ResponseWriter, _ *http.Request) {
+	response := &Response{
+		Status: StatusOK,
+		Checks: make(map[string]string),
+	}
+
+	for _, c := range h.checks {
+		if err := c.check(); err != nil {
+			logger.Warnf("check [%s] failed: %s", c.name, err.Error())
+
+			response.Status = StatusUnavailable
+			response.Checks[c.name] = err.Error()
+
+			continue
+		}
+
+		response.Checks[c.name] = StatusOK
+	}
+
+	status := http.StatusOK
+	if response.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	common.WriteResponseWithContentType(rw, status, "application/json", response)
+}
+
+// Stoppable is implemented by subsystems that can be stopped (e.g. batch writer, observer).
+type Stoppable interface {
+	Stopped() bool
+}
+
+// StoppedCheck returns check that fails if the subsystem has been stopped.
+func StoppedCheck(s Stoppable) CheckFunc {
+	return func() error {
+		if s.Stopped() {
+			return errors.New("stopped")
+		}
+
+		return nil
+	}
+}
+
+// LagCheck returns check that fails if the latest transaction time handled by the observer is more than maxLag
+// behind the chain head (e.g. node is not ready until observer catches up with the chain).
+func LagCheck(lastTransactionTime func() uint64, chainHead func() (uint64, error), maxLag uint64) CheckFunc {
+	return func() error {
+		head, err := chainHead()
+		if err != nil {
+			return fmt.Errorf("failed to get chain head: %s", err.Error())
+		}
+
+		last := lastTransactionTime()
+
+		if head > last && head-last > maxLag {
+			return fmt.Errorf("%d behind chain head (maximum %d)", head-last, maxLag)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package healthhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Run("success - no checks", func(t *testing.T) {
+		h := New(HealthPath)
+		require.Equal(t, HealthPath, h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+
+		response := check(t, h, http.StatusOK)
+		require.Equal(t, StatusOK, response.Status)
+		require.Empty(t, response.Checks)
+	})
+
+	t.Run("success - all checks pass", func(t *testing.T) {
+		h := New(ReadyPath,
+			WithCheck("store", func() error { return nil }),
+			WithCheck("writer", StoppedCheck(&mockStoppable{})),
+		)
+
+		response := check(t, h, http.StatusOK)
+		require.Equal(t, StatusOK, response.Status)
+		require.Equal(t, map[string]string{"store": StatusOK, "writer": StatusOK}, response.Checks)
+	})
+
+	t.Run("error - check fails", func(t *testing.T) {
+		h := New(ReadyPath,
+			WithCheck("store", func() error { return errors.New("connection refused") }),
+			WithCheck("writer", StoppedCheck(&mockStoppable{stopped: true})),
+			WithCheck("cas", func() error { return nil }),
+		)
+
+		response := check(t, h, http.StatusServiceUnavailable)
+		require.Equal(t, StatusUnavailable, response.Status)
+		require.Equal(t, "connection refused", response.Checks["store"])
+		require.Equal(t, "stopped", response.Checks["writer"])
+		require.Equal(t, StatusOK, response.Checks["cas"])
+	})
+}
+
+func TestLagCheck(t *testing.T) {
+	last := func() uint64 { return 100 }
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, LagCheck(last, func() (uint64, error) { return 110, nil }, 10)())
+		require.NoError(t, LagCheck(last, func() (uint64, error) { return 90, nil }, 10)())
+	})
+
+	t.Run("error - too far behind", func(t *testing.T) {
+		err := LagCheck(last, func() (uint64, error) { return 111, nil }, 10)()
+		require.Error(t, err)
+		require.Equal(t, "11 behind chain head (maximum 10)", err.Error())
+	})
+
+	t.Run("error - chain head error", func(t *testing.T) {
+		err := LagCheck(last, func() (uint64, error) { return 0, errors.New("ledger error") }, 10)()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get chain head: ledger error")
+	})
+}
+
+func check(t *testing.T, h *HealthHandler, status int) *Response {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	h.Handler()(rw, httptest.NewRequest(http.MethodGet, h.Path(), nil))
+	require.Equal(t, status, rw.Code)
+	require.Equal(t, "application/json", rw.Header().Get("content-type"))
+
+	var response Response
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+
+	return &response
+}
+
+type mockStoppable struct {
+	stopped bool
+}
+
+func (m *mockStoppable) Stopped() bool {
+	return m.stopped
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricshandler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+var logger = log.New("sidetree-core-restapi-metricshandler")
+
+const contentType = "text/plain; version=0.0.4"
+
+// Gatherer writes metrics in Prometheus text exposition format.
+type Gatherer interface {
+	WriteMetrics(w io.Writer) error
+}
+
+// MetricsHandler exposes metrics (e.g. for scraping by Prometheus).
+type MetricsHandler struct {
+	path     string
+	gatherer Gatherer
+}
+
+// New returns a new metrics handler.
+func New(path string, gatherer Gatherer) *MetricsHandler {
+	return &MetricsHandler{
+		path:     path,
+		gatherer: gatherer,
+	}
+}
+
+// Path returns the context path.
+func (h *MetricsHandler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *MetricsHandler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *MetricsHandler) Handler() common.HTTPRequestHandler {
+	return h.writeMetrics
+}
+
+func (h *MetricsHandler) writeMetrics(rw http.ResponseWriter, _ *http.Request) {
+	buf := &bytes.Buffer{}
+
+	if err := h.gatherer.WriteMetrics(buf); err != nil {
+		common.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(http.StatusOK)
+
+	if _, err := rw.Write(buf.Bytes()); err != nil {
+		logger.Errorf("Unable to write response: %s", err)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metricshandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/metrics"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		registry.HTTPRequest("GET /identifiers/{id}", http.StatusOK, time.Second)
+
+		h := New("/metrics", registry)
+		require.Equal(t, "/metrics", h.Path())
+		require.Equal(t, http.MethodGet, h.Method())
+
+		rw := httptest.NewRecorder()
+		h.Handler()(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, contentType, rw.Header().Get("content-type"))
+		require.Contains(t, rw.Body.String(), `sidetree_http_requests_total{handler="GET /identifiers/{id}",code="200"} 1`)
+	})
+
+	t.Run("error - gatherer error", func(t *testing.T) {
+		h := New("/metrics", &failingGatherer{})
+
+		rw := httptest.NewRecorder()
+		h.Handler()(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.Contains(t, rw.Body.String(), "gather error")
+	})
+}
+
+type failingGatherer struct{}
+
+func (g *failingGatherer) WriteMetrics(io.Writer) error {
+	return errors.New("gather error")
+}
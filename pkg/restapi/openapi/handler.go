@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+// DefaultPath is default path of OpenAPI document endpoint.
+const DefaultPath = "/openapi.json"
+
+// Handler serves OpenAPI document.
+type Handler struct {
+	path string
+	doc  *Document
+}
+
+// NewHandler returns a new handler serving OpenAPI document generated for the given handlers.
+func NewHandler(path string, info Info, handlers ...common.HTTPHandler) *Handler {
+	return &Handler{
+		path: path,
+		doc:  Generate(info, handlers...),
+	}
+}
+
+// Path returns the context path.
+func (h *Handler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *Handler) Handler() common.HTTPRequestHandler {
+	return func(rw http.ResponseWriter, _ *http.Request) {
+		common.WriteResponseWithContentType(rw, http.StatusOK, "application/json", h.doc)
+	}
+}
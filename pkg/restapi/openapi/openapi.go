@@ -0,0 +1,240 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package openapi generates OpenAPI v3 specification for REST handlers. Paths and methods are taken from
+// handler descriptors while operation details (request and response models) are provided by handlers that
+// implement Describer; request and response schemas are generated from Go models (JSON tags).
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	// Version is OpenAPI specification version of generated documents.
+	Version = "3.0.3"
+
+	defaultContentType = "application/did+ld+json"
+	errorContentType   = "application/json"
+)
+
+// nolint:gochecknoglobals
+var pathParamRegex = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+// Describer is implemented by handlers that provide OpenAPI operation details.
+type Describer interface {
+	OpenAPISpec() *Spec
+}
+
+// Spec describes operation of a handler.
+type Spec struct {
+	OperationID string
+	Summary     string
+	Query       []*QueryParam
+	// Request contains request body models (request body schema is 'oneOf' if there is more than one model).
+	Request []interface{}
+	// RequestContentType is request body content type (default is application/did+ld+json).
+	RequestContentType string
+	Responses          []*ResponseSpec
+}
+
+// QueryParam describes query parameter.
+type QueryParam struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// ResponseSpec describes response with the given status code.
+type ResponseSpec struct {
+	Status      int
+	Description string
+	// Body is response model (nil if response doesn't have a body).
+	Body interface{}
+	// ContentType is response content type (default is application/did+ld+json).
+	ContentType string
+}
+
+// Info contains API metadata.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document is OpenAPI document.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components *Components                      `json:"components,omitempty"`
+}
+
+// Components contains reusable schemas.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Operation is OpenAPI operation.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is OpenAPI (path or query) parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is OpenAPI request body.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is OpenAPI response.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType contains schema of the content.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Generate generates OpenAPI document for the given handlers.
+func Generate(info Info, handlers ...common.HTTPHandler) *Document {
+	g := newSchemaGenerator()
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+	}
+
+	for _, h := range handlers {
+		path := pathParamRegex.ReplaceAllString(h.Path(), "{$1}")
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+
+		doc.Paths[path][strings.ToLower(h.Method())] = g.operation(h)
+	}
+
+	if len(g.schemas) > 0 {
+		doc.Components = &Components{Schemas: g.schemas}
+	}
+
+	return doc
+}
+
+func (g *schemaGenerator) operation(h common.HTTPHandler) *Operation {
+	spec := &Spec{}
+	if d, ok := h.(Describer); ok && d.OpenAPISpec() != nil {
+		spec = d.OpenAPISpec()
+	}
+
+	op := &Operation{
+		OperationID: spec.OperationID,
+		Summary:     spec.Summary,
+		Parameters:  pathParameters(h.Path()),
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, q := range spec.Query {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        q.Name,
+			In:          "query",
+			Description: q.Description,
+			Required:    q.Required,
+			Schema:      &Schema{Type: typeString},
+		})
+	}
+
+	if len(spec.Request) > 0 {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				contentTypeOrDefault(spec.RequestContentType): {Schema: g.oneOf(spec.Request)},
+			},
+		}
+	}
+
+	for _, r := range spec.Responses {
+		response := &Response{Description: r.Description}
+		if response.Description == "" {
+			response.Description = http.StatusText(r.Status)
+		}
+
+		if r.Body != nil {
+			response.Content = map[string]*MediaType{
+				contentTypeOrDefault(r.ContentType): {Schema: g.schema(r.Body)},
+			}
+		}
+
+		op.Responses[strconv.Itoa(r.Status)] = response
+	}
+
+	// errors are returned as JSON error response (code and message)
+	op.Responses["default"] = &Response{
+		Description: "error",
+		Content: map[string]*MediaType{
+			errorContentType: {Schema: g.schema(&common.ErrorResponse{})},
+		},
+	}
+
+	return op
+}
+
+func (g *schemaGenerator) oneOf(models []interface{}) *Schema {
+	if len(models) == 1 {
+		return g.schema(models[0])
+	}
+
+	schema := &Schema{}
+	for _, m := range models {
+		schema.OneOf = append(schema.OneOf, g.schema(m))
+	}
+
+	return schema
+}
+
+func pathParameters(path string) []*Parameter {
+	var params []*Parameter
+
+	for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+		params = append(params, &Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: typeString},
+		})
+	}
+
+	return params
+}
+
+func contentTypeOrDefault(contentType string) string {
+	if contentType == "" {
+		return defaultContentType
+	}
+
+	return contentType
+}
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+type request struct {
+	Type     string                 `json:"type"`
+	Values   []uint64               `json:"values,omitempty"`
+	Data     []byte                 `json:"data"`
+	Enabled  bool                   `json:"enabled"`
+	Score    float64                `json:"score"`
+	Extra    map[string]interface{} `json:"extra"`
+	Child    *request               `json:"child,omitempty"`
+	Ignored  string                 `json:"-"`
+	NoTag    string
+	internal string
+}
+
+type response struct {
+	ID string `json:"id"`
+}
+
+type otherRequest struct {
+	Name string `json:"name"`
+}
+
+func TestGenerate(t *testing.T) {
+	handlers := []common.HTTPHandler{
+		&describedHandler{
+			path:   "/sidetree/operations",
+			method: http.MethodPost,
+			spec: &Spec{
+				OperationID: "submit",
+				Summary:     "Submits operation.",
+				Request:     []interface{}{&request{}, &otherRequest{}},
+				Responses:   []*ResponseSpec{{Status: http.StatusOK, Body: &response{}}},
+			},
+		},
+		&describedHandler{
+			path:   "/sidetree/identifiers/{id:.+}",
+			method: http.MethodGet,
+			spec: &Spec{
+				Query:     []*QueryParam{{Name: "version", Required: false}},
+				Responses: []*ResponseSpec{{Status: http.StatusGone, Description: "deactivated"}},
+			},
+		},
+		&describedHandler{path: "/sidetree/identifiers/{id:.+}", method: http.MethodDelete},
+	}
+
+	doc := Generate(Info{Title: "Sidetree", Version: "1.0"}, handlers...)
+	require.Equal(t, Version, doc.OpenAPI)
+	require.Equal(t, "Sidetree", doc.Info.Title)
+	require.Len(t, doc.Paths, 2)
+
+	submit := doc.Paths["/sidetree/operations"]["post"]
+	require.NotNil(t, submit)
+	require.Equal(t, "submit", submit.OperationID)
+	require.Empty(t, submit.Parameters)
+
+	body := submit.RequestBody.Content[defaultContentType].Schema
+	require.Len(t, body.OneOf, 2)
+	require.Equal(t, "#/components/schemas/request", body.OneOf[0].Ref)
+	require.Equal(t, "#/components/schemas/otherRequest", body.OneOf[1].Ref)
+
+	require.Equal(t, "OK", submit.Responses["200"].Description)
+	require.Equal(t, "#/components/schemas/response", submit.Responses["200"].Content[defaultContentType].Schema.Ref)
+	require.Equal(t, "#/components/schemas/ErrorResponse", submit.Responses["default"].Content[errorContentType].Schema.Ref)
+
+	resolve := doc.Paths["/sidetree/identifiers/{id}"]["get"]
+	require.NotNil(t, resolve)
+	require.Len(t, resolve.Parameters, 2)
+	require.Equal(t, &Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: typeString}}, resolve.Parameters[0])
+	require.Equal(t, "version", resolve.Parameters[1].Name)
+	require.Equal(t, "query", resolve.Parameters[1].In)
+	require.Equal(t, "deactivated", resolve.Responses["410"].Description)
+	require.Nil(t, resolve.Responses["410"].Content)
+
+	undescribed := doc.Paths["/sidetree/identifiers/{id}"]["delete"]
+	require.NotNil(t, undescribed)
+	require.Len(t, undescribed.Responses, 1)
+
+	schema := doc.Components.Schemas["request"]
+	require.NotNil(t, schema)
+	require.Equal(t, typeObject, schema.Type)
+	require.Len(t, schema.Properties, 8)
+	require.Equal(t, &Schema{Type: typeString}, schema.Properties["type"])
+	require.Equal(t, &Schema{Type: typeArray, Items: &Schema{Type: typeInteger}}, schema.Properties["values"])
+	require.Equal(t, &Schema{Type: typeString, Format: "byte"}, schema.Properties["data"])
+	require.Equal(t, &Schema{Type: typeBoolean}, schema.Properties["enabled"])
+	require.Equal(t, &Schema{Type: typeNumber}, schema.Properties["score"])
+	require.Equal(t, &Schema{Type: typeObject, AdditionalProperties: &Schema{}}, schema.Properties["extra"])
+	require.Equal(t, &Schema{Ref: "#/components/schemas/request"}, schema.Properties["child"])
+	require.Equal(t, &Schema{Type: typeString}, schema.Properties["NoTag"])
+
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(DefaultPath, Info{Title: "Sidetree", Version: "1.0"},
+		&describedHandler{path: "/operations", method: http.MethodPost})
+	require.Equal(t, DefaultPath, h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+
+	rw := httptest.NewRecorder()
+	h.Handler()(rw, httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Equal(t, "application/json", rw.Header().Get("content-type"))
+
+	var doc Document
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &doc))
+	require.Equal(t, Version, doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/operations")
+}
+
+type describedHandler struct {
+	path   string
+	method string
+	spec   *Spec
+}
+
+func (h *describedHandler) Path() string {
+	return h.path
+}
+
+func (h *describedHandler) Method() string {
+	return h.method
+}
+
+func (h *describedHandler) Handler() common.HTTPRequestHandler {
+	return func(http.ResponseWriter, *http.Request) {}
+}
+
+func (h *describedHandler) OpenAPISpec() *Spec {
+	return h.spec
+}
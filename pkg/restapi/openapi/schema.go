@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	typeString  = "string"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeBoolean = "boolean"
+	typeArray   = "array"
+	typeObject  = "object"
+
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// Schema is OpenAPI schema object.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// schemaGenerator generates schemas from Go models; named structs are added to component schemas
+// and referenced.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{schemas: make(map[string]*Schema)}
+}
+
+func (g *schemaGenerator) schema(model interface{}) *Schema {
+	return g.typeSchema(reflect.TypeOf(model))
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: typeString}
+	case reflect.Bool:
+		return &Schema{Type: typeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: typeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: typeNumber}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are marshalled as base64 strings
+			return &Schema{Type: typeString, Format: "byte"}
+		}
+
+		return &Schema{Type: typeArray, Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: typeObject, AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interface values may be of any type
+		return &Schema{}
+	}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	name := t.Name()
+	if name == "" {
+		return g.properties(t)
+	}
+
+	if _, ok := g.schemas[name]; !ok {
+		// placeholder prevents infinite recursion for recursive types
+		g.schemas[name] = &Schema{}
+		g.schemas[name] = g.properties(t)
+	}
+
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func (g *schemaGenerator) properties(t reflect.Type) *Schema {
+	schema := &Schema{Type: typeObject, Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+
+		name := field.Name
+
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		schema.Properties[name] = g.typeSchema(field.Type)
+	}
+
+	return schema
+}
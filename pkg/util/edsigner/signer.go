@@ -9,6 +9,7 @@ package edsigner
 import (
 	"crypto/ed25519"
 	"errors"
+	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
@@ -46,5 +47,9 @@ func (signer *Signer) Sign(msg []byte) ([]byte, error) {
 		return nil, errors.New("invalid private key size")
 	}
 
+	if signer.alg != "" && signer.alg != jws.AlgorithmEdDSA {
+		return nil, fmt.Errorf("algorithm '%s' is not supported for ED25519 key", signer.alg)
+	}
+
 	return ed25519.Sign(signer.privateKey, msg), nil
 }
@@ -37,6 +37,15 @@ func TestSign(t *testing.T) {
 		require.Nil(t, signature)
 		require.Contains(t, err.Error(), "invalid private key size")
 	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		signer := New(privateKey, "ES256", "key-1")
+
+		signature, err := signer.Sign(msg)
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "algorithm 'ES256' is not supported for ED25519 key")
+	})
 }
 
 func TestHeaders(t *testing.T) {
@@ -0,0 +1,767 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package jsonschema implements validation of JSON values against JSON Schema.
+//
+// Supported keywords (validation vocabulary of JSON Schema draft-07 without formats):
+// type, enum, const, properties, required, additionalProperties, patternProperties, minProperties,
+// maxProperties, items (single schema), minItems, maxItems, uniqueItems, minLength, maxLength, pattern,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, allOf, anyOf, oneOf, not and
+// local references ($ref to '#', '#/definitions/...' or '#/$defs/...'). Other keywords are ignored.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeObject  = "object"
+	typeArray   = "array"
+	typeNumber  = "number"
+	typeInteger = "integer"
+	typeString  = "string"
+)
+
+// Schema is compiled JSON Schema.
+type Schema struct {
+	root *node
+}
+
+type node struct {
+	ref    string
+	target *node
+
+	// boolean schema (true accepts and false rejects any value)
+	boolean *bool
+
+	types []string
+	enum  []interface{}
+	cnst  *constValue
+
+	properties           map[string]*node
+	required             []string
+	additionalProperties *node
+	patternProperties    map[*regexp.Regexp]*node
+	minProperties        *int
+	maxProperties        *int
+
+	items       *node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+}
+
+type constValue struct {
+	value interface{}
+}
+
+type compiler struct {
+	root  map[string]interface{}
+	nodes map[string]*node
+}
+
+// New compiles JSON Schema.
+func New(schema []byte) (*Schema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(schema, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %s", err.Error())
+	}
+
+	rootMap, _ := raw.(map[string]interface{})
+
+	c := &compiler{root: rootMap, nodes: make(map[string]*node)}
+
+	root, err := c.compile(raw, "#")
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err.Error())
+	}
+
+	c.nodes["#"] = root
+
+	if err := c.resolveRefs(root, make(map[*node]bool)); err != nil {
+		return nil, fmt.Errorf("invalid schema: %s", err.Error())
+	}
+
+	return &Schema{root: root}, nil
+}
+
+// Validate validates JSON value (as produced by encoding/json) against the schema; returned error
+// contains JSON pointer of the invalid value.
+func (s *Schema) Validate(value interface{}) error {
+	return s.root.validate(normalize(value), "")
+}
+
+// ValidateBytes validates JSON document against the schema.
+func (s *Schema) ValidateBytes(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to parse document: %s", err.Error())
+	}
+
+	return s.Validate(value)
+}
+
+// normalize converts named map and slice types (e.g. document.Document) to their JSON representation.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, float64, map[string]interface{}, []interface{}:
+		return v
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+//nolint:gocyclo,funlen
+func (c *compiler) compile(raw interface{}, path string) (*node, error) {
+	if b, ok := raw.(bool); ok {
+		return &node{boolean: &b}, nil
+	}
+
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", path)
+	}
+
+	n := &node{}
+
+	if ref, ok := m["$ref"]; ok {
+		refStr, ok := ref.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: $ref must be a string", path)
+		}
+
+		// $ref overrides all other keywords (draft-07)
+		n.ref = refStr
+
+		return n, nil
+	}
+
+	var err error
+
+	if n.types, err = getTypes(m["type"], path); err != nil {
+		return nil, err
+	}
+
+	if enum, ok := m["enum"]; ok {
+		if n.enum, ok = enum.([]interface{}); !ok {
+			return nil, fmt.Errorf("%s: enum must be an array", path)
+		}
+	}
+
+	if cnst, ok := m["const"]; ok {
+		n.cnst = &constValue{value: cnst}
+	}
+
+	if n.properties, err = c.compileMap(m["properties"], path+"/properties"); err != nil {
+		return nil, err
+	}
+
+	if n.required, err = getStrings(m["required"], path+"/required"); err != nil {
+		return nil, err
+	}
+
+	if additional, ok := m["additionalProperties"]; ok {
+		if n.additionalProperties, err = c.compile(additional, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+
+	patternProperties, err := c.compileMap(m["patternProperties"], path+"/patternProperties")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(patternProperties) > 0 {
+		n.patternProperties = make(map[*regexp.Regexp]*node)
+
+		for pattern, schema := range patternProperties {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s/patternProperties: invalid pattern '%s': %s", path, pattern, err.Error())
+			}
+
+			n.patternProperties[re] = schema
+		}
+	}
+
+	if items, ok := m["items"]; ok {
+		if n.items, err = c.compile(items, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+
+	n.uniqueItems, _ = m["uniqueItems"].(bool)
+
+	if pattern, ok := m["pattern"]; ok {
+		patternStr, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: pattern must be a string", path)
+		}
+
+		if n.pattern, err = regexp.Compile(patternStr); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern '%s': %s", path, patternStr, err.Error())
+		}
+	}
+
+	for keyword, target := range map[string]**int{
+		"minProperties": &n.minProperties,
+		"maxProperties": &n.maxProperties,
+		"minItems":      &n.minItems,
+		"maxItems":      &n.maxItems,
+		"minLength":     &n.minLength,
+		"maxLength":     &n.maxLength,
+	} {
+		if *target, err = getInt(m, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for keyword, target := range map[string]**float64{
+		"minimum":          &n.minimum,
+		"maximum":          &n.maximum,
+		"exclusiveMinimum": &n.exclusiveMinimum,
+		"exclusiveMaximum": &n.exclusiveMaximum,
+		"multipleOf":       &n.multipleOf,
+	} {
+		if *target, err = getNumber(m, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for keyword, target := range map[string]*[]*node{
+		"allOf": &n.allOf,
+		"anyOf": &n.anyOf,
+		"oneOf": &n.oneOf,
+	} {
+		if *target, err = c.compileList(m[keyword], path+"/"+keyword); err != nil {
+			return nil, err
+		}
+	}
+
+	if not, ok := m["not"]; ok {
+		if n.not, err = c.compile(not, path+"/not"); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+func (c *compiler) compileMap(raw interface{}, path string) (map[string]*node, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an object", path)
+	}
+
+	nodes := make(map[string]*node)
+
+	for key, value := range m {
+		n, err := c.compile(value, path+"/"+key)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[key] = n
+	}
+
+	return nodes, nil
+}
+
+func (c *compiler) compileList(raw interface{}, path string) ([]*node, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%s: must be a non-empty array", path)
+	}
+
+	nodes := make([]*node, len(list))
+
+	for i, value := range list {
+		n, err := c.compile(value, fmt.Sprintf("%s/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+
+		nodes[i] = n
+	}
+
+	return nodes, nil
+}
+
+// resolveRefs resolves local references; referenced schemas are compiled once (recursive schemas are supported).
+func (c *compiler) resolveRefs(n *node, visited map[*node]bool) error {
+	if n == nil || visited[n] {
+		return nil
+	}
+
+	visited[n] = true
+
+	if n.ref != "" {
+		target, err := c.resolve(n.ref)
+		if err != nil {
+			return err
+		}
+
+		n.target = target
+
+		return c.resolveRefs(target, visited)
+	}
+
+	children := []*node{n.additionalProperties, n.items, n.not}
+	children = append(children, n.allOf...)
+	children = append(children, n.anyOf...)
+	children = append(children, n.oneOf...)
+
+	for _, child := range n.properties {
+		children = append(children, child)
+	}
+
+	for _, child := range n.patternProperties {
+		children = append(children, child)
+	}
+
+	for _, child := range children {
+		if err := c.resolveRefs(child, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *compiler) resolve(ref string) (*node, error) {
+	if n, ok := c.nodes[ref]; ok {
+		return n, nil
+	}
+
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported reference '%s': only local references are supported", ref)
+	}
+
+	var current interface{} = c.root
+
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("reference '%s' cannot be resolved", ref)
+		}
+
+		if current, ok = m[token]; !ok {
+			return nil, fmt.Errorf("reference '%s' cannot be resolved", ref)
+		}
+	}
+
+	n, err := c.compile(current, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	c.nodes[ref] = n
+
+	return n, nil
+}
+
+func getTypes(raw interface{}, path string) ([]string, error) {
+	switch t := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{t}, nil
+	default:
+		return getStrings(raw, path+"/type")
+	}
+}
+
+func getStrings(raw interface{}, path string) ([]string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array of strings", path)
+	}
+
+	values := make([]string, len(list))
+
+	for i, v := range list {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: must be an array of strings", path)
+		}
+
+		values[i] = s
+	}
+
+	return values, nil
+}
+
+func getInt(m map[string]interface{}, keyword, path string) (*int, error) {
+	f, err := getNumber(m, keyword, path)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	if *f < 0 || *f != math.Trunc(*f) {
+		return nil, fmt.Errorf("%s: %s must be a non-negative integer", path, keyword)
+	}
+
+	i := int(*f)
+
+	return &i, nil
+}
+
+func getNumber(m map[string]interface{}, keyword, path string) (*float64, error) {
+	raw, ok := m[keyword]
+	if !ok {
+		return nil, nil
+	}
+
+	f, ok := raw.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s must be a number", path, keyword)
+	}
+
+	return &f, nil
+}
+
+//nolint:gocyclo
+func (n *node) validate(value interface{}, path string) error {
+	if n.target != nil {
+		return n.target.validate(value, path)
+	}
+
+	if n.boolean != nil {
+		if !*n.boolean {
+			return newError(path, "value is not allowed")
+		}
+
+		return nil
+	}
+
+	if len(n.types) > 0 && !matchesType(value, n.types) {
+		return newError(path, fmt.Sprintf("expected %s, got %s", strings.Join(n.types, " or "), typeOf(value)))
+	}
+
+	if n.enum != nil && !containsValue(n.enum, value) {
+		return newError(path, "value is not one of the allowed values")
+	}
+
+	if n.cnst != nil && !reflect.DeepEqual(n.cnst.value, value) {
+		return newError(path, "value does not match constant value")
+	}
+
+	var err error
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		err = n.validateObject(v, path)
+	case []interface{}:
+		err = n.validateArray(v, path)
+	case string:
+		err = n.validateString(v, path)
+	case float64:
+		err = n.validateNumber(v, path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return n.validateCombinators(value, path)
+}
+
+func (n *node) validateObject(obj map[string]interface{}, path string) error {
+	if n.minProperties != nil && len(obj) < *n.minProperties {
+		return newError(path, fmt.Sprintf("object must have at least %d properties", *n.minProperties))
+	}
+
+	if n.maxProperties != nil && len(obj) > *n.maxProperties {
+		return newError(path, fmt.Sprintf("object must have at most %d properties", *n.maxProperties))
+	}
+
+	for _, required := range n.required {
+		if _, ok := obj[required]; !ok {
+			return newError(path, fmt.Sprintf("missing required property '%s'", required))
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+
+	// sorted for deterministic errors
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propertyPath := path + "/" + escape(key)
+		matched := false
+
+		if schema, ok := n.properties[key]; ok {
+			matched = true
+
+			if err := schema.validate(obj[key], propertyPath); err != nil {
+				return err
+			}
+		}
+
+		for re, schema := range n.patternProperties {
+			if !re.MatchString(key) {
+				continue
+			}
+
+			matched = true
+
+			if err := schema.validate(obj[key], propertyPath); err != nil {
+				return err
+			}
+		}
+
+		if !matched && n.additionalProperties != nil {
+			if n.additionalProperties.isFalse() {
+				return newError(path, fmt.Sprintf("property '%s' is not allowed", key))
+			}
+
+			if err := n.additionalProperties.validate(obj[key], propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (n *node) validateArray(arr []interface{}, path string) error {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		return newError(path, fmt.Sprintf("array must have at least %d items", *n.minItems))
+	}
+
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		return newError(path, fmt.Sprintf("array must have at most %d items", *n.maxItems))
+	}
+
+	if n.uniqueItems {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					return newError(path, fmt.Sprintf("array items %d and %d are equal", i, j))
+				}
+			}
+		}
+	}
+
+	if n.items == nil {
+		return nil
+	}
+
+	for i, item := range arr {
+		if err := n.items.validate(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *node) validateString(s, path string) error {
+	length := utf8.RuneCountInString(s)
+
+	if n.minLength != nil && length < *n.minLength {
+		return newError(path, fmt.Sprintf("string length must be at least %d", *n.minLength))
+	}
+
+	if n.maxLength != nil && length > *n.maxLength {
+		return newError(path, fmt.Sprintf("string length must be at most %d", *n.maxLength))
+	}
+
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		return newError(path, fmt.Sprintf("string does not match pattern '%s'", n.pattern.String()))
+	}
+
+	return nil
+}
+
+func (n *node) validateNumber(f float64, path string) error {
+	switch {
+	case n.minimum != nil && f < *n.minimum:
+		return newError(path, fmt.Sprintf("number must be at least %v", *n.minimum))
+	case n.maximum != nil && f > *n.maximum:
+		return newError(path, fmt.Sprintf("number must be at most %v", *n.maximum))
+	case n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum:
+		return newError(path, fmt.Sprintf("number must be greater than %v", *n.exclusiveMinimum))
+	case n.exclusiveMaximum != nil && f >= *n.exclusiveMaximum:
+		return newError(path, fmt.Sprintf("number must be less than %v", *n.exclusiveMaximum))
+	case n.multipleOf != nil && *n.multipleOf > 0 && math.Mod(f, *n.multipleOf) != 0:
+		return newError(path, fmt.Sprintf("number must be a multiple of %v", *n.multipleOf))
+	}
+
+	return nil
+}
+
+func (n *node) validateCombinators(value interface{}, path string) error {
+	for _, schema := range n.allOf {
+		if err := schema.validate(value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(n.anyOf) > 0 && countValid(n.anyOf, value, path) == 0 {
+		return newError(path, "value does not match any of the schemas (anyOf)")
+	}
+
+	if len(n.oneOf) > 0 && countValid(n.oneOf, value, path) != 1 {
+		return newError(path, "value must match exactly one schema (oneOf)")
+	}
+
+	if n.not != nil && n.not.validate(value, path) == nil {
+		return newError(path, "value must not match schema (not)")
+	}
+
+	return nil
+}
+
+func (n *node) isFalse() bool {
+	if n.target != nil {
+		return n.target.isFalse()
+	}
+
+	return n.boolean != nil && !*n.boolean
+}
+
+func countValid(schemas []*node, value interface{}, path string) int {
+	count := 0
+
+	for _, schema := range schemas {
+		if schema.validate(value, path) == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+func matchesType(value interface{}, types []string) bool {
+	actual := typeOf(value)
+
+	for _, t := range types {
+		if t == actual || (t == typeNumber && actual == typeInteger) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return typeNull
+	case bool:
+		return typeBoolean
+	case map[string]interface{}:
+		return typeObject
+	case []interface{}:
+		return typeArray
+	case string:
+		return typeString
+	case float64:
+		if v == math.Trunc(v) {
+			return typeInteger
+		}
+
+		return typeNumber
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// escape escapes key for use in JSON pointer (RFC 6901).
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// ValidationError is returned for values that don't match the schema.
+type ValidationError struct {
+	// Pointer is JSON pointer (RFC 6901) of invalid value ("" for the whole document).
+	Pointer string
+	Message string
+}
+
+func newError(pointer, message string) error {
+	return &ValidationError{Pointer: pointer, Message: message}
+}
+
+// Error returns error message.
+func (e *ValidationError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return fmt.Sprintf("schema validation failed at '%s': %s", pointer, e.Message)
+}
+
+// IsValidationError returns true if error is schema validation error.
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+
+	return errors.As(err, &validationErr)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "type": "object",
+  "required": ["name", "tags"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "minLength": 2, "maxLength": 10, "pattern": "^[a-z]+$"},
+    "age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+    "score": {"type": "number", "multipleOf": 0.5},
+    "kind": {"enum": ["person", "organization"]},
+    "version": {"const": 1},
+    "tags": {"type": "array", "minItems": 1, "maxItems": 3, "uniqueItems": true, "items": {"type": "string"}},
+    "address": {"$ref": "#/definitions/address"},
+    "contact": {"oneOf": [{"type": "string"}, {"type": "object", "required": ["email"]}]},
+    "labels": {"type": "object", "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false},
+    "note": {"not": {"type": "null"}}
+  },
+  "definitions": {
+    "address": {
+      "type": "object",
+      "required": ["city"],
+      "properties": {"city": {"type": "string"}, "parent": {"$ref": "#/definitions/address"}}
+    }
+  }
+}`
+
+func TestSchema(t *testing.T) {
+	schema, err := New([]byte(testSchema))
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		err := schema.ValidateBytes([]byte(`{
+			"name": "alice",
+			"age": 30,
+			"score": 4.5,
+			"kind": "person",
+			"version": 1,
+			"tags": ["a", "b"],
+			"address": {"city": "Toronto", "parent": {"city": "Ontario"}},
+			"contact": {"email": "alice@example.com"},
+			"labels": {"x-team": "core"},
+			"note": "text"
+		}`))
+		require.NoError(t, err)
+	})
+
+	t.Run("success - named types are normalized", func(t *testing.T) {
+		type doc map[string]interface{}
+
+		require.NoError(t, schema.Validate(doc{"name": "bob", "tags": []string{"a"}}))
+	})
+
+	tests := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{"not an object", `[]`, "at '/': expected object, got array"},
+		{"missing required", `{"name": "alice"}`, "at '/': missing required property 'tags'"},
+		{"additional property", `{"name": "alice", "tags": ["a"], "other": 1}`, "at '/': property 'other' is not allowed"},
+		{"too short", `{"name": "a", "tags": ["a"]}`, "at '/name': string length must be at least 2"},
+		{"too long", `{"name": "abcdefghijk", "tags": ["a"]}`, "at '/name': string length must be at most 10"},
+		{"pattern", `{"name": "Alice", "tags": ["a"]}`, "at '/name': string does not match pattern '^[a-z]+$'"},
+		{"not integer", `{"name": "alice", "tags": ["a"], "age": 1.5}`, "at '/age': expected integer, got number"},
+		{"minimum", `{"name": "alice", "tags": ["a"], "age": -1}`, "at '/age': number must be at least 0"},
+		{"exclusive maximum", `{"name": "alice", "tags": ["a"], "age": 150}`, "at '/age': number must be less than 150"},
+		{"multiple of", `{"name": "alice", "tags": ["a"], "score": 0.3}`, "at '/score': number must be a multiple of 0.5"},
+		{"enum", `{"name": "alice", "tags": ["a"], "kind": "robot"}`, "at '/kind': value is not one of the allowed values"},
+		{"const", `{"name": "alice", "tags": ["a"], "version": 2}`, "at '/version': value does not match constant value"},
+		{"min items", `{"name": "alice", "tags": []}`, "at '/tags': array must have at least 1 items"},
+		{"max items", `{"name": "alice", "tags": ["a", "b", "c", "d"]}`, "at '/tags': array must have at most 3 items"},
+		{"unique items", `{"name": "alice", "tags": ["a", "a"]}`, "at '/tags': array items 0 and 1 are equal"},
+		{"items", `{"name": "alice", "tags": ["a", 1]}`, "at '/tags/1': expected string, got integer"},
+		{"reference", `{"name": "alice", "tags": ["a"], "address": {"parent": {}}}`, "at '/address': missing required property 'city'"},
+		{"recursive reference", `{"name": "alice", "tags": ["a"], "address": {"city": "a", "parent": {"city": 1}}}`,
+			"at '/address/parent/city': expected string, got integer"},
+		{"one of", `{"name": "alice", "tags": ["a"], "contact": 5}`, "at '/contact': value must match exactly one schema (oneOf)"},
+		{"pattern properties", `{"name": "alice", "tags": ["a"], "labels": {"x-team": 1}}`, "at '/labels/x-team': expected string, got integer"},
+		{"pattern properties - additional", `{"name": "alice", "tags": ["a"], "labels": {"team": "core"}}`,
+			"at '/labels': property 'team' is not allowed"},
+		{"not", `{"name": "alice", "tags": ["a"], "note": null}`, "at '/note': value must not match schema (not)"},
+	}
+
+	for _, tc := range tests {
+		t.Run("error - "+tc.name, func(t *testing.T) {
+			err := schema.ValidateBytes([]byte(tc.doc))
+			require.Error(t, err)
+			require.True(t, IsValidationError(err))
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	t.Run("error - invalid document", func(t *testing.T) {
+		err := schema.ValidateBytes([]byte(`{`))
+		require.Error(t, err)
+		require.False(t, IsValidationError(err))
+		require.Contains(t, err.Error(), "failed to parse document")
+	})
+
+	t.Run("validation error pointer", func(t *testing.T) {
+		err := schema.ValidateBytes([]byte(`{"name": "alice", "tags": ["a", 1]}`))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, "/tags/1", validationErr.Pointer)
+	})
+}
+
+func TestSchema_Combinators(t *testing.T) {
+	schema, err := New([]byte(`{
+		"allOf": [{"type": "object"}, {"minProperties": 1, "maxProperties": 2}],
+		"anyOf": [{"required": ["a"]}, {"required": ["b"]}],
+		"properties": {"a/b": false}
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.Validate(map[string]interface{}{"a": 1}))
+
+	err = schema.Validate(map[string]interface{}{})
+	require.EqualError(t, err, "schema validation failed at '/': object must have at least 1 properties")
+
+	err = schema.Validate(map[string]interface{}{"a": 1, "b": 2, "c": 3})
+	require.EqualError(t, err, "schema validation failed at '/': object must have at most 2 properties")
+
+	err = schema.Validate(map[string]interface{}{"c": 1})
+	require.EqualError(t, err, "schema validation failed at '/': value does not match any of the schemas (anyOf)")
+
+	err = schema.Validate(map[string]interface{}{"a": 1, "a/b": 1})
+	require.EqualError(t, err, "schema validation failed at '/a~1b': value is not allowed")
+
+	schema, err = New([]byte(`true`))
+	require.NoError(t, err)
+	require.NoError(t, schema.Validate("anything"))
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`{`, "failed to parse schema"},
+		{`5`, "schema must be an object or a boolean"},
+		{`{"type": 5}`, "must be an array of strings"},
+		{`{"enum": "a"}`, "enum must be an array"},
+		{`{"properties": []}`, "must be an object"},
+		{`{"required": [1]}`, "must be an array of strings"},
+		{`{"pattern": "("}`, "invalid pattern"},
+		{`{"pattern": 1}`, "pattern must be a string"},
+		{`{"patternProperties": {"(": {}}}`, "invalid pattern"},
+		{`{"minLength": -1}`, "minLength must be a non-negative integer"},
+		{`{"minimum": "1"}`, "minimum must be a number"},
+		{`{"allOf": []}`, "must be a non-empty array"},
+		{`{"not": 1}`, "schema must be an object or a boolean"},
+		{`{"items": 1}`, "schema must be an object or a boolean"},
+		{`{"additionalProperties": 1}`, "schema must be an object or a boolean"},
+		{`{"$ref": 1}`, "$ref must be a string"},
+		{`{"$ref": "http://example.com/schema"}`, "only local references are supported"},
+		{`{"$ref": "#/definitions/missing"}`, "reference '#/definitions/missing' cannot be resolved"},
+		{`{"$ref": "#/definitions/x/y", "definitions": {"x": 1}}`, "cannot be resolved"},
+		{`{"$ref": "#/definitions/x", "definitions": {"x": 1}}`, "schema must be an object or a boolean"},
+	}
+
+	for _, tc := range tests {
+		schema, err := New([]byte(tc.schema))
+		require.Error(t, err, tc.schema)
+		require.Nil(t, schema)
+		require.Contains(t, err.Error(), tc.err, tc.schema)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kmssigner provides JWS signer for Sidetree requests that delegates signing to a remote key management
+// system (KMS) or HSM, so that private keys don't have to be loaded into process memory.
+package kmssigner
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// KeyManager is implemented by remote KMS/HSM backends (adapters). It signs JWS signing input
+// (BASE64URL(protected headers) || '.' || BASE64URL(payload)) with the key that is referenced by key reference.
+// Protected headers provide signing algorithm.
+type KeyManager interface {
+	Sign(keyRef string, headers jws.Headers, payload []byte) ([]byte, error)
+}
+
+// KeyManagerFunc is an adapter to allow the use of ordinary function as KeyManager.
+type KeyManagerFunc func(keyRef string, headers jws.Headers, payload []byte) ([]byte, error)
+
+// Sign calls f(keyRef, headers, payload).
+func (f KeyManagerFunc) Sign(keyRef string, headers jws.Headers, payload []byte) ([]byte, error) {
+	return f(keyRef, headers, payload)
+}
+
+// Option is an option for signer.
+type Option func(opts *Signer)
+
+// WithKeyID sets key ID ('kid' protected header).
+func WithKeyID(kid string) Option {
+	return func(opts *Signer) {
+		opts.kid = kid
+	}
+}
+
+// WithDERSignature should be used if key manager returns ASN.1 DER encoded ECDSA signatures (as most KMS do);
+// signatures are converted to JWS format (R || S).
+func WithDERSignature() Option {
+	return func(opts *Signer) {
+		opts.derSignature = true
+	}
+}
+
+// Signer implements signer interface.
+type Signer struct {
+	km     KeyManager
+	keyRef string
+	alg    string
+
+	kid          string
+	derSignature bool
+}
+
+// New returns signer that signs with the key referenced by key reference in the key manager.
+func New(km KeyManager, keyRef, alg string, opts ...Option) *Signer {
+	s := &Signer{
+		km:     km,
+		keyRef: keyRef,
+		alg:    alg,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Headers provides required JWS protected headers. It provides information about signing key and algorithm.
+func (signer *Signer) Headers() jws.Headers {
+	headers := make(jws.Headers)
+
+	if signer.alg != "" {
+		headers[jws.HeaderAlgorithm] = signer.alg
+	}
+
+	if signer.kid != "" {
+		headers[jws.HeaderKeyID] = signer.kid
+	}
+
+	return headers
+}
+
+// Sign signs msg and returns signature value.
+func (signer *Signer) Sign(msg []byte) ([]byte, error) {
+	if signer.km == nil {
+		return nil, errors.New("key manager not provided")
+	}
+
+	if signer.keyRef == "" {
+		return nil, errors.New("key reference not provided")
+	}
+
+	signature, err := signer.km.Sign(signer.keyRef, signer.Headers(), msg)
+	if err != nil {
+		return nil, fmt.Errorf("key manager failed to sign with key [%s]: %s", signer.keyRef, err.Error())
+	}
+
+	if !signer.derSignature {
+		return signature, nil
+	}
+
+	keySize, ok := ecKeySizes[signer.alg]
+	if !ok {
+		return nil, fmt.Errorf("DER signature is not supported for algorithm '%s'", signer.alg)
+	}
+
+	return fromDER(signature, keySize)
+}
+
+const (
+	p256KeySize      = 32
+	p384KeySize      = 48
+	p521KeySize      = 66
+	secp256k1KeySize = 32
+)
+
+var ecKeySizes = map[string]int{
+	jws.AlgorithmES256:  p256KeySize,
+	jws.AlgorithmES256K: secp256k1KeySize,
+	jws.AlgorithmES384:  p384KeySize,
+	jws.AlgorithmES512:  p521KeySize,
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// fromDER converts ASN.1 DER encoded ECDSA signature to JWS signature (R || S).
+func fromDER(der []byte, keySize int) ([]byte, error) {
+	var sig ecdsaSignature
+
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER signature: %s", err.Error())
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("failed to parse DER signature: trailing data")
+	}
+
+	rBytes := sig.R.Bytes()
+	sBytes := sig.S.Bytes()
+
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || len(rBytes) > keySize || len(sBytes) > keySize {
+		return nil, errors.New("invalid DER signature values")
+	}
+
+	signature := make([]byte, 2*keySize)
+	copy(signature[keySize-len(rBytes):keySize], rBytes)
+	copy(signature[2*keySize-len(sBytes):], sBytes)
+
+	return signature, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kmssigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+const keyRef = "kms://keys/update-key"
+
+func TestSign(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	t.Run("success - JWS signature", func(t *testing.T) {
+		km := newKeyManager(privateKey, false)
+
+		signer := New(km, keyRef, "ES256", WithKeyID("key-1"))
+
+		compactJWS, err := signutil.SignPayload([]byte("payload"), signer)
+		require.NoError(t, err)
+		require.Equal(t, keyRef, km.keyRef)
+		require.Equal(t, "ES256", km.headers[jws.HeaderAlgorithm])
+
+		_, err = internaljws.VerifyJWS(compactJWS, jwk)
+		require.NoError(t, err)
+	})
+
+	t.Run("success - DER signature", func(t *testing.T) {
+		signer := New(newKeyManager(privateKey, true), keyRef, "ES256", WithDERSignature())
+
+		compactJWS, err := signutil.SignPayload([]byte("payload"), signer)
+		require.NoError(t, err)
+
+		_, err = internaljws.VerifyJWS(compactJWS, jwk)
+		require.NoError(t, err)
+	})
+
+	t.Run("success - key manager func", func(t *testing.T) {
+		ecSigner := ecsigner.New(privateKey, "ES256", "")
+
+		km := KeyManagerFunc(func(_ string, _ jws.Headers, payload []byte) ([]byte, error) {
+			return ecSigner.Sign(payload)
+		})
+
+		compactJWS, err := signutil.SignPayload([]byte("payload"), New(km, keyRef, "ES256"))
+		require.NoError(t, err)
+
+		_, err = internaljws.VerifyJWS(compactJWS, jwk)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - missing key manager", func(t *testing.T) {
+		signature, err := New(nil, keyRef, "ES256").Sign([]byte("msg"))
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "key manager not provided")
+	})
+
+	t.Run("error - missing key reference", func(t *testing.T) {
+		signature, err := New(newKeyManager(privateKey, false), "", "ES256").Sign([]byte("msg"))
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "key reference not provided")
+	})
+
+	t.Run("error - key manager error", func(t *testing.T) {
+		km := KeyManagerFunc(func(string, jws.Headers, []byte) ([]byte, error) {
+			return nil, errors.New("injected KMS error")
+		})
+
+		signature, err := New(km, keyRef, "ES256").Sign([]byte("msg"))
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "key manager failed to sign with key [kms://keys/update-key]: injected KMS error")
+	})
+
+	t.Run("error - DER signature not supported for algorithm", func(t *testing.T) {
+		signature, err := New(newKeyManager(privateKey, true), keyRef, "EdDSA", WithDERSignature()).Sign([]byte("msg"))
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "DER signature is not supported for algorithm 'EdDSA'")
+	})
+
+	t.Run("error - invalid DER signature", func(t *testing.T) {
+		km := KeyManagerFunc(func(string, jws.Headers, []byte) ([]byte, error) {
+			return []byte("invalid"), nil
+		})
+
+		signature, err := New(km, keyRef, "ES256", WithDERSignature()).Sign([]byte("msg"))
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "failed to parse DER signature")
+	})
+}
+
+func TestHeaders(t *testing.T) {
+	t.Run("success - kid, alg provided", func(t *testing.T) {
+		signer := New(&keyManager{}, keyRef, "ES256", WithKeyID("key-1"))
+
+		kid, ok := signer.Headers().KeyID()
+		require.True(t, ok)
+		require.Equal(t, "key-1", kid)
+
+		alg, ok := signer.Headers().Algorithm()
+		require.True(t, ok)
+		require.Equal(t, "ES256", alg)
+	})
+
+	t.Run("success - kid, alg not provided", func(t *testing.T) {
+		signer := New(&keyManager{}, keyRef, "")
+
+		_, ok := signer.Headers().KeyID()
+		require.False(t, ok)
+
+		_, ok = signer.Headers().Algorithm()
+		require.False(t, ok)
+	})
+}
+
+func TestFromDER(t *testing.T) {
+	t.Run("success - values are padded", func(t *testing.T) {
+		der, err := asn1.Marshal(ecdsaSignature{R: big.NewInt(1), S: big.NewInt(2)})
+		require.NoError(t, err)
+
+		signature, err := fromDER(der, p256KeySize)
+		require.NoError(t, err)
+		require.Len(t, signature, 2*p256KeySize)
+		require.Equal(t, byte(1), signature[p256KeySize-1])
+		require.Equal(t, byte(2), signature[2*p256KeySize-1])
+	})
+
+	t.Run("error - trailing data", func(t *testing.T) {
+		der, err := asn1.Marshal(ecdsaSignature{R: big.NewInt(1), S: big.NewInt(2)})
+		require.NoError(t, err)
+
+		signature, err := fromDER(append(der, 0), p256KeySize)
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "trailing data")
+	})
+
+	t.Run("error - values too large", func(t *testing.T) {
+		der, err := asn1.Marshal(ecdsaSignature{R: new(big.Int).Lsh(big.NewInt(1), 300), S: big.NewInt(2)})
+		require.NoError(t, err)
+
+		signature, err := fromDER(der, p256KeySize)
+		require.Error(t, err)
+		require.Nil(t, signature)
+		require.Contains(t, err.Error(), "invalid DER signature values")
+	})
+}
+
+// keyManager simulates remote KMS (private key is held by the key manager).
+type keyManager struct {
+	privateKey *ecdsa.PrivateKey
+	der        bool
+
+	keyRef  string
+	headers jws.Headers
+}
+
+func newKeyManager(privateKey *ecdsa.PrivateKey, der bool) *keyManager {
+	return &keyManager{privateKey: privateKey, der: der}
+}
+
+func (km *keyManager) Sign(keyRef string, headers jws.Headers, payload []byte) ([]byte, error) {
+	km.keyRef = keyRef
+	km.headers = headers
+
+	if !km.der {
+		return ecsigner.New(km.privateKey, "ES256", "").Sign(payload)
+	}
+
+	hash := sha256.Sum256(payload)
+
+	r, s, err := ecdsa.Sign(rand.Reader, km.privateKey, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
@@ -9,6 +9,8 @@ package pubkey
 import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"reflect"
@@ -24,8 +26,25 @@ import (
 const (
 	secp256k1Crv = "secp256k1"
 	secp256k1Kty = "EC"
+
+	x25519Crv = "X25519"
+	x25519Kty = "OKP"
+
+	bls12381G2Crv = "BLS12381_G2"
+	bls12381G2Kty = "EC"
+
+	x25519KeySize = 32
+
+	// bls12381G2KeySize is size of compressed BLS12-381 G2 point.
+	bls12381G2KeySize = 96
 )
 
+// X25519PublicKey is X25519 public key (montgomery u coordinate).
+type X25519PublicKey []byte
+
+// BLS12381G2PublicKey is BLS12-381 G2 public key (compressed point).
+type BLS12381G2PublicKey []byte
+
 // GetPublicKeyJWK returns public key in JWK format.
 func GetPublicKeyJWK(pubKey interface{}) (*jws.JWK, error) {
 	internalJWK := internal.JWK{
@@ -33,7 +52,11 @@ func GetPublicKeyJWK(pubKey interface{}) (*jws.JWK, error) {
 	}
 
 	switch key := pubKey.(type) {
-	case ed25519.PublicKey:
+	case X25519PublicKey:
+		return getRawKeyJWK(x25519Kty, x25519Crv, key, x25519KeySize)
+	case BLS12381G2PublicKey:
+		return getRawKeyJWK(bls12381G2Kty, bls12381G2Crv, key, bls12381G2KeySize)
+	case ed25519.PublicKey, *rsa.PublicKey:
 		// handled automatically by gojose
 	case *ecdsa.PublicKey:
 		ecdsaPubKey, ok := pubKey.(*ecdsa.PublicKey)
@@ -63,3 +86,67 @@ func GetPublicKeyJWK(pubKey interface{}) (*jws.JWK, error) {
 
 	return &jwk, nil
 }
+
+// GetPublicKey returns public key from JWK: ed25519.PublicKey, *ecdsa.PublicKey (P-256, P-384, P-521, secp256k1),
+// *rsa.PublicKey, X25519PublicKey or BLS12381G2PublicKey.
+func GetPublicKey(jwk *jws.JWK) (interface{}, error) {
+	if jwk == nil {
+		return nil, errors.New("missing JWK")
+	}
+
+	switch {
+	case jwk.Kty == x25519Kty && jwk.Crv == x25519Crv:
+		key, err := getRawKey(jwk, x25519KeySize)
+		if err != nil {
+			return nil, err
+		}
+
+		return X25519PublicKey(key), nil
+	case jwk.Kty == bls12381G2Kty && jwk.Crv == bls12381G2Crv:
+		key, err := getRawKey(jwk, bls12381G2KeySize)
+		if err != nil {
+			return nil, err
+		}
+
+		return BLS12381G2PublicKey(key), nil
+	}
+
+	jsonJWK, err := json.Marshal(jwk)
+	if err != nil {
+		return nil, err
+	}
+
+	var internalJWK internal.JWK
+
+	err = internalJWK.UnmarshalJSON(jsonJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	return internalJWK.Key, nil
+}
+
+func getRawKeyJWK(kty, crv string, key []byte, size int) (*jws.JWK, error) {
+	if len(key) != size {
+		return nil, fmt.Errorf("invalid %s public key size: %d", crv, len(key))
+	}
+
+	return &jws.JWK{
+		Kty: kty,
+		Crv: crv,
+		X:   base64.RawURLEncoding.EncodeToString(key),
+	}, nil
+}
+
+func getRawKey(jwk *jws.JWK, size int) ([]byte, error) {
+	key, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s public key: %s", jwk.Crv, err.Error())
+	}
+
+	if len(key) != size {
+		return nil, fmt.Errorf("invalid %s public key size: %d", jwk.Crv, len(key))
+	}
+
+	return key, nil
+}
@@ -11,10 +11,13 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
 
 func TestGetPublicKeyJWK(t *testing.T) {
@@ -51,6 +54,60 @@ func TestGetPublicKeyJWK(t *testing.T) {
 		require.Equal(t, "OKP", jwk.Kty)
 	})
 
+	t.Run("success RSA", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		jwk, err := GetPublicKeyJWK(&privateKey.PublicKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, jwk)
+		require.Equal(t, "RSA", jwk.Kty)
+		require.Equal(t, "AQAB", jwk.E)
+		require.NotEmpty(t, jwk.N)
+		require.NoError(t, jwk.Validate())
+	})
+
+	t.Run("success EC P-384 and P-521", func(t *testing.T) {
+		for _, curve := range []elliptic.Curve{elliptic.P384(), elliptic.P521()} {
+			privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+
+			jwk, err := GetPublicKeyJWK(&privateKey.PublicKey)
+			require.NoError(t, err)
+			require.Equal(t, curve.Params().Name, jwk.Crv)
+			require.Equal(t, "EC", jwk.Kty)
+		}
+	})
+
+	t.Run("success X25519", func(t *testing.T) {
+		jwk, err := GetPublicKeyJWK(X25519PublicKey(make([]byte, 32)))
+		require.NoError(t, err)
+		require.Equal(t, "X25519", jwk.Crv)
+		require.Equal(t, "OKP", jwk.Kty)
+		require.Equal(t, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", jwk.X)
+		require.Empty(t, jwk.Y)
+	})
+
+	t.Run("success BLS12-381 G2", func(t *testing.T) {
+		jwk, err := GetPublicKeyJWK(BLS12381G2PublicKey(make([]byte, 96)))
+		require.NoError(t, err)
+		require.Equal(t, "BLS12381_G2", jwk.Crv)
+		require.Equal(t, "EC", jwk.Kty)
+		require.NotEmpty(t, jwk.X)
+	})
+
+	t.Run("invalid key size", func(t *testing.T) {
+		jwk, err := GetPublicKeyJWK(X25519PublicKey([]byte("short")))
+		require.Error(t, err)
+		require.Nil(t, jwk)
+		require.Contains(t, err.Error(), "invalid X25519 public key size: 5")
+
+		jwk, err = GetPublicKeyJWK(BLS12381G2PublicKey(make([]byte, 48)))
+		require.Error(t, err)
+		require.Nil(t, jwk)
+		require.Contains(t, err.Error(), "invalid BLS12381_G2 public key size: 48")
+	})
+
 	t.Run("unknown key type", func(t *testing.T) {
 		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
 		require.NoError(t, err)
@@ -76,3 +133,97 @@ func TestGetPublicKeyJWK(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid EC key")
 	})
 }
+
+func TestGetPublicKey(t *testing.T) {
+	t.Run("success EC", func(t *testing.T) {
+		for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521(), btcec.S256()} {
+			privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+
+			jwk, err := GetPublicKeyJWK(&privateKey.PublicKey)
+			require.NoError(t, err)
+
+			pubKey, err := GetPublicKey(jwk)
+			require.NoError(t, err)
+
+			ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+			require.True(t, ok)
+			require.Equal(t, curve, ecPubKey.Curve)
+			require.Equal(t, privateKey.X, ecPubKey.X)
+			require.Equal(t, privateKey.Y, ecPubKey.Y)
+		}
+	})
+
+	t.Run("success ED25519", func(t *testing.T) {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := GetPublicKeyJWK(publicKey)
+		require.NoError(t, err)
+
+		pubKey, err := GetPublicKey(jwk)
+		require.NoError(t, err)
+		require.Equal(t, publicKey, pubKey)
+	})
+
+	t.Run("success RSA", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		jwk, err := GetPublicKeyJWK(&privateKey.PublicKey)
+		require.NoError(t, err)
+
+		pubKey, err := GetPublicKey(jwk)
+		require.NoError(t, err)
+		require.Equal(t, &privateKey.PublicKey, pubKey)
+	})
+
+	t.Run("success X25519 and BLS12-381 G2", func(t *testing.T) {
+		x25519Key := X25519PublicKey(make([]byte, 32))
+		x25519Key[0] = 9
+
+		blsKey := BLS12381G2PublicKey(make([]byte, 96))
+		blsKey[0] = 0xc0
+
+		for _, key := range []interface{}{x25519Key, blsKey} {
+			jwk, err := GetPublicKeyJWK(key)
+			require.NoError(t, err)
+
+			pubKey, err := GetPublicKey(jwk)
+			require.NoError(t, err)
+			require.Equal(t, key, pubKey)
+		}
+	})
+
+	t.Run("error - missing JWK", func(t *testing.T) {
+		pubKey, err := GetPublicKey(nil)
+		require.Error(t, err)
+		require.Nil(t, pubKey)
+		require.Contains(t, err.Error(), "missing JWK")
+	})
+
+	t.Run("error - invalid X25519 key", func(t *testing.T) {
+		pubKey, err := GetPublicKey(&jws.JWK{Kty: "OKP", Crv: "X25519", X: "!"})
+		require.Error(t, err)
+		require.Nil(t, pubKey)
+		require.Contains(t, err.Error(), "invalid X25519 public key")
+
+		pubKey, err = GetPublicKey(&jws.JWK{Kty: "OKP", Crv: "X25519", X: "AQID"})
+		require.Error(t, err)
+		require.Nil(t, pubKey)
+		require.Contains(t, err.Error(), "invalid X25519 public key size: 3")
+	})
+
+	t.Run("error - invalid BLS12-381 G2 key", func(t *testing.T) {
+		pubKey, err := GetPublicKey(&jws.JWK{Kty: "EC", Crv: "BLS12381_G2", X: "AQID"})
+		require.Error(t, err)
+		require.Nil(t, pubKey)
+		require.Contains(t, err.Error(), "invalid BLS12381_G2 public key size: 3")
+	})
+
+	t.Run("error - invalid EC key", func(t *testing.T) {
+		pubKey, err := GetPublicKey(&jws.JWK{Kty: "EC", Crv: "P-256", X: "AQID", Y: "AQID"})
+		require.Error(t, err)
+		require.Nil(t, pubKey)
+	})
+}
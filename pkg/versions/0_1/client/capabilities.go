@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// LatestMultihashCode returns the latest multihash algorithm supported by the node (e.g. to be used
+// as MultihashCode of request info).
+func LatestMultihashCode(capabilities protocol.Capabilities) (uint, error) {
+	if len(capabilities.MultihashAlgorithms) == 0 {
+		return 0, errors.New("node doesn't advertise any multihash algorithms")
+	}
+
+	return capabilities.MultihashAlgorithms[len(capabilities.MultihashAlgorithms)-1], nil
+}
+
+// CheckPatches checks that the patches are supported by the node.
+func CheckPatches(capabilities protocol.Capabilities, patches []patch.Patch) error {
+	for _, p := range patches {
+		action, err := p.GetAction()
+		if err != nil {
+			return err
+		}
+
+		if !capabilities.SupportsPatch(string(action)) {
+			return fmt.Errorf("patch action '%s' is not supported by the node", action)
+		}
+	}
+
+	return nil
+}
+
+// CheckSigner checks that the signature algorithm of the signer is supported by the node.
+func CheckSigner(capabilities protocol.Capabilities, signer Signer) error {
+	if signer == nil {
+		return errors.New("missing signer")
+	}
+
+	alg, ok := signer.Headers().Algorithm()
+	if !ok {
+		return errors.New("signer must provide algorithm")
+	}
+
+	if !capabilities.SupportsSignatureAlgorithm(alg) {
+		return fmt.Errorf("signature algorithm '%s' is not supported by the node", alg)
+	}
+
+	return nil
+}
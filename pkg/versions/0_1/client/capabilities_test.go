@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+)
+
+func TestLatestMultihashCode(t *testing.T) {
+	code, err := LatestMultihashCode(protocol.Capabilities{MultihashAlgorithms: []uint{18, 22}})
+	require.NoError(t, err)
+	require.Equal(t, uint(22), code)
+
+	code, err = LatestMultihashCode(protocol.Capabilities{})
+	require.EqualError(t, err, "node doesn't advertise any multihash algorithms")
+	require.Equal(t, uint(0), code)
+}
+
+func TestCheckPatches(t *testing.T) {
+	capabilities := protocol.Capabilities{PatchActions: []string{"ietf-json-patch"}}
+
+	jsonPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/name", "value": "Jane"}]`)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckPatches(capabilities, []patch.Patch{jsonPatch}))
+
+	removeKeys, err := patch.NewRemovePublicKeysPatch(`["key1"]`)
+	require.NoError(t, err)
+
+	err = CheckPatches(capabilities, []patch.Patch{jsonPatch, removeKeys})
+	require.EqualError(t, err, "patch action 'remove-public-keys' is not supported by the node")
+
+	err = CheckPatches(capabilities, []patch.Patch{{}})
+	require.Error(t, err)
+}
+
+func TestCheckSigner(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	capabilities := protocol.Capabilities{SignatureAlgorithms: []string{"EdDSA"}}
+
+	require.NoError(t, CheckSigner(capabilities, edsigner.New(privateKey, "EdDSA", "kid")))
+
+	err = CheckSigner(capabilities, edsigner.New(privateKey, "ES256", "kid"))
+	require.EqualError(t, err, "signature algorithm 'ES256' is not supported by the node")
+
+	err = CheckSigner(capabilities, &MockSigner{})
+	require.EqualError(t, err, "signer must provide algorithm")
+
+	err = CheckSigner(capabilities, nil)
+	require.EqualError(t, err, "missing signer")
+}
@@ -82,7 +82,7 @@ func getPatches(opaque string, patches []patch.Patch) ([]patch.Patch, error) {
 		return patch.PatchesFromDocument(opaque)
 	}
 
-	return patches, nil
+	return patch.Normalize(patches)
 }
 
 func validateCreateRequest(info *CreateRequestInfo) error {
@@ -144,6 +144,27 @@ func TestNewCreateRequest(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, request)
 	})
+
+	t.Run("success - duplicate patches are removed", func(t *testing.T) {
+		p, err := patch.NewAddPublicKeysPatch(addKeys)
+		require.NoError(t, err)
+
+		info := &CreateRequestInfo{
+			Patches:            []patch.Patch{p},
+			RecoveryCommitment: recoveryCommitment,
+			UpdateCommitment:   updateCommitment,
+			MultihashCode:      sha2_256,
+		}
+
+		request, err := NewCreateRequest(info)
+		require.NoError(t, err)
+
+		info.Patches = []patch.Patch{p, p}
+
+		requestWithDuplicates, err := NewCreateRequest(info)
+		require.NoError(t, err)
+		require.Equal(t, request, requestWithDuplicates)
+	})
 }
 
 const addKeys = `[{
@@ -18,6 +18,7 @@ import (
 )
 
 // Signer defines JWS Signer interface that will be used to sign required data in Sidetree request.
+// Signers for keys held by remote KMS/HSM can be created with kmssigner package.
 type Signer interface {
 	// Sign signs data and returns signature value
 	Sign(data []byte) ([]byte, error)
@@ -41,6 +41,9 @@ type UpdateRequestInfo struct {
 
 	// RevealValue is reveal value
 	RevealValue string
+
+	// Nonce is optional update sequence number; it has to be greater than nonce used in previous update (if any)
+	Nonce uint64
 }
 
 // NewUpdateRequest is utility function to create payload for 'update' request.
@@ -49,9 +52,14 @@ func NewUpdateRequest(info *UpdateRequestInfo) ([]byte, error) {
 		return nil, err
 	}
 
+	patches, err := patch.Normalize(info.Patches)
+	if err != nil {
+		return nil, err
+	}
+
 	delta := &model.DeltaModel{
 		UpdateCommitment: info.UpdateCommitment,
-		Patches:          info.Patches,
+		Patches:          patches,
 	}
 
 	deltaHash, err := hashing.CalculateModelMultihash(delta, info.MultihashCode)
@@ -64,6 +72,11 @@ func NewUpdateRequest(info *UpdateRequestInfo) ([]byte, error) {
 		UpdateKey: info.UpdateKey,
 	}
 
+	if info.Nonce > 0 {
+		nonce := info.Nonce
+		signedDataModel.Nonce = &nonce
+	}
+
 	err = validateCommitment(info.UpdateKey, info.MultihashCode, info.UpdateCommitment)
 	if err != nil {
 		return nil, err
@@ -10,15 +10,18 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 func TestNewUpdateRequest(t *testing.T) {
@@ -166,6 +169,37 @@ func TestNewUpdateRequest(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, request)
 	})
+
+	t.Run("success - with nonce", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signer := ecsigner.New(privateKey, "ES256", "key-1")
+
+		info := &UpdateRequestInfo{
+			DidSuffix:     didSuffix,
+			Patches:       patches,
+			MultihashCode: sha2_256,
+			UpdateKey:     updateJWK,
+			Signer:        signer,
+			RevealValue:   "reveal",
+			Nonce:         7,
+		}
+
+		request, err := NewUpdateRequest(info)
+		require.NoError(t, err)
+
+		var updateRequest model.UpdateRequest
+		require.NoError(t, json.Unmarshal(request, &updateRequest))
+
+		signedData, err := internal.ParseJWS(updateRequest.SignedData)
+		require.NoError(t, err)
+
+		var signedDataModel model.UpdateSignedDataModel
+		require.NoError(t, json.Unmarshal(signedData.Payload, &signedDataModel))
+		require.NotNil(t, signedDataModel.Nonce)
+		require.Equal(t, uint64(7), *signedDataModel.Nonce)
+	})
 }
 
 func getTestPatches() ([]patch.Patch, error) {
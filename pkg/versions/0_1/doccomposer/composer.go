@@ -13,40 +13,109 @@ import (
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/trustbloc/edge-core/pkg/log"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser/patchvalidator"
 )
 
 var logger = log.New("sidetree-core-composer")
 
+// PatchApplier applies custom patch value to the document.
+type PatchApplier func(doc document.Document, value interface{}) (document.Document, error)
+
+// Option is a document composer instance option.
+type Option func(opts *DocumentComposer)
+
+// WithPatchApplier registers applier for custom patch action.
+func WithPatchApplier(action patch.Action, applier PatchApplier) Option {
+	return func(opts *DocumentComposer) {
+		opts.appliers[action] = applier
+	}
+}
+
+// WithKeyPurposes sets allowed public key purposes (e.g. from protocol parameters) that are validated
+// when verification relationships are added to existing keys; if not set all standard purposes are allowed.
+func WithKeyPurposes(purposes ...string) Option {
+	return func(opts *DocumentComposer) {
+		opts.keyPurposes = append(opts.keyPurposes, purposes...)
+	}
+}
+
+// WithUniqueIDValidation enables validation that ids are unique across public keys and services
+// of the patched document (e.g. from protocol parameters); by default only ids within each section are unique.
+func WithUniqueIDValidation(enabled bool) Option {
+	return func(opts *DocumentComposer) {
+		opts.validateUniqueIDs = enabled
+	}
+}
+
 // DocumentComposer applies patches to the document.
 type DocumentComposer struct {
+	appliers          map[patch.Action]PatchApplier
+	keyPurposes       []string
+	validateUniqueIDs bool
 }
 
 // New creates new document composer.
-func New() *DocumentComposer {
-	return &DocumentComposer{}
+func New(opts ...Option) *DocumentComposer {
+	composer := &DocumentComposer{
+		appliers: make(map[patch.Action]PatchApplier),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(composer)
+	}
+
+	return composer
 }
 
-// ApplyPatches applies patches to the document.
+// ApplyPatches applies patches to the document. Applying patches is all-or-nothing: patches are applied
+// to a copy of the document and if any of the patches fails an error is returned (no partially patched document)
+// and the original document is left untouched.
+//
+// Documents are copy-on-write: only the top level of the document is copied and the resulting document
+// shares unmodified entries with the original document. Built-in patches never modify shared entries in place
+// (modified entries are replaced), so applying long operation chains doesn't copy the whole document
+// for every operation. Documents passed to and returned from the composer must be treated as immutable.
 func (c *DocumentComposer) ApplyPatches(doc document.Document, patches []patch.Patch) (document.Document, error) {
-	result, err := deepCopy(doc)
-	if err != nil {
-		return nil, err
-	}
+	result := copyDocument(doc)
 
-	for _, p := range patches {
-		result, err = applyPatch(result, p)
+	for i, p := range patches {
+		var err error
+
+		result, err = c.applyPatch(result, p)
 		if err != nil {
-			return nil, err
+			return nil, wrapError(err, fmt.Errorf("failed to apply patch[%d]: %s", i, err.Error()))
+		}
+
+		if result == nil {
+			return nil, fmt.Errorf("failed to apply patch[%d]: patch produced nil document", i)
+		}
+	}
+
+	if c.validateUniqueIDs {
+		services := document.DidDocumentFromJSONLDObject(result).Services()
+		if err := document.CheckUniqueIDs(result.PublicKeys(), services); err != nil {
+			return nil, fmt.Errorf("failed to apply patches: %s", err.Error())
 		}
 	}
 
 	return result, nil
 }
 
+// wrapError keeps internal error code (e.g. returned by custom patch applier) for wrapped error.
+func wrapError(err, wrapped error) error {
+	if protocol.IsInternalError(err) {
+		return protocol.NewInternalError(wrapped)
+	}
+
+	return wrapped
+}
+
 // applyPatch applies a patch to the document.
-func applyPatch(doc document.Document, p patch.Patch) (document.Document, error) {
+func (c *DocumentComposer) applyPatch(doc document.Document, p patch.Patch) (document.Document, error) {
 	action, err := p.GetAction()
 	if err != nil {
 		return nil, err
@@ -57,11 +126,24 @@ func applyPatch(doc document.Document, p patch.Patch) (document.Document, error)
 		return nil, err
 	}
 
+	// patch values become part of the document so they are copied in order to keep patches
+	// (and documents produced from them) isolated from subsequent modifications
+	value, err = deepCopyValue(value)
+	if err != nil {
+		return nil, err
+	}
+
 	switch action {
 	case patch.Replace:
 		return applyRecover(value)
 	case patch.JSONPatch:
 		return applyJSON(doc, value)
+	case patch.JSONMergePatch:
+		return applyJSONMerge(doc, value)
+	case patch.AddVerificationRelationships:
+		return c.applyVerificationRelationships(doc, value, addPurposes)
+	case patch.RemoveVerificationRelationships:
+		return c.applyVerificationRelationships(doc, value, removePurposes)
 	case patch.AddPublicKeys:
 		return applyAddPublicKeys(doc, value)
 	case patch.RemovePublicKeys:
@@ -70,6 +152,27 @@ func applyPatch(doc document.Document, p patch.Patch) (document.Document, error)
 		return applyAddServiceEndpoints(doc, value)
 	case patch.RemoveServiceEndpoints:
 		return applyRemoveServiceEndpoints(doc, value)
+	case patch.ReplaceServiceEndpoints:
+		return applyReplaceServiceEndpoints(doc, value)
+	case patch.AddAlsoKnownAs:
+		return applyAddAlsoKnownAs(doc, value)
+	case patch.RemoveAlsoKnownAs:
+		return applyRemoveAlsoKnownAs(doc, value)
+	case patch.SetController:
+		return applySetController(doc, value)
+	}
+
+	if applier, ok := c.appliers[action]; ok {
+		logger.Debugf("applying custom '%s' patch: %v", action, value)
+
+		// custom appliers may modify document entries in place so they are given a deep copy
+		// of the document in order not to modify entries shared with other documents
+		docCopy, err := deepCopy(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return applier(docCopy, value)
 	}
 
 	return nil, fmt.Errorf("action '%s' is not supported", action)
@@ -101,6 +204,27 @@ func applyJSON(doc document.Document, entry interface{}) (document.Document, err
 	return document.FromBytes(docBytes)
 }
 
+func applyJSONMerge(doc document.Document, entry interface{}) (document.Document, error) {
+	logger.Debugf("applying JSON merge patch: %v", entry)
+
+	mergePatchBytes, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	docBytes, err := doc.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	docBytes, err = jsonpatch.MergePatch(docBytes, mergePatchBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.FromBytes(docBytes)
+}
+
 func applyRecover(replaceDoc interface{}) (document.Document, error) {
 	logger.Debugf("applying replace patch: %v", replaceDoc)
 	docBytes, err := json.Marshal(replaceDoc)
@@ -117,6 +241,12 @@ func applyRecover(replaceDoc interface{}) (document.Document, error) {
 	doc[document.PublicKeyProperty] = replace[document.ReplacePublicKeyProperty]
 	doc[document.ServiceProperty] = replace[document.ReplaceServiceProperty]
 
+	doc.SetAlsoKnownAs(replace.AlsoKnownAs())
+
+	if len(replace.Controller()) > 0 {
+		doc[document.ControllerProperty] = replace[document.ReplaceControllerProperty]
+	}
+
 	return doc, nil
 }
 
@@ -163,6 +293,95 @@ func convertPublicKeys(pubKeys []document.PublicKey) []interface{} {
 	return values
 }
 
+// adds or removes purposes (verification relationships) of existing public keys.
+func (c *DocumentComposer) applyVerificationRelationships(doc document.Document, entry interface{},
+	update func(existing, purposes []string) []string) (document.Document, error) {
+	logger.Debugf("applying verification relationships patch: %v", entry)
+
+	publicKeys := doc.PublicKeys()
+	existingPublicKeysMap := sliceToMapPK(publicKeys)
+
+	var updatedKeys []document.PublicKey
+
+	for _, relationship := range document.ParsePublicKeys(entry) {
+		existingKey, ok := existingPublicKeysMap[relationship.ID()]
+		if !ok {
+			return nil, fmt.Errorf("public key '%s' not found", relationship.ID())
+		}
+
+		// existing key may be shared with other documents so it is copied before modification
+		key := copyPublicKey(existingKey)
+		updateKey(publicKeys, key)
+		existingPublicKeysMap[key.ID()] = key
+
+		purposes := update(key.Purpose(), relationship.Purpose())
+		if len(purposes) == 0 {
+			// key without purposes is general key
+			delete(key, document.PurposesProperty)
+		} else {
+			key[document.PurposesProperty] = getGenericArray(purposes)
+		}
+
+		updatedKeys = append(updatedKeys, key)
+	}
+
+	// purposes must be allowed for key type
+	if err := patchvalidator.ValidatePublicKeys(updatedKeys, patchvalidator.WithKeyPurposes(c.keyPurposes...)); err != nil {
+		return nil, err
+	}
+
+	doc[document.PublicKeyProperty] = convertPublicKeys(publicKeys)
+
+	return doc, nil
+}
+
+func copyPublicKey(pk document.PublicKey) document.PublicKey {
+	result := make(document.PublicKey, len(pk))
+	for key, value := range pk {
+		result[key] = value
+	}
+
+	return result
+}
+
+func addPurposes(existing, purposes []string) []string {
+	existingMap := sliceToMap(existing)
+
+	result := append([]string{}, existing...)
+
+	for _, purpose := range purposes {
+		if _, ok := existingMap[purpose]; !ok {
+			result = append(result, purpose)
+			existingMap[purpose] = true
+		}
+	}
+
+	return result
+}
+
+func removePurposes(existing, purposes []string) []string {
+	purposesToRemove := sliceToMap(purposes)
+
+	var result []string
+
+	for _, purpose := range existing {
+		if _, ok := purposesToRemove[purpose]; !ok {
+			result = append(result, purpose)
+		}
+	}
+
+	return result
+}
+
+func getGenericArray(arr []string) []interface{} {
+	var values []interface{}
+	for _, v := range arr {
+		values = append(values, v)
+	}
+
+	return values
+}
+
 // remove public keys from the document.
 func applyRemovePublicKeys(doc document.Document, entry interface{}) (document.Document, error) {
 	logger.Debugf("applying remove public keys patch: %v", entry)
@@ -270,6 +489,22 @@ func applyRemoveServiceEndpoints(doc document.Document, entry interface{}) (docu
 	return doc, nil
 }
 
+// replaces all existing service endpoints in document.
+func applyReplaceServiceEndpoints(doc document.Document, entry interface{}) (document.Document, error) {
+	logger.Debugf("applying replace service endpoints patch: %v", entry)
+
+	services := document.ParseServices(entry)
+	if len(services) == 0 {
+		delete(doc, document.ServiceProperty)
+
+		return doc, nil
+	}
+
+	doc[document.ServiceProperty] = convertServices(services)
+
+	return doc, nil
+}
+
 func sliceToMapServices(services []document.Service) map[string]document.Service {
 	// convert slice to map
 	values := make(map[string]document.Service)
@@ -280,7 +515,88 @@ func sliceToMapServices(services []document.Service) map[string]document.Service
 	return values
 }
 
-// deepCopy returns deep copy of JSON object.
+// adds also-known-as URIs to document (URIs that already exist are ignored).
+func applyAddAlsoKnownAs(doc document.Document, entry interface{}) (document.Document, error) {
+	logger.Debugf("applying add also known as patch: %v", entry)
+
+	existingURIs := doc.AlsoKnownAs()
+	existingURIsMap := sliceToMap(existingURIs)
+
+	newURIs := existingURIs
+
+	for _, uri := range document.StringArray(entry) {
+		if _, ok := existingURIsMap[uri]; !ok {
+			newURIs = append(newURIs, uri)
+			existingURIsMap[uri] = true
+		}
+	}
+
+	doc.SetAlsoKnownAs(newURIs)
+
+	return doc, nil
+}
+
+// removes also-known-as URIs from document.
+func applyRemoveAlsoKnownAs(doc document.Document, entry interface{}) (document.Document, error) {
+	logger.Debugf("applying remove also known as patch: %v", entry)
+
+	urisToRemove := sliceToMap(document.StringArray(entry))
+
+	var newURIs []string
+
+	for _, uri := range doc.AlsoKnownAs() {
+		_, ok := urisToRemove[uri]
+		if !ok {
+			// not in remove list so add to resulting also known as
+			newURIs = append(newURIs, uri)
+		}
+	}
+
+	// also known as is removed if there are no remaining URIs
+	doc.SetAlsoKnownAs(newURIs)
+
+	return doc, nil
+}
+
+// sets controller (single value or set) of the document; empty set removes controller.
+func applySetController(doc document.Document, entry interface{}) (document.Document, error) {
+	logger.Debugf("applying set controller patch: %v", entry)
+
+	doc[document.ControllerProperty] = entry
+
+	if len(doc.Controller()) == 0 {
+		delete(doc, document.ControllerProperty)
+	}
+
+	return doc, nil
+}
+
+// copyDocument returns copy of the top level of the document (entries are shared).
+func copyDocument(doc document.Document) document.Document {
+	result := make(document.Document, len(doc))
+	for key, value := range doc {
+		result[key] = value
+	}
+
+	return result
+}
+
+// deepCopyValue returns deep copy of JSON object.
+func deepCopyValue(value interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err = json.Unmarshal(bytes, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func deepCopy(doc document.Document) (document.Document, error) {
 	bytes, err := json.Marshal(doc)
 	if err != nil {
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package doccomposer
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
@@ -53,15 +56,194 @@ func TestApplyPatches(t *testing.T) {
 		require.Nil(t, doc)
 		require.Contains(t, err.Error(), "not supported")
 	})
-	t.Run("error - original document deep copy fails (not json)", func(t *testing.T) {
+	t.Run("error - original document deep copy for custom patch fails (not json)", func(t *testing.T) {
+		const customAction patch.Action = "composer-copy-action"
+
+		err := patch.RegisterAction(customAction, "data")
+		require.NoError(t, err)
+
+		composer := New(WithPatchApplier(customAction, func(doc document.Document, value interface{}) (document.Document, error) {
+			return doc, nil
+		}))
+
+		p, err := patch.NewPatch(customAction, "value")
+		require.NoError(t, err)
+
 		doc := make(document.Document)
 		doc["key"] = make(chan int)
 
-		doc, err := documentComposer.ApplyPatches(doc, nil)
+		doc, err = composer.ApplyPatches(doc, []patch.Patch{p})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "json: unsupported type: chan int")
+	})
+}
+
+func TestApplyPatches_CopyOnWrite(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success - unmodified entries are shared with original document", func(t *testing.T) {
+		original, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		addAlsoKnownAs, err := patch.NewAddAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		doc, err := documentComposer.ApplyPatches(original, []patch.Patch{addAlsoKnownAs})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:domain.com"}, doc.AlsoKnownAs())
+		require.Empty(t, original.AlsoKnownAs())
+
+		require.Equal(t, reflect.ValueOf(original[document.PublicKeyProperty]).Pointer(),
+			reflect.ValueOf(doc[document.PublicKeyProperty]).Pointer())
+		require.Equal(t, reflect.ValueOf(original[document.ServiceProperty]).Pointer(),
+			reflect.ValueOf(doc[document.ServiceProperty]).Pointer())
+	})
+
+	t.Run("success - modified public key is not shared with original document", func(t *testing.T) {
+		original, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		originalBytes, err := original.Bytes()
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(
+			`[{"id": "key1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		doc, err := documentComposer.ApplyPatches(original, []patch.Patch{add})
+		require.NoError(t, err)
+		require.Equal(t, []string{"assertionMethod", "authentication"}, doc.PublicKeys()[0].Purpose())
+
+		docBytes, err := original.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, originalBytes, docBytes)
+	})
+
+	t.Run("success - custom patch is applied to deep copy", func(t *testing.T) {
+		const customAction patch.Action = "composer-cow-action"
+
+		err := patch.RegisterAction(customAction, "data")
+		require.NoError(t, err)
+
+		composer := New(WithPatchApplier(customAction, func(doc document.Document, value interface{}) (document.Document, error) {
+			doc.PublicKeys()[0][document.PurposesProperty] = []interface{}{value}
+
+			return doc, nil
+		}))
+
+		original, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		p, err := patch.NewPatch(customAction, "authentication")
+		require.NoError(t, err)
+
+		doc, err := composer.ApplyPatches(original, []patch.Patch{p})
+		require.NoError(t, err)
+		require.Equal(t, []string{"authentication"}, doc.PublicKeys()[0].Purpose())
+		require.Equal(t, []string{"assertionMethod"}, original.PublicKeys()[0].Purpose())
+	})
+}
+
+func TestApplyPatches_Transactional(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("error - mid-sequence failure leaves original document untouched", func(t *testing.T) {
+		original, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		originalBytes, err := original.Bytes()
+		require.NoError(t, err)
+
+		addPublicKeys, err := patch.NewAddPublicKeysPatch(addKeys)
+		require.NoError(t, err)
+
+		removeServices, err := patch.NewRemoveServiceEndpointsPatch(`["svc1"]`)
+		require.NoError(t, err)
+
+		invalidJSONPatch, err := patch.NewJSONPatch(invalidPatches)
+		require.NoError(t, err)
+
+		addServices, err := patch.NewAddServiceEndpointsPatch(addServices)
+		require.NoError(t, err)
+
+		addAlsoKnownAs, err := patch.NewAddAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		doc, err := documentComposer.ApplyPatches(original,
+			[]patch.Patch{addPublicKeys, removeServices, invalidJSONPatch, addServices, addAlsoKnownAs})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "failed to apply patch[2]")
+
+		docBytes, err := original.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, originalBytes, docBytes)
+	})
+
+	t.Run("success - patch values are not shared with resulting document", func(t *testing.T) {
+		addPublicKeys, err := patch.NewAddPublicKeysPatch(addKeys)
+		require.NoError(t, err)
+
+		doc, err := documentComposer.ApplyPatches(make(document.Document), []patch.Patch{addPublicKeys})
+		require.NoError(t, err)
+
+		doc.PublicKeys()[0][document.PurposesProperty] = []interface{}{"keyAgreement"}
+
+		value, err := addPublicKeys.GetValue()
+		require.NoError(t, err)
+
+		keys := document.ParsePublicKeys(value)
+		require.Empty(t, keys[0].Purpose())
+	})
+
+	t.Run("error - patch value copy fails (not json)", func(t *testing.T) {
+		p := patch.Patch{patch.ActionKey: patch.AddAlsoKnownAs, patch.UrisKey: make(chan int)}
+
+		doc, err := documentComposer.ApplyPatches(make(document.Document), []patch.Patch{p})
 		require.Error(t, err)
 		require.Nil(t, doc)
 		require.Contains(t, err.Error(), "json: unsupported type: chan int")
 	})
+
+	t.Run("error - custom patch internal error code is kept", func(t *testing.T) {
+		const internalAction patch.Action = "composer-internal-action"
+
+		err := patch.RegisterAction(internalAction, "data")
+		require.NoError(t, err)
+
+		composer := New(WithPatchApplier(internalAction, func(doc document.Document, value interface{}) (document.Document, error) {
+			return nil, protocol.NewInternalError(errors.New("resolver not available"))
+		}))
+
+		p, err := patch.NewPatch(internalAction, "value")
+		require.NoError(t, err)
+
+		doc, err := composer.ApplyPatches(make(document.Document), []patch.Patch{p})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.True(t, protocol.IsInternalError(err))
+		require.Contains(t, err.Error(), "failed to apply patch[0]: resolver not available")
+	})
+
+	t.Run("error - custom patch produced nil document", func(t *testing.T) {
+		const nilAction patch.Action = "composer-nil-action"
+
+		err := patch.RegisterAction(nilAction, "data")
+		require.NoError(t, err)
+
+		composer := New(WithPatchApplier(nilAction, func(doc document.Document, value interface{}) (document.Document, error) {
+			return nil, nil
+		}))
+
+		p, err := patch.NewPatch(nilAction, "value")
+		require.NoError(t, err)
+
+		doc, err := composer.ApplyPatches(make(document.Document), []patch.Patch{p})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "failed to apply patch[0]: patch produced nil document")
+	})
 }
 
 func TestApplyPatches_PatchesFromOpaqueDoc(t *testing.T) {
@@ -95,6 +277,21 @@ func TestApplyPatches_ReplacePatch(t *testing.T) {
 		didDoc := document.DidDocumentFromJSONLDObject(doc.JSONLdObject())
 		require.Len(t, didDoc.Services(), 1)
 		require.Len(t, didDoc.PublicKeys(), 1)
+		require.Empty(t, didDoc.AlsoKnownAs())
+	})
+	t.Run("success - also known as", func(t *testing.T) {
+		replace, err := patch.NewReplacePatch(`{"alsoKnownAs": ["did:example:123"]}`)
+		require.NoError(t, err)
+
+		original := make(document.Document)
+		original[document.AlsoKnownAs] = []interface{}{"did:example:old"}
+
+		doc, err := documentComposer.ApplyPatches(original, []patch.Patch{replace})
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+
+		didDoc := document.DidDocumentFromJSONLDObject(doc.JSONLdObject())
+		require.Equal(t, []string{"did:example:123"}, didDoc.AlsoKnownAs())
 	})
 }
 
@@ -126,6 +323,33 @@ func TestApplyPatches_JSON(t *testing.T) {
 	})
 }
 
+func TestApplyPatches_JSONMerge(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		doc["name"] = "John"
+		doc["address"] = map[string]interface{}{"city": "Toronto", "street": "Main"}
+
+		mergePatch, err := patch.NewJSONMergePatch(`{"name": null, "address": {"street": "King"}, "age": 30}`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{mergePatch})
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+
+		_, ok := doc["name"]
+		require.False(t, ok)
+		require.Equal(t, map[string]interface{}{"city": "Toronto", "street": "King"}, doc["address"])
+		require.Equal(t, float64(30), doc["age"])
+
+		// public keys are not affected
+		require.Equal(t, 2, len(doc.PublicKeys()))
+	})
+}
+
 func TestApplyPatches_AddPublicKeys(t *testing.T) {
 	documentComposer := New()
 
@@ -285,6 +509,24 @@ func TestApplyPatches_AddServiceEndpoints(t *testing.T) {
 		diddoc := document.DidDocumentFromJSONLDObject(doc)
 		require.Equal(t, 3, len(diddoc.Services()))
 	})
+	t.Run("error - service with the same id as public key", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		addServices, err := patch.NewAddServiceEndpointsPatch(
+			`[{"id": "key1", "type": "LinkedDomains", "serviceEndpoint": "https://example.com"}]`)
+		require.NoError(t, err)
+
+		result, err := New(WithUniqueIDValidation(true)).ApplyPatches(doc, []patch.Patch{addServices})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "duplicate id: key1 is used by both public key and service")
+
+		// ids are not validated across public keys and services by default
+		result, err = documentComposer.ApplyPatches(doc, []patch.Patch{addServices})
+		require.NoError(t, err)
+		require.Len(t, document.DidDocumentFromJSONLDObject(result).Services(), 3)
+	})
 }
 
 func TestApplyPatches_RemoveServiceEndpoints(t *testing.T) {
@@ -342,6 +584,267 @@ func TestApplyPatches_RemoveServiceEndpoints(t *testing.T) {
 	})
 }
 
+func TestApplyPatches_ReplaceServiceEndpoints(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success - replace existing services", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		replaceServices, err := patch.NewReplaceServiceEndpointsPatch(addServices)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{replaceServices})
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+
+		diddoc := document.DidDocumentFromJSONLDObject(doc)
+		require.Equal(t, 1, len(diddoc.Services()))
+		require.Equal(t, "svc3", diddoc.Services()[0].ID())
+
+		// public keys are not affected
+		require.Equal(t, 2, len(diddoc.PublicKeys()))
+	})
+
+	t.Run("success - replace with empty services", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		replaceServices, err := patch.NewReplaceServiceEndpointsPatch(`[]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{replaceServices})
+		require.NoError(t, err)
+
+		diddoc := document.DidDocumentFromJSONLDObject(doc)
+		require.Empty(t, diddoc.Services())
+
+		_, ok := doc[document.ServiceProperty]
+		require.False(t, ok)
+	})
+}
+
+func TestApplyPatches_SetController(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success - set, replace and remove controller", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+		require.Empty(t, doc.Controller())
+
+		single, err := patch.NewSetControllerPatch(`"did:example:123"`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{single})
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", doc[document.ControllerProperty])
+		require.Equal(t, []string{"did:example:123"}, doc.Controller())
+
+		set, err := patch.NewSetControllerPatch(`["did:example:123", "did:example:456"]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{set})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:123", "did:example:456"}, doc.Controller())
+
+		remove, err := patch.NewSetControllerPatch(`[]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{remove})
+		require.NoError(t, err)
+
+		_, ok := doc[document.ControllerProperty]
+		require.False(t, ok)
+
+		// public keys are not affected
+		require.Len(t, doc.PublicKeys(), 2)
+	})
+
+	t.Run("success - replace patch with controller", func(t *testing.T) {
+		replace, err := patch.NewReplacePatch(`{"controller": "did:example:123"}`)
+		require.NoError(t, err)
+
+		doc, err := documentComposer.ApplyPatches(make(document.Document), []patch.Patch{replace})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:123"}, doc.Controller())
+	})
+}
+
+func TestApplyPatches_AlsoKnownAs(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success - add and remove also known as", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		addAlsoKnownAs, err := patch.NewAddAlsoKnownAs(`["did:domain.com", "did:other.com"]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{addAlsoKnownAs})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:domain.com", "did:other.com"}, doc.AlsoKnownAs())
+
+		removeAlsoKnownAs, err := patch.NewRemoveAlsoKnownAs(`["did:domain.com", "did:not-existing.com"]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{removeAlsoKnownAs})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:other.com"}, doc.AlsoKnownAs())
+
+		removeAlsoKnownAs, err = patch.NewRemoveAlsoKnownAs(`["did:other.com"]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{removeAlsoKnownAs})
+		require.NoError(t, err)
+		require.Empty(t, doc.AlsoKnownAs())
+
+		_, ok := doc[document.AlsoKnownAs]
+		require.False(t, ok)
+	})
+
+	t.Run("add same uri twice - no error; one uri added", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		addAlsoKnownAs, err := patch.NewAddAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{addAlsoKnownAs, addAlsoKnownAs})
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:domain.com"}, doc.AlsoKnownAs())
+	})
+}
+
+func TestApplyPatches_VerificationRelationships(t *testing.T) {
+	documentComposer := New()
+
+	t.Run("success - add and remove verification relationships", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(
+			`[{"id": "key1", "purposes": ["assertionMethod", "authentication"]}]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{add})
+		require.NoError(t, err)
+
+		diddoc := document.DidDocumentFromJSONLDObject(doc)
+		require.Equal(t, 2, len(diddoc.PublicKeys()))
+		require.Equal(t, []string{"assertionMethod", "authentication"}, diddoc.PublicKeys()[0].Purpose())
+		require.Equal(t, []string{"authentication"}, diddoc.PublicKeys()[1].Purpose())
+
+		remove, err := patch.NewRemoveVerificationRelationshipsPatch(
+			`[{"id": "key1", "purposes": ["assertionMethod", "keyAgreement"]}]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{remove})
+		require.NoError(t, err)
+
+		diddoc = document.DidDocumentFromJSONLDObject(doc)
+		require.Equal(t, []string{"authentication"}, diddoc.PublicKeys()[0].Purpose())
+
+		remove, err = patch.NewRemoveVerificationRelationshipsPatch(
+			`[{"id": "key1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{remove})
+		require.NoError(t, err)
+
+		diddoc = document.DidDocumentFromJSONLDObject(doc)
+		require.Empty(t, diddoc.PublicKeys()[0].Purpose())
+
+		_, ok := diddoc.PublicKeys()[0][document.PurposesProperty]
+		require.False(t, ok)
+	})
+
+	t.Run("error - public key not found", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key3", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{add})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "public key 'key3' not found")
+	})
+
+	t.Run("error - purpose not allowed for key type", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(`{"publicKey": [{
+			"id": "key1",
+			"type": "Ed25519VerificationKey2018",
+			"purposes": ["authentication"],
+			"publicKeyJwk": {"kty": "OKP", "crv": "Ed25519", "x": "K24aib_Py_D2ST8F_IiIA2SJo1EcEUd3zIhxuTdv3jg"}
+		}]}`))
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["keyAgreement"]}]`)
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{add})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "invalid key type: Ed25519VerificationKey2018")
+	})
+
+	t.Run("success - custom key purpose", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["custom"]}]`)
+		require.NoError(t, err)
+
+		result, err := New(WithKeyPurposes("custom", document.KeyPurposeAuthentication,
+			document.KeyPurposeAssertionMethod)).ApplyPatches(
+			doc, []patch.Patch{add})
+		require.NoError(t, err)
+		require.Contains(t, document.DidDocumentFromJSONLDObject(result).PublicKeys()[0].Purpose(), "custom")
+
+		result, err = documentComposer.ApplyPatches(doc, []patch.Patch{add})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "invalid purpose: custom")
+	})
+}
+
+func TestApplyPatches_CustomPatch(t *testing.T) {
+	const customAction patch.Action = "composer-custom-action"
+
+	err := patch.RegisterAction(customAction, "data")
+	require.NoError(t, err)
+
+	customPatch, err := patch.NewPatch(customAction, "value")
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		documentComposer := New(WithPatchApplier(customAction,
+			func(doc document.Document, value interface{}) (document.Document, error) {
+				doc["custom"] = value
+
+				return doc, nil
+			}))
+
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{customPatch})
+		require.NoError(t, err)
+		require.Equal(t, "value", doc["custom"])
+	})
+
+	t.Run("error - applier not registered", func(t *testing.T) {
+		doc, err := setupDefaultDoc()
+		require.NoError(t, err)
+
+		doc, err = New().ApplyPatches(doc, []patch.Patch{customPatch})
+		require.Error(t, err)
+		require.Nil(t, doc)
+		require.Contains(t, err.Error(), "action 'composer-custom-action' is not supported")
+	})
+}
+
 func setupDefaultDoc() (document.Document, error) {
 	documentComposer := New()
 
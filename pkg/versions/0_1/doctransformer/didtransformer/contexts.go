@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const (
+	jsonWebKey2020 = "JsonWebKey2020"
+
+	jsonWebKey2020Context = "https://w3id.org/security/suites/jws-2020/v1"
+
+	ecdsaSecp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
+
+	ecdsaSecp256k1VerificationKey2019Context = "https://w3id.org/security/suites/secp256k1-2019/v1"
+
+	ed25519VerificationKey2018Context = "https://w3id.org/security/suites/ed25519-2018/v1"
+
+	x25519KeyAgreementKey2019Context = "https://w3id.org/security/suites/x25519-2019/v1"
+)
+
+// DefaultVerificationMethodContexts returns default mapping of verification method type to JSON-LD context
+// that defines the type (and its properties) and is added to the document when a verification method
+// of that type is included in the document.
+func DefaultVerificationMethodContexts() map[string]string {
+	return map[string]string{
+		jsonWebKey2020:                    jsonWebKey2020Context,
+		ecdsaSecp256k1VerificationKey2019: ecdsaSecp256k1VerificationKey2019Context,
+		ed25519VerificationKey2018:        ed25519VerificationKey2018Context,
+		ed25519VerificationKey2020:        ed25519VerificationKey2020Context,
+		x25519KeyAgreementKey2019:         x25519KeyAgreementKey2019Context,
+		bls12381G2Key2020:                 bls12381G2Key2020Context,
+	}
+}
+
+// WithVerificationMethodContexts sets mapping of verification method type to JSON-LD context (replaces
+// default mapping); verification method types without mapping don't add any context to the document.
+func WithVerificationMethodContexts(contexts map[string]string) Option {
+	return func(opts *Transformer) {
+		opts.vmContexts = contexts
+	}
+}
+
+// WithContextRegistry sets context registry that is consulted when assembling document contexts; method
+// and verification method contexts that are not allowed by the registry are not added to the document.
+func WithContextRegistry(registry *document.ContextRegistry) Option {
+	return func(opts *Transformer) {
+		opts.contextRegistry = registry
+	}
+}
+
+// addVerificationMethodContexts adds contexts for the types of verification methods (in order of appearance)
+// that are not already included in the document contexts.
+func (t *Transformer) addVerificationMethodContexts(doc document.Document, publicKeys []document.PublicKey) {
+	ctx, ok := doc[document.ContextProperty].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, pk := range publicKeys {
+		c, ok := t.vmContexts[pk.Type()]
+		if !ok || c == "" || containsContext(ctx, c) || !t.isContextAllowed(c) {
+			continue
+		}
+
+		ctx = append(ctx, c)
+	}
+
+	doc[document.ContextProperty] = ctx
+}
+
+func (t *Transformer) isContextAllowed(c string) bool {
+	return t.contextRegistry == nil || t.contextRegistry.IsAllowed(c)
+}
+
+func containsContext(ctx []interface{}, c string) bool {
+	for _, v := range ctx {
+		if v == c {
+			return true
+		}
+	}
+
+	return false
+}
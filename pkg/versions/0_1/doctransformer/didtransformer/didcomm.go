@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+// transformDIDCommService transforms DIDComm v2 messaging service: routing keys that reference keys
+// in the same document are converted to DID URLs (relative references are kept if @base is included).
+// Routing keys may be specified either as service property or as property of object-valued service endpoint.
+func (t *Transformer) transformDIDCommService(did string, service document.Service) {
+	t.transformRoutingKeys(did, service)
+
+	endpoint, ok := service.Endpoint().AsMap()
+	if !ok {
+		return
+	}
+
+	// copy endpoint object so that internal document is not modified
+	externalEndpoint := make(map[string]interface{})
+	for key, value := range endpoint {
+		externalEndpoint[key] = value
+	}
+
+	t.transformRoutingKeys(did, externalEndpoint)
+
+	service[document.ServiceEndpointProperty] = externalEndpoint
+}
+
+func (t *Transformer) transformRoutingKeys(did string, obj map[string]interface{}) {
+	routingKeys, ok := obj[document.RoutingKeysProperty].([]interface{})
+	if !ok {
+		return
+	}
+
+	externalKeys := make([]interface{}, len(routingKeys))
+
+	for i, entry := range routingKeys {
+		key, ok := entry.(string)
+		if ok && strings.HasPrefix(key, "#") {
+			externalKeys[i] = t.getObjectID(did, strings.TrimPrefix(key, "#"))
+		} else {
+			externalKeys[i] = entry
+		}
+	}
+
+	obj[document.RoutingKeysProperty] = externalKeys
+}
@@ -0,0 +1,45 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+// purposeRelationships maps key purpose to verification relationship.
+// nolint:gochecknoglobals
+var purposeRelationships = map[string]string{
+	document.KeyPurposeAuthentication:       document.AuthenticationProperty,
+	document.KeyPurposeAssertionMethod:      document.AssertionMethodProperty,
+	document.KeyPurposeKeyAgreement:         document.KeyAgreementProperty,
+	document.KeyPurposeCapabilityDelegation: document.DelegationKeyProperty,
+	document.KeyPurposeCapabilityInvocation: document.InvocationKeyProperty,
+}
+
+// WithEmbeddedVerificationMethods sets verification relationships (e.g. authentication, assertionMethod) that
+// embed verification methods instead of referencing them by ID. Keys that are only embedded are not included
+// in verificationMethod section since verification method ID has to be unique within the document.
+func WithEmbeddedVerificationMethods(relationships ...string) Option {
+	return func(opts *Transformer) {
+		opts.embeddedRelationships = make(map[string]bool)
+
+		for _, r := range relationships {
+			opts.embeddedRelationships[r] = true
+		}
+	}
+}
+
+// appendEmbedded appends verification method to relationship unless it is already embedded.
+func appendEmbedded(entries []interface{}, vm document.PublicKey) []interface{} {
+	for _, e := range entries {
+		if embeddedVM, ok := e.(document.PublicKey); ok && embeddedVM.ID() == vm.ID() {
+			return entries
+		}
+	}
+
+	return append(entries, vm)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+func TestEmbeddedVerificationMethods(t *testing.T) {
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	t.Run("success - embedded in all relationships of the key", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(embeddedKeysDoc))
+		require.NoError(t, err)
+
+		result, err := New(WithEmbeddedVerificationMethods(document.AuthenticationProperty)).TransformDocument(
+			&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		// auth key is only embedded so it is not listed in verificationMethod
+		require.Len(t, didDoc.VerificationMethods(), 2)
+		require.Equal(t, testID+"#assertion", didDoc.VerificationMethods()[0].ID())
+		require.Equal(t, testID+"#both", didDoc.VerificationMethods()[1].ID())
+
+		require.Len(t, didDoc.Authentications(), 2)
+
+		embedded, ok := didDoc.Authentications()[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, testID+"#auth", embedded[document.IDProperty])
+		require.Equal(t, "JsonWebKey2020", embedded[document.TypeProperty])
+		require.Equal(t, testID, embedded[document.ControllerProperty])
+		require.NotEmpty(t, embedded[document.PublicKeyJwkProperty])
+
+		embedded, ok = didDoc.Authentications()[1].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, testID+"#both", embedded[document.IDProperty])
+
+		require.Equal(t, []interface{}{testID + "#assertion", testID + "#both"}, didDoc.AssertionMethods())
+
+		require.Equal(t, []interface{}{didContext, jsonWebKey2020Context}, didDoc.Context())
+	})
+
+	t.Run("success - referenced by default", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(embeddedKeysDoc))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 3)
+		require.Equal(t, []interface{}{testID + "#auth", testID + "#both"}, didDoc.Authentications())
+	})
+
+	t.Run("success - derived key agreement key embedded", func(t *testing.T) {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+		require.NoError(t, err)
+
+		publicKeyBytes, err := json.Marshal(jwk)
+		require.NoError(t, err)
+
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true), WithBase(true),
+			WithEmbeddedVerificationMethods(document.KeyAgreementProperty)).TransformDocument(
+			&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 1)
+		require.Equal(t, "#key1", didDoc.VerificationMethods()[0].ID())
+		require.Equal(t, []interface{}{testID + "#key1"}, didDoc.Authentications())
+
+		require.Len(t, didDoc.AgreementKeys(), 1)
+
+		embedded, ok := didDoc.AgreementKeys()[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "#key1-x25519", embedded[document.IDProperty])
+		require.Equal(t, "X25519KeyAgreementKey2019", embedded[document.TypeProperty])
+
+		require.Contains(t, didDoc.Context(), x25519KeyAgreementKey2019Context)
+	})
+}
+
+func TestAppendEmbedded(t *testing.T) {
+	vm := document.PublicKey{document.IDProperty: "#key1"}
+
+	entries := appendEmbedded(nil, vm)
+	require.Len(t, entries, 1)
+
+	entries = appendEmbedded(entries, document.PublicKey{document.IDProperty: "#key1"})
+	require.Len(t, entries, 1)
+
+	entries = appendEmbedded(entries, document.PublicKey{document.IDProperty: "#key2"})
+	require.Len(t, entries, 2)
+}
+
+const embeddedKeysDoc = `{
+  "publicKey": [
+	{
+		"id": "auth",
+		"type": "JsonWebKey2020",
+		"purposes": ["authentication"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	},
+	{
+		"id": "assertion",
+		"type": "JsonWebKey2020",
+		"purposes": ["assertionMethod"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	},
+	{
+		"id": "both",
+		"type": "JsonWebKey2020",
+		"purposes": ["authentication", "assertionMethod"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	}
+  ]
+}`
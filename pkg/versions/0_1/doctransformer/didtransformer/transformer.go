@@ -14,6 +14,8 @@ import (
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
@@ -25,8 +27,22 @@ const (
 
 	// ed25519VerificationKey2018 requires special handling (convert to base58).
 	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+
+	// ed25519VerificationKey2020 requires special handling (convert to multibase).
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+
+	ed25519VerificationKey2020Context = "https://w3id.org/security/suites/ed25519-2020/v1"
+
+	// bls12381G2Key2020 requires special handling (convert to base58).
+	bls12381G2Key2020 = "Bls12381G2Key2020"
+
+	bls12381G2Key2020Context = "https://w3id.org/security/bbs/v1"
 )
 
+// multicodec prefix for ed25519 public key (ed25519-pub).
+// nolint:gochecknoglobals
+var ed25519PubMulticodec = []byte{0xed, 0x01}
+
 // Option is a registry instance option.
 type Option func(opts *Transformer)
 
@@ -44,15 +60,40 @@ func WithBase(enabled bool) Option {
 	}
 }
 
+// WithEd25519VerificationKey2020 enables emitting Ed25519VerificationKey2018 keys as Ed25519VerificationKey2020
+// verification methods with publicKeyMultibase (instead of publicKeyBase58).
+func WithEd25519VerificationKey2020(enabled bool) Option {
+	return func(opts *Transformer) {
+		opts.ed25519Key2020 = enabled
+	}
+}
+
+// WithPublicKeyJwkOnly enables emitting all verification methods as JsonWebKey2020 with publicKeyJwk
+// regardless of internal key type (for relying parties that only consume JWKs).
+func WithPublicKeyJwkOnly(enabled bool) Option {
+	return func(opts *Transformer) {
+		opts.publicKeyJwkOnly = enabled
+	}
+}
+
 // Transformer is responsible for transforming internal to external document.
 type Transformer struct {
-	methodCtx   []string // used for setting additional contexts during resolution
-	includeBase bool
+	methodCtx          []string // used for setting additional contexts during resolution
+	includeBase        bool
+	ed25519Key2020     bool
+	x25519KeyAgreement bool
+	publicKeyJwkOnly   bool
+	vmContexts         map[string]string
+
+	embeddedRelationships map[string]bool
+	contextRegistry       *document.ContextRegistry
 }
 
 // New creates a new DID Transformer.
 func New(opts ...Option) *Transformer {
-	transformer := &Transformer{}
+	transformer := &Transformer{
+		vmContexts: DefaultVerificationMethodContexts(),
+	}
 
 	// apply options
 	for _, opt := range opts {
@@ -83,7 +124,17 @@ func (t *Transformer) TransformDocument(rm *protocol.ResolutionModel, info proto
 		return nil, errors.New("published is required for document transformation")
 	}
 
-	internal := document.DidDocumentFromJSONLDObject(rm.Doc.JSONLdObject())
+	// plain JSON representation (application/did+json) has no @context so object IDs can't be relative to @base
+	plainJSON := info[document.ContentTypeProperty] == document.ContentTypeDIDJSON
+	if plainJSON && t.includeBase {
+		withoutBase := *t
+		withoutBase.includeBase = false
+
+		return withoutBase.TransformDocument(rm, info)
+	}
+
+	// copy internal document so that transformed document doesn't share (nested) values with resolution model
+	internal := document.DidDocumentFromJSONLDObject(rm.Doc.Copy().JSONLdObject())
 
 	// start with empty document
 	external := document.DidDocumentFromJSONLDObject(make(document.DIDDocument))
@@ -93,21 +144,44 @@ func (t *Transformer) TransformDocument(rm *protocol.ResolutionModel, info proto
 
 	// add optional method contexts
 	for _, c := range t.methodCtx {
-		ctx = append(ctx, c)
+		if t.isContextAllowed(c) {
+			ctx = append(ctx, c)
+		}
 	}
 
 	if t.includeBase {
 		ctx = append(ctx, getBase(id.(string)))
 	}
 
-	external[document.ContextProperty] = ctx
+	// verification method contexts are only added if document has contexts (JSON-LD representation)
+	if !plainJSON {
+		external[document.ContextProperty] = ctx
+	}
+
 	external[document.IDProperty] = id
 
+	// also known as values are absolute URIs (enforced by patch validation) so they are copied as is
+	// and are not affected by @base
+	external.SetAlsoKnownAs(internal.AlsoKnownAs())
+
+	// controller is either a single value or a set
+	if len(internal.Controller()) > 0 {
+		external[document.ControllerProperty] = internal[document.ControllerProperty]
+	}
+
 	methodMetadata := make(document.Metadata)
 	methodMetadata[document.PublishedProperty] = published
 	methodMetadata[document.RecoveryCommitmentProperty] = rm.RecoveryCommitment
 	methodMetadata[document.UpdateCommitmentProperty] = rm.UpdateCommitment
 
+	if rm.PatchMode != "" {
+		methodMetadata[document.PatchModeProperty] = string(rm.PatchMode)
+	}
+
+	if len(rm.SkippedPatches) > 0 {
+		methodMetadata[document.SkippedPatchesProperty] = rm.SkippedPatches
+	}
+
 	result := &document.ResolutionResult{
 		Context:        didResolutionContext,
 		Document:       external.JSONLdObject(),
@@ -121,6 +195,17 @@ func (t *Transformer) TransformDocument(rm *protocol.ResolutionModel, info proto
 		docMetadata[document.CanonicalIDProperty] = canonicalID
 	}
 
+	// zero transaction time means that operation time is not available (e.g. unpublished document)
+	if rm.CreatedTransactionTime > 0 {
+		docMetadata[document.CreatedTransactionTimeProperty] = rm.CreatedTransactionTime
+		docMetadata[document.CreatedTransactionNumberProperty] = rm.CreatedTransactionNumber
+	}
+
+	if rm.UpdatedTransactionTime > 0 {
+		docMetadata[document.UpdatedTransactionTimeProperty] = rm.UpdatedTransactionTime
+		docMetadata[document.UpdatedTransactionNumberProperty] = rm.UpdatedTransactionNumber
+	}
+
 	if len(docMetadata) > 0 {
 		result.DocumentMetadata = docMetadata
 	}
@@ -165,6 +250,10 @@ func (t *Transformer) processServices(internal document.DIDDocument, resolutionR
 			}
 		}
 
+		if sv.Type() == document.DIDCommMessagingServiceType {
+			t.transformDIDCommService(did, externalService)
+		}
+
 		services = append(services, externalService)
 	}
 
@@ -174,7 +263,8 @@ func (t *Transformer) processServices(internal document.DIDDocument, resolutionR
 }
 
 // processKeys will process keys according to Sidetree rules bellow and add them to external document.
-// every key will be included in the verificationMethod section of the resolved DID Document.
+// every key will be included in the verificationMethod section of the resolved DID Document (unless the key
+// is only embedded in verification relationships, see WithEmbeddedVerificationMethods).
 //
 // -- authentication: the key MUST be included by reference (full id) in the authentication section of the resolved DID Document
 // -- assertion: the key MUST be included by reference in the assertionMethod section.
@@ -192,41 +282,112 @@ func (t *Transformer) processKeys(internal document.DIDDocument, resolutionResul
 
 	did := resolutionResult.Document.ID()
 
-	var publicKeys []document.PublicKey
+	var publicKeys, allKeys []document.PublicKey
+
+	// verification methods are included in the order of appearance in internal document; only first key
+	// with a given ID is included so that transformed document is deterministic and has no duplicates
+	included := make(map[string]bool)
 
 	for _, pk := range internal.PublicKeys() {
 		// construct full DID URL for inclusion in purpose sections
 		id := did + "#" + pk.ID()
 
+		if included[id] {
+			continue
+		}
+
+		included[id] = true
+
 		externalPK := make(document.PublicKey)
 		externalPK[document.IDProperty] = t.getObjectID(did, pk.ID())
 		externalPK[document.TypeProperty] = pk.Type()
-		externalPK[document.ControllerProperty] = did
+		externalPK[document.ControllerProperty] = getKeyController(did, pk)
 
-		if pk.Type() == ed25519VerificationKey2018 {
+		switch {
+		case t.publicKeyJwkOnly:
+			externalPK[document.TypeProperty] = jsonWebKey2020
+			externalPK[document.PublicKeyJwkProperty] = pk.PublicKeyJwk()
+		case pk.Type() == ed25519VerificationKey2020 || (pk.Type() == ed25519VerificationKey2018 && t.ed25519Key2020):
+			ed25519PubKey, err := getED2519PublicKey(pk.PublicKeyJwk())
+			if err != nil {
+				return err
+			}
+			multibase, err := getEd25519Multibase(ed25519PubKey)
+			if err != nil {
+				return err
+			}
+			externalPK[document.TypeProperty] = ed25519VerificationKey2020
+			externalPK[document.PublicKeyMultibaseProperty] = multibase
+		case pk.Type() == ed25519VerificationKey2018:
 			ed25519PubKey, err := getED2519PublicKey(pk.PublicKeyJwk())
 			if err != nil {
 				return err
 			}
 			externalPK[document.PublicKeyBase58Property] = base58.Encode(ed25519PubKey)
-		} else {
+		case pk.Type() == bls12381G2Key2020:
+			blsPubKey, err := getBLS12381G2PublicKey(pk.PublicKeyJwk())
+			if err != nil {
+				return err
+			}
+			externalPK[document.PublicKeyBase58Property] = base58.Encode(blsPubKey)
+		default:
 			externalPK[document.PublicKeyJwkProperty] = pk.PublicKeyJwk()
 		}
 
-		publicKeys = append(publicKeys, externalPK)
+		keyAgreementID := id
+		keyAgreementPK := externalPK
+
+		var x25519PK document.PublicKey
+
+		if t.x25519KeyAgreement && isEd25519Key(pk) && hasPurpose(pk, document.KeyPurposeKeyAgreement) &&
+			!included[id+x25519KeyIDSuffix] {
+			var err error
+
+			x25519PK, err = t.getX25519KeyAgreementKey(did, pk)
+			if err != nil {
+				return err
+			}
+
+			keyAgreementID = id + x25519KeyIDSuffix
+			keyAgreementPK = x25519PK
+			included[keyAgreementID] = true
+		}
+
+		embedded := make(map[string]bool)
+		referenced := make(map[string]bool)
 
 		for _, p := range pk.Purpose() {
-			switch p {
-			case document.KeyPurposeAuthentication:
-				purposes[document.AuthenticationProperty] = append(purposes[document.AuthenticationProperty], id)
-			case document.KeyPurposeAssertionMethod:
-				purposes[document.AssertionMethodProperty] = append(purposes[document.AssertionMethodProperty], id)
-			case document.KeyPurposeKeyAgreement:
-				purposes[document.KeyAgreementProperty] = append(purposes[document.KeyAgreementProperty], id)
-			case document.KeyPurposeCapabilityDelegation:
-				purposes[document.DelegationKeyProperty] = append(purposes[document.DelegationKeyProperty], id)
-			case document.KeyPurposeCapabilityInvocation:
-				purposes[document.InvocationKeyProperty] = append(purposes[document.InvocationKeyProperty], id)
+			relationship, ok := purposeRelationships[p]
+			if !ok {
+				continue
+			}
+
+			refID, vm := id, externalPK
+			if relationship == document.KeyAgreementProperty {
+				refID, vm = keyAgreementID, keyAgreementPK
+			}
+
+			if t.embeddedRelationships[relationship] {
+				purposes[relationship] = appendEmbedded(purposes[relationship], vm)
+				embedded[refID] = true
+			} else {
+				purposes[relationship] = appendUnique(purposes[relationship], refID)
+				referenced[refID] = true
+			}
+		}
+
+		// keys that are only embedded in verification relationships are not included in verificationMethod
+		allKeys = append(allKeys, externalPK)
+
+		if !embedded[id] || referenced[id] {
+			publicKeys = append(publicKeys, externalPK)
+		}
+
+		if x25519PK != nil {
+			allKeys = append(allKeys, x25519PK)
+
+			if !embedded[keyAgreementID] || referenced[keyAgreementID] {
+				publicKeys = append(publicKeys, x25519PK)
 			}
 		}
 	}
@@ -235,6 +396,8 @@ func (t *Transformer) processKeys(internal document.DIDDocument, resolutionResul
 		resolutionResult.Document[document.VerificationMethodProperty] = publicKeys
 	}
 
+	t.addVerificationMethodContexts(resolutionResult.Document, allKeys)
+
 	for key, value := range purposes {
 		if len(value) > 0 {
 			resolutionResult.Document[key] = value
@@ -253,6 +416,54 @@ func (t *Transformer) getObjectID(docID string, objectID string) interface{} {
 	return docID + relativeID
 }
 
+// getKeyController returns public key controller; defaults to DID subject if key doesn't specify controller.
+func getKeyController(did string, pk document.PublicKey) string {
+	if pk.Controller() != "" {
+		return pk.Controller()
+	}
+
+	return did
+}
+
+// appendUnique appends reference to relationship references unless it is already included.
+func appendUnique(refs []interface{}, ref string) []interface{} {
+	for _, r := range refs {
+		if r == ref {
+			return refs
+		}
+	}
+
+	return append(refs, ref)
+}
+
+func hasPurpose(pk document.PublicKey, purpose string) bool {
+	for _, p := range pk.Purpose() {
+		if p == purpose {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEd25519Multibase returns multibase (base58btc) encoding of multicodec prefixed ed25519 public key.
+func getEd25519Multibase(pubKey []byte) (string, error) {
+	return docutil.EncodeMultibase(docutil.MultibaseBase58BTC, append(append([]byte{}, ed25519PubMulticodec...), pubKey...))
+}
+
+func getBLS12381G2PublicKey(pkJWK document.JWK) ([]byte, error) {
+	if !pkJWK.IsBLS12381G2() {
+		return nil, fmt.Errorf("unexpected JWK for %s key: kty[%s], crv[%s]", bls12381G2Key2020, pkJWK.Kty(), pkJWK.Crv())
+	}
+
+	pubKey, err := encoder.DecodeString(pkJWK.X())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s key: %s", bls12381G2Key2020, err.Error())
+	}
+
+	return pubKey, nil
+}
+
 func getED2519PublicKey(pkJWK document.JWK) ([]byte, error) {
 	jwk := &jws.JWK{
 		Crv: pkJWK.Crv(),
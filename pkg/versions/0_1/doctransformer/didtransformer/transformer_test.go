@@ -14,6 +14,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
 )
 
@@ -44,6 +46,15 @@ func TestNewTransformer(t *testing.T) {
 
 	transformer = New(WithBase(true))
 	require.Equal(t, true, transformer.includeBase)
+
+	transformer = New(WithEd25519VerificationKey2020(true))
+	require.Equal(t, true, transformer.ed25519Key2020)
+
+	transformer = New(WithX25519KeyAgreement(true))
+	require.Equal(t, true, transformer.x25519KeyAgreement)
+
+	transformer = New(WithPublicKeyJwkOnly(true))
+	require.Equal(t, true, transformer.publicKeyJwkOnly)
 }
 
 func TestTransformDocument(t *testing.T) {
@@ -76,8 +87,11 @@ func TestTransformDocument(t *testing.T) {
 
 		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
 		require.NoError(t, err)
-		require.Equal(t, 1, len(didDoc.Context()))
-		require.Equal(t, didContext, didDoc.Context()[0])
+		require.Equal(t, []interface{}{
+			didContext,
+			ecdsaSecp256k1VerificationKey2019Context,
+			jsonWebKey2020Context,
+		}, didDoc.Context())
 
 		// validate services
 		service := didDoc.Services()[0]
@@ -140,6 +154,133 @@ func TestTransformDocument(t *testing.T) {
 		require.Equal(t, "canonical", result.DocumentMetadata[document.CanonicalIDProperty])
 	})
 
+	t.Run("success - with created and updated", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		created := &protocol.ResolutionModel{Doc: doc, CreatedTransactionTime: 10, CreatedTransactionNumber: 1}
+
+		result, err := transformer.TransformDocument(created, info)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.CreatedTransactionTimeProperty])
+		require.Equal(t, uint64(1), result.DocumentMetadata[document.CreatedTransactionNumberProperty])
+		require.NotContains(t, result.DocumentMetadata, document.UpdatedTransactionTimeProperty)
+		require.NotContains(t, result.DocumentMetadata, document.UpdatedTransactionNumberProperty)
+
+		updated := &protocol.ResolutionModel{Doc: doc, CreatedTransactionTime: 10, CreatedTransactionNumber: 1,
+			UpdatedTransactionTime: 20, UpdatedTransactionNumber: 2}
+
+		result, err = transformer.TransformDocument(updated, info)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.CreatedTransactionTimeProperty])
+		require.Equal(t, uint64(1), result.DocumentMetadata[document.CreatedTransactionNumberProperty])
+		require.Equal(t, uint64(20), result.DocumentMetadata[document.UpdatedTransactionTimeProperty])
+		require.Equal(t, uint64(2), result.DocumentMetadata[document.UpdatedTransactionNumberProperty])
+	})
+
+	t.Run("success - with patch mode and skipped patches", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		lenient := &protocol.ResolutionModel{
+			Doc:            doc,
+			PatchMode:      protocol.PatchModeLenient,
+			SkippedPatches: []string{"patch[0] 'ietf-json-patch' skipped"},
+		}
+
+		result, err := transformer.TransformDocument(lenient, info)
+		require.NoError(t, err)
+		require.Equal(t, "lenient", result.MethodMetadata[document.PatchModeProperty])
+		require.Equal(t, lenient.SkippedPatches, result.MethodMetadata[document.SkippedPatchesProperty])
+	})
+
+	t.Run("success - with also known as", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		docWithAlsoKnownAs, err := document.FromBytes(docBytes)
+		require.NoError(t, err)
+
+		docWithAlsoKnownAs[document.AlsoKnownAs] = []interface{}{"did:domain.com"}
+
+		result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: docWithAlsoKnownAs}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:domain.com"}, didDoc.AlsoKnownAs())
+	})
+
+	t.Run("success - with also known as and base", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		docWithAlsoKnownAs, err := document.FromBytes(docBytes)
+		require.NoError(t, err)
+
+		docWithAlsoKnownAs[document.AlsoKnownAs] = []interface{}{"did:domain.com", "https://myblog.example/"}
+
+		result, err := New(WithBase(true)).TransformDocument(&protocol.ResolutionModel{Doc: docWithAlsoKnownAs}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:domain.com", "https://myblog.example/"}, didDoc.AlsoKnownAs())
+
+		// internal document is not modified
+		require.Equal(t, []interface{}{"did:domain.com", "https://myblog.example/"},
+			docWithAlsoKnownAs[document.AlsoKnownAs])
+	})
+
+	t.Run("success - with key controller", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		docWithKeyController, err := document.FromBytes(docBytes)
+		require.NoError(t, err)
+
+		docWithKeyController.PublicKeys()[0][document.ControllerProperty] = "did:example:delegate"
+
+		result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: docWithKeyController}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+		require.Equal(t, "did:example:delegate", didDoc.VerificationMethods()[0].Controller())
+		require.Equal(t, testID, didDoc.VerificationMethods()[1].Controller())
+	})
+
+	t.Run("success - with controller", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = testID
+		info[document.PublishedProperty] = true
+
+		for _, controller := range []interface{}{"did:example:123", []interface{}{"did:example:123", "did:example:456"}} {
+			docWithController, err := document.FromBytes(docBytes)
+			require.NoError(t, err)
+
+			docWithController[document.ControllerProperty] = controller
+
+			result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: docWithController}, info)
+			require.NoError(t, err)
+			require.Equal(t, controller, result.Document[document.ControllerProperty])
+		}
+	})
+
 	t.Run("error - internal document is missing", func(t *testing.T) {
 		info := make(protocol.TransformationInfo)
 		info[document.IDProperty] = testID
@@ -203,6 +344,117 @@ func TestWithMethodContext(t *testing.T) {
 	require.Equal(t, "ctx-2", didDoc.Context()[2])
 }
 
+func TestDIDCommMessagingService(t *testing.T) {
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	t.Run("success - routing keys in service endpoint object", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(didCommDoc))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		services := result.Document[document.ServiceProperty].([]document.Service)
+		require.Len(t, services, 2)
+
+		endpoint := services[0].ServiceEndpoint().(map[string]interface{})
+		require.Equal(t, "https://example.com/didcomm", endpoint[document.URIProperty])
+		require.Equal(t, []interface{}{"didcomm/v2"}, endpoint[document.AcceptProperty])
+		require.Equal(t, []interface{}{testID + "#key1", "did:example:mediator#key1"}, endpoint[document.RoutingKeysProperty])
+
+		// internal document is not modified
+		internalEndpoint := document.DidDocumentFromJSONLDObject(doc).Services()[0].ServiceEndpoint().(map[string]interface{})
+		require.Equal(t, []interface{}{"#key1", "did:example:mediator#key1"}, internalEndpoint[document.RoutingKeysProperty])
+
+		require.Equal(t, "https://example.com/legacy", services[1].ServiceEndpoint())
+		require.Equal(t, []interface{}{testID + "#key1"}, services[1][document.RoutingKeysProperty])
+	})
+
+	t.Run("success - with base", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(didCommDoc))
+		require.NoError(t, err)
+
+		result, err := New(WithBase(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		services := result.Document[document.ServiceProperty].([]document.Service)
+		endpoint := services[0].ServiceEndpoint().(map[string]interface{})
+		require.Equal(t, []interface{}{"#key1", "did:example:mediator#key1"}, endpoint[document.RoutingKeysProperty])
+	})
+}
+
+func TestVerificationMethodContexts(t *testing.T) {
+	r := reader(t, "testdata/doc.json")
+	docBytes, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	doc, err := document.FromBytes(docBytes)
+	require.NoError(t, err)
+
+	internal := &protocol.ResolutionModel{Doc: doc}
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	t.Run("success - custom mapping", func(t *testing.T) {
+		transformer := New(WithVerificationMethodContexts(map[string]string{jsonWebKey2020: "ctx-jwk"}))
+
+		result, err := transformer.TransformDocument(internal, info)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{didContext, "ctx-jwk"}, result.Document[document.ContextProperty])
+	})
+
+	t.Run("success - no mapping", func(t *testing.T) {
+		transformer := New(WithVerificationMethodContexts(nil))
+
+		result, err := transformer.TransformDocument(internal, info)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{didContext}, result.Document[document.ContextProperty])
+	})
+
+	t.Run("success - context already included (method context)", func(t *testing.T) {
+		transformer := New(WithMethodContext([]string{jsonWebKey2020Context}))
+
+		result, err := transformer.TransformDocument(internal, info)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			didContext,
+			jsonWebKey2020Context,
+			ecdsaSecp256k1VerificationKey2019Context,
+		}, result.Document[document.ContextProperty])
+	})
+
+	t.Run("success - context registry", func(t *testing.T) {
+		registry := document.NewContextRegistry(
+			document.WithContextAllowlist(jsonWebKey2020Context, "https://example.com/method/v1"))
+
+		transformer := New(WithContextRegistry(registry),
+			WithMethodContext([]string{"https://example.com/method/v1", "https://example.com/other/v1"}))
+
+		result, err := transformer.TransformDocument(internal, info)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			didContext,
+			"https://example.com/method/v1",
+			jsonWebKey2020Context,
+		}, result.Document[document.ContextProperty])
+
+		require.NoError(t, registry.Validate(result.Document[document.ContextProperty].([]interface{})))
+	})
+
+	t.Run("success - default mapping", func(t *testing.T) {
+		contexts := DefaultVerificationMethodContexts()
+		require.Equal(t, ed25519VerificationKey2018Context, contexts[ed25519VerificationKey2018])
+		require.Equal(t, x25519KeyAgreementKey2019Context, contexts[x25519KeyAgreementKey2019])
+
+		// default mapping is copied
+		contexts[jsonWebKey2020] = "ctx-jwk"
+		require.Equal(t, jsonWebKey2020Context, DefaultVerificationMethodContexts()[jsonWebKey2020])
+	})
+}
+
 func TestWithBase(t *testing.T) {
 	r := reader(t, "testdata/doc.json")
 	docBytes, err := ioutil.ReadAll(r)
@@ -226,7 +478,7 @@ func TestWithBase(t *testing.T) {
 
 	didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
 	require.NoError(t, err)
-	require.Equal(t, 2, len(didDoc.Context()))
+	require.Equal(t, 4, len(didDoc.Context()))
 
 	// second context is @base
 	baseMap := didDoc.Context()[1].(map[string]interface{})
@@ -241,6 +493,41 @@ func TestWithBase(t *testing.T) {
 	require.NotContains(t, pk.ID(), testID)
 }
 
+func TestPlainJSON(t *testing.T) {
+	r := reader(t, "testdata/doc.json")
+	docBytes, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	doc, err := document.FromBytes(docBytes)
+	require.NoError(t, err)
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+	info[document.ContentTypeProperty] = document.ContentTypeDIDJSON
+
+	transformer := New(WithBase(true), WithMethodContext([]string{"https://w3id.org/method/v1"}))
+
+	result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+	require.NoError(t, err)
+	require.NotContains(t, result.Document, document.ContextProperty)
+
+	jsonTransformed, err := json.Marshal(result.Document)
+	require.NoError(t, err)
+
+	didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+	require.NoError(t, err)
+
+	// object IDs are absolute since there is no @base
+	require.Contains(t, didDoc.Services()[0].ID(), testID)
+	require.Contains(t, didDoc.VerificationMethods()[0].ID(), testID)
+
+	info[document.ContentTypeProperty] = document.ContentTypeDIDLDJSON
+
+	result, err = transformer.TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Document[document.ContextProperty])
+}
+
 func TestEd25519VerificationKey2018(t *testing.T) {
 	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
 	require.NoError(t, err)
@@ -300,6 +587,204 @@ func TestEd25519VerificationKey2018(t *testing.T) {
 	require.Equal(t, 0, len(didDoc.AgreementKeys()))
 }
 
+func TestEd25519VerificationKey2020(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+	require.NoError(t, err)
+
+	publicKeyBytes, err := json.Marshal(jwk)
+	require.NoError(t, err)
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	expectedMultibase := "z" + base58.Encode(append([]byte{0xed, 0x01}, publicKey...))
+
+	t.Run("success - 2018 key is emitted as 2020 key (option enabled)", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519DocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithEd25519VerificationKey2020(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{didContext, ed25519VerificationKey2020Context}, didDoc.Context())
+
+		pk := didDoc.VerificationMethods()[0]
+		require.Equal(t, "Ed25519VerificationKey2020", pk.Type())
+		require.Equal(t, expectedMultibase, pk.PublicKeyMultibase())
+		require.Empty(t, pk.PublicKeyBase58())
+		require.Empty(t, pk.PublicKeyJwk())
+		require.Len(t, didDoc.AssertionMethods(), 1)
+	})
+
+	t.Run("success - 2020 key", func(t *testing.T) {
+		data := strings.Replace(fmt.Sprintf(ed25519DocTemplate, string(publicKeyBytes)),
+			"Ed25519VerificationKey2018", "Ed25519VerificationKey2020", 1)
+
+		doc, err := document.FromBytes([]byte(data))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+
+		pk := didDoc.VerificationMethods()[0]
+		require.Equal(t, "Ed25519VerificationKey2020", pk.Type())
+		require.Equal(t, expectedMultibase, pk.PublicKeyMultibase())
+	})
+
+	t.Run("error - invalid key", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(ed25519Invalid))
+		require.NoError(t, err)
+
+		result, err := New(WithEd25519VerificationKey2020(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "unknown curve")
+	})
+}
+
+func TestPublicKeyJwkOnly(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+	require.NoError(t, err)
+
+	publicKeyBytes, err := json.Marshal(jwk)
+	require.NoError(t, err)
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519DocTemplate, string(publicKeyBytes))))
+	require.NoError(t, err)
+
+	// option takes precedence over emitting ed25519 keys as Ed25519VerificationKey2020
+	transformer := New(WithPublicKeyJwkOnly(true), WithEd25519VerificationKey2020(true))
+
+	result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+	require.NoError(t, err)
+
+	jsonTransformed, err := json.Marshal(result.Document)
+	require.NoError(t, err)
+
+	didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{didContext, jsonWebKey2020Context}, didDoc.Context())
+
+	pk := didDoc.VerificationMethods()[0]
+	require.Equal(t, "JsonWebKey2020", pk.Type())
+	require.Equal(t, "OKP", pk.PublicKeyJwk().Kty())
+	require.Equal(t, "Ed25519", pk.PublicKeyJwk().Crv())
+	require.Equal(t, jwk.X, pk.PublicKeyJwk().X())
+	require.Empty(t, pk.PublicKeyBase58())
+	require.Empty(t, pk.PublicKeyMultibase())
+}
+
+func TestBls12381G2Key2020(t *testing.T) {
+	blsPubKey := make([]byte, document.BLS12381G2PublicKeySize)
+	_, err := rand.Read(blsPubKey)
+	require.NoError(t, err)
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	t.Run("success", func(t *testing.T) {
+		jwk := fmt.Sprintf(`{"kty":"EC","crv":"BLS12381_G2","x":"%s"}`, encoder.EncodeToString(blsPubKey))
+
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(blsDocTemplate, jwk)))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		jsonTransformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+
+		didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{didContext, bls12381G2Key2020Context}, didDoc.Context())
+
+		pk := didDoc.VerificationMethods()[0]
+		require.Equal(t, "Bls12381G2Key2020", pk.Type())
+		require.Equal(t, base58.Encode(blsPubKey), pk.PublicKeyBase58())
+		require.Empty(t, pk.PublicKeyJwk())
+		require.Len(t, didDoc.AssertionMethods(), 1)
+	})
+
+	t.Run("error - unexpected curve", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(blsDocTemplate, `{"kty":"EC","crv":"P-256","x":"eA"}`)))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "unexpected JWK for Bls12381G2Key2020 key: kty[EC], crv[P-256]")
+	})
+
+	t.Run("error - invalid x", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(blsDocTemplate, `{"kty":"EC","crv":"BLS12381_G2","x":"!!!"}`)))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "failed to decode Bls12381G2Key2020 key")
+	})
+}
+
+func TestDeterministicVerificationMethods(t *testing.T) {
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	doc, err := document.FromBytes([]byte(duplicateKeysDoc))
+	require.NoError(t, err)
+
+	transformer := New()
+
+	result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+	require.NoError(t, err)
+
+	didDoc := transformedDIDDocument(t, result)
+
+	require.Len(t, didDoc.VerificationMethods(), 2)
+	require.Equal(t, testID+"#key1", didDoc.VerificationMethods()[0].ID())
+	require.Equal(t, "EcdsaSecp256k1VerificationKey2019", didDoc.VerificationMethods()[0].Type())
+	require.Equal(t, testID+"#key2", didDoc.VerificationMethods()[1].ID())
+
+	require.Equal(t, []interface{}{testID + "#key1", testID + "#key2"}, didDoc.Authentications())
+	require.Equal(t, []interface{}{testID + "#key2"}, didDoc.AssertionMethods())
+
+	expected, err := json.Marshal(result.Document)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		result, err := transformer.TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		transformed, err := json.Marshal(result.Document)
+		require.NoError(t, err)
+		require.Equal(t, expected, transformed)
+	}
+}
+
 func TestEd25519VerificationKey2018_Error(t *testing.T) {
 	doc, err := document.FromBytes([]byte(ed25519Invalid))
 	require.NoError(t, err)
@@ -343,6 +828,60 @@ const ed25519DocTemplate = `{
   ]
 }`
 
+const duplicateKeysDoc = `{
+  "publicKey": [
+	{
+		"id": "key1",
+		"type": "EcdsaSecp256k1VerificationKey2019",
+		"purposes": ["authentication", "authentication"],
+		"publicKeyJwk": {"kty": "EC", "crv": "secp256k1", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	},
+	{
+		"id": "key2",
+		"type": "JsonWebKey2020",
+		"purposes": ["authentication", "assertionMethod"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	},
+	{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"purposes": ["assertionMethod"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA", "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}
+	}
+  ]
+}`
+
+const blsDocTemplate = `{
+  "publicKey": [
+	{
+		"id": "bbs",
+		"type": "Bls12381G2Key2020",
+		"purposes": ["assertionMethod"],
+		"publicKeyJwk": %s
+	}
+  ]
+}`
+
+const didCommDoc = `{
+  "service": [
+	{
+		"id": "didcomm",
+		"type": "DIDCommMessaging",
+		"serviceEndpoint": {
+			"uri": "https://example.com/didcomm",
+			"accept": ["didcomm/v2"],
+			"routingKeys": ["#key1", "did:example:mediator#key1"]
+		}
+	},
+	{
+		"id": "didcomm-legacy",
+		"type": "DIDCommMessaging",
+		"serviceEndpoint": "https://example.com/legacy",
+		"routingKeys": ["#key1"]
+	}
+  ]
+}`
+
 const ed25519Invalid = `{
   "publicKey": [
 	{
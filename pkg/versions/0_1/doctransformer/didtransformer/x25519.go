@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const (
+	// x25519KeyAgreementKey2019 is type of key agreement key derived from ed25519 key.
+	x25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+
+	// x25519KeyIDSuffix is appended to ed25519 key ID to create ID of derived key agreement key.
+	x25519KeyIDSuffix = "-x25519"
+
+	okpKty     = "OKP"
+	ed25519Crv = "Ed25519"
+	x25519Crv  = "X25519"
+
+	curve25519KeySize = 32
+)
+
+// curve25519P is field prime of curve25519 (2^255 - 19).
+// nolint:gochecknoglobals
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// WithX25519KeyAgreement enables deriving X25519KeyAgreementKey2019 verification methods from ed25519 keys
+// with key agreement purpose; derived key (instead of ed25519 key) is referenced in keyAgreement.
+func WithX25519KeyAgreement(enabled bool) Option {
+	return func(opts *Transformer) {
+		opts.x25519KeyAgreement = enabled
+	}
+}
+
+// isEd25519Key returns true if public key is ed25519 key.
+func isEd25519Key(pk document.PublicKey) bool {
+	if pk.Type() == ed25519VerificationKey2018 || pk.Type() == ed25519VerificationKey2020 {
+		return true
+	}
+
+	jwk := pk.PublicKeyJwk()
+
+	return jwk != nil && jwk.Kty() == okpKty && jwk.Crv() == ed25519Crv
+}
+
+// getX25519KeyAgreementKey derives X25519KeyAgreementKey2019 verification method from ed25519 public key.
+func (t *Transformer) getX25519KeyAgreementKey(did string, pk document.PublicKey) (document.PublicKey, error) {
+	ed25519PubKey, err := getED2519PublicKey(pk.PublicKeyJwk())
+	if err != nil {
+		return nil, err
+	}
+
+	x25519PubKey, err := ed25519PublicKeyToX25519(ed25519PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive x25519 key agreement key from key[%s]: %s", pk.ID(), err.Error())
+	}
+
+	externalPK := make(document.PublicKey)
+	externalPK[document.IDProperty] = t.getObjectID(did, pk.ID()+x25519KeyIDSuffix)
+	externalPK[document.TypeProperty] = x25519KeyAgreementKey2019
+	externalPK[document.ControllerProperty] = getKeyController(did, pk)
+
+	if t.publicKeyJwkOnly {
+		externalPK[document.TypeProperty] = jsonWebKey2020
+		externalPK[document.PublicKeyJwkProperty] = document.JWK{
+			"kty": okpKty,
+			"crv": x25519Crv,
+			"x":   base64.RawURLEncoding.EncodeToString(x25519PubKey),
+		}
+	} else {
+		externalPK[document.PublicKeyBase58Property] = base58.Encode(x25519PubKey)
+	}
+
+	return externalPK, nil
+}
+
+// ed25519PublicKeyToX25519 converts ed25519 public key (edwards y coordinate) to x25519 public key
+// (montgomery u coordinate) using birational map u = (1 + y) / (1 - y).
+func ed25519PublicKeyToX25519(pubKey []byte) ([]byte, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key size: %d", len(pubKey))
+	}
+
+	// y coordinate is encoded in little-endian with the sign of x in the most significant bit
+	y := new(big.Int).SetBytes(reverse(pubKey))
+	y.SetBit(y, 255, 0)
+
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, errors.New("invalid ed25519 public key: y coordinate out of range")
+	}
+
+	one := big.NewInt(1)
+
+	denominator := new(big.Int).Sub(one, y)
+	denominator.Mod(denominator, curve25519P)
+
+	if denominator.Sign() == 0 {
+		return nil, errors.New("invalid ed25519 public key: point at infinity")
+	}
+
+	u := new(big.Int).Add(one, y)
+	u.Mul(u, new(big.Int).ModInverse(denominator, curve25519P))
+	u.Mod(u, curve25519P)
+
+	out := make([]byte, curve25519KeySize)
+	b := u.Bytes()
+	copy(out[curve25519KeySize-len(b):], b)
+
+	return reverse(out), nil
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+
+	return r
+}
@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didtransformer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+func TestEd25519PublicKeyToX25519(t *testing.T) {
+	t.Run("success - matches x25519 public key derived from ed25519 private key", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+
+			x25519PubKey, err := ed25519PublicKeyToX25519(publicKey)
+			require.NoError(t, err)
+			require.Len(t, x25519PubKey, curve25519KeySize)
+
+			// x25519 private key is (clamped) first half of hashed ed25519 seed
+			h := sha512.Sum512(privateKey.Seed())
+
+			expected, err := curve25519.X25519(h[:32], curve25519.Basepoint)
+			require.NoError(t, err)
+			require.Equal(t, expected, x25519PubKey)
+		}
+	})
+
+	t.Run("error - invalid key size", func(t *testing.T) {
+		x25519PubKey, err := ed25519PublicKeyToX25519([]byte("key"))
+		require.Error(t, err)
+		require.Nil(t, x25519PubKey)
+		require.Contains(t, err.Error(), "invalid ed25519 public key size: 3")
+	})
+
+	t.Run("error - y coordinate out of range", func(t *testing.T) {
+		pubKey := make([]byte, ed25519.PublicKeySize)
+		for i := range pubKey {
+			pubKey[i] = 0xff
+		}
+
+		x25519PubKey, err := ed25519PublicKeyToX25519(pubKey)
+		require.Error(t, err)
+		require.Nil(t, x25519PubKey)
+		require.Contains(t, err.Error(), "y coordinate out of range")
+	})
+
+	t.Run("error - point at infinity", func(t *testing.T) {
+		pubKey := make([]byte, ed25519.PublicKeySize)
+		pubKey[0] = 1
+
+		x25519PubKey, err := ed25519PublicKeyToX25519(pubKey)
+		require.Error(t, err)
+		require.Nil(t, x25519PubKey)
+		require.Contains(t, err.Error(), "point at infinity")
+	})
+}
+
+func TestX25519KeyAgreement(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+	require.NoError(t, err)
+
+	publicKeyBytes, err := json.Marshal(jwk)
+	require.NoError(t, err)
+
+	expectedX25519PubKey, err := ed25519PublicKeyToX25519(publicKey)
+	require.NoError(t, err)
+
+	info := make(protocol.TransformationInfo)
+	info[document.IDProperty] = testID
+	info[document.PublishedProperty] = true
+
+	t.Run("success - key agreement key derived (option enabled)", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 2)
+
+		pk := didDoc.VerificationMethods()[0]
+		require.Equal(t, testID+"#key1", pk.ID())
+		require.Equal(t, "JsonWebKey2020", pk.Type())
+
+		x25519PK := didDoc.VerificationMethods()[1]
+		require.Equal(t, testID+"#key1-x25519", x25519PK.ID())
+		require.Equal(t, "X25519KeyAgreementKey2019", x25519PK.Type())
+		require.Equal(t, testID, x25519PK.Controller())
+		require.Equal(t, base58.Encode(expectedX25519PubKey), x25519PK.PublicKeyBase58())
+		require.Empty(t, x25519PK.PublicKeyJwk())
+
+		require.Equal(t, []interface{}{testID + "#key1-x25519"}, didDoc.AgreementKeys())
+
+		require.Equal(t, []interface{}{testID + "#key1"}, didDoc.Authentications())
+	})
+
+	t.Run("success - key agreement key emitted as JWK", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true), WithPublicKeyJwkOnly(true)).TransformDocument(
+			&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 2)
+
+		x25519PK := didDoc.VerificationMethods()[1]
+		require.Equal(t, testID+"#key1-x25519", x25519PK.ID())
+		require.Equal(t, "JsonWebKey2020", x25519PK.Type())
+		require.Equal(t, "OKP", x25519PK.PublicKeyJwk().Kty())
+		require.Equal(t, "X25519", x25519PK.PublicKeyJwk().Crv())
+		require.Equal(t, base64.RawURLEncoding.EncodeToString(expectedX25519PubKey), x25519PK.PublicKeyJwk().X())
+		require.Empty(t, x25519PK.PublicKeyBase58())
+	})
+
+	t.Run("success - with base", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true), WithBase(true)).TransformDocument(
+			&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 2)
+		require.Equal(t, "#key1-x25519", didDoc.VerificationMethods()[1].ID())
+	})
+
+	t.Run("success - key agreement key not derived (option disabled)", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New().TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 1)
+		require.Equal(t, []interface{}{testID + "#key1"}, didDoc.AgreementKeys())
+	})
+
+	t.Run("success - key agreement key not derived for key without key agreement purpose", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519DocTemplate, string(publicKeyBytes))))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.NoError(t, err)
+
+		didDoc := transformedDIDDocument(t, result)
+
+		require.Len(t, didDoc.VerificationMethods(), 1)
+		require.Empty(t, didDoc.AgreementKeys())
+	})
+
+	t.Run("error - invalid ed25519 key", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(fmt.Sprintf(ed25519KeyAgreementDocTemplate,
+			`{"kty":"OKP","crv":"Ed25519","x":"__________________________________________8"}`)))
+		require.NoError(t, err)
+
+		result, err := New(WithX25519KeyAgreement(true)).TransformDocument(&protocol.ResolutionModel{Doc: doc}, info)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "failed to derive x25519 key agreement key from key[key1]")
+	})
+}
+
+func transformedDIDDocument(t *testing.T, result *document.ResolutionResult) document.DIDDocument {
+	t.Helper()
+
+	jsonTransformed, err := json.Marshal(result.Document)
+	require.NoError(t, err)
+
+	didDoc, err := document.DidDocumentFromBytes(jsonTransformed)
+	require.NoError(t, err)
+
+	return didDoc
+}
+
+const ed25519KeyAgreementDocTemplate = `{
+  "publicKey": [
+	{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"purposes": ["authentication", "keyAgreement"],
+		"publicKeyJwk": %s
+	}
+  ]
+}`
@@ -43,15 +43,25 @@ func (v *Transformer) TransformDocument(rm *protocol.ResolutionModel, info proto
 		return nil, errors.New("published is required for document transformation")
 	}
 
-	rm.Doc[document.IDProperty] = id
+	// resolution model may be cached so returned document must not share state with it
+	doc := rm.Doc.Copy()
+	doc[document.IDProperty] = id
 
 	methodMetadata := make(document.Metadata)
 	methodMetadata[document.PublishedProperty] = published
 	methodMetadata[document.RecoveryCommitmentProperty] = rm.RecoveryCommitment
 	methodMetadata[document.UpdateCommitmentProperty] = rm.UpdateCommitment
 
+	if rm.PatchMode != "" {
+		methodMetadata[document.PatchModeProperty] = string(rm.PatchMode)
+	}
+
+	if len(rm.SkippedPatches) > 0 {
+		methodMetadata[document.SkippedPatchesProperty] = rm.SkippedPatches
+	}
+
 	result := &document.ResolutionResult{
-		Document:       rm.Doc,
+		Document:       doc,
 		MethodMetadata: methodMetadata,
 	}
 
@@ -62,6 +72,17 @@ func (v *Transformer) TransformDocument(rm *protocol.ResolutionModel, info proto
 		docMetadata[document.CanonicalIDProperty] = canonicalID
 	}
 
+	// zero transaction time means that operation time is not available (e.g. unpublished document)
+	if rm.CreatedTransactionTime > 0 {
+		docMetadata[document.CreatedTransactionTimeProperty] = rm.CreatedTransactionTime
+		docMetadata[document.CreatedTransactionNumberProperty] = rm.CreatedTransactionNumber
+	}
+
+	if rm.UpdatedTransactionTime > 0 {
+		docMetadata[document.UpdatedTransactionTimeProperty] = rm.UpdatedTransactionTime
+		docMetadata[document.UpdatedTransactionNumberProperty] = rm.UpdatedTransactionNumber
+	}
+
 	if len(docMetadata) > 0 {
 		result.DocumentMetadata = docMetadata
 	}
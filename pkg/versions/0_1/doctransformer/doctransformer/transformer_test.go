@@ -41,6 +41,19 @@ func TestTransformDocument(t *testing.T) {
 		require.Empty(t, result.DocumentMetadata)
 	})
 
+	t.Run("success - resolution model is not modified", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = "did:abc:123"
+		info[document.PublishedProperty] = true
+
+		result, err := transformer.TransformDocument(internal, info)
+		require.NoError(t, err)
+		require.NotContains(t, internal.Doc, document.IDProperty)
+
+		result.Document["other"] = "value"
+		require.NotContains(t, internal.Doc, "other")
+	})
+
 	t.Run("success - with canonical ID", func(t *testing.T) {
 		info := make(protocol.TransformationInfo)
 		info[document.IDProperty] = "did:abc:123"
@@ -56,6 +69,48 @@ func TestTransformDocument(t *testing.T) {
 		require.Equal(t, "canonical", result.DocumentMetadata[document.CanonicalIDProperty])
 	})
 
+	t.Run("success - with created and updated", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = "did:abc:123"
+		info[document.PublishedProperty] = true
+
+		created := &protocol.ResolutionModel{Doc: doc, CreatedTransactionTime: 10, CreatedTransactionNumber: 1}
+
+		result, err := transformer.TransformDocument(created, info)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.CreatedTransactionTimeProperty])
+		require.Equal(t, uint64(1), result.DocumentMetadata[document.CreatedTransactionNumberProperty])
+		require.NotContains(t, result.DocumentMetadata, document.UpdatedTransactionTimeProperty)
+		require.NotContains(t, result.DocumentMetadata, document.UpdatedTransactionNumberProperty)
+
+		updated := &protocol.ResolutionModel{Doc: doc, CreatedTransactionTime: 10, CreatedTransactionNumber: 1,
+			UpdatedTransactionTime: 20, UpdatedTransactionNumber: 2}
+
+		result, err = transformer.TransformDocument(updated, info)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), result.DocumentMetadata[document.CreatedTransactionTimeProperty])
+		require.Equal(t, uint64(1), result.DocumentMetadata[document.CreatedTransactionNumberProperty])
+		require.Equal(t, uint64(20), result.DocumentMetadata[document.UpdatedTransactionTimeProperty])
+		require.Equal(t, uint64(2), result.DocumentMetadata[document.UpdatedTransactionNumberProperty])
+	})
+
+	t.Run("success - with patch mode and skipped patches", func(t *testing.T) {
+		info := make(protocol.TransformationInfo)
+		info[document.IDProperty] = "did:abc:123"
+		info[document.PublishedProperty] = true
+
+		lenient := &protocol.ResolutionModel{
+			Doc:            doc,
+			PatchMode:      protocol.PatchModeLenient,
+			SkippedPatches: []string{"patch[0] 'ietf-json-patch' skipped"},
+		}
+
+		result, err := transformer.TransformDocument(lenient, info)
+		require.NoError(t, err)
+		require.Equal(t, "lenient", result.MethodMetadata[document.PatchModeProperty])
+		require.Equal(t, lenient.SkippedPatches, result.MethodMetadata[document.SkippedPatchesProperty])
+	})
+
 	t.Run("error - internal document is missing", func(t *testing.T) {
 		info := make(protocol.TransformationInfo)
 		info[document.IDProperty] = "doc:abc:xyz"
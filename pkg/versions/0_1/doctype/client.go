@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package doctype
+
+import (
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// Client is protocol client that returns protocol versions for custom document type.
+// Versions are created once per base version (and re-created only if base client returns new version).
+type Client struct {
+	protocol.Client
+
+	opts []Option
+
+	mutex    sync.RWMutex
+	versions map[protocol.Version]*Version
+}
+
+// NewClient creates protocol client for custom document type from the base client.
+func NewClient(base protocol.Client, opts ...Option) *Client {
+	return &Client{
+		Client:   base,
+		opts:     opts,
+		versions: make(map[protocol.Version]*Version),
+	}
+}
+
+// Current returns latest version of protocol for document type.
+func (c *Client) Current() (protocol.Version, error) {
+	v, err := c.Client.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getVersion(v), nil
+}
+
+// Get returns the version of protocol for document type at the given transaction time.
+func (c *Client) Get(transactionTime uint64) (protocol.Version, error) {
+	v, err := c.Client.Get(transactionTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getVersion(v), nil
+}
+
+// getVersion returns cached document type version for the base version.
+func (c *Client) getVersion(base protocol.Version) *Version {
+	c.mutex.RLock()
+	v, ok := c.versions[base]
+	c.mutex.RUnlock()
+
+	if ok {
+		return v
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	v, ok = c.versions[base]
+	if !ok {
+		v = NewVersion(base, c.opts...)
+		c.versions[base] = v
+	}
+
+	return v
+}
+
+// ClientProvider returns protocol clients with document type registered for the namespace.
+// Namespaces without registered document type use base protocol clients.
+type ClientProvider struct {
+	protocol.ClientProvider
+
+	mutex      sync.RWMutex
+	namespaces map[string][]Option
+}
+
+// NewClientProvider creates new document type client provider.
+func NewClientProvider(base protocol.ClientProvider) *ClientProvider {
+	return &ClientProvider{
+		ClientProvider: base,
+		namespaces:     make(map[string][]Option),
+	}
+}
+
+// Register registers document type (composer, validator, transformer) for the namespace.
+func (p *ClientProvider) Register(namespace string, opts ...Option) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.namespaces[namespace] = opts
+}
+
+// ForNamespace returns protocol client for the namespace.
+func (p *ClientProvider) ForNamespace(namespace string) (protocol.Client, error) {
+	pc, err := p.ClientProvider.ForNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.RLock()
+	opts, ok := p.namespaces[namespace]
+	p.mutex.RUnlock()
+
+	if !ok {
+		return pc, nil
+	}
+
+	return NewClient(pc, opts...), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package doctype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doctransformer/doctransformer"
+)
+
+const customNS = "doc:custom"
+
+func TestClient(t *testing.T) {
+	dt := doctransformer.New()
+
+	t.Run("success", func(t *testing.T) {
+		pc := NewClient(mocks.NewMockProtocolClient(), WithDocumentTransformer(dt))
+
+		v, err := pc.Current()
+		require.NoError(t, err)
+		require.Equal(t, dt, v.DocumentTransformer())
+
+		v, err = pc.Get(0)
+		require.NoError(t, err)
+		require.Equal(t, dt, v.DocumentTransformer())
+	})
+
+	t.Run("success - versions are cached per base version", func(t *testing.T) {
+		base := mocks.NewMockProtocolClient()
+
+		pc := NewClient(base, WithDocumentComposer(doccomposer.New()))
+
+		v1, err := pc.Current()
+		require.NoError(t, err)
+
+		v2, err := pc.Get(0)
+		require.NoError(t, err)
+		require.True(t, v1 == v2)
+
+		// base client returns new version (e.g. protocol parameters have been updated)
+		base.CurrentVersion = mocks.GetProtocolVersion(mocks.GetDefaultProtocolParameters())
+
+		v3, err := pc.Current()
+		require.NoError(t, err)
+		require.False(t, v1 == v3)
+	})
+
+	t.Run("error - base client error", func(t *testing.T) {
+		base := mocks.NewMockProtocolClient()
+		base.Err = errors.New("injected error")
+
+		pc := NewClient(base, WithDocumentTransformer(dt))
+
+		v, err := pc.Current()
+		require.EqualError(t, err, "injected error")
+		require.Nil(t, v)
+
+		v, err = pc.Get(0)
+		require.EqualError(t, err, "injected error")
+		require.Nil(t, v)
+	})
+}
+
+func TestClientProvider(t *testing.T) {
+	dt := doctransformer.New()
+
+	base := mocks.NewMockProtocolClientProvider().WithProtocolClient(customNS, mocks.NewMockProtocolClient())
+
+	provider := NewClientProvider(base)
+	provider.Register(customNS, WithDocumentTransformer(dt))
+
+	t.Run("success - namespace with document type", func(t *testing.T) {
+		pc, err := provider.ForNamespace(customNS)
+		require.NoError(t, err)
+
+		v, err := pc.Current()
+		require.NoError(t, err)
+		require.Equal(t, dt, v.DocumentTransformer())
+	})
+
+	t.Run("success - namespace without document type", func(t *testing.T) {
+		pc, err := provider.ForNamespace(mocks.DefaultNS)
+		require.NoError(t, err)
+
+		v, err := pc.Current()
+		require.NoError(t, err)
+		require.NotEqual(t, dt, v.DocumentTransformer())
+	})
+
+	t.Run("error - namespace not found", func(t *testing.T) {
+		pc, err := provider.ForNamespace("doc:other")
+		require.Error(t, err)
+		require.Nil(t, pc)
+		require.Contains(t, err.Error(), "protocol client not found for namespace [doc:other]")
+	})
+}
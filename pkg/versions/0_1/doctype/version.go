@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package doctype
+
+import (
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+// Option overrides document type specific component of protocol version.
+type Option func(opts *Version)
+
+// WithDocumentComposer sets document composer for the document type;
+// operation applier is re-created with this composer.
+func WithDocumentComposer(dc protocol.DocumentComposer) Option {
+	return func(opts *Version) {
+		opts.composer = dc
+	}
+}
+
+// WithDocumentValidator sets document validator for the document type.
+func WithDocumentValidator(dv protocol.DocumentValidator) Option {
+	return func(opts *Version) {
+		opts.validator = dv
+	}
+}
+
+// WithDocumentTransformer sets document transformer for the document type.
+func WithDocumentTransformer(dt protocol.DocumentTransformer) Option {
+	return func(opts *Version) {
+		opts.transformer = dt
+	}
+}
+
+// WithApplierOptions sets options for operation applier that is re-created with custom document composer.
+func WithApplierOptions(applierOpts ...operationapplier.Option) Option {
+	return func(opts *Version) {
+		opts.applierOpts = applierOpts
+	}
+}
+
+// WithResultValidation enables validation of documents produced by applying patches using document
+// type validator; operation applier is re-created and rejects operations that produce invalid document.
+func WithResultValidation() Option {
+	return func(opts *Version) {
+		opts.validateResult = true
+	}
+}
+
+// Version is protocol version with document type specific components (document composer, validator
+// and transformer). Remaining components (parsing, batching, anchoring) are provided by the base version.
+type Version struct {
+	base protocol.Version
+
+	composer    protocol.DocumentComposer
+	validator   protocol.DocumentValidator
+	transformer protocol.DocumentTransformer
+	applier     protocol.OperationApplier
+	applierOpts []operationapplier.Option
+
+	validateResult bool
+}
+
+// NewVersion creates protocol version for custom document type from the base version.
+func NewVersion(base protocol.Version, opts ...Option) *Version {
+	v := &Version{
+		base:        base,
+		validator:   base.DocumentValidator(),
+		transformer: base.DocumentTransformer(),
+		applier:     base.OperationApplier(),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	customApplier := v.composer != nil || len(v.applierOpts) > 0 || v.validateResult
+
+	if v.composer == nil {
+		v.composer = base.DocumentComposer()
+	}
+
+	if !customApplier {
+		return v
+	}
+
+	p := base.Protocol()
+
+	applierOpts := v.applierOpts
+	if v.validateResult {
+		applierOpts = append(append([]operationapplier.Option{}, applierOpts...),
+			operationapplier.WithDocumentValidator(v.validator))
+	}
+
+	// operation applier has to apply patches using document type composer
+	v.applier = operationapplier.New(p, getParser(base), v.composer, applierOpts...)
+
+	return v
+}
+
+// getParser returns operation parser of the base version so that operations are validated the same way
+// during resolution as when they are submitted (parser options configured for the base version apply).
+// Default parser is returned only if the base version doesn't provide protocol version 0.1 parser.
+func getParser(base protocol.Version) operationapplier.OperationParser {
+	if parser, ok := base.OperationParser().(operationapplier.OperationParser); ok {
+		return parser
+	}
+
+	return operationparser.New(base.Protocol())
+}
+
+// Version returns protocol version.
+func (v *Version) Version() string {
+	return v.base.Version()
+}
+
+// Protocol returns protocol parameters.
+func (v *Version) Protocol() protocol.Protocol {
+	return v.base.Protocol()
+}
+
+// Capabilities returns capabilities of the base version.
+func (v *Version) Capabilities() protocol.Capabilities {
+	return v.base.Capabilities()
+}
+
+// TransactionProcessor returns transaction processor of the base version.
+func (v *Version) TransactionProcessor() protocol.TxnProcessor {
+	return v.base.TransactionProcessor()
+}
+
+// OperationParser returns operation parser of the base version.
+func (v *Version) OperationParser() protocol.OperationParser {
+	return v.base.OperationParser()
+}
+
+// OperationHandler returns operation handler (batching) of the base version.
+func (v *Version) OperationHandler() protocol.OperationHandler {
+	return v.base.OperationHandler()
+}
+
+// OperationProvider returns operation provider of the base version.
+func (v *Version) OperationProvider() protocol.OperationProvider {
+	return v.base.OperationProvider()
+}
+
+// OperationApplier returns operation applier that applies patches using document type composer.
+func (v *Version) OperationApplier() protocol.OperationApplier {
+	return v.applier
+}
+
+// DocumentComposer returns document type composer.
+func (v *Version) DocumentComposer() protocol.DocumentComposer {
+	return v.composer
+}
+
+// DocumentValidator returns document type validator.
+func (v *Version) DocumentValidator() protocol.DocumentValidator {
+	return v.validator
+}
+
+// DocumentTransformer returns document type transformer.
+func (v *Version) DocumentTransformer() protocol.DocumentTransformer {
+	return v.transformer
+}
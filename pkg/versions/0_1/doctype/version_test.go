@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package doctype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/compression"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doctransformer/doctransformer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/docvalidator"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/factory"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+func TestNewVersion(t *testing.T) {
+	base := mocks.GetProtocolVersion(mocks.GetDefaultProtocolParameters())
+
+	t.Run("success - base components", func(t *testing.T) {
+		v := NewVersion(base)
+		require.Equal(t, base.DocumentComposer(), v.DocumentComposer())
+		require.Equal(t, base.DocumentValidator(), v.DocumentValidator())
+		require.Equal(t, base.DocumentTransformer(), v.DocumentTransformer())
+		require.Equal(t, base.OperationApplier(), v.OperationApplier())
+		require.Equal(t, base.OperationParser(), v.OperationParser())
+		require.Equal(t, base.OperationHandler(), v.OperationHandler())
+		require.Equal(t, base.Protocol(), v.Protocol())
+		require.Equal(t, base.Capabilities(), v.Capabilities())
+		require.Equal(t, base.Version(), v.Version())
+	})
+
+	t.Run("success - document type components", func(t *testing.T) {
+		dc := doccomposer.New()
+		dv := docvalidator.New(mocks.NewMockOperationStore(nil))
+		dt := doctransformer.New()
+
+		v := NewVersion(base,
+			WithDocumentComposer(dc),
+			WithDocumentValidator(dv),
+			WithDocumentTransformer(dt))
+
+		require.Equal(t, dc, v.DocumentComposer())
+		require.Equal(t, dv, v.DocumentValidator())
+		require.Equal(t, dt, v.DocumentTransformer())
+
+		applier, ok := v.OperationApplier().(*operationapplier.Applier)
+		require.True(t, ok)
+		require.Equal(t, dc, applier.DocumentComposer)
+
+		// parsing, batching and anchoring are provided by base version
+		require.Equal(t, base.OperationParser(), v.OperationParser())
+		require.Equal(t, base.OperationHandler(), v.OperationHandler())
+		require.Equal(t, base.OperationProvider(), v.OperationProvider())
+		require.Equal(t, base.TransactionProcessor(), v.TransactionProcessor())
+	})
+
+	t.Run("success - applier options", func(t *testing.T) {
+		v := NewVersion(base, WithApplierOptions(operationapplier.WithPatchMode(protocol.PatchModeLenient)))
+
+		applier, ok := v.OperationApplier().(*operationapplier.Applier)
+		require.True(t, ok)
+		require.Equal(t, base.DocumentComposer(), applier.DocumentComposer)
+	})
+
+	t.Run("success - applier uses configured parser of the base version", func(t *testing.T) {
+		deps := &versions.Dependencies{
+			CasClient:           mocks.NewMockCasClient(nil),
+			OperationStore:      &mockOperationStore{},
+			CompressionProvider: compression.New(compression.WithDefaultAlgorithms()),
+		}
+
+		configured, err := factory.New(factory.WithParserOptions(
+			operationparser.WithKeyIDValidator(operationparser.KeyIDThumbprintValidator))).
+			Create(factory.Version, mocks.GetDefaultProtocolParameters(), deps)
+		require.NoError(t, err)
+
+		v := NewVersion(configured, WithDocumentComposer(doccomposer.New()))
+
+		applier, ok := v.OperationApplier().(*operationapplier.Applier)
+		require.True(t, ok)
+		require.False(t, configured.OperationApplier() == applier)
+		require.True(t, configured.OperationParser() == applier.OperationParser.(protocol.OperationParser))
+	})
+
+	t.Run("success - result validation", func(t *testing.T) {
+		dv := docvalidator.New(mocks.NewMockOperationStore(nil))
+
+		v := NewVersion(base, WithDocumentValidator(dv), WithResultValidation())
+		require.Equal(t, dv, v.DocumentValidator())
+
+		applier, ok := v.OperationApplier().(*operationapplier.Applier)
+		require.True(t, ok)
+		require.NotEqual(t, base.OperationApplier(), applier)
+		require.Equal(t, base.DocumentComposer(), applier.DocumentComposer)
+	})
+}
+
+type mockOperationStore struct{}
+
+func (m *mockOperationStore) Get(string) ([]*operation.AnchoredOperation, error) {
+	return nil, nil
+}
+
+func (m *mockOperationStore) Put([]*operation.AnchoredOperation) error {
+	return nil
+}
@@ -10,7 +10,9 @@ import (
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/rules"
 )
 
 const (
@@ -19,7 +21,65 @@ const (
 
 // Validator is responsible for validating did operations and sidetree rules.
 type Validator struct {
-	store OperationStoreClient
+	store       OperationStoreClient
+	limits      document.Limits
+	keyPurposes []string
+	svcTypes    []string
+	endpoints   *document.EndpointPolicy
+	custom      []namedRule
+	disabled    []string
+	rules       *rules.Registry
+}
+
+type namedRule struct {
+	name    string
+	rule    rules.Rule
+	warning bool
+}
+
+// Option is a did validator instance option.
+type Option func(opts *Validator)
+
+// WithDocumentLimits sets document size and complexity limits (e.g. derived from protocol parameters)
+// enforced for original documents.
+func WithDocumentLimits(limits document.Limits) Option {
+	return func(opts *Validator) {
+		opts.limits = limits
+	}
+}
+
+// WithProtocol sets document limits (maximum number of public keys and services) and allowed public key
+// purposes from protocol parameters.
+func WithProtocol(p protocol.Protocol) Option {
+	return func(opts *Validator) {
+		opts.limits.MaxPublicKeys = p.MaxPublicKeys
+		opts.limits.MaxServices = p.MaxServices
+		opts.keyPurposes = append(opts.keyPurposes, p.KeyPurposes...)
+	}
+}
+
+// WithKeyPurposes sets allowed public key purposes (e.g. from protocol parameters); if not set
+// all standard purposes are allowed.
+func WithKeyPurposes(purposes ...string) Option {
+	return func(opts *Validator) {
+		opts.keyPurposes = append(opts.keyPurposes, purposes...)
+	}
+}
+
+// WithServiceTypes sets allowed service types; documents with services of other types are rejected.
+// If not set any service type is allowed.
+func WithServiceTypes(types ...string) Option {
+	return func(opts *Validator) {
+		opts.svcTypes = append(opts.svcTypes, types...)
+	}
+}
+
+// WithServiceEndpointPolicy enables validation of service endpoints in original documents: endpoints have
+// to be valid and their URIs have to satisfy the policy (allowed schemes, private addresses, maximum length).
+func WithServiceEndpointPolicy(policy document.EndpointPolicy) Option {
+	return func(opts *Validator) {
+		opts.endpoints = &policy
+	}
 }
 
 // OperationStoreClient defines interface for retrieving all operations related to document.
@@ -28,11 +88,66 @@ type OperationStoreClient interface {
 	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
 }
 
+// WithRule adds custom original document validation rule; rule with the same name as default rule
+// (see rules package) replaces default rule.
+func WithRule(name string, rule rules.Rule) Option {
+	return func(opts *Validator) {
+		opts.custom = append(opts.custom, namedRule{name: name, rule: rule})
+	}
+}
+
+// WithWarningRule adds custom original document validation rule whose failures are reported as warnings
+// (e.g. deprecated properties, limits that will be enforced in the future) instead of errors.
+func WithWarningRule(name string, rule rules.Rule) Option {
+	return func(opts *Validator) {
+		opts.custom = append(opts.custom, namedRule{name: name, rule: rule, warning: true})
+	}
+}
+
+// WithoutRules disables original document validation rules with specified names.
+func WithoutRules(names ...string) Option {
+	return func(opts *Validator) {
+		opts.disabled = append(opts.disabled, names...)
+	}
+}
+
 // New creates a new did validator.
-func New(store OperationStoreClient) *Validator {
-	return &Validator{
+func New(store OperationStoreClient, opts ...Option) *Validator {
+	v := &Validator{
 		store: store,
 	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.rules = rules.NewRegistry()
+
+	// Sidetree rules: the document must NOT have the id and context properties
+	v.rules.Set(rules.NoIDRule, rules.NoID())
+	v.rules.Set(rules.NoContextRule, rules.NoContext())
+	v.rules.Set(rules.LimitsRule, rules.Limits(v.limits))
+	v.rules.Set(rules.KeyPurposesRule, rules.KeyPurposes(v.keyPurposes))
+	v.rules.Set(rules.ServiceTypesRule, rules.ServiceTypes(v.svcTypes))
+
+	if v.endpoints != nil {
+		v.rules.Set(rules.ServiceEndpointsRule, rules.ServiceEndpoints(*v.endpoints))
+	}
+
+	for _, r := range v.custom {
+		if r.warning {
+			v.rules.SetWarning(r.name, r.rule)
+		} else {
+			v.rules.Set(r.name, r.rule)
+		}
+	}
+
+	for _, name := range v.disabled {
+		v.rules.Remove(name)
+	}
+
+	return v
 }
 
 // IsValidPayload verifies that the given payload is a valid Sidetree specific payload
@@ -63,21 +178,20 @@ func (v *Validator) IsValidPayload(payload []byte) error {
 
 // IsValidOriginalDocument verifies that the given payload is a valid Sidetree specific did document that can be accepted by the Sidetree create operation.
 func (v *Validator) IsValidOriginalDocument(payload []byte) error {
-	didDoc, err := document.DidDocumentFromBytes(payload)
+	doc, err := document.FromBytes(payload)
 	if err != nil {
 		return err
 	}
 
-	// Sidetree rule: The document must NOT have the id property
-	if didDoc.ID() != "" {
-		return errors.New("document must NOT have the id property")
-	}
+	return v.rules.Validate(doc)
+}
 
-	// Sidetree rule: must not have context
-	ctx := didDoc.Context()
-	if len(ctx) != 0 {
-		return errors.New("document must NOT have context")
+// ValidateOriginalDocument validates original document and returns validation errors and warnings.
+func (v *Validator) ValidateOriginalDocument(payload []byte) *protocol.ValidationResult {
+	doc, err := document.FromBytes(payload)
+	if err != nil {
+		return &protocol.ValidationResult{Errors: []error{err}}
 	}
 
-	return nil
+	return v.rules.Check(doc)
 }
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package didvalidator
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -16,7 +17,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/rules"
 )
 
 func TestNew(t *testing.T) {
@@ -53,6 +57,155 @@ func TestIsValidOriginalDocument_MustNotHaveIDError(t *testing.T) {
 	require.Contains(t, err.Error(), "document must NOT have the id property")
 }
 
+func TestIsValidOriginalDocument_Limits(t *testing.T) {
+	r := reader(t, "testdata/doc.json")
+	didDoc, err := ioutil.ReadAll(r)
+	require.Nil(t, err)
+
+	t.Run("success - within limits", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithDocumentLimits(document.Limits{
+			MaxSize:       uint(len(didDoc)),
+			MaxPublicKeys: 100,
+			MaxServices:   100,
+			MaxDepth:      100,
+		}))
+
+		require.NoError(t, v.IsValidOriginalDocument(didDoc))
+	})
+
+	t.Run("error - document too large", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithDocumentLimits(document.Limits{MaxSize: 100}))
+
+		err := v.IsValidOriginalDocument(didDoc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds maximum size 100")
+	})
+
+	t.Run("error - too many public keys", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithDocumentLimits(document.Limits{MaxPublicKeys: 1}))
+
+		err := v.IsValidOriginalDocument(didDoc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds maximum 1")
+	})
+
+	t.Run("protocol limits", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithProtocol(protocol.Protocol{MaxPublicKeys: 100, MaxServices: 100}))
+		require.NoError(t, v.IsValidOriginalDocument(didDoc))
+
+		v = New(mocks.NewMockOperationStore(nil), WithProtocol(protocol.Protocol{MaxPublicKeys: 1}))
+
+		err := v.IsValidOriginalDocument(didDoc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document public keys count")
+		require.Contains(t, err.Error(), "exceeds maximum 1")
+
+		v = New(mocks.NewMockOperationStore(nil), WithProtocol(protocol.Protocol{MaxServices: 1}))
+
+		err = v.IsValidOriginalDocument([]byte(`{"service": [{"id": "svc1"}, {"id": "svc2"}]}`))
+		require.EqualError(t, err, "document services count 2 exceeds maximum 1")
+	})
+}
+
+func TestIsValidOriginalDocument_Rules(t *testing.T) {
+	t.Run("success - rule disabled", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithoutRules(rules.NoContextRule))
+
+		require.NoError(t, v.IsValidOriginalDocument(docWithContext))
+	})
+
+	t.Run("success - default rule replaced", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithRule(rules.NoIDRule, rules.RuleFunc(
+			func(doc document.Document) error {
+				return nil
+			})))
+
+		require.NoError(t, v.IsValidOriginalDocument(docWithID))
+	})
+
+	t.Run("key purposes", func(t *testing.T) {
+		doc := []byte(`{"publicKey": [{"id": "key1", "purposes": ["custom"]}]}`)
+
+		err := New(mocks.NewMockOperationStore(nil)).IsValidOriginalDocument(doc)
+		require.EqualError(t, err, "public key 'key1' purpose 'custom' is not allowed")
+
+		v := New(mocks.NewMockOperationStore(nil), WithKeyPurposes("custom"))
+		require.NoError(t, v.IsValidOriginalDocument(doc))
+	})
+
+	t.Run("service types", func(t *testing.T) {
+		doc := []byte(`{"service": [{"id": "svc1", "type": "Spam", "serviceEndpoint": "https://example.com"}]}`)
+
+		require.NoError(t, New(mocks.NewMockOperationStore(nil)).IsValidOriginalDocument(doc))
+
+		v := New(mocks.NewMockOperationStore(nil), WithServiceTypes("LinkedDomains"))
+
+		err := v.IsValidOriginalDocument(doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service type not allowed")
+
+		v = New(mocks.NewMockOperationStore(nil), WithServiceTypes("LinkedDomains", "Spam"))
+		require.NoError(t, v.IsValidOriginalDocument(doc))
+	})
+
+	t.Run("service endpoints", func(t *testing.T) {
+		doc := []byte(`{"service": [{"id": "svc1", "type": "Hub", "serviceEndpoint": "https://192.168.0.1/hub"}]}`)
+
+		require.NoError(t, New(mocks.NewMockOperationStore(nil)).IsValidOriginalDocument(doc))
+
+		v := New(mocks.NewMockOperationStore(nil), WithServiceEndpointPolicy(document.EndpointPolicy{
+			Schemes:              document.DefaultEndpointSchemes(),
+			DenyPrivateAddresses: true,
+		}))
+
+		err := v.IsValidOriginalDocument(doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "host is a private or loopback address")
+
+		require.NoError(t, v.IsValidOriginalDocument(
+			[]byte(`{"service": [{"id": "svc1", "type": "Hub", "serviceEndpoint": "https://example.com/hub"}]}`)))
+	})
+
+	t.Run("error - custom rule", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithRule("custom", rules.RuleFunc(
+			func(doc document.Document) error {
+				if len(document.DidDocumentFromJSONLDObject(doc).Services()) == 0 {
+					return errors.New("document must have services")
+				}
+
+				return nil
+			})))
+
+		err := v.IsValidOriginalDocument([]byte(`{}`))
+		require.EqualError(t, err, "document must have services")
+	})
+}
+
+func TestValidateOriginalDocument(t *testing.T) {
+	v := New(mocks.NewMockOperationStore(nil),
+		WithWarningRule(rules.DeprecatedPropertiesRule, rules.DeprecatedProperties("legacy")))
+
+	t.Run("success - warnings", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`{"legacy": "value"}`))
+		require.NoError(t, result.Err())
+		require.Equal(t, []string{"property 'legacy' is deprecated"}, result.Warnings)
+
+		require.NoError(t, v.IsValidOriginalDocument([]byte(`{"legacy": "value"}`)))
+	})
+
+	t.Run("error - errors and warnings", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`{"id": "abc", "legacy": "value"}`))
+		require.EqualError(t, result.Err(), "document must NOT have the id property")
+		require.Equal(t, []string{"property 'legacy' is deprecated"}, result.Warnings)
+	})
+
+	t.Run("error - invalid payload", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`[]`))
+		require.Error(t, result.Err())
+		require.Empty(t, result.Warnings)
+	})
+}
+
 func TestIsValidPayload(t *testing.T) {
 	store := mocks.NewMockOperationStore(nil)
 	v := New(store)
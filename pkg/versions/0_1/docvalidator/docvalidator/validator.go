@@ -10,14 +10,61 @@ import (
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/jsonschema"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/rules"
 )
 
 const didSuffix = "didSuffix"
 
 // Validator is responsible for validating document operations and Sidetree rules.
 type Validator struct {
-	store OperationStoreClient
+	store    OperationStoreClient
+	schema   *jsonschema.Schema
+	custom   []namedRule
+	disabled []string
+	rules    *rules.Registry
+}
+
+type namedRule struct {
+	name    string
+	rule    rules.Rule
+	warning bool
+}
+
+// Option is a document validator instance option.
+type Option func(opts *Validator)
+
+// WithSchema sets JSON Schema that original documents have to conform to; since validator is created per
+// protocol version (and namespace) each document type and protocol version can use its own schema.
+func WithSchema(schema *jsonschema.Schema) Option {
+	return func(opts *Validator) {
+		opts.schema = schema
+	}
+}
+
+// WithRule adds custom original document validation rule; rule with the same name as default rule
+// (see rules package) replaces default rule.
+func WithRule(name string, rule rules.Rule) Option {
+	return func(opts *Validator) {
+		opts.custom = append(opts.custom, namedRule{name: name, rule: rule})
+	}
+}
+
+// WithWarningRule adds custom original document validation rule whose failures are reported as warnings
+// (e.g. deprecated properties, limits that will be enforced in the future) instead of errors.
+func WithWarningRule(name string, rule rules.Rule) Option {
+	return func(opts *Validator) {
+		opts.custom = append(opts.custom, namedRule{name: name, rule: rule, warning: true})
+	}
+}
+
+// WithoutRules disables original document validation rules with specified names.
+func WithoutRules(names ...string) Option {
+	return func(opts *Validator) {
+		opts.disabled = append(opts.disabled, names...)
+	}
 }
 
 // OperationStoreClient defines interface for retrieving all operations related to document.
@@ -27,10 +74,38 @@ type OperationStoreClient interface {
 }
 
 // New creates a new document validator.
-func New(store OperationStoreClient) *Validator {
-	return &Validator{
+func New(store OperationStoreClient, opts ...Option) *Validator {
+	v := &Validator{
 		store: store,
 	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	v.rules = rules.NewRegistry()
+
+	// Sidetree rule: the document must NOT have the id property
+	v.rules.Set(rules.NoIDRule, rules.NoID())
+
+	if v.schema != nil {
+		v.rules.Set(rules.SchemaRule, rules.Schema(v.schema))
+	}
+
+	for _, r := range v.custom {
+		if r.warning {
+			v.rules.SetWarning(r.name, r.rule)
+		} else {
+			v.rules.Set(r.name, r.rule)
+		}
+	}
+
+	for _, name := range v.disabled {
+		v.rules.Remove(name)
+	}
+
+	return v
 }
 
 // IsValidPayload verifies that the given payload is a valid Sidetree specific payload
@@ -66,10 +141,15 @@ func (v *Validator) IsValidOriginalDocument(payload []byte) error {
 		return err
 	}
 
-	// The document must NOT have the id property
-	if doc.ID() != "" {
-		return errors.New("document must NOT have the id property")
+	return v.rules.Validate(doc)
+}
+
+// ValidateOriginalDocument validates original document and returns validation errors and warnings.
+func (v *Validator) ValidateOriginalDocument(payload []byte) *protocol.ValidationResult {
+	doc, err := document.FromBytes(payload)
+	if err != nil {
+		return &protocol.ValidationResult{Errors: []error{err}}
 	}
 
-	return nil
+	return v.rules.Check(doc)
 }
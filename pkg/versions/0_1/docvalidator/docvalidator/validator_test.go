@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package docvalidator
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/jsonschema"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/rules"
 )
 
 func TestNew(t *testing.T) {
@@ -36,6 +40,74 @@ func TestValidatoIsValidOriginalDocumentError(t *testing.T) {
 	require.Contains(t, err.Error(), "document must NOT have the id property")
 }
 
+func TestIsValidOriginalDocument_Rules(t *testing.T) {
+	t.Run("success - rule disabled", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithoutRules(rules.NoIDRule))
+
+		require.NoError(t, v.IsValidOriginalDocument(invalidDoc))
+	})
+
+	t.Run("error - custom rule", func(t *testing.T) {
+		v := New(mocks.NewMockOperationStore(nil), WithRule("custom", rules.RuleFunc(
+			func(doc document.Document) error {
+				return errors.New("custom error")
+			})))
+
+		err := v.IsValidOriginalDocument(validDoc)
+		require.EqualError(t, err, "custom error")
+	})
+}
+
+func TestIsValidOriginalDocument_Schema(t *testing.T) {
+	schema, err := jsonschema.New([]byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {"name": {"type": "string"}}
+	}`))
+	require.NoError(t, err)
+
+	v := New(mocks.NewMockOperationStore(nil), WithSchema(schema))
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, v.IsValidOriginalDocument([]byte(`{"name": "value"}`)))
+	})
+
+	t.Run("error - document doesn't match schema", func(t *testing.T) {
+		err := v.IsValidOriginalDocument([]byte(`{"name": 1}`))
+		require.EqualError(t, err, "schema validation failed at '/name': expected string, got integer")
+	})
+
+	t.Run("error - sidetree rules are still applied", func(t *testing.T) {
+		err := v.IsValidOriginalDocument([]byte(`{"id": "abc", "name": "value"}`))
+		require.EqualError(t, err, "document must NOT have the id property")
+	})
+}
+
+func TestValidateOriginalDocument(t *testing.T) {
+	v := New(mocks.NewMockOperationStore(nil),
+		WithWarningRule(rules.DeprecatedPropertiesRule, rules.DeprecatedProperties("legacy")))
+
+	t.Run("success - warnings", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`{"legacy": "value"}`))
+		require.NoError(t, result.Err())
+		require.Equal(t, []string{"property 'legacy' is deprecated"}, result.Warnings)
+
+		require.NoError(t, v.IsValidOriginalDocument([]byte(`{"legacy": "value"}`)))
+	})
+
+	t.Run("error - errors and warnings", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`{"id": "abc", "legacy": "value"}`))
+		require.EqualError(t, result.Err(), "document must NOT have the id property")
+		require.Equal(t, []string{"property 'legacy' is deprecated"}, result.Warnings)
+	})
+
+	t.Run("error - invalid payload", func(t *testing.T) {
+		result := v.ValidateOriginalDocument([]byte(`[]`))
+		require.Error(t, result.Err())
+		require.Empty(t, result.Warnings)
+	})
+}
+
 func TestValidatorIsValidPayload(t *testing.T) {
 	store := mocks.NewMockOperationStore(nil)
 	v := New(store)
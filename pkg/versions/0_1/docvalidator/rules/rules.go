@@ -0,0 +1,280 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/jsonschema"
+)
+
+const (
+	// NoIDRule is name of the rule that rejects documents with id property.
+	NoIDRule = "no-id"
+
+	// NoContextRule is name of the rule that rejects documents with @context property.
+	NoContextRule = "no-context"
+
+	// LimitsRule is name of the rule that enforces document size and complexity limits.
+	LimitsRule = "limits"
+
+	// KeyPurposesRule is name of the rule that enforces allowed public key purposes.
+	KeyPurposesRule = "key-purposes"
+
+	// ServiceTypesRule is name of the rule that enforces allowed service types.
+	ServiceTypesRule = "service-types"
+
+	// ServiceEndpointsRule is name of the rule that validates service endpoints.
+	ServiceEndpointsRule = "service-endpoints"
+
+	// DeprecatedPropertiesRule is name of the (warning) rule that reports deprecated document properties.
+	DeprecatedPropertiesRule = "deprecated-properties"
+
+	// SchemaRule is name of the rule that validates documents against JSON Schema.
+	SchemaRule = "schema"
+)
+
+// StandardKeyPurposes returns standard public key purposes.
+func StandardKeyPurposes() []string {
+	return []string{
+		document.KeyPurposeAuthentication,
+		document.KeyPurposeAssertionMethod,
+		document.KeyPurposeKeyAgreement,
+		document.KeyPurposeCapabilityDelegation,
+		document.KeyPurposeCapabilityInvocation,
+	}
+}
+
+// Rule validates original document.
+type Rule interface {
+	Validate(doc document.Document) error
+}
+
+// RuleFunc is an adapter that allows use of ordinary function as a rule.
+type RuleFunc func(doc document.Document) error
+
+// Validate calls f(doc).
+func (f RuleFunc) Validate(doc document.Document) error {
+	return f(doc)
+}
+
+// Registry contains named validation rules that are evaluated in order of registration. Failures of
+// warning rules don't invalidate the document (they are reported as warnings).
+type Registry struct {
+	names    []string
+	rules    map[string]Rule
+	warnings map[string]bool
+}
+
+// NewRegistry creates new (empty) rule registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		rules:    make(map[string]Rule),
+		warnings: make(map[string]bool),
+	}
+}
+
+// Set registers rule with the given name; rule that is already registered with the same name
+// is replaced (and keeps its position).
+func (r *Registry) Set(name string, rule Rule) {
+	r.set(name, rule, false)
+}
+
+// SetWarning registers warning rule with the given name; rule that is already registered with the same name
+// is replaced (and keeps its position).
+func (r *Registry) SetWarning(name string, rule Rule) {
+	r.set(name, rule, true)
+}
+
+func (r *Registry) set(name string, rule Rule, warning bool) {
+	if _, ok := r.rules[name]; !ok {
+		r.names = append(r.names, name)
+	}
+
+	r.rules[name] = rule
+	r.warnings[name] = warning
+}
+
+// Remove removes rule with the given name (if registered).
+func (r *Registry) Remove(name string) {
+	if _, ok := r.rules[name]; !ok {
+		return
+	}
+
+	delete(r.rules, name)
+	delete(r.warnings, name)
+
+	for i, n := range r.names {
+		if n == name {
+			r.names = append(r.names[:i], r.names[i+1:]...)
+
+			break
+		}
+	}
+}
+
+// Names returns names of registered rules in order of evaluation.
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.names...)
+}
+
+// Validate validates document against all registered rules and returns the first error (warning rules
+// are not evaluated).
+func (r *Registry) Validate(doc document.Document) error {
+	for _, name := range r.names {
+		if r.warnings[name] {
+			continue
+		}
+
+		if err := r.rules[name].Validate(doc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Check validates document against all registered rules and returns errors of all failed rules
+// and warnings of all failed warning rules.
+func (r *Registry) Check(doc document.Document) *protocol.ValidationResult {
+	result := &protocol.ValidationResult{}
+
+	for _, name := range r.names {
+		err := r.rules[name].Validate(doc)
+		if err == nil {
+			continue
+		}
+
+		if r.warnings[name] {
+			result.Warnings = append(result.Warnings, err.Error())
+		} else {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+
+	return result
+}
+
+// NoID returns rule that rejects documents with id property (Sidetree rule).
+func NoID() Rule {
+	return RuleFunc(func(doc document.Document) error {
+		if doc.ID() != "" {
+			return errors.New("document must NOT have the id property")
+		}
+
+		return nil
+	})
+}
+
+// NoContext returns rule that rejects documents with @context property (Sidetree rule).
+func NoContext() Rule {
+	return RuleFunc(func(doc document.Document) error {
+		if len(document.DidDocumentFromJSONLDObject(doc).Context()) != 0 {
+			return errors.New("document must NOT have context")
+		}
+
+		return nil
+	})
+}
+
+// Limits returns rule that enforces document size and complexity limits.
+func Limits(limits document.Limits) Rule {
+	return RuleFunc(func(doc document.Document) error {
+		stats, err := document.GetStats(doc)
+		if err != nil {
+			return fmt.Errorf("failed to get document stats: %s", err.Error())
+		}
+
+		return stats.CheckLimits(limits)
+	})
+}
+
+// KeyPurposes returns rule that rejects documents with public key purposes that are not allowed;
+// if allowed purposes are not specified standard purposes are allowed.
+func KeyPurposes(allowed []string) Rule {
+	if len(allowed) == 0 {
+		allowed = StandardKeyPurposes()
+	}
+
+	allowedMap := make(map[string]bool)
+	for _, p := range allowed {
+		allowedMap[p] = true
+	}
+
+	return RuleFunc(func(doc document.Document) error {
+		for _, pk := range doc.PublicKeys() {
+			for _, p := range pk.Purpose() {
+				if !allowedMap[p] {
+					return fmt.Errorf("public key '%s' purpose '%s' is not allowed", pk.ID(), p)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// ServiceTypes returns rule that rejects documents with service types that are not allowed;
+// if allowed service types are not specified any service type is allowed.
+func ServiceTypes(allowed []string) Rule {
+	allowedMap := make(map[string]bool)
+	for _, t := range allowed {
+		allowedMap[t] = true
+	}
+
+	return RuleFunc(func(doc document.Document) error {
+		if len(allowedMap) == 0 {
+			return nil
+		}
+
+		for _, svc := range document.DidDocumentFromJSONLDObject(doc).Services() {
+			if !allowedMap[svc.Type()] {
+				return fmt.Errorf("service type not allowed: service '%s' type '%s'", svc.ID(), svc.Type())
+			}
+		}
+
+		return nil
+	})
+}
+
+// ServiceEndpoints returns rule that rejects documents with invalid service endpoints or service endpoint
+// URIs that are not allowed by the policy.
+func ServiceEndpoints(policy document.EndpointPolicy) Rule {
+	return RuleFunc(func(doc document.Document) error {
+		for _, svc := range document.DidDocumentFromJSONLDObject(doc).Services() {
+			if err := policy.Validate(svc.Endpoint()); err != nil {
+				return fmt.Errorf("service '%s': %s", svc.ID(), err.Error())
+			}
+		}
+
+		return nil
+	})
+}
+
+// Schema returns rule that validates documents against JSON Schema.
+func Schema(schema *jsonschema.Schema) Rule {
+	return RuleFunc(func(doc document.Document) error {
+		return schema.Validate(map[string]interface{}(doc))
+	})
+}
+
+// DeprecatedProperties returns rule that fails for documents with deprecated (top level) properties;
+// it is meant to be registered as warning rule.
+func DeprecatedProperties(properties ...string) Rule {
+	return RuleFunc(func(doc document.Document) error {
+		for _, property := range properties {
+			if _, ok := doc[property]; ok {
+				return fmt.Errorf("property '%s' is deprecated", property)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,200 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/jsonschema"
+)
+
+func TestRegistry(t *testing.T) {
+	var evaluated []string
+
+	newRule := func(name string, err error) Rule {
+		return RuleFunc(func(doc document.Document) error {
+			evaluated = append(evaluated, name)
+
+			return err
+		})
+	}
+
+	t.Run("success - rules evaluated in order", func(t *testing.T) {
+		evaluated = nil
+
+		r := NewRegistry()
+		r.Set("first", newRule("first", nil))
+		r.Set("second", newRule("second", nil))
+		r.Set("third", newRule("third", nil))
+
+		// replaced rule keeps its position
+		r.Set("first", newRule("first-replaced", nil))
+
+		require.Equal(t, []string{"first", "second", "third"}, r.Names())
+
+		require.NoError(t, r.Validate(document.Document{}))
+		require.Equal(t, []string{"first-replaced", "second", "third"}, evaluated)
+	})
+
+	t.Run("success - remove rule", func(t *testing.T) {
+		evaluated = nil
+
+		r := NewRegistry()
+		r.Set("first", newRule("first", nil))
+		r.Set("second", newRule("second", nil))
+
+		r.Remove("first")
+		r.Remove("unknown")
+
+		require.Equal(t, []string{"second"}, r.Names())
+
+		require.NoError(t, r.Validate(document.Document{}))
+		require.Equal(t, []string{"second"}, evaluated)
+	})
+
+	t.Run("error - first error is returned", func(t *testing.T) {
+		evaluated = nil
+
+		r := NewRegistry()
+		r.Set("first", newRule("first", errors.New("first error")))
+		r.Set("second", newRule("second", errors.New("second error")))
+
+		err := r.Validate(document.Document{})
+		require.EqualError(t, err, "first error")
+		require.Equal(t, []string{"first"}, evaluated)
+	})
+}
+
+func TestRegistry_Check(t *testing.T) {
+	failing := func(msg string) Rule {
+		return RuleFunc(func(doc document.Document) error {
+			return errors.New(msg)
+		})
+	}
+
+	r := NewRegistry()
+	r.Set("error1", failing("error 1"))
+	r.SetWarning("warning1", failing("warning 1"))
+	r.Set("error2", failing("error 2"))
+	r.SetWarning("warning2", RuleFunc(func(doc document.Document) error { return nil }))
+
+	result := r.Check(document.Document{})
+	require.Len(t, result.Errors, 2)
+	require.EqualError(t, result.Err(), "error 1")
+	require.EqualError(t, result.Errors[1], "error 2")
+	require.Equal(t, []string{"warning 1"}, result.Warnings)
+
+	// warning rules are not evaluated by Validate
+	r.Remove("error1")
+	r.Remove("error2")
+
+	require.NoError(t, r.Validate(document.Document{}))
+
+	result = r.Check(document.Document{})
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"warning 1"}, result.Warnings)
+
+	// rule replaced with error rule
+	r.Set("warning1", failing("error 1"))
+	require.EqualError(t, r.Validate(document.Document{}), "error 1")
+}
+
+func TestDeprecatedProperties(t *testing.T) {
+	rule := DeprecatedProperties("publicKeys", "services")
+
+	require.NoError(t, rule.Validate(document.Document{"publicKey": []interface{}{}}))
+
+	err := rule.Validate(document.Document{"services": []interface{}{}})
+	require.EqualError(t, err, "property 'services' is deprecated")
+}
+
+func TestNoID(t *testing.T) {
+	require.NoError(t, NoID().Validate(document.Document{}))
+
+	err := NoID().Validate(document.Document{document.IDProperty: "did:example:123"})
+	require.EqualError(t, err, "document must NOT have the id property")
+}
+
+func TestNoContext(t *testing.T) {
+	require.NoError(t, NoContext().Validate(document.Document{}))
+
+	err := NoContext().Validate(document.Document{document.ContextProperty: []interface{}{document.DIDContext}})
+	require.EqualError(t, err, "document must NOT have context")
+}
+
+func TestLimits(t *testing.T) {
+	doc := document.Document{document.ServiceProperty: []interface{}{map[string]interface{}{"id": "svc"}}}
+
+	require.NoError(t, Limits(document.Limits{}).Validate(doc))
+	require.NoError(t, Limits(document.Limits{MaxServices: 1}).Validate(doc))
+
+	err := Limits(document.Limits{MaxServices: 0, MaxDepth: 2}).Validate(doc)
+	require.EqualError(t, err, "document depth 3 exceeds maximum depth 2")
+
+	err = Limits(document.Limits{}).Validate(document.Document{"key": make(chan int)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get document stats")
+}
+
+func TestKeyPurposes(t *testing.T) {
+	doc, err := document.FromBytes([]byte(`{"publicKey": [{"id": "key1", "purposes": ["authentication", "custom"]}]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, KeyPurposes([]string{document.KeyPurposeAuthentication, "custom"}).Validate(doc))
+
+	err = KeyPurposes(nil).Validate(doc)
+	require.EqualError(t, err, "public key 'key1' purpose 'custom' is not allowed")
+
+	err = KeyPurposes([]string{"custom"}).Validate(doc)
+	require.EqualError(t, err, "public key 'key1' purpose 'authentication' is not allowed")
+
+	require.Len(t, StandardKeyPurposes(), 5)
+}
+
+func TestServiceTypes(t *testing.T) {
+	doc, err := document.FromBytes([]byte(`{"service": [{"id": "svc1", "type": "LinkedDomains"}, {"id": "svc2", "type": "Spam"}]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, ServiceTypes(nil).Validate(doc))
+	require.NoError(t, ServiceTypes([]string{"LinkedDomains", "Spam"}).Validate(doc))
+	require.NoError(t, ServiceTypes([]string{"LinkedDomains"}).Validate(document.Document{}))
+
+	err = ServiceTypes([]string{"LinkedDomains"}).Validate(doc)
+	require.EqualError(t, err, "service type not allowed: service 'svc2' type 'Spam'")
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	doc, err := document.FromBytes([]byte(`{"service": [{"id": "svc1", "serviceEndpoint": "http://127.0.0.1"}]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, ServiceEndpoints(document.EndpointPolicy{}).Validate(doc))
+
+	err = ServiceEndpoints(document.EndpointPolicy{Schemes: document.DefaultEndpointSchemes()}).Validate(doc)
+	require.EqualError(t, err, "service 'svc1': service endpoint 'http://127.0.0.1' scheme 'http' is not allowed")
+
+	err = ServiceEndpoints(document.EndpointPolicy{DenyPrivateAddresses: true}).Validate(doc)
+	require.EqualError(t, err, "service 'svc1': service endpoint 'http://127.0.0.1' host is a private or loopback address")
+
+	err = ServiceEndpoints(document.EndpointPolicy{}).Validate(document.Document{
+		document.ServiceProperty: []interface{}{map[string]interface{}{"id": "svc1"}},
+	})
+	require.EqualError(t, err, "service 'svc1': service endpoint is missing")
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := jsonschema.New([]byte(`{"type": "object", "required": ["name"]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, Schema(schema).Validate(document.Document{"name": "value"}))
+
+	err = Schema(schema).Validate(document.Document{})
+	require.EqualError(t, err, "schema validation failed at '/': missing required property 'name'")
+}
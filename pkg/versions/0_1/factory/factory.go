@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package factory creates protocol version 0.1 components (parser, applier, document composer,
+// validator and transformer, batch file handling). The factory can be registered with versions.Register.
+package factory
+
+import (
+	"errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doctransformer/didtransformer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/didvalidator"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprocessor"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprovider"
+)
+
+// Version is the name of the protocol version created by this factory.
+const Version = "0.1"
+
+// Option is an option for protocol version factory.
+type Option func(opts *Factory)
+
+// WithParserOptions sets options for operation parser.
+func WithParserOptions(parserOpts ...operationparser.Option) Option {
+	return func(opts *Factory) {
+		opts.parserOpts = append(opts.parserOpts, parserOpts...)
+	}
+}
+
+// WithTransformerOptions sets options for DID document transformer.
+func WithTransformerOptions(transformerOpts ...didtransformer.Option) Option {
+	return func(opts *Factory) {
+		opts.transformerOpts = append(opts.transformerOpts, transformerOpts...)
+	}
+}
+
+// WithComposerOptions sets options for document composer (e.g. custom patch appliers); they are applied
+// after the options derived from protocol parameters.
+func WithComposerOptions(composerOpts ...doccomposer.Option) Option {
+	return func(opts *Factory) {
+		opts.composerOpts = append(opts.composerOpts, composerOpts...)
+	}
+}
+
+// WithApplierOptions sets options for operation applier (e.g. patch mode, document validator).
+func WithApplierOptions(applierOpts ...operationapplier.Option) Option {
+	return func(opts *Factory) {
+		opts.applierOpts = append(opts.applierOpts, applierOpts...)
+	}
+}
+
+// Factory creates protocol version 0.1 for DID documents.
+type Factory struct {
+	parserOpts      []operationparser.Option
+	composerOpts    []doccomposer.Option
+	applierOpts     []operationapplier.Option
+	transformerOpts []didtransformer.Option
+}
+
+// New returns a new protocol version factory.
+func New(opts ...Option) *Factory {
+	f := &Factory{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Create creates protocol version for the given protocol parameters.
+func (f *Factory) Create(version string, p protocol.Protocol, deps *versions.Dependencies) (protocol.Version, error) {
+	if err := validateDependencies(deps); err != nil {
+		return nil, err
+	}
+
+	parser := operationparser.New(p, f.parserOpts...)
+	composerOpts := []doccomposer.Option{
+		doccomposer.WithKeyPurposes(p.KeyPurposes...),
+		doccomposer.WithUniqueIDValidation(p.ValidateUniqueIDs),
+	}
+
+	composer := doccomposer.New(append(composerOpts, f.composerOpts...)...)
+	provider := txnprovider.NewOperationProvider(p, parser, deps.CasClient, deps.CompressionProvider)
+
+	return &protocolVersion{
+		version:  version,
+		protocol: p,
+		parser:   parser,
+		applier:  operationapplier.New(p, parser, composer, f.applierOpts...),
+		composer: composer,
+		handler:  txnprovider.NewOperationHandler(p, deps.CasClient, deps.CompressionProvider, parser),
+		provider: provider,
+		processor: txnprocessor.New(&txnprocessor.Providers{
+			OpStore:                   deps.OperationStore,
+			OperationProtocolProvider: provider,
+		}),
+		validator:   didvalidator.New(deps.OperationStore, didvalidator.WithProtocol(p)),
+		transformer: didtransformer.New(f.transformerOpts...),
+	}, nil
+}
+
+func validateDependencies(deps *versions.Dependencies) error {
+	switch {
+	case deps == nil:
+		return errors.New("missing dependencies")
+	case deps.CasClient == nil:
+		return errors.New("missing CAS client")
+	case deps.OperationStore == nil:
+		return errors.New("missing operation store")
+	case deps.CompressionProvider == nil:
+		return errors.New("missing compression provider")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/compression"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doctransformer/didtransformer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+func TestFactory_Create(t *testing.T) {
+	p := mocks.GetDefaultProtocolParameters()
+
+	t.Run("success", func(t *testing.T) {
+		f := New(WithParserOptions(operationparser.WithKeyIDValidator(nil)),
+			WithTransformerOptions(didtransformer.WithBase(true)))
+
+		v, err := f.Create(Version, p, getDependencies())
+		require.NoError(t, err)
+		require.Equal(t, Version, v.Version())
+		require.Equal(t, p.MaxOperationSize, v.Protocol().MaxOperationSize)
+		require.Equal(t, p.Patches, v.Capabilities().PatchActions)
+		require.Equal(t, p.SignatureAlgorithms, v.Capabilities().SignatureAlgorithms)
+		require.True(t, v.Capabilities().LongFormDID)
+		require.NotNil(t, v.TransactionProcessor())
+		require.NotNil(t, v.OperationParser())
+		require.NotNil(t, v.OperationApplier())
+		require.NotNil(t, v.OperationHandler())
+		require.NotNil(t, v.OperationProvider())
+		require.NotNil(t, v.DocumentComposer())
+		require.NotNil(t, v.DocumentValidator())
+		require.NotNil(t, v.DocumentTransformer())
+	})
+
+	t.Run("success - document composer uses protocol key purposes", func(t *testing.T) {
+		doc, err := document.FromBytes([]byte(`{"publicKey": [{
+			"id": "key1",
+			"type": "JsonWebKey2020",
+			"purposes": ["assertionMethod"],
+			"publicKeyJwk": {
+				"kty": "EC",
+				"crv": "P-256K",
+				"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+				"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+			}
+		}]}`))
+		require.NoError(t, err)
+
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["custom"]}]`)
+		require.NoError(t, err)
+
+		customPurposes := p
+		customPurposes.KeyPurposes = []string{"custom", document.KeyPurposeAuthentication, document.KeyPurposeAssertionMethod}
+
+		v, err := New().Create(Version, customPurposes, getDependencies())
+		require.NoError(t, err)
+
+		result, err := v.DocumentComposer().ApplyPatches(doc, []patch.Patch{add})
+		require.NoError(t, err)
+		require.Contains(t, result.PublicKeys()[0].Purpose(), "custom")
+
+		v, err = New().Create(Version, p, getDependencies())
+		require.NoError(t, err)
+
+		result, err = v.DocumentComposer().ApplyPatches(doc, []patch.Patch{add})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "invalid purpose: custom")
+	})
+
+	t.Run("success - composer and applier options", func(t *testing.T) {
+		const customAction = "factory-custom"
+
+		require.NoError(t, patch.RegisterAction(customAction, "data"))
+
+		var phases []operationapplier.Phase
+
+		f := New(
+			WithComposerOptions(doccomposer.WithPatchApplier(customAction,
+				func(doc document.Document, value interface{}) (document.Document, error) {
+					doc["custom"] = value
+
+					return doc, nil
+				})),
+			WithApplierOptions(operationapplier.WithSpanStarter(
+				func(ctx context.Context, phase operationapplier.Phase,
+					_ *operation.AnchoredOperation) (context.Context, func(err error)) {
+					phases = append(phases, phase)
+
+					return ctx, func(error) {}
+				})),
+		)
+
+		v, err := f.Create(Version, p, getDependencies())
+		require.NoError(t, err)
+
+		custom, err := patch.NewPatch(customAction, "value")
+		require.NoError(t, err)
+
+		doc, err := v.DocumentComposer().ApplyPatches(make(document.Document), []patch.Patch{custom})
+		require.NoError(t, err)
+		require.Equal(t, "value", doc["custom"])
+
+		rm, err := v.OperationApplier().Apply(&operation.AnchoredOperation{Type: "invalid"}, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Nil(t, rm)
+		require.Equal(t, []operationapplier.Phase{operationapplier.PhaseApply}, phases)
+	})
+
+	t.Run("success - registered factory", func(t *testing.T) {
+		require.NoError(t, versions.Register(Version, New()))
+
+		pc, err := versions.NewClient([]versions.Config{{Version: Version, Protocol: p}}, getDependencies())
+		require.NoError(t, err)
+
+		v, err := pc.Current()
+		require.NoError(t, err)
+		require.Equal(t, Version, v.Version())
+	})
+
+	t.Run("error - missing dependencies", func(t *testing.T) {
+		f := New()
+
+		v, err := f.Create(Version, p, nil)
+		require.EqualError(t, err, "missing dependencies")
+		require.Nil(t, v)
+
+		deps := getDependencies()
+		deps.CasClient = nil
+		_, err = f.Create(Version, p, deps)
+		require.EqualError(t, err, "missing CAS client")
+
+		deps = getDependencies()
+		deps.OperationStore = nil
+		_, err = f.Create(Version, p, deps)
+		require.EqualError(t, err, "missing operation store")
+
+		deps = getDependencies()
+		deps.CompressionProvider = nil
+		_, err = f.Create(Version, p, deps)
+		require.EqualError(t, err, "missing compression provider")
+	})
+}
+
+func getDependencies() *versions.Dependencies {
+	return &versions.Dependencies{
+		CasClient:           mocks.NewMockCasClient(nil),
+		OperationStore:      &mockOperationStore{},
+		CompressionProvider: compression.New(compression.WithDefaultAlgorithms()),
+	}
+}
+
+type mockOperationStore struct{}
+
+func (m *mockOperationStore) Get(string) ([]*operation.AnchoredOperation, error) {
+	return nil, nil
+}
+
+func (m *mockOperationStore) Put([]*operation.AnchoredOperation) error {
+	return nil
+}
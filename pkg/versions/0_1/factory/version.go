@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// protocolVersion implements protocol.Version.
+type protocolVersion struct {
+	version     string
+	protocol    protocol.Protocol
+	processor   protocol.TxnProcessor
+	parser      protocol.OperationParser
+	applier     protocol.OperationApplier
+	handler     protocol.OperationHandler
+	provider    protocol.OperationProvider
+	composer    protocol.DocumentComposer
+	validator   protocol.DocumentValidator
+	transformer protocol.DocumentTransformer
+}
+
+// Version returns protocol version.
+func (v *protocolVersion) Version() string {
+	return v.version
+}
+
+// Protocol returns protocol parameters.
+func (v *protocolVersion) Protocol() protocol.Protocol {
+	return v.protocol
+}
+
+// Capabilities returns features supported by the protocol version.
+func (v *protocolVersion) Capabilities() protocol.Capabilities {
+	c := v.protocol.Capabilities()
+	c.LongFormDID = true
+
+	return c
+}
+
+// TransactionProcessor returns transaction processor.
+func (v *protocolVersion) TransactionProcessor() protocol.TxnProcessor {
+	return v.processor
+}
+
+// OperationParser returns operation parser.
+func (v *protocolVersion) OperationParser() protocol.OperationParser {
+	return v.parser
+}
+
+// OperationApplier returns operation applier.
+func (v *protocolVersion) OperationApplier() protocol.OperationApplier {
+	return v.applier
+}
+
+// OperationHandler returns operation handler (batching).
+func (v *protocolVersion) OperationHandler() protocol.OperationHandler {
+	return v.handler
+}
+
+// OperationProvider returns operation provider.
+func (v *protocolVersion) OperationProvider() protocol.OperationProvider {
+	return v.provider
+}
+
+// DocumentComposer returns document composer.
+func (v *protocolVersion) DocumentComposer() protocol.DocumentComposer {
+	return v.composer
+}
+
+// DocumentValidator returns document validator.
+func (v *protocolVersion) DocumentValidator() protocol.DocumentValidator {
+	return v.validator
+}
+
+// DocumentTransformer returns document transformer.
+func (v *protocolVersion) DocumentTransformer() protocol.DocumentTransformer {
+	return v.transformer
+}
@@ -89,6 +89,9 @@ type UpdateSignedDataModel struct {
 
 	// DeltaHash of the unsigned delta object
 	DeltaHash string `json:"deltaHash"`
+
+	// Nonce is optional update sequence number (has to be strictly increasing across updates)
+	Nonce *uint64 `json:"nonce,omitempty"`
 }
 
 // RecoverSignedDataModel defines signed data model for recovery.
@@ -7,12 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package model
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
-	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 )
 
 // GetAnchoredOperation is utility method for converting operation model into anchored operation.
@@ -70,15 +69,11 @@ func GetAnchoredOperation(op *Operation) (*operation.AnchoredOperation, error) {
 
 // GetUniqueSuffix calculates unique suffix from suffix data and multihash algorithms.
 func GetUniqueSuffix(model *SuffixDataModel, algs []uint) (string, error) {
-	if len(algs) == 0 {
-		return "", errors.New("failed to calculate unique suffix: algorithm not provided")
-	}
-
 	// Even though protocol supports the list of multihashing algorithms in this protocol version (v1) we can have
 	// only one multihashing algorithm. Later versions may have multiple values for backward compatibility.
 	// At that point (version 2) the spec will hopefully better define how to handle this scenarios:
 	// https://github.com/decentralized-identity/sidetree/issues/965
-	encodedComputedMultihash, err := hashing.CalculateModelMultihash(model, algs[0])
+	encodedComputedMultihash, err := docutil.CalculateUniqueSuffix(model, algs)
 	if err != nil {
 		return "", fmt.Errorf("failed to calculate unique suffix: %s", err.Error())
 	}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package operationapplier
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
@@ -29,6 +31,29 @@ type Applier struct {
 	protocol.Protocol
 	OperationParser
 	protocol.DocumentComposer
+
+	patchMode         protocol.PatchMode
+	spanStarter       SpanStarter
+	documentValidator protocol.DocumentValidator
+}
+
+// Option is an operation applier instance option.
+type Option func(opts *Applier)
+
+// WithPatchMode sets the mode for handling patches that cannot be applied (default is strict).
+func WithPatchMode(mode protocol.PatchMode) Option {
+	return func(opts *Applier) {
+		opts.patchMode = mode
+	}
+}
+
+// WithDocumentValidator sets validator for documents produced by applying patches; operations that
+// produce invalid document are handled like operations whose patches cannot be applied (commitments are
+// advanced and the document is left unchanged). By default resulting documents are not validated.
+func WithDocumentValidator(validator protocol.DocumentValidator) Option {
+	return func(opts *Applier) {
+		opts.documentValidator = validator
+	}
 }
 
 // OperationParser defines the functions for parsing operations.
@@ -45,38 +70,146 @@ type OperationParser interface {
 }
 
 // New returns a new operation applier for the given protocol.
-func New(p protocol.Protocol, parser OperationParser, dc protocol.DocumentComposer) *Applier {
-	return &Applier{
+func New(p protocol.Protocol, parser OperationParser, dc protocol.DocumentComposer, opts ...Option) *Applier {
+	applier := &Applier{
 		Protocol:         p,
 		OperationParser:  parser,
 		DocumentComposer: dc,
+		patchMode:        protocol.PatchModeStrict,
 	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(applier)
+	}
+
+	return applier
 }
 
-// Apply applies the given anchored operation.
+// Apply applies the given anchored operation. Returned errors are typed: operations that are invalid
+// (e.g. bad signature, stale commitment) are rejected with protocol.ErrorCodeRejected while transient/internal
+// failures (e.g. reported by document composer) have protocol.ErrorCodeInternal (see protocol.GetErrorCode).
 func (s *Applier) Apply(op *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	return s.ApplyWithContext(context.Background(), op, rm)
+}
+
+// ApplyWithContext applies the given anchored operation (see Apply). The context is passed to span starter
+// so that spans of operation processing phases are children of the caller's span.
+func (s *Applier) ApplyWithContext(ctx context.Context, op *operation.AnchoredOperation,
+	rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	var result *protocol.ResolutionModel
+	var err error
+
+	ctx, end := s.startSpan(ctx, PhaseApply, op)
+	defer func() { end(err) }()
+
 	switch op.Type {
 	case operation.TypeCreate:
-		return s.applyCreateOperation(op, rm)
+		result, err = s.applyCreateOperation(ctx, op, rm)
 	case operation.TypeUpdate:
-		return s.applyUpdateOperation(op, rm)
+		result, err = s.applyUpdateOperation(ctx, op, rm)
 	case operation.TypeDeactivate:
-		return s.applyDeactivateOperation(op, rm)
+		result, err = s.applyDeactivateOperation(ctx, op, rm)
 	case operation.TypeRecover:
-		return s.applyRecoverOperation(op, rm)
+		result, err = s.applyRecoverOperation(ctx, op, rm)
 	default:
-		return nil, fmt.Errorf("operation type not supported for process operation")
+		err = fmt.Errorf("operation type not supported for process operation")
 	}
+
+	if err != nil {
+		err = typedError(err)
+
+		return nil, err
+	}
+
+	result.PatchMode = s.patchMode
+
+	return result, nil
 }
 
-func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+// applyPatches applies patches according to patch mode. In lenient mode patches that fail are skipped and
+// returned as warnings (internal errors are never skipped).
+func (s *Applier) applyPatches(doc document.Document, patches []patch.Patch) (document.Document, []string, error) {
+	if s.patchMode != protocol.PatchModeLenient {
+		result, err := s.ApplyPatches(doc, patches)
+
+		return result, nil, err
+	}
+
+	var skipped []string
+
+	result := doc
+
+	for i, p := range patches {
+		patched, err := s.ApplyPatches(result, []patch.Patch{p})
+		if protocol.IsInternalError(err) {
+			return nil, nil, err
+		}
+
+		if err != nil {
+			action, _ := p.GetAction()
+
+			logger.Infof("Skipping patch[%d] '%s' in lenient mode. Reason: %s", i, action, err)
+
+			skipped = append(skipped, fmt.Sprintf("patch[%d] '%s' skipped: %s", i, action, err.Error()))
+
+			continue
+		}
+
+		result = patched
+	}
+
+	return result, skipped, nil
+}
+
+// typedError returns typed error; errors that are not typed are rejections (operation is invalid).
+func typedError(err error) error {
+	var applyErr *protocol.ApplyError
+	if errors.As(err, &applyErr) {
+		return err
+	}
+
+	return protocol.NewRejectedError(err)
+}
+
+// validateDocument validates document produced by applying patches (if document validator is set).
+func (s *Applier) validateDocument(ctx context.Context, anchoredOp *operation.AnchoredOperation, doc document.Document) error {
+	if s.documentValidator == nil {
+		return nil
+	}
+
+	var err error
+
+	_, end := s.startSpan(ctx, PhaseValidateDocument, anchoredOp)
+	defer func() { end(err) }()
+
+	docBytes, err := doc.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to marshal resulting document: %s", err.Error())
+	}
+
+	err = s.documentValidator.IsValidOriginalDocument(docBytes)
+	if protocol.IsInternalError(err) {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("resulting document is not valid: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (s *Applier) applyCreateOperation(ctx context.Context, anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	logger.Debugf("Applying create operation: %+v", anchoredOp)
 
 	if rm.Doc != nil {
 		return nil, errors.New("create has to be the first operation")
 	}
 
+	_, endParse := s.startSpan(ctx, PhaseParse, anchoredOp)
 	op, err := s.OperationParser.ParseCreateOperation(anchoredOp.OperationBuffer, true)
+	endParse(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse create operation in batch mode: %s", err.Error())
 	}
@@ -87,6 +220,8 @@ func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation,
 		LastOperationTransactionTime:     anchoredOp.TransactionTime,
 		LastOperationTransactionNumber:   anchoredOp.TransactionTime,
 		LastOperationProtocolGenesisTime: anchoredOp.ProtocolGenesisTime,
+		CreatedTransactionTime:           anchoredOp.TransactionTime,
+		CreatedTransactionNumber:         anchoredOp.TransactionNumber,
 		RecoveryCommitment:               op.SuffixData.RecoveryCommitment,
 	}
 
@@ -107,26 +242,46 @@ func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation,
 
 	result.UpdateCommitment = op.Delta.UpdateCommitment
 
-	doc, err := s.ApplyPatches(make(document.Document), op.Delta.Patches)
+	_, endApply := s.startSpan(ctx, PhaseApplyPatches, anchoredOp)
+	doc, skipped, err := s.applyPatches(make(document.Document), op.Delta.Patches)
+	endApply(err)
+	if protocol.IsInternalError(err) {
+		return nil, err
+	}
+
 	if err != nil {
 		logger.Infof("Apply patches failed; advance commitments {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", anchoredOp.UniqueSuffix, anchoredOp.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
 
 		return result, nil
 	}
 
+	err = s.validateDocument(ctx, anchoredOp, doc)
+	if protocol.IsInternalError(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		logger.Infof("Resulting document is not valid; advance commitments {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", anchoredOp.UniqueSuffix, anchoredOp.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
+
+		return result, nil
+	}
+
 	result.Doc = doc
+	result.SkippedPatches = skipped
 
 	return result, nil
 }
 
-func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
+func (s *Applier) applyUpdateOperation(ctx context.Context, anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
 	logger.Debugf("Applying update operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("update cannot be first operation")
 	}
 
+	_, endParse := s.startSpan(ctx, PhaseParse, anchoredOp)
 	op, err := s.OperationParser.ParseUpdateOperation(anchoredOp.OperationBuffer, true)
+	endParse(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse update operation in batch mode: %s", err.Error())
 	}
@@ -143,11 +298,23 @@ func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation,
 	}
 
 	// verify signature
+	_, endVerify := s.startSpan(ctx, PhaseVerifySignature, anchoredOp)
 	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.UpdateKey)
+	endVerify(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
 
+	// verify that optional nonce is strictly increasing (anti-replay)
+	lastUpdateNonce := rm.LastUpdateNonce
+	if signedDataModel.Nonce != nil {
+		if *signedDataModel.Nonce <= rm.LastUpdateNonce {
+			return nil, fmt.Errorf("update nonce[%d] must be greater than last update nonce[%d]", *signedDataModel.Nonce, rm.LastUpdateNonce)
+		}
+
+		lastUpdateNonce = *signedDataModel.Nonce
+	}
+
 	err = s.OperationParser.ValidateDelta(op.Delta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate delta: %s", err.Error())
@@ -159,31 +326,59 @@ func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation,
 		LastOperationTransactionTime:     anchoredOp.TransactionTime,
 		LastOperationTransactionNumber:   anchoredOp.TransactionTime,
 		LastOperationProtocolGenesisTime: anchoredOp.ProtocolGenesisTime,
+		CreatedTransactionTime:           rm.CreatedTransactionTime,
+		CreatedTransactionNumber:         rm.CreatedTransactionNumber,
+		UpdatedTransactionTime:           anchoredOp.TransactionTime,
+		UpdatedTransactionNumber:         anchoredOp.TransactionNumber,
 		UpdateCommitment:                 op.Delta.UpdateCommitment,
 		RecoveryCommitment:               rm.RecoveryCommitment,
+		LastUpdateNonce:                  lastUpdateNonce,
+		SkippedPatches:                   rm.SkippedPatches,
+	}
+
+	_, endApply := s.startSpan(ctx, PhaseApplyPatches, anchoredOp)
+	doc, skipped, err := s.applyPatches(rm.Doc, op.Delta.Patches)
+	endApply(err)
+	if protocol.IsInternalError(err) {
+		return nil, err
 	}
 
-	doc, err := s.ApplyPatches(rm.Doc, op.Delta.Patches)
 	if err != nil {
 		logger.Infof("Apply patches failed; advance update commitment {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", op.UniqueSuffix, op.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
 
 		return result, nil
 	}
 
+	err = s.validateDocument(ctx, anchoredOp, doc)
+	if protocol.IsInternalError(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		logger.Infof("Resulting document is not valid; advance update commitment {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", op.UniqueSuffix, op.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
+
+		return result, nil
+	}
+
 	// applying patches succeeded so update document
 	result.Doc = doc
+	if len(skipped) > 0 {
+		result.SkippedPatches = append(append([]string{}, rm.SkippedPatches...), skipped...)
+	}
 
 	return result, nil
 }
 
-func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+func (s *Applier) applyDeactivateOperation(ctx context.Context, anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	logger.Debugf("[%s] Applying deactivate operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("deactivate can only be applied to an existing document")
 	}
 
+	_, endParse := s.startSpan(ctx, PhaseParse, anchoredOp)
 	op, err := s.OperationParser.ParseDeactivateOperation(anchoredOp.OperationBuffer, true)
+	endParse(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse deactive operation in batch mode: %s", err.Error())
 	}
@@ -199,7 +394,9 @@ func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperati
 	}
 
 	// verify signature
+	_, endVerify := s.startSpan(ctx, PhaseVerifySignature, anchoredOp)
 	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.RecoveryKey)
+	endVerify(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
@@ -209,19 +406,27 @@ func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperati
 		LastOperationTransactionTime:     anchoredOp.TransactionTime,
 		LastOperationTransactionNumber:   anchoredOp.TransactionTime,
 		LastOperationProtocolGenesisTime: anchoredOp.ProtocolGenesisTime,
+		CreatedTransactionTime:           rm.CreatedTransactionTime,
+		CreatedTransactionNumber:         rm.CreatedTransactionNumber,
+		UpdatedTransactionTime:           anchoredOp.TransactionTime,
+		UpdatedTransactionNumber:         anchoredOp.TransactionNumber,
 		UpdateCommitment:                 "",
 		RecoveryCommitment:               "",
+		Deactivated:                      true,
+		FinalDoc:                         rm.Doc,
 	}, nil
 }
 
-func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
+func (s *Applier) applyRecoverOperation(ctx context.Context, anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
 	logger.Debugf("Applying recover operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("recover can only be applied to an existing document")
 	}
 
+	_, endParse := s.startSpan(ctx, PhaseParse, anchoredOp)
 	op, err := s.OperationParser.ParseRecoverOperation(anchoredOp.OperationBuffer, true)
+	endParse(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse recover operation in batch mode: %s", err.Error())
 	}
@@ -232,7 +437,9 @@ func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation,
 	}
 
 	// verify signature
+	_, endVerify := s.startSpan(ctx, PhaseVerifySignature, anchoredOp)
 	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.RecoveryKey)
+	endVerify(err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
@@ -243,7 +450,12 @@ func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation,
 		LastOperationTransactionTime:     anchoredOp.TransactionTime,
 		LastOperationTransactionNumber:   anchoredOp.TransactionTime,
 		LastOperationProtocolGenesisTime: anchoredOp.ProtocolGenesisTime,
+		CreatedTransactionTime:           rm.CreatedTransactionTime,
+		CreatedTransactionNumber:         rm.CreatedTransactionNumber,
+		UpdatedTransactionTime:           anchoredOp.TransactionTime,
+		UpdatedTransactionNumber:         anchoredOp.TransactionNumber,
 		RecoveryCommitment:               signedDataModel.RecoveryCommitment,
+		LastUpdateNonce:                  rm.LastUpdateNonce,
 	}
 
 	// verify the delta against the signed delta hash
@@ -263,14 +475,32 @@ func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation,
 
 	result.UpdateCommitment = op.Delta.UpdateCommitment
 
-	doc, err := s.ApplyPatches(make(document.Document), op.Delta.Patches)
+	_, endApply := s.startSpan(ctx, PhaseApplyPatches, anchoredOp)
+	doc, skipped, err := s.applyPatches(make(document.Document), op.Delta.Patches)
+	endApply(err)
+	if protocol.IsInternalError(err) {
+		return nil, err
+	}
+
 	if err != nil {
 		logger.Infof("Apply patches failed; advance commitments {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", op.UniqueSuffix, op.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
 
 		return result, nil
 	}
 
+	err = s.validateDocument(ctx, anchoredOp, doc)
+	if protocol.IsInternalError(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		logger.Infof("Resulting document is not valid; advance commitments {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", op.UniqueSuffix, op.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
+
+		return result, nil
+	}
+
 	result.Doc = doc
+	result.SkippedPatches = skipped
 
 	return result, nil
 }
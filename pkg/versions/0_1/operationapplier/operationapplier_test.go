@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package operationapplier
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -22,8 +24,10 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
@@ -262,6 +266,46 @@ func TestUpdateDocument(t *testing.T) {
 		require.EqualError(t, err, "update delta doesn't match delta hash: supplied hash doesn't match original content")
 	})
 
+	t.Run("update nonce", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		nonce := uint64(5)
+
+		updateOp, nextUpdateKey, err := getUpdateOperationWithNonce(ecsigner.New(updateKey, "ES256", updateKeyID), updateKey, uniqueSuffix, 1, &nonce)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.NoError(t, err)
+		require.Equal(t, nonce, rm.LastUpdateNonce)
+
+		// update without nonce keeps last nonce
+		updateOp, nextUpdateKey, err = getUpdateOperation(nextUpdateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.NoError(t, err)
+		require.Equal(t, nonce, rm.LastUpdateNonce)
+
+		// replayed (not increasing) nonce is rejected
+		updateOp, _, err = getUpdateOperationWithNonce(ecsigner.New(nextUpdateKey, "ES256", updateKeyID), nextUpdateKey, uniqueSuffix, 3, &nonce)
+		require.NoError(t, err)
+
+		_, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.EqualError(t, err, "update nonce[5] must be greater than last update nonce[5]")
+
+		nextNonce := nonce + 1
+
+		updateOp, _, err = getUpdateOperationWithNonce(ecsigner.New(nextUpdateKey, "ES256", updateKeyID), nextUpdateKey, uniqueSuffix, 3, &nextNonce)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.NoError(t, err)
+		require.Equal(t, nextNonce, rm.LastUpdateNonce)
+	})
+
 	t.Run("missing signed data error", func(t *testing.T) {
 		applier := New(p, parser, dc)
 
@@ -375,6 +419,777 @@ func TestUpdateDocument(t *testing.T) {
 	})
 }
 
+func TestApplier_TransactionTimes(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	createOp.TransactionTime = 1
+	createOp.TransactionNumber = 10
+
+	uniqueSuffix := createOp.UniqueSuffix
+
+	applier := New(p, parser, dc)
+
+	rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), rm.CreatedTransactionTime)
+	require.Equal(t, uint64(10), rm.CreatedTransactionNumber)
+	require.Equal(t, uint64(0), rm.UpdatedTransactionTime)
+
+	updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 3)
+	require.NoError(t, err)
+
+	updateOp.TransactionNumber = 30
+
+	rm, err = applier.Apply(updateOp, rm)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), rm.CreatedTransactionTime)
+	require.Equal(t, uint64(10), rm.CreatedTransactionNumber)
+	require.Equal(t, uint64(3), rm.UpdatedTransactionTime)
+	require.Equal(t, uint64(30), rm.UpdatedTransactionNumber)
+
+	recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 4)
+	require.NoError(t, err)
+
+	rm, err = applier.Apply(recoverOp, rm)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), rm.CreatedTransactionTime)
+	require.Equal(t, uint64(4), rm.UpdatedTransactionTime)
+
+	deactivateOp, err := getAnchoredDeactivateOperation(nextRecoveryKey, uniqueSuffix)
+	require.NoError(t, err)
+
+	deactivateOp.TransactionTime = 5
+	deactivateOp.TransactionNumber = 50
+
+	rm, err = applier.Apply(deactivateOp, rm)
+	require.NoError(t, err)
+	require.True(t, rm.Deactivated)
+	require.Equal(t, uint64(1), rm.CreatedTransactionTime)
+	require.Equal(t, uint64(10), rm.CreatedTransactionNumber)
+	require.Equal(t, uint64(5), rm.UpdatedTransactionTime)
+	require.Equal(t, uint64(50), rm.UpdatedTransactionNumber)
+}
+
+func TestUpdateDocument_PatchMode(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	uniqueSuffix := createOp.UniqueSuffix
+
+	validPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/test", "value": "special1"}]`)
+	require.NoError(t, err)
+
+	invalidPatch, err := patch.NewJSONPatch(`[{"op": "remove", "path": "/nonexistent"}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, uniqueSuffix, []patch.Patch{invalidPatch, validPatch}, nil)
+	require.NoError(t, err)
+
+	anchoredUpdateOp := getAnchoredOperation(updateOp)
+
+	t.Run("strict (default) - none of the patches are applied", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, protocol.PatchModeStrict, rm.PatchMode)
+
+		result, err := applier.Apply(anchoredUpdateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, rm.Doc, result.Doc)
+		require.NotEqual(t, rm.UpdateCommitment, result.UpdateCommitment)
+		require.Equal(t, protocol.PatchModeStrict, result.PatchMode)
+		require.Empty(t, result.SkippedPatches)
+	})
+
+	t.Run("lenient - invalid patch is skipped", func(t *testing.T) {
+		applier := New(p, parser, dc, WithPatchMode(protocol.PatchModeLenient))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, protocol.PatchModeLenient, rm.PatchMode)
+		require.Empty(t, rm.SkippedPatches)
+
+		result, err := applier.Apply(anchoredUpdateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, protocol.PatchModeLenient, result.PatchMode)
+		require.Equal(t, "special1", result.Doc["test"])
+		require.Len(t, result.SkippedPatches, 1)
+		require.Contains(t, result.SkippedPatches[0], "patch[0] 'ietf-json-patch' skipped")
+	})
+
+	t.Run("lenient - document composer error", func(t *testing.T) {
+		applier := New(p, parser, dc, WithPatchMode(protocol.PatchModeLenient))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		applier = New(p, parser, &mockDocComposer{Err: errors.New("document composer error")},
+			WithPatchMode(protocol.PatchModeLenient))
+
+		result, err := applier.Apply(anchoredUpdateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, rm.Doc, result.Doc)
+		require.Len(t, result.SkippedPatches, 2)
+		require.Contains(t, result.SkippedPatches[1], "patch[1] 'ietf-json-patch' skipped: document composer error")
+	})
+}
+
+func TestApplier_ErrorCodes(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	otherKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	uniqueSuffix := createOp.UniqueSuffix
+
+	validPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/test", "value": "special1"}]`)
+	require.NoError(t, err)
+
+	t.Run("rejected - update is first operation", func(t *testing.T) {
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		result, err := New(p, parser, dc).Apply(updateOp, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsRejectedError(err))
+		require.Contains(t, err.Error(), "update cannot be first operation")
+	})
+
+	t.Run("rejected - invalid signature", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(otherKey, "ES256", updateKeyID),
+			updateKey, uniqueSuffix, []patch.Patch{validPatch}, nil)
+		require.NoError(t, err)
+
+		result, err := applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Equal(t, protocol.ErrorCodeRejected, protocol.GetErrorCode(err))
+		require.Contains(t, err.Error(), "failed to check signature")
+	})
+
+	t.Run("rejected - operation type not supported", func(t *testing.T) {
+		result, err := New(p, parser, dc).Apply(&operation.AnchoredOperation{Type: "invalid"}, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsRejectedError(err))
+	})
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, uniqueSuffix, []patch.Patch{validPatch}, nil)
+	require.NoError(t, err)
+
+	anchoredUpdateOp := getAnchoredOperation(updateOp)
+
+	internalErr := protocol.NewInternalError(errors.New("storage not available"))
+
+	t.Run("internal - document composer error is not skipped", func(t *testing.T) {
+		rm, err := New(p, parser, dc).Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		applier := New(p, parser, &mockDocComposer{Err: internalErr})
+
+		result, err := applier.Apply(anchoredUpdateOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsInternalError(err))
+		require.Contains(t, err.Error(), "storage not available")
+
+		result, err = applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsInternalError(err))
+	})
+
+	t.Run("internal - document composer error is not skipped in lenient mode", func(t *testing.T) {
+		rm, err := New(p, parser, dc).Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		applier := New(p, parser, &mockDocComposer{Err: internalErr}, WithPatchMode(protocol.PatchModeLenient))
+
+		result, err := applier.Apply(anchoredUpdateOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsInternalError(err))
+	})
+}
+
+func TestApplier_KeyIDPolicy(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	validPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/test", "value": "special1"}]`)
+	require.NoError(t, err)
+
+	protocolWithPolicy := p
+	protocolWithPolicy.KeyIDPolicy = protocol.KeyIDPolicyThumbprint
+
+	policyParser := operationparser.New(protocolWithPolicy)
+	applier := New(protocolWithPolicy, policyParser, dc)
+
+	rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+	require.NoError(t, err)
+
+	t.Run("success - kid is signing key thumbprint", func(t *testing.T) {
+		thumbprint, err := getThumbprint(&updateKey.PublicKey)
+		require.NoError(t, err)
+
+		updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", thumbprint),
+			updateKey, createOp.UniqueSuffix, []patch.Patch{validPatch}, nil)
+		require.NoError(t, err)
+
+		anchoredOp := getAnchoredOperation(updateOp)
+
+		// submission
+		op, err := policyParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.NoError(t, err)
+		require.NotNil(t, op)
+
+		// resolution
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, "special1", result.Doc["test"])
+	})
+
+	t.Run("rejected - kid doesn't match signing key thumbprint", func(t *testing.T) {
+		updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+			updateKey, createOp.UniqueSuffix, []patch.Patch{validPatch}, nil)
+		require.NoError(t, err)
+
+		anchoredOp := getAnchoredOperation(updateOp)
+
+		// submission
+		op, err := policyParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+		require.Contains(t, err.Error(), "kid 'update-key' doesn't match signing key thumbprint")
+
+		// resolution
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsRejectedError(err))
+		require.Contains(t, err.Error(), "kid 'update-key' doesn't match signing key thumbprint")
+
+		// no key ID policy (default)
+		result, err = New(p, parser, dc).Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, "special1", result.Doc["test"])
+	})
+}
+
+func TestApplier_UnencodedPayload(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	validPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/test", "value": "special1"}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(&unencodedPayloadSigner{ecsigner.New(updateKey, "ES256", updateKeyID)},
+		updateKey, createOp.UniqueSuffix, []patch.Patch{validPatch}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - unencoded payload is allowed by protocol", func(t *testing.T) {
+		protocolWithUnencodedPayload := p
+		protocolWithUnencodedPayload.UnencodedPayload = true
+
+		unencodedParser := operationparser.New(protocolWithUnencodedPayload)
+		applier := New(protocolWithUnencodedPayload, unencodedParser, dc)
+
+		// submission
+		op, err := unencodedParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.NoError(t, err)
+		require.NotNil(t, op)
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, "special1", result.Doc["test"])
+	})
+
+	t.Run("rejected - unencoded payload is not allowed by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		// submission
+		op, err := parser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+		require.Contains(t, err.Error(), "invalid protected header")
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.True(t, protocol.IsRejectedError(err))
+	})
+}
+
+func TestApplier_PatchConflicts(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	// replace 'key1' by removing and adding it in the same delta
+	removeKey, err := patch.NewRemovePublicKeysPatch(`["key1"]`)
+	require.NoError(t, err)
+
+	addKey, err := patch.NewAddPublicKeysPatch(`[{"id": "key1", "type": "JsonWebKey2020", "purposes": ["authentication"],
+		"publicKeyJwk": {"kty": "EC", "crv": "P-256K", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+		"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, createOp.UniqueSuffix, []patch.Patch{removeKey, addKey}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - anchored delta with conflicting patches is resolved by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+
+		keys := result.Doc.PublicKeys()
+		require.Len(t, keys, 1)
+		require.Equal(t, "key1", keys[0].ID())
+		require.Equal(t, []string{"authentication"}, keys[0].Purpose())
+	})
+
+	t.Run("rejected - patch conflicts are validated", func(t *testing.T) {
+		protocolWithConflicts := p
+		protocolWithConflicts.ValidatePatchConflicts = true
+
+		conflictsParser := operationparser.New(protocolWithConflicts)
+		applier := New(protocolWithConflicts, conflictsParser, dc)
+
+		// submission
+		op, err := conflictsParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+		require.Contains(t, err.Error(), "conflicting patches in delta: public key 'key1'")
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "conflicting patches in delta: public key 'key1'")
+	})
+}
+
+func TestApplier_IdentityPaths(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	setController, err := patch.NewJSONPatch(`[{"op": "add", "path": "/controller", "value": "did:other:123"}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, createOp.UniqueSuffix, []patch.Patch{setController}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - anchored patch of identity path is applied by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+		require.Equal(t, "did:other:123", result.Doc[document.ControllerProperty])
+	})
+
+	t.Run("rejected - identity paths are protected", func(t *testing.T) {
+		protocolWithIdentityPaths := p
+		protocolWithIdentityPaths.ProtectIdentityPaths = true
+
+		identityPathsParser := operationparser.New(protocolWithIdentityPaths)
+		applier := New(protocolWithIdentityPaths, identityPathsParser, dc)
+
+		// submission
+		op, err := identityPathsParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+		require.Contains(t, err.Error(), "cannot modify protected path '/controller'")
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "cannot modify protected path '/controller'")
+	})
+}
+
+func TestApplier_DIDCommServices(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	addServices, err := patch.NewAddServiceEndpointsPatch(`[{"id": "didcomm", "type": "DIDCommMessaging",
+		"serviceEndpoint": "https://example.com/didcomm", "accept": "didcomm/v2"}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, createOp.UniqueSuffix, []patch.Patch{addServices}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - anchored DIDComm service is applied by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+
+		services := document.DidDocumentFromJSONLDObject(result.Doc).Services()
+		require.Len(t, services, 1)
+		require.Equal(t, "didcomm", services[0].ID())
+	})
+
+	t.Run("rejected - DIDComm services are validated", func(t *testing.T) {
+		protocolWithDIDComm := p
+		protocolWithDIDComm.ValidateDIDCommServices = true
+
+		didCommParser := operationparser.New(protocolWithDIDComm)
+		applier := New(protocolWithDIDComm, didCommParser, dc)
+
+		// submission
+		op, err := didCommParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+		require.Contains(t, err.Error(), "DIDCommMessaging service property 'accept' must be an array of strings")
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "DIDCommMessaging service property 'accept' must be an array of strings")
+	})
+}
+
+func TestApplier_ServiceEndpoints(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	addServices, err := patch.NewAddServiceEndpointsPatch(`[{"id": "svc", "type": "type", "serviceEndpoint": {}}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, createOp.UniqueSuffix, []patch.Patch{addServices}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - anchored service endpoint object is applied by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+
+		services := document.DidDocumentFromJSONLDObject(result.Doc).Services()
+		require.Len(t, services, 1)
+		require.Equal(t, "svc", services[0].ID())
+	})
+
+	t.Run("rejected - service endpoints are validated", func(t *testing.T) {
+		protocolWithEndpoints := p
+		protocolWithEndpoints.ValidateServiceEndpoints = true
+
+		endpointsParser := operationparser.New(protocolWithEndpoints)
+		applier := New(protocolWithEndpoints, endpointsParser, dc)
+
+		// submission
+		op, err := endpointsParser.Parse(mocks.DefaultNS, anchoredOp.OperationBuffer)
+		require.Error(t, err)
+		require.Nil(t, op)
+
+		// resolution
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestApplier_UniqueIDs(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	// service id collides with public key id 'key1' of created document
+	addServices, err := patch.NewAddServiceEndpointsPatch(
+		`[{"id": "key1", "type": "LinkedDomains", "serviceEndpoint": "https://example.com"}]`)
+	require.NoError(t, err)
+
+	updateOp, _, err := getUpdateOperationWithPatches(ecsigner.New(updateKey, "ES256", updateKeyID),
+		updateKey, createOp.UniqueSuffix, []patch.Patch{addServices}, nil)
+	require.NoError(t, err)
+
+	anchoredOp := getAnchoredOperation(updateOp)
+
+	t.Run("success - anchored update with id collision is applied by default", func(t *testing.T) {
+		applier := New(p, parser, dc)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+
+		services := document.DidDocumentFromJSONLDObject(result.Doc).Services()
+		require.Len(t, services, 1)
+		require.Equal(t, "key1", services[0].ID())
+	})
+
+	t.Run("success - id collision is validated (patches are not applied)", func(t *testing.T) {
+		protocolWithUniqueIDs := p
+		protocolWithUniqueIDs.ValidateUniqueIDs = true
+
+		applier := New(protocolWithUniqueIDs, operationparser.New(protocolWithUniqueIDs),
+			doccomposer.New(doccomposer.WithUniqueIDValidation(true)))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		result, err := applier.Apply(anchoredOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, updateOp.Delta.UpdateCommitment, result.UpdateCommitment)
+		require.Equal(t, rm.Doc, result.Doc)
+		require.Empty(t, document.DidDocumentFromJSONLDObject(result.Doc).Services())
+	})
+}
+
+func TestApplier_DocumentValidator(t *testing.T) {
+	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	updateKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, e)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	uniqueSuffix := createOp.UniqueSuffix
+
+	t.Run("success - resulting documents are valid", func(t *testing.T) {
+		validator := &mocks.DocumentValidator{}
+
+		applier := New(p, parser, dc, WithDocumentValidator(validator))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(updateOp, rm)
+		require.NoError(t, err)
+
+		recoverOp, _, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 2)
+		require.NoError(t, err)
+
+		_, err = applier.Apply(recoverOp, rm)
+		require.NoError(t, err)
+
+		require.Equal(t, 3, validator.IsValidOriginalDocumentCallCount())
+
+		docBytes := validator.IsValidOriginalDocumentArgsForCall(1)
+
+		doc, err := document.FromBytes(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, "special1", doc["test"])
+	})
+
+	invalidDocValidator := func() *mocks.DocumentValidator {
+		validator := &mocks.DocumentValidator{}
+		validator.IsValidOriginalDocumentReturns(errors.New("no keys"))
+
+		return validator
+	}
+
+	patchErrComposer := &mockDocComposer{Err: errors.New("patch error")}
+
+	t.Run("success - resulting create document is not valid (commitments are advanced)", func(t *testing.T) {
+		rm, err := New(p, parser, dc, WithDocumentValidator(invalidDocValidator())).
+			Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, make(document.Document), rm.Doc)
+		require.NotEmpty(t, rm.RecoveryCommitment)
+		require.NotEmpty(t, rm.UpdateCommitment)
+
+		// same as when patches cannot be applied
+		patchErrRM, err := New(p, parser, patchErrComposer).Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, patchErrRM, rm)
+	})
+
+	t.Run("success - resulting update document is not valid (update commitment is advanced)", func(t *testing.T) {
+		validator := &mocks.DocumentValidator{}
+		validator.IsValidOriginalDocumentReturnsOnCall(1, errors.New("no keys"))
+
+		var phases []Phase
+
+		applier := New(p, parser, dc, WithDocumentValidator(validator),
+			WithSpanStarter(func(ctx context.Context, phase Phase,
+				op *operation.AnchoredOperation) (context.Context, func(err error)) {
+				return ctx, func(err error) {
+					if phase == PhaseValidateDocument && err != nil {
+						phases = append(phases, phase)
+					}
+				}
+			}))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		result, err := applier.Apply(updateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, rm.Doc, result.Doc)
+		require.NotEqual(t, rm.UpdateCommitment, result.UpdateCommitment)
+		require.Equal(t, rm.RecoveryCommitment, result.RecoveryCommitment)
+		require.Equal(t, []Phase{PhaseValidateDocument}, phases)
+
+		// same as when patches cannot be applied
+		patchErrResult, err := New(p, parser, patchErrComposer).Apply(updateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, patchErrResult, result)
+	})
+
+	t.Run("success - resulting recover document is not valid (commitments are advanced)", func(t *testing.T) {
+		rm, err := New(p, parser, dc).Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		recoverOp, _, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		result, err := New(p, parser, dc, WithDocumentValidator(invalidDocValidator())).Apply(recoverOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, make(document.Document), result.Doc)
+		require.NotEmpty(t, result.UpdateCommitment)
+		require.NotEqual(t, rm.RecoveryCommitment, result.RecoveryCommitment)
+
+		// same as when patches cannot be applied
+		patchErrResult, err := New(p, parser, patchErrComposer).Apply(recoverOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, patchErrResult, result)
+	})
+
+	t.Run("error - internal document validator error", func(t *testing.T) {
+		validator := &mocks.DocumentValidator{}
+		validator.IsValidOriginalDocumentReturns(protocol.NewInternalError(errors.New("store not available")))
+
+		rm, err := New(p, parser, dc, WithDocumentValidator(validator)).Apply(createOp, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Nil(t, rm)
+		require.True(t, protocol.IsInternalError(err))
+		require.Contains(t, err.Error(), "store not available")
+	})
+}
+
 func TestDeactivate(t *testing.T) {
 	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -730,6 +1545,10 @@ func getAnchoredUpdateOperation(privateKey *ecdsa.PrivateKey, uniqueSuffix strin
 }
 
 func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey, uniqueSuffix string, operationNumber uint) (*model.Operation, *ecdsa.PrivateKey, error) {
+	return getUpdateOperationWithNonce(s, privateKey, uniqueSuffix, operationNumber, nil)
+}
+
+func getUpdateOperationWithNonce(s client.Signer, privateKey *ecdsa.PrivateKey, uniqueSuffix string, operationNumber uint, nonce *uint64) (*model.Operation, *ecdsa.PrivateKey, error) {
 	p := map[string]interface{}{
 		"op":    "replace",
 		"path":  "/test",
@@ -746,6 +1565,10 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 		return nil, nil, err
 	}
 
+	return getUpdateOperationWithPatches(s, privateKey, uniqueSuffix, []patch.Patch{jsonPatch}, nonce)
+}
+
+func getUpdateOperationWithPatches(s client.Signer, privateKey *ecdsa.PrivateKey, uniqueSuffix string, patches []patch.Patch, nonce *uint64) (*model.Operation, *ecdsa.PrivateKey, error) {
 	nextUpdateKey, updateCommitment, err := generateKeyAndCommitment()
 	if err != nil {
 		return nil, nil, err
@@ -753,7 +1576,7 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 
 	delta := &model.DeltaModel{
 		UpdateCommitment: updateCommitment,
-		Patches:          []patch.Patch{jsonPatch},
+		Patches:          patches,
 	}
 
 	deltaHash, err := hashing.CalculateModelMultihash(delta, sha2_256)
@@ -769,6 +1592,7 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 	signedData := &model.UpdateSignedDataModel{
 		DeltaHash: deltaHash,
 		UpdateKey: updatePubKey,
+		Nonce:     nonce,
 	}
 
 	jws, err := signutil.SignModel(signedData, s)
@@ -1035,6 +1859,39 @@ func getAnchoredCreateOperation(recoveryKey, updateKey *ecdsa.PrivateKey) (*oper
 	return getAnchoredOperation(op), nil
 }
 
+// unencodedPayloadSigner signs operations with unencoded payload (RFC 7797).
+type unencodedPayloadSigner struct {
+	*ecsigner.Signer
+}
+
+func (s *unencodedPayloadSigner) Headers() jws.Headers {
+	headers := s.Signer.Headers()
+	headers[jws.HeaderB64Payload] = false
+
+	return headers
+}
+
+func getThumbprint(key *ecdsa.PublicKey) (string, error) {
+	jwk, err := pubkey.GetPublicKeyJWK(key)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := canonicalizer.MarshalCanonical(map[string]string{
+		"crv": jwk.Crv,
+		"kty": jwk.Kty,
+		"x":   jwk.X,
+		"y":   jwk.Y,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(bytes)
+
+	return encoder.EncodeToString(hash[:]), nil
+}
+
 func getAnchoredOperation(op *model.Operation) *operation.AnchoredOperation {
 	anchoredOp, err := model.GetAnchoredOperation(op)
 	if err != nil {
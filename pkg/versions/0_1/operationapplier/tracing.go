@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationapplier
+
+import (
+	"context"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+// Phase is operation processing phase.
+type Phase string
+
+const (
+	// PhaseApply covers applying of the whole operation.
+	PhaseApply Phase = "apply"
+
+	// PhaseParse covers parsing of operation.
+	PhaseParse Phase = "parse"
+
+	// PhaseVerifySignature covers verifying signature of operation's signed data.
+	PhaseVerifySignature Phase = "verify-signature"
+
+	// PhaseApplyPatches covers applying operation's patches to the document (document composer).
+	PhaseApplyPatches Phase = "apply-patches"
+
+	// PhaseValidateDocument covers validating document produced by applying patches (document validator).
+	PhaseValidateDocument Phase = "validate-document"
+)
+
+// SpanStarter is invoked at the beginning of each operation processing phase with the context of the enclosing
+// phase (or the context passed to ApplyWithContext) and returns the context of the phase together with the function
+// that is invoked at the end of the phase with the phase error (if any). It can be used to create tracing spans
+// (e.g. OpenTelemetry) in order to see which phase dominates resolution latency.
+type SpanStarter func(ctx context.Context, phase Phase,
+	op *operation.AnchoredOperation) (context.Context, func(err error))
+
+// WithSpanStarter sets span starter that is invoked around operation processing phases.
+func WithSpanStarter(starter SpanStarter) Option {
+	return func(opts *Applier) {
+		opts.spanStarter = starter
+	}
+}
+
+func (s *Applier) startSpan(ctx context.Context, phase Phase,
+	op *operation.AnchoredOperation) (context.Context, func(err error)) {
+	if s.spanStarter == nil {
+		return ctx, func(error) {}
+	}
+
+	return s.spanStarter(ctx, phase, op)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationapplier
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+type span struct {
+	phase  Phase
+	parent *span
+	ended  bool
+	err    error
+}
+
+type spanKey struct{}
+
+type mockTracer struct {
+	spans []*span
+}
+
+func (m *mockTracer) startSpan(ctx context.Context, phase Phase,
+	_ *operation.AnchoredOperation) (context.Context, func(err error)) {
+	s := &span{phase: phase}
+
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.parent = parent
+	}
+
+	m.spans = append(m.spans, s)
+
+	return context.WithValue(ctx, spanKey{}, s), func(err error) {
+		s.ended = true
+		s.err = err
+	}
+}
+
+func (m *mockTracer) phases() []Phase {
+	var phases []Phase
+	for _, s := range m.spans {
+		phases = append(phases, s.phase)
+	}
+
+	return phases
+}
+
+func TestWithSpanStarter(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	t.Run("success - create and update", func(t *testing.T) {
+		tracer := &mockTracer{}
+
+		applier := New(p, parser, dc, WithSpanStarter(tracer.startSpan))
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, []Phase{PhaseApply, PhaseParse, PhaseApplyPatches}, tracer.phases())
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, createOp.UniqueSuffix, 1)
+		require.NoError(t, err)
+
+		tracer.spans = nil
+
+		_, err = applier.Apply(updateOp, rm)
+		require.NoError(t, err)
+		require.Equal(t, []Phase{PhaseApply, PhaseParse, PhaseVerifySignature, PhaseApplyPatches}, tracer.phases())
+
+		for _, s := range tracer.spans {
+			require.True(t, s.ended)
+			require.NoError(t, s.err)
+		}
+	})
+
+	t.Run("success - phase spans are children of apply span", func(t *testing.T) {
+		tracer := &mockTracer{}
+
+		root := &span{phase: "resolve"}
+
+		applier := New(p, parser, dc, WithSpanStarter(tracer.startSpan))
+
+		_, err := applier.ApplyWithContext(context.WithValue(context.Background(), spanKey{}, root),
+			createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+		require.Equal(t, []Phase{PhaseApply, PhaseParse, PhaseApplyPatches}, tracer.phases())
+
+		applySpan := tracer.spans[0]
+		require.Equal(t, root, applySpan.parent)
+
+		for _, s := range tracer.spans[1:] {
+			require.Equal(t, applySpan, s.parent)
+		}
+	})
+
+	t.Run("error - phase error is reported", func(t *testing.T) {
+		tracer := &mockTracer{}
+
+		applier := New(p, parser, dc, WithSpanStarter(tracer.startSpan))
+
+		_, err := applier.Apply(&operation.AnchoredOperation{Type: operation.TypeCreate}, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Equal(t, []Phase{PhaseApply, PhaseParse}, tracer.phases())
+
+		for _, s := range tracer.spans {
+			require.True(t, s.ended)
+			require.Error(t, s.err)
+		}
+	})
+
+	t.Run("error - operation type not supported", func(t *testing.T) {
+		tracer := &mockTracer{}
+
+		applier := New(p, parser, dc, WithSpanStarter(tracer.startSpan))
+
+		_, err := applier.Apply(&operation.AnchoredOperation{Type: "invalid"}, &protocol.ResolutionModel{})
+		require.Error(t, err)
+		require.Len(t, tracer.spans, 1)
+		require.True(t, tracer.spans[0].ended)
+		require.Error(t, tracer.spans[0].err)
+	})
+}
@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationparser
+
+import (
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+const (
+	publicKeyEntry    = "public key"
+	serviceEntry      = "service"
+	alsoKnownAsEntry  = "also known as uri"
+	relationshipEntry = "verification relationship"
+
+	// allEntries is recorded for patches that modify all entries of the given type (e.g. replace-services).
+	allEntries = "*"
+)
+
+type relationshipKey struct {
+	id     string
+	action patch.Action
+}
+
+// patchConflicts keeps track of document entries (per entry type) modified by patches within a single delta.
+type patchConflicts map[string]map[string]patch.Action
+
+// validatePatchConflicts checks that patches within a single delta don't modify the same entries
+// (e.g. add and remove the same public key) since the outcome would depend on the order of patches.
+func validatePatchConflicts(patches []patch.Patch) error {
+	conflicts := make(patchConflicts)
+
+	// public keys referenced by verification relationship patches
+	var relationshipKeys []relationshipKey
+
+	for _, ptch := range patches {
+		action, err := ptch.GetAction()
+		if err != nil {
+			return err
+		}
+
+		value, err := ptch.GetValue()
+		if err != nil {
+			return err
+		}
+
+		switch action {
+		case patch.AddPublicKeys:
+			err = conflicts.addAll(publicKeyEntry, publicKeyIDs(value), action)
+		case patch.RemovePublicKeys:
+			err = conflicts.addAll(publicKeyEntry, document.StringArray(value), action)
+		case patch.AddServiceEndpoints:
+			err = conflicts.addAll(serviceEntry, serviceIDs(value), action)
+		case patch.RemoveServiceEndpoints:
+			err = conflicts.addAll(serviceEntry, document.StringArray(value), action)
+		case patch.ReplaceServiceEndpoints, patch.Replace:
+			// replace-services and replace (services part of the document) modify all services
+			err = conflicts.addAny(serviceEntry, action)
+		case patch.AddAlsoKnownAs, patch.RemoveAlsoKnownAs:
+			err = conflicts.addAll(alsoKnownAsEntry, document.StringArray(value), action)
+		case patch.AddVerificationRelationships, patch.RemoveVerificationRelationships:
+			for _, relationship := range document.ParsePublicKeys(value) {
+				relationshipKeys = append(relationshipKeys, relationshipKey{id: relationship.ID(), action: action})
+
+				if err := conflicts.addAll(relationshipEntry, relationshipIDs(relationship), action); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// verification relationships cannot be modified for public keys that are added or removed in the same delta
+	for _, key := range relationshipKeys {
+		if existing, ok := conflicts[publicKeyEntry][key.id]; ok {
+			return newConflictError(publicKeyEntry, key.id, existing, key.action)
+		}
+	}
+
+	return nil
+}
+
+func (c patchConflicts) addAll(entryType string, ids []string, action patch.Action) error {
+	for _, id := range ids {
+		if err := c.add(entryType, id, action); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c patchConflicts) add(entryType, id string, action patch.Action) error {
+	entries := c.entries(entryType)
+
+	if existing, ok := entries[id]; ok && existing != action {
+		return newConflictError(entryType, id, existing, action)
+	}
+
+	if existing, ok := entries[allEntries]; ok && existing != action {
+		return newConflictError(entryType, id, existing, action)
+	}
+
+	entries[id] = action
+
+	return nil
+}
+
+// addAny records a patch that modifies all entries of the given type; it conflicts with any other patch
+// that modifies an entry of the same type.
+func (c patchConflicts) addAny(entryType string, action patch.Action) error {
+	entries := c.entries(entryType)
+
+	for id, existing := range entries {
+		if existing != action {
+			return newConflictError(entryType, id, existing, action)
+		}
+	}
+
+	entries[allEntries] = action
+
+	return nil
+}
+
+func (c patchConflicts) entries(entryType string) map[string]patch.Action {
+	entries, ok := c[entryType]
+	if !ok {
+		entries = make(map[string]patch.Action)
+		c[entryType] = entries
+	}
+
+	return entries
+}
+
+func publicKeyIDs(entry interface{}) []string {
+	var ids []string
+	for _, pk := range document.ParsePublicKeys(entry) {
+		ids = append(ids, pk.ID())
+	}
+
+	return ids
+}
+
+func serviceIDs(entry interface{}) []string {
+	var ids []string
+	for _, svc := range document.ParseServices(entry) {
+		ids = append(ids, svc.ID())
+	}
+
+	return ids
+}
+
+// relationshipIDs returns verification relationship (public key ID and purpose) identifiers.
+func relationshipIDs(relationship document.PublicKey) []string {
+	var ids []string
+	for _, purpose := range relationship.Purpose() {
+		ids = append(ids, relationship.ID()+" "+purpose)
+	}
+
+	return ids
+}
+
+func newConflictError(entryType, id string, action1, action2 patch.Action) error {
+	return fmt.Errorf("conflicting patches in delta: %s '%s' is modified by both '%s' and '%s' patches",
+		entryType, id, action1, action2)
+}
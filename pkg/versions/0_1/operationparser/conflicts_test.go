@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+const conflictKeys = `[{
+	"id": "key1",
+	"type": "JsonWebKey2020",
+	"publicKeyJwk": {
+		"kty": "EC",
+		"crv": "P-256K",
+		"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+		"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+	}
+}]`
+
+const conflictServices = `[{
+	"id": "svc1",
+	"type": "SecureDataStore",
+	"serviceEndpoint": "http://hub.my-personal-server.com"
+}]`
+
+func TestValidatePatchConflicts(t *testing.T) {
+	addKeys, err := patch.NewAddPublicKeysPatch(conflictKeys)
+	require.NoError(t, err)
+
+	removeKeys, err := patch.NewRemovePublicKeysPatch(`["key1"]`)
+	require.NoError(t, err)
+
+	addServices, err := patch.NewAddServiceEndpointsPatch(conflictServices)
+	require.NoError(t, err)
+
+	removeServices, err := patch.NewRemoveServiceEndpointsPatch(`["svc1"]`)
+	require.NoError(t, err)
+
+	t.Run("success - no conflicts", func(t *testing.T) {
+		removeOtherKey, err := patch.NewRemovePublicKeysPatch(`["key2"]`)
+		require.NoError(t, err)
+
+		addAuthentication, err := patch.NewAddVerificationRelationshipsPatch(
+			`[{"id": "key3", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		removeAssertion, err := patch.NewRemoveVerificationRelationshipsPatch(
+			`[{"id": "key3", "purposes": ["assertionMethod"]}]`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{addKeys, removeOtherKey, addServices,
+			addAuthentication, removeAssertion})
+		require.NoError(t, err)
+	})
+
+	t.Run("success - same patch twice", func(t *testing.T) {
+		err := validatePatchConflicts([]patch.Patch{addKeys, addKeys})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - add and remove same public key", func(t *testing.T) {
+		err := validatePatchConflicts([]patch.Patch{addKeys, removeKeys})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: public key 'key1' is modified by both 'add-public-keys' and 'remove-public-keys' patches")
+	})
+
+	t.Run("error - remove and add same service", func(t *testing.T) {
+		err := validatePatchConflicts([]patch.Patch{removeServices, addServices})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: service 'svc1' is modified by both 'remove-services' and 'add-services' patches")
+	})
+
+	t.Run("error - replace services and add or remove service", func(t *testing.T) {
+		replaceServices, err := patch.NewReplaceServiceEndpointsPatch(`[]`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{replaceServices, addServices})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: service 'svc1' is modified by both 'replace-services' and 'add-services' patches")
+
+		err = validatePatchConflicts([]patch.Patch{removeServices, replaceServices})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: service 'svc1' is modified by both 'remove-services' and 'replace-services' patches")
+
+		err = validatePatchConflicts([]patch.Patch{replaceServices, replaceServices, addKeys})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - replace document and add or remove service", func(t *testing.T) {
+		replace, err := patch.NewReplacePatch(`{"services": ` + conflictServices + `}`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{addServices, replace})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: service 'svc1' is modified by both 'add-services' and 'replace' patches")
+
+		err = validatePatchConflicts([]patch.Patch{replace, removeServices})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"conflicting patches in delta: service 'svc1' is modified by both 'replace' and 'remove-services' patches")
+	})
+
+	t.Run("error - add and remove same also known as uri", func(t *testing.T) {
+		addAlsoKnownAs, err := patch.NewAddAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		removeAlsoKnownAs, err := patch.NewRemoveAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{addAlsoKnownAs, removeAlsoKnownAs})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "also known as uri 'did:domain.com' is modified by both")
+	})
+
+	t.Run("error - add and remove same verification relationship", func(t *testing.T) {
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key3", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		remove, err := patch.NewRemoveVerificationRelationshipsPatch(
+			`[{"id": "key3", "purposes": ["assertionMethod", "authentication"]}]`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{add, remove})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification relationship 'key3 authentication' is modified by both")
+	})
+
+	t.Run("error - verification relationship for removed public key", func(t *testing.T) {
+		add, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		err = validatePatchConflicts([]patch.Patch{add, removeKeys})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"public key 'key1' is modified by both 'remove-public-keys' and 'add-verification-relationships' patches")
+	})
+
+	t.Run("error - missing action", func(t *testing.T) {
+		err := validatePatchConflicts([]patch.Patch{make(patch.Patch)})
+		require.Error(t, err)
+	})
+}
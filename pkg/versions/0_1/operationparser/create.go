@@ -12,7 +12,10 @@ import (
 	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
@@ -29,23 +32,25 @@ func (p *Parser) ParseCreateOperation(request []byte, batch bool) (*model.Operat
 	// create is not valid if suffix data is not valid
 	err = p.ValidateSuffixData(schema.SuffixData)
 	if err != nil {
-		return nil, err
+		return nil, protocol.WithPointer(suffixDataPointer, err)
 	}
 
 	if !batch {
 		err = p.ValidateDelta(schema.Delta)
 		if err != nil {
-			return nil, err
+			return nil, protocol.WithPointer(deltaPointer, err)
 		}
 
 		// verify actual delta hash matches expected delta hash
 		err = hashing.IsValidModelMultihash(schema.Delta, schema.SuffixData.DeltaHash)
 		if err != nil {
-			return nil, fmt.Errorf("delta doesn't match suffix data delta hash: %s", err.Error())
+			return nil, protocol.NewFieldError(suffixDataPointer+deltaHashPointer,
+				fmt.Errorf("delta doesn't match suffix data delta hash: %s", err.Error()))
 		}
 
 		if schema.Delta.UpdateCommitment == schema.SuffixData.RecoveryCommitment {
-			return nil, errors.New("recovery and update commitments cannot be equal, re-using public keys is not allowed")
+			return nil, protocol.NewFieldError(deltaPointer+updateCommitmentPointer,
+				errors.New("recovery and update commitments cannot be equal, re-using public keys is not allowed"))
 		}
 	}
 
@@ -81,31 +86,64 @@ func (p *Parser) ValidateDelta(delta *model.DeltaModel) error {
 	}
 
 	if len(delta.Patches) == 0 {
-		return errors.New("missing patches")
+		return protocol.NewFieldError(patchesPointer, errors.New("missing patches"))
 	}
 
-	for _, ptch := range delta.Patches {
-		action, err := ptch.GetAction()
-		if err != nil {
-			return err
-		}
+	if p.MaxPatchesPerDelta > 0 && len(delta.Patches) > int(p.MaxPatchesPerDelta) {
+		return protocol.NewFieldError(patchesPointer,
+			fmt.Errorf("number of patches[%d] exceeds maximum number of patches per delta[%d]",
+				len(delta.Patches), p.MaxPatchesPerDelta))
+	}
 
-		if !p.isPatchEnabled(action) {
-			return fmt.Errorf("%s patch action is not enabled", action)
+	for i, ptch := range delta.Patches {
+		if err := p.validatePatch(ptch); err != nil {
+			return protocol.WithPointer(fmt.Sprintf("%s/%d", patchesPointer, i), err)
 		}
+	}
 
-		if err := patchvalidator.Validate(ptch); err != nil {
-			return err
+	if p.ValidatePatchConflicts {
+		if err := validatePatchConflicts(delta.Patches); err != nil {
+			return protocol.NewFieldError(patchesPointer, err)
 		}
 	}
 
 	if err := p.validateMultihash(delta.UpdateCommitment, "update commitment"); err != nil {
-		return err
+		return protocol.NewFieldError(updateCommitmentPointer, err)
 	}
 
 	return p.validateDeltaSize(delta)
 }
 
+// validatePatch validates patch; returned error identifies the invalid field with JSON pointer relative
+// to the patch.
+func (p *Parser) validatePatch(ptch patch.Patch) error {
+	action, err := ptch.GetAction()
+	if err != nil {
+		return protocol.NewFieldError(actionPointer, err)
+	}
+
+	if !p.isPatchEnabled(action) {
+		return protocol.NewFieldError(actionPointer, fmt.Errorf("%s patch action is not enabled", action))
+	}
+
+	if err := p.validatePatchSize(action, ptch); err != nil {
+		return err
+	}
+
+	if err := patchvalidator.Validate(ptch, patchvalidator.WithProtectedPaths(p.JSONPatchProtectedPaths...),
+		patchvalidator.WithIdentityPathProtection(p.ProtectIdentityPaths),
+		patchvalidator.WithKeyPurposes(p.KeyPurposes...),
+		patchvalidator.WithKeyAlgorithms(p.DocumentKeyAlgorithms...),
+		patchvalidator.WithUniqueIDValidation(p.ValidateUniqueIDs),
+		patchvalidator.WithKeyMaterialValidation(p.ValidateKeyMaterial),
+		patchvalidator.WithDIDCommServiceValidation(p.ValidateDIDCommServices),
+		patchvalidator.WithServiceEndpointValidation(p.ValidateServiceEndpoints)); err != nil {
+		return err
+	}
+
+	return p.validatePatchConstraints(action, ptch)
+}
+
 func (p *Parser) validateMultihash(mh, alias string) error {
 	if len(mh) > int(p.MaxOperationHashLength) {
 		return fmt.Errorf("%s length[%d] exceeds maximum hash length[%d]", alias, len(mh), p.MaxOperationHashLength)
@@ -118,6 +156,14 @@ func (p *Parser) validateMultihash(mh, alias string) error {
 	return nil
 }
 
+func (p *Parser) validateSuffix(suffix string) error {
+	if !p.ValidateDIDSuffix {
+		return nil
+	}
+
+	return docutil.ValidateSuffix(suffix, p.MultihashAlgorithms, p.MaxOperationHashLength)
+}
+
 func (p *Parser) validateDeltaSize(delta *model.DeltaModel) error {
 	canonicalDelta, err := canonicalizer.MarshalCanonical(delta)
 	if err != nil {
@@ -125,7 +171,72 @@ func (p *Parser) validateDeltaSize(delta *model.DeltaModel) error {
 	}
 
 	if len(canonicalDelta) > int(p.MaxDeltaSize) {
-		return fmt.Errorf("delta size[%d] exceeds maximum delta size[%d]", len(canonicalDelta), p.MaxDeltaSize)
+		return protocol.NewCodedError(protocol.CodeDeltaExceedsMaximumSize,
+			fmt.Errorf("delta size[%d] exceeds maximum delta size[%d]", len(canonicalDelta), p.MaxDeltaSize))
+	}
+
+	return nil
+}
+
+func (p *Parser) validatePatchSize(action patch.Action, ptch patch.Patch) error {
+	if p.MaxPatchSize == 0 {
+		return nil
+	}
+
+	canonicalPatch, err := canonicalizer.MarshalCanonical(ptch)
+	if err != nil {
+		return fmt.Errorf("marshal canonical for %s patch failed: %s", action, err.Error())
+	}
+
+	if len(canonicalPatch) > int(p.MaxPatchSize) {
+		return fmt.Errorf("%s patch: patch size[%d] exceeds maximum patch size[%d]", action, len(canonicalPatch), p.MaxPatchSize)
+	}
+
+	return nil
+}
+
+// validatePatchConstraints validates per-action constraints configured in protocol.
+func (p *Parser) validatePatchConstraints(action patch.Action, ptch patch.Patch) error {
+	value, err := ptch.GetValue()
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case patch.AddPublicKeys:
+		return checkMaxCount(value, p.MaxPublicKeysPerPatch, "public keys", action)
+	case patch.AddServiceEndpoints, patch.ReplaceServiceEndpoints:
+		return checkMaxCount(value, p.MaxServicesPerPatch, "services", action)
+	case patch.JSONPatch:
+		return checkMaxCount(value, p.MaxJSONPatchOperations, "operations", action)
+	case patch.Replace:
+		replaceDoc, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		if err := checkMaxCount(replaceDoc[document.ReplacePublicKeyProperty], p.MaxPublicKeysPerPatch, "public keys", action); err != nil {
+			return err
+		}
+
+		return checkMaxCount(replaceDoc[document.ReplaceServiceProperty], p.MaxServicesPerPatch, "services", action)
+	}
+
+	return nil
+}
+
+func checkMaxCount(entry interface{}, max uint, alias string, action patch.Action) error {
+	if max == 0 {
+		return nil
+	}
+
+	arr, ok := entry.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	if len(arr) > int(max) {
+		return fmt.Errorf("%s patch: number of %s[%d] exceeds maximum[%d]", action, alias, len(arr), max)
 	}
 
 	return nil
@@ -148,10 +259,14 @@ func (p *Parser) ValidateSuffixData(suffixData *model.SuffixDataModel) error {
 	}
 
 	if err := p.validateMultihash(suffixData.RecoveryCommitment, "recovery commitment"); err != nil {
-		return err
+		return protocol.NewFieldError(recoveryCommitmentPointer, err)
 	}
 
-	return p.validateMultihash(suffixData.DeltaHash, "delta hash")
+	if err := p.validateMultihash(suffixData.DeltaHash, "delta hash"); err != nil {
+		return protocol.NewFieldError(deltaHashPointer, err)
+	}
+
+	return nil
 }
 
 func (p *Parser) validateCreateRequest(create *model.CreateRequest) error {
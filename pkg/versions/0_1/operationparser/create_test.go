@@ -8,6 +8,7 @@ package operationparser
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -82,6 +83,7 @@ func TestParseCreateOperation(t *testing.T) {
 		op, err := parser.ParseCreateOperation(request, true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "recovery commitment is not computed with the required hash algorithms: [18]")
+		require.Equal(t, "/suffixData/recoveryCommitment", protocol.GetPointer(err))
 		require.Nil(t, op)
 	})
 	t.Run("missing delta", func(t *testing.T) {
@@ -123,6 +125,26 @@ func TestParseCreateOperation(t *testing.T) {
 		op, err := parser.ParseCreateOperation(request, false)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "missing patches")
+		require.Equal(t, "/delta/patches", protocol.GetPointer(err))
+		require.Nil(t, op)
+	})
+
+	t.Run("error - invalid patch", func(t *testing.T) {
+		create, err := getCreateRequest()
+		require.NoError(t, err)
+
+		addKeys, err := patch.NewAddPublicKeysPatch(`[{"id":"key1","type":"JsonWebKey2020","purposes":["invalid"],"publicKeyJwk":{"kty":"EC","crv":"P-256K","x":"PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA","y":"nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}}]`)
+		require.NoError(t, err)
+
+		create.Delta.Patches = []patch.Patch{addKeys}
+
+		request, err := json.Marshal(create)
+		require.NoError(t, err)
+
+		op, err := parser.ParseCreateOperation(request, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: invalid")
+		require.Equal(t, "/delta/patches/0/publicKeys/0/purposes", protocol.GetPointer(err))
 		require.Nil(t, op)
 	})
 
@@ -220,6 +242,50 @@ func TestValidateDelta(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("error - conflicting patches", func(t *testing.T) {
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		removeKeys, err := patch.NewRemovePublicKeysPatch(`["key1"]`)
+		require.NoError(t, err)
+
+		delta.Patches = append(delta.Patches, removeKeys)
+
+		// conflicting patches are allowed by default
+		err = parser.ValidateDelta(delta)
+		require.NoError(t, err)
+
+		protocolWithConflicts := p
+		protocolWithConflicts.ValidatePatchConflicts = true
+
+		err = New(protocolWithConflicts).ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "conflicting patches in delta: public key 'key1'")
+	})
+
+	t.Run("error - invalid key material", func(t *testing.T) {
+		addKeys, err := patch.NewAddPublicKeysPatch(`[{"id": "key2", "type": "JsonWebKey2020", "purposes": ["authentication"],
+			"publicKeyJwk": {"kty": "EC", "crv": "P-256", "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA"}}]`)
+		require.NoError(t, err)
+
+		delta := &model.DeltaModel{
+			Patches:          []patch.Patch{addKeys},
+			UpdateCommitment: computeMultihash([]byte("updateReveal")),
+		}
+
+		// key material is not validated by default
+		err = parser.ValidateDelta(delta)
+		require.NoError(t, err)
+
+		protocolWithKeyMaterial := p
+		protocolWithKeyMaterial.ValidateKeyMaterial = true
+
+		err = New(protocolWithKeyMaterial).ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key 'key2': JWK is not a valid P-256 public key: point is not on curve")
+	})
+
 	t.Run("error - delta exceeds max delta size ", func(t *testing.T) {
 		parserWithLowMaxDeltaSize := New(protocol.Protocol{
 			MaxOperationHashLength: maxHashLength,
@@ -234,6 +300,7 @@ func TestValidateDelta(t *testing.T) {
 		err = parserWithLowMaxDeltaSize.ValidateDelta(delta)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "delta size[336] exceeds maximum delta size[50]")
+		require.Equal(t, protocol.CodeDeltaExceedsMaximumSize, protocol.GetCode(err))
 	})
 
 	t.Run("invalid next update commitment hash", func(t *testing.T) {
@@ -275,6 +342,151 @@ func TestValidateDelta(t *testing.T) {
 			"missing patches")
 	})
 
+	t.Run("error - number of patches exceeds maximum", func(t *testing.T) {
+		parserWithMaxPatches := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MaxDeltaSize:           maxDeltaSize,
+			MultihashAlgorithms:    []uint{sha2_256},
+			Patches:                patches,
+			MaxPatchesPerDelta:     1,
+		})
+
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		err = parserWithMaxPatches.ValidateDelta(delta)
+		require.NoError(t, err)
+
+		delta.Patches = append(delta.Patches, delta.Patches[0])
+
+		err = parserWithMaxPatches.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "number of patches[2] exceeds maximum number of patches per delta[1]")
+	})
+
+	t.Run("error - patch size exceeds maximum", func(t *testing.T) {
+		parserWithMaxPatchSize := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MaxDeltaSize:           maxDeltaSize,
+			MultihashAlgorithms:    []uint{sha2_256},
+			Patches:                patches,
+			MaxPatchSize:           50,
+		})
+
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		err = parserWithMaxPatchSize.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "patch size[")
+		require.Contains(t, err.Error(), "exceeds maximum patch size[50]")
+	})
+
+	t.Run("per patch constraints", func(t *testing.T) {
+		parserWithConstraints := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MaxDeltaSize:           maxDeltaSize,
+			MultihashAlgorithms:    []uint{sha2_256},
+			Patches:                patches,
+			MaxPublicKeysPerPatch:  1,
+			MaxServicesPerPatch:    1,
+			MaxJSONPatchOperations: 1,
+		})
+
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		err = parserWithConstraints.ValidateDelta(delta)
+		require.NoError(t, err)
+
+		addPublicKeys, err := patch.NewAddPublicKeysPatch(twoPublicKeys)
+		require.NoError(t, err)
+
+		delta.Patches = []patch.Patch{addPublicKeys}
+		err = parserWithConstraints.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-public-keys patch: number of public keys[2] exceeds maximum[1]")
+
+		addServices, err := patch.NewAddServiceEndpointsPatch(twoServices)
+		require.NoError(t, err)
+
+		delta.Patches = []patch.Patch{addServices}
+		err = parserWithConstraints.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-services patch: number of services[2] exceeds maximum[1]")
+
+		jsonPatch, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/name", "value": "Jane"}, {"op": "remove", "path": "/other"}]`)
+		require.NoError(t, err)
+
+		delta.Patches = []patch.Patch{jsonPatch}
+		err = parserWithConstraints.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ietf-json-patch patch: number of operations[2] exceeds maximum[1]")
+
+		replacePatch, err := patch.NewReplacePatch(fmt.Sprintf(`{"publicKeys": %s}`, twoPublicKeys))
+		require.NoError(t, err)
+
+		err = parserWithConstraints.validatePatchConstraints(patch.Replace, replacePatch)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "replace patch: number of public keys[2] exceeds maximum[1]")
+
+		replacePatch, err = patch.NewReplacePatch(fmt.Sprintf(`{"services": %s}`, twoServices))
+		require.NoError(t, err)
+
+		err = parserWithConstraints.validatePatchConstraints(patch.Replace, replacePatch)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "replace patch: number of services[2] exceeds maximum[1]")
+	})
+
+	t.Run("key purposes", func(t *testing.T) {
+		parserWithKeyPurposes := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MaxDeltaSize:           maxDeltaSize,
+			MultihashAlgorithms:    []uint{sha2_256},
+			Patches:                patches,
+			KeyPurposes:            []string{"custom"},
+		})
+
+		addPublicKeys, err := patch.NewAddPublicKeysPatch(customPurposeKey)
+		require.NoError(t, err)
+
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		delta.Patches = []patch.Patch{addPublicKeys}
+
+		err = parserWithKeyPurposes.ValidateDelta(delta)
+		require.NoError(t, err)
+
+		err = parser.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: custom")
+	})
+
+	t.Run("document key algorithms", func(t *testing.T) {
+		parserWithKeyAlgorithms := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MaxDeltaSize:           maxDeltaSize,
+			MultihashAlgorithms:    []uint{sha2_256},
+			Patches:                patches,
+			DocumentKeyAlgorithms:  []string{"OKP:Ed25519"},
+		})
+
+		addPublicKeys, err := patch.NewAddPublicKeysPatch(twoPublicKeys)
+		require.NoError(t, err)
+
+		delta, err := getDelta()
+		require.NoError(t, err)
+
+		delta.Patches = []patch.Patch{addPublicKeys}
+
+		err = parserWithKeyAlgorithms.ValidateDelta(delta)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key algorithm kty 'EC' crv 'P-256K' is not allowed")
+
+		require.NoError(t, parser.ValidateDelta(delta))
+	})
+
 	t.Run("error - invalid delta", func(t *testing.T) {
 		err := parser.validateDeltaSize(nil)
 		require.Error(t, err)
@@ -398,6 +610,56 @@ const validDoc = `{
 	]
 }`
 
+const customPurposeKey = `[
+	{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"purposes": ["custom"],
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	}
+]`
+
+const twoPublicKeys = `[
+	{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	},
+	{
+		"id": "key2",
+		"type": "JsonWebKey2020",
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	}
+]`
+
+const twoServices = `[
+	{
+		"id": "svc1",
+		"type": "IdentityHub",
+		"serviceEndpoint": "https://example.com/hub1"
+	},
+	{
+		"id": "svc2",
+		"type": "IdentityHub",
+		"serviceEndpoint": "https://example.com/hub2"
+	}
+]`
+
 // samples bellow are taken from reference implementation tests.
 const (
 	jcsRequest = `{"delta":{"patches":[{"action":"replace","document":{"publicKeys":[{"id":"anySigningKeyId","publicKeyJwk":{"crv":"secp256k1","kty":"EC","x":"H61vqAm_-TC3OrFSqPrEfSfg422NR8QHPqr0mLx64DM","y":"s0WnWY87JriBjbyoY3FdUmifK7JJRLR65GtPthXeyuc"},"purposes":["authentication"],"type":"EcdsaSecp256k1VerificationKey2019"}],"services":[{"serviceEndpoint":"http://any.endpoint","id":"anyServiceEndpointId","type":"anyType"}]}}],"updateCommitment":"EiBMWE2JFaFipPdthcFiQek-SXTMi5IWIFXAN8hKFCyLJw"},"suffixData":{"deltaHash":"EiBP6gAOxx3YOL8PZPZG3medFgdqWSDayVX3u1W2f-IPEQ","recoveryCommitment":"EiBg8oqvU0Zq_H5BoqmWf0IrhetQ91wXc5fDPpIjB9wW5w"},"type":"create"}`
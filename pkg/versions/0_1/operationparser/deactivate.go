@@ -12,7 +12,8 @@ import (
 	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
@@ -25,16 +26,17 @@ func (p *Parser) ParseDeactivateOperation(request []byte, batch bool) (*model.Op
 
 	signedData, err := p.ParseSignedDataForDeactivate(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, protocol.WithPointer(signedDataPointer, err)
 	}
 
 	if signedData.DidSuffix != schema.DidSuffix {
-		return nil, errors.New("signed did suffix mismatch for deactivate")
+		return nil, protocol.NewFieldError(signedDataPointer, errors.New("signed did suffix mismatch for deactivate"))
 	}
 
-	err = hashing.IsValidModelMultihash(signedData.RecoveryKey, schema.RevealValue)
+	err = commitment.VerifyRevealValue(signedData.RecoveryKey, schema.RevealValue)
 	if err != nil {
-		return nil, fmt.Errorf("canonicalized recovery public key hash doesn't match reveal value: %s", err.Error())
+		return nil, protocol.NewFieldError(revealValuePointer,
+			fmt.Errorf("canonicalized recovery public key hash doesn't match reveal value: %s", err.Error()))
 	}
 
 	return &model.Operation{
@@ -61,15 +63,7 @@ func (p *Parser) parseDeactivateRequest(payload []byte) (*model.DeactivateReques
 }
 
 func (p *Parser) validateDeactivateRequest(req *model.DeactivateRequest) error {
-	if req.DidSuffix == "" {
-		return errors.New("missing did suffix")
-	}
-
-	if req.SignedData == "" {
-		return errors.New("missing signed data")
-	}
-
-	return p.validateMultihash(req.RevealValue, "reveal value")
+	return p.validateRequest(req.DidSuffix, req.SignedData, req.RevealValue)
 }
 
 // ParseSignedDataForDeactivate will parse and validate signed data for deactivate.
@@ -89,5 +83,9 @@ func (p *Parser) ParseSignedDataForDeactivate(compactJWS string) (*model.Deactiv
 		return nil, fmt.Errorf("validate signed data for deactivate: %s", err.Error())
 	}
 
+	if err := p.validateKeyID(jws.ProtectedHeaders, signedData.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("validate signed data for deactivate: %s", err.Error())
+	}
+
 	return signedData, nil
 }
@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationparser
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// KeyIDValidator validates 'kid' protected header against the key that is expected to sign the operation
+// (update key for update; recovery key for recover and deactivate).
+type KeyIDValidator func(kid string, signingKey *jws.JWK) error
+
+// KeyIDThumbprintValidator is key ID policy that requires 'kid' (if present) to be
+// the JWK thumbprint (RFC 7638) of the signing key.
+func KeyIDThumbprintValidator(kid string, signingKey *jws.JWK) error {
+	thumbprint, err := getThumbprint(signingKey)
+	if err != nil {
+		return fmt.Errorf("calculate signing key thumbprint: %s", err.Error())
+	}
+
+	if kid != thumbprint {
+		return fmt.Errorf("kid '%s' doesn't match signing key thumbprint", kid)
+	}
+
+	return nil
+}
+
+// validateKeyID validates optional 'kid' protected header.
+func (p *Parser) validateKeyID(headers jws.Headers, signingKey *jws.JWK) error {
+	if _, ok := headers[jws.HeaderKeyID]; !ok {
+		// kid MAY be present in the protected header
+		return nil
+	}
+
+	kid, ok := headers.KeyID()
+	if !ok {
+		return errors.New("kid must be a string in the protected header")
+	}
+
+	if kid == "" || p.kidValidator == nil || signingKey == nil {
+		return nil
+	}
+
+	return p.kidValidator(kid, signingKey)
+}
+
+// getThumbprint calculates JWK thumbprint using required members only (https://tools.ietf.org/html/rfc7638)
+func getThumbprint(jwk *jws.JWK) (string, error) {
+	if jwk == nil {
+		return "", errors.New("missing key")
+	}
+
+	members := map[string]string{
+		"kty": jwk.Kty,
+	}
+
+	if jwk.Kty == jws.KeyTypeRSA {
+		members["e"] = jwk.E
+		members["n"] = jwk.N
+	} else {
+		members["crv"] = jwk.Crv
+		members["x"] = jwk.X
+
+		if jwk.Y != "" {
+			members["y"] = jwk.Y
+		}
+	}
+
+	bytes, err := canonicalizer.MarshalCanonical(members)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(bytes)
+
+	return encoder.EncodeToString(hash[:]), nil
+}
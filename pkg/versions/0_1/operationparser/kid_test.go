@@ -0,0 +1,157 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationparser
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
+)
+
+func TestValidateKeyID(t *testing.T) {
+	key := &jws.JWK{Kty: "EC", Crv: "P-256", X: "x", Y: "y"}
+
+	t.Run("success - kid not provided", func(t *testing.T) {
+		parser := New(protocol.Protocol{}, WithKeyIDValidator(KeyIDThumbprintValidator))
+
+		protected := make(jws.Headers)
+		protected[algKey] = "alg"
+
+		err := parser.validateKeyID(protected, key)
+		require.NoError(t, err)
+	})
+	t.Run("success - no policy configured", func(t *testing.T) {
+		parser := New(protocol.Protocol{})
+
+		err := parser.validateKeyID(getHeaders("alg", "kid"), key)
+		require.NoError(t, err)
+	})
+	t.Run("success - kid matches thumbprint", func(t *testing.T) {
+		parser := New(protocol.Protocol{}, WithKeyIDValidator(KeyIDThumbprintValidator))
+
+		thumbprint, err := getThumbprint(key)
+		require.NoError(t, err)
+
+		err = parser.validateKeyID(getHeaders("alg", thumbprint), key)
+		require.NoError(t, err)
+	})
+	t.Run("success - RSA key thumbprint", func(t *testing.T) {
+		// example from RFC 7638 section 3.1
+		rsaKey := &jws.JWK{
+			Kty: jws.KeyTypeRSA,
+			E:   "AQAB",
+			N: "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECP" +
+				"ebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2Qvz" +
+				"qY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu" +
+				"0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		}
+
+		thumbprint, err := getThumbprint(rsaKey)
+		require.NoError(t, err)
+		require.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+
+		require.NoError(t, KeyIDThumbprintValidator(thumbprint, rsaKey))
+	})
+	t.Run("error - kid doesn't match thumbprint", func(t *testing.T) {
+		parser := New(protocol.Protocol{}, WithKeyIDValidator(KeyIDThumbprintValidator))
+
+		err := parser.validateKeyID(getHeaders("alg", "kid"), key)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kid 'kid' doesn't match signing key thumbprint")
+	})
+	t.Run("error - custom policy", func(t *testing.T) {
+		parser := New(protocol.Protocol{}, WithKeyIDValidator(func(kid string, _ *jws.JWK) error {
+			return errors.New("custom error")
+		}))
+
+		err := parser.validateKeyID(getHeaders("alg", "kid"), key)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "custom error")
+	})
+	t.Run("error - kid is not a string", func(t *testing.T) {
+		parser := New(protocol.Protocol{})
+
+		protected := make(jws.Headers)
+		protected[algKey] = "alg"
+		protected[kidKey] = 1
+
+		err := parser.validateKeyID(protected, key)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "kid must be a string in the protected header")
+	})
+	t.Run("error - thumbprint of missing key", func(t *testing.T) {
+		err := KeyIDThumbprintValidator("kid", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing key")
+	})
+}
+
+func TestParseSignedDataWithKeyIDPolicy(t *testing.T) {
+	p := protocol.Protocol{
+		MaxOperationHashLength: maxHashLength,
+		MultihashAlgorithms:    []uint{sha2_256},
+		SignatureAlgorithms:    []string{"alg"},
+		KeyAlgorithms:          []string{"crv"},
+	}
+
+	parser := New(p, WithKeyIDValidator(KeyIDThumbprintValidator))
+
+	signedModel := model.UpdateSignedDataModel{
+		DeltaHash: computeMultihash([]byte("operation")),
+		UpdateKey: testJWK,
+	}
+
+	payload, err := json.Marshal(signedModel)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		thumbprint, err := getThumbprint(testJWK)
+		require.NoError(t, err)
+
+		signer := NewMockSigner()
+		signer.MockHeaders[kidKey] = thumbprint
+
+		compactJWS, err := signutil.SignPayload(payload, signer)
+		require.NoError(t, err)
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+	})
+	t.Run("error - kid doesn't match update key", func(t *testing.T) {
+		compactJWS, err := signutil.SignPayload(payload, NewMockSigner())
+		require.NoError(t, err)
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.Error(t, err)
+		require.Nil(t, schema)
+		require.Contains(t, err.Error(), "validate signed data for update: kid 'kid' doesn't match signing key thumbprint")
+	})
+	t.Run("error - protocol key ID policy", func(t *testing.T) {
+		protocolWithPolicy := p
+		protocolWithPolicy.KeyIDPolicy = protocol.KeyIDPolicyThumbprint
+
+		compactJWS, err := signutil.SignPayload(payload, NewMockSigner())
+		require.NoError(t, err)
+
+		schema, err := New(protocolWithPolicy).ParseSignedDataForUpdate(compactJWS)
+		require.Error(t, err)
+		require.Nil(t, schema)
+		require.Contains(t, err.Error(), "validate signed data for update: kid 'kid' doesn't match signing key thumbprint")
+
+		schema, err = New(p).ParseSignedDataForUpdate(compactJWS)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+	})
+}
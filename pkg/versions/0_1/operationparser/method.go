@@ -10,61 +10,111 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
-const (
-	longFormSeparator = ":"
-	didSeparator      = ":"
-)
+// LongFormDID contains components of parsed long-form DID.
+type LongFormDID struct {
+	// DID is short-form DID (namespace and unique suffix)
+	DID string
+
+	// Namespace is DID namespace
+	Namespace string
+
+	// UniqueSuffix is DID unique suffix
+	UniqueSuffix string
+
+	// CreateRequest is create request decoded from initial state
+	CreateRequest *model.CreateRequest
+}
 
 // ParseDID inspects resolution request and returns:
 // - did and create request in case of long form resolution
 // - just did in case of short form resolution (common scenario).
 func (p *Parser) ParseDID(namespace, shortOrLongFormDID string) (string, []byte, error) {
-	var err error
-
-	withoutNamespace := strings.ReplaceAll(shortOrLongFormDID, namespace+didSeparator, "")
-	posLongFormSeparator := strings.Index(withoutNamespace, longFormSeparator)
+	// initial state cannot be larger than create operation
+	did, createRequest, err := splitDID(namespace, shortOrLongFormDID,
+		encoder.WithStrict(), encoder.WithMaxDecodedSize(p.MaxOperationSize))
+	if err != nil {
+		return "", nil, err
+	}
 
-	if posLongFormSeparator == -1 {
+	if createRequest == nil {
 		// there is short form did
-		return shortOrLongFormDID, nil, nil
+		return did.ShortForm(), nil, nil
 	}
 
-	// long form format: '<namespace>:<unique-portion>:Base64url(JCS({suffix-data, delta}))'
-	endOfDIDPos := strings.LastIndex(shortOrLongFormDID, longFormSeparator)
+	createRequestBytes, err := canonicalizer.MarshalCanonical(createRequest)
+	if err != nil {
+		return "", nil, err
+	}
 
-	did := shortOrLongFormDID[0:endOfDIDPos]
-	longFormDID := shortOrLongFormDID[endOfDIDPos+1:]
+	// return did and initial state
+	return did.ShortForm(), createRequestBytes, nil
+}
 
-	createRequest, err := parseInitialState(longFormDID)
+// ParseLongFormDID parses long-form DID '<namespace>:<unique-suffix>:Base64url(JCS({suffix-data, delta}))'
+// and verifies that unique suffix has been computed from suffix data provided in initial state.
+func ParseLongFormDID(namespace, longFormDID string) (*LongFormDID, error) {
+	did, createRequest, err := splitDID(namespace, longFormDID, encoder.WithStrict())
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
-	createRequestBytes, err := canonicalizer.MarshalCanonical(createRequest)
+	if createRequest == nil {
+		return nil, errors.New("initial state is missing")
+	}
+
+	if createRequest.SuffixData == nil {
+		return nil, errors.New("initial state is missing suffix data")
+	}
+
+	err = hashing.IsValidModelMultihash(createRequest.SuffixData, did.UniqueSuffix)
 	if err != nil {
-		return "", nil, err
+		return nil, fmt.Errorf("unique suffix doesn't match initial state: %s", err.Error())
 	}
 
-	// return did and initial state
-	return did, createRequestBytes, nil
+	return &LongFormDID{
+		DID:           did.ShortForm(),
+		Namespace:     namespace,
+		UniqueSuffix:  did.UniqueSuffix,
+		CreateRequest: createRequest,
+	}, nil
 }
 
-// parse initial state will get create request from encoded initial value.
-func parseInitialState(initialState string) (*model.CreateRequest, error) {
-	decodedJCS, err := encoder.DecodeString(initialState)
+// splitDID parses did and decodes create request from initial state (long-form only).
+func splitDID(namespace, shortOrLongFormDID string, opts ...encoder.DecodeOption) (*docutil.DID, *model.CreateRequest, error) {
+	// long form format: '<namespace>:<unique-portion>:Base64url(JCS({suffix-data, delta}))'
+	did, err := docutil.ParseDID(namespace, shortOrLongFormDID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if !did.IsLongForm() {
+		// there is short form did
+		return did, nil, nil
 	}
 
-	fmt.Println(string(decodedJCS))
+	createRequest, err := parseInitialState(did.InitialState, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return did, createRequest, nil
+}
+
+// parse initial state will get create request from encoded initial value.
+func parseInitialState(initialState string, opts ...encoder.DecodeOption) (*model.CreateRequest, error) {
+	decodedJCS, err := encoder.DecodeString(initialState, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode initial state: %s", err.Error())
+	}
 
 	var createRequest model.CreateRequest
 	err = json.Unmarshal(decodedJCS, &createRequest)
@@ -77,8 +127,6 @@ func parseInitialState(initialState string) (*model.CreateRequest, error) {
 		return nil, err
 	}
 
-	fmt.Println(string(expected))
-
 	if encoder.EncodeToString(expected) != initialState {
 		return nil, errors.New("initial state is not valid")
 	}
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package operationparser
 
 import (
+	"encoding/base64"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
@@ -21,6 +23,8 @@ import (
 
 const (
 	docNS = "doc:method"
+
+	longFormSeparator = ":"
 )
 
 func TestParser_ParseDID(t *testing.T) {
@@ -80,11 +84,31 @@ func TestParser_ParseDID(t *testing.T) {
 	t.Run("error - initial state not encoded", func(t *testing.T) {
 		notEncoded := "not encoded"
 
-		did, initial, err := parser.ParseDID(namespace, testDID+longFormSeparator+notEncoded)
+		did, initial, err := parser.ParseDID(docNS, testDID+longFormSeparator+notEncoded)
+		require.Error(t, err)
+		require.Empty(t, did)
+		require.Nil(t, initial)
+		require.Contains(t, err.Error(), "failed to decode initial state: invalid base64url character")
+	})
+
+	t.Run("error - initial state padded", func(t *testing.T) {
+		padded := base64.URLEncoding.EncodeToString(append(reqBytes, ' '))
+
+		did, initial, err := parser.ParseDID(docNS, testDID+longFormSeparator+padded)
 		require.Error(t, err)
 		require.Empty(t, did)
 		require.Nil(t, initial)
-		require.Contains(t, err.Error(), "illegal base64 data")
+		require.Contains(t, err.Error(), "failed to decode initial state: padding is not allowed")
+	})
+
+	t.Run("error - initial state exceeds maximum operation size", func(t *testing.T) {
+		tooLarge := encoder.EncodeToString(make([]byte, p.Protocol.MaxOperationSize+1))
+
+		did, initial, err := parser.ParseDID(docNS, testDID+longFormSeparator+tooLarge)
+		require.Error(t, err)
+		require.Empty(t, did)
+		require.Nil(t, initial)
+		require.Contains(t, err.Error(), "failed to decode initial state: decoded size[2001] exceeds maximum size[2000]")
 	})
 
 	t.Run("error - initial state not JSON", func(t *testing.T) {
@@ -107,3 +131,70 @@ func TestParser_ParseDID(t *testing.T) {
 		require.Contains(t, err.Error(), "initial state is not valid")
 	})
 }
+
+func TestParseLongFormDID(t *testing.T) {
+	createReq, err := getCreateRequest()
+	require.NoError(t, err)
+
+	createReq.Operation = ""
+
+	reqBytes, err := canonicalizer.MarshalCanonical(createReq)
+	require.NoError(t, err)
+
+	initialState := encoder.EncodeToString(reqBytes)
+
+	suffix, err := model.GetUniqueSuffix(createReq.SuffixData, []uint{sha2_256})
+	require.NoError(t, err)
+
+	shortFormDID := docNS + docutil.NamespaceDelimiter + suffix
+
+	t.Run("success", func(t *testing.T) {
+		result, err := ParseLongFormDID(docNS, shortFormDID+longFormSeparator+initialState)
+		require.NoError(t, err)
+		require.Equal(t, shortFormDID, result.DID)
+		require.Equal(t, docNS, result.Namespace)
+		require.Equal(t, suffix, result.UniqueSuffix)
+		require.Equal(t, createReq.SuffixData, result.CreateRequest.SuffixData)
+		require.Equal(t, operation.TypeCreate, result.CreateRequest.Operation)
+	})
+
+	t.Run("error - short form did", func(t *testing.T) {
+		result, err := ParseLongFormDID(docNS, shortFormDID)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "initial state is missing")
+	})
+
+	t.Run("error - namespace mismatch", func(t *testing.T) {
+		result, err := ParseLongFormDID("other:method", shortFormDID+longFormSeparator+initialState)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "did must start with configured namespace")
+	})
+
+	t.Run("error - suffix doesn't match initial state", func(t *testing.T) {
+		otherSuffix := computeMultihash([]byte("other"))
+
+		result, err := ParseLongFormDID(docNS, docNS+docutil.NamespaceDelimiter+otherSuffix+longFormSeparator+initialState)
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "unique suffix doesn't match initial state")
+	})
+
+	t.Run("error - missing suffix data", func(t *testing.T) {
+		emptyReqBytes, err := canonicalizer.MarshalCanonical(model.CreateRequest{Delta: &model.DeltaModel{}})
+		require.NoError(t, err)
+
+		result, err := ParseLongFormDID(docNS, shortFormDID+longFormSeparator+encoder.EncodeToString(emptyReqBytes))
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "initial state is missing suffix data")
+	})
+
+	t.Run("error - initial state not encoded", func(t *testing.T) {
+		result, err := ParseLongFormDID(docNS, shortFormDID+longFormSeparator+"not encoded")
+		require.Error(t, err)
+		require.Nil(t, result)
+		require.Contains(t, err.Error(), "failed to decode initial state: invalid base64url character")
+	})
+}
@@ -20,16 +20,73 @@ import (
 
 var logger = log.New("sidetree-core-parser")
 
+// JSON pointers of request fields reported with validation errors (see protocol.FieldError).
+const (
+	typePointer               = "/type"
+	didSuffixPointer          = "/didSuffix"
+	revealValuePointer        = "/revealValue"
+	signedDataPointer         = "/signedData"
+	suffixDataPointer         = "/suffixData"
+	deltaPointer              = "/delta"
+	patchesPointer            = "/patches"
+	actionPointer             = "/action"
+	updateCommitmentPointer   = "/updateCommitment"
+	recoveryCommitmentPointer = "/recoveryCommitment"
+	deltaHashPointer          = "/deltaHash"
+)
+
 // Parser is an operation parser.
 type Parser struct {
 	protocol.Protocol
+
+	kidValidator KeyIDValidator
+
+	allowedHeaders   map[string]bool
+	forbiddenHeaders map[string]bool
+	criticalHeaders  map[string]bool
+}
+
+// Option is a parser instance option.
+type Option func(opts *Parser)
+
+// WithKeyIDValidator sets custom policy for validating 'kid' protected header against signing key (overrides
+// the validator selected by protocol key ID policy). The parser is used for resolution of anchored operations
+// as well so the same validator has to be configured on all nodes (e.g. factory.WithParserOptions).
+func WithKeyIDValidator(v KeyIDValidator) Option {
+	return func(opts *Parser) {
+		opts.kidValidator = v
+	}
 }
 
 // New returns a new operation parser.
-func New(p protocol.Protocol) *Parser {
-	return &Parser{
-		Protocol: p,
+func New(p protocol.Protocol, opts ...Option) *Parser {
+	parser := &Parser{
+		Protocol:         p,
+		allowedHeaders:   toSet(p.AllowedProtectedHeaders),
+		forbiddenHeaders: toSet(p.ForbiddenProtectedHeaders),
+		criticalHeaders:  toSet(p.CriticalHeaders),
+	}
+
+	if p.KeyIDPolicy == protocol.KeyIDPolicyThumbprint {
+		parser.kidValidator = KeyIDThumbprintValidator
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(parser)
 	}
+
+	return parser
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		set[v] = true
+	}
+
+	return set
 }
 
 // Parse parses and validates operation.
@@ -53,7 +110,8 @@ func (p *Parser) Parse(namespace string, operationBuffer []byte) (*operation.Ope
 func (p *Parser) ParseOperation(namespace string, operationBuffer []byte, batch bool) (*model.Operation, error) {
 	// check maximum operation size against protocol before parsing
 	if len(operationBuffer) > int(p.MaxOperationSize) {
-		return nil, fmt.Errorf("operation size[%d] exceeds maximum operation size[%d]", len(operationBuffer), int(p.MaxOperationSize))
+		return nil, protocol.NewCodedError(protocol.CodeOperationExceedsMaximumSize,
+			fmt.Errorf("operation size[%d] exceeds maximum operation size[%d]", len(operationBuffer), int(p.MaxOperationSize)))
 	}
 
 	schema := &operationSchema{}
@@ -74,7 +132,8 @@ func (p *Parser) ParseOperation(namespace string, operationBuffer []byte, batch
 	case operation.TypeRecover:
 		op, parseErr = p.ParseRecoverOperation(operationBuffer, batch)
 	default:
-		return nil, fmt.Errorf("parse operation: operation type [%s] not supported", schema.Operation)
+		return nil, protocol.NewFieldError(typePointer,
+			fmt.Errorf("parse operation: operation type [%s] not supported", schema.Operation))
 	}
 
 	if parseErr != nil {
@@ -81,6 +81,7 @@ func TestGetOperation(t *testing.T) {
 		op, err := New(invalid).Parse(namespace, operation)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "operation size[761] exceeds maximum operation size[20]")
+		require.Equal(t, protocol.CodeOperationExceedsMaximumSize, protocol.GetCode(err))
 		require.Nil(t, op)
 	})
 	t.Run("operation parsing error", func(t *testing.T) {
@@ -14,12 +14,21 @@ import (
 )
 
 // NewAddPublicKeysValidator creates new validator.
-func NewAddPublicKeysValidator() *AddPublicKeysValidator {
-	return &AddPublicKeysValidator{}
+func NewAddPublicKeysValidator(opts ...Option) *AddPublicKeysValidator {
+	o := getOptions(opts)
+
+	return &AddPublicKeysValidator{
+		allowedPurposes:   o.allowedPurposes(),
+		allowedAlgorithms: o.allowedAlgorithms(),
+		keyMaterial:       o.keyMaterial,
+	}
 }
 
 // AddPublicKeysValidator implements validator for "add-public-keys" patch.
 type AddPublicKeysValidator struct {
+	allowedPurposes   map[document.KeyPurpose]bool
+	allowedAlgorithms map[string]bool
+	keyMaterial       bool
 }
 
 // Validate validates patch.
@@ -36,5 +45,13 @@ func (v *AddPublicKeysValidator) Validate(p patch.Patch) error {
 
 	publicKeys := document.ParsePublicKeys(value)
 
-	return validatePublicKeys(publicKeys)
+	if err := validatePublicKeys(publicKeys, v.allowedPurposes); err != nil {
+		return err
+	}
+
+	if err := validateKeyAlgorithms(publicKeys, v.allowedAlgorithms); err != nil {
+		return err
+	}
+
+	return validatePublicKeysMaterial(publicKeys, v.keyMaterial)
 }
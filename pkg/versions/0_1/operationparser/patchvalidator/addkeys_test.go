@@ -1,10 +1,12 @@
 package patchvalidator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
@@ -16,6 +18,50 @@ func TestAddPublicKeysPatch(t *testing.T) {
 		err = NewAddPublicKeysValidator().Validate(p)
 		require.NoError(t, err)
 	})
+	t.Run("success - custom purpose allowed", func(t *testing.T) {
+		p, err := patch.NewAddPublicKeysPatch(strings.Replace(addPublicKeysValue, "assertionMethod", "custom", 1))
+		require.NoError(t, err)
+
+		err = NewAddPublicKeysValidator(WithKeyPurposes("custom")).Validate(p)
+		require.NoError(t, err)
+
+		err = Validate(p, WithKeyPurposes("custom"))
+		require.NoError(t, err)
+
+		err = NewAddPublicKeysValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: custom")
+	})
+	t.Run("error - standard purpose not allowed", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(addPublicKeysPatch))
+		require.NoError(t, err)
+
+		err = NewAddPublicKeysValidator(WithKeyPurposes(document.KeyPurposeAuthentication)).Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: assertionMethod")
+	})
+	t.Run("success - key algorithm allowed", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(addPublicKeysPatch))
+		require.NoError(t, err)
+
+		err = NewAddPublicKeysValidator(WithKeyAlgorithms(DefaultKeyAlgorithms()...)).Validate(p)
+		require.NoError(t, err)
+
+		err = Validate(p, WithKeyAlgorithms("EC:P-256K"))
+		require.NoError(t, err)
+	})
+	t.Run("error - key algorithm not allowed", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(addPublicKeysPatch))
+		require.NoError(t, err)
+
+		err = NewAddPublicKeysValidator(WithKeyAlgorithms("EC:P-256", "OKP:Ed25519")).Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key 'key1': key algorithm kty 'EC' crv 'P-256K' is not allowed")
+
+		err = ValidatePublicKeys(document.ParsePublicKeys(p[patch.PublicKeys]), WithKeyAlgorithms("EC:P-256"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key algorithm kty 'EC' crv 'P-256K' is not allowed")
+	})
 	t.Run("error - missing value", func(t *testing.T) {
 		p, err := patch.FromBytes([]byte(addPublicKeysPatch))
 		require.NoError(t, err)
@@ -50,3 +96,15 @@ const addPublicKeysPatch = `{
       }
    }]
 }`
+
+const addPublicKeysValue = `[{
+   "id": "key1",
+   "type": "JsonWebKey2020",
+   "purposes": ["assertionMethod"],
+   "publicKeyJwk": {
+      "kty": "EC",
+      "crv": "P-256K",
+      "x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+      "y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+   }
+}]`
@@ -14,12 +14,13 @@ import (
 )
 
 // NewAddServicesValidator creates new validator.
-func NewAddServicesValidator() *AddServicesValidator {
-	return &AddServicesValidator{}
+func NewAddServicesValidator(opts ...Option) *AddServicesValidator {
+	return &AddServicesValidator{rules: getOptions(opts).serviceRules()}
 }
 
 // AddServicesValidator implements validator for "add-public-keys" patch.
 type AddServicesValidator struct {
+	rules serviceRules
 }
 
 // Validate validates patch.
@@ -36,5 +37,5 @@ func (v *AddServicesValidator) Validate(p patch.Patch) error {
 
 	services := document.ParseServices(value)
 
-	return validateServices(services)
+	return validateServices(services, v.rules)
 }
@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// NewAlsoKnownAsValidator creates new validator.
+func NewAlsoKnownAsValidator() *AlsoKnownAsValidator {
+	return &AlsoKnownAsValidator{}
+}
+
+// AlsoKnownAsValidator implements validator for "add-also-known-as" and "remove-also-known-as" patches.
+type AlsoKnownAsValidator struct {
+}
+
+// Validate validates patch.
+func (v *AlsoKnownAsValidator) Validate(p patch.Patch) error {
+	action, err := p.GetAction()
+	if err != nil {
+		return err
+	}
+
+	value, err := p.GetValue()
+	if err != nil {
+		return err
+	}
+
+	genericArr, err := getRequiredArray(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %s", action, err.Error())
+	}
+
+	return validateAlsoKnownAs(genericArr)
+}
+
+func validateAlsoKnownAs(uris []interface{}) error {
+	values := make(map[string]bool)
+
+	for i, entry := range uris {
+		uri, ok := entry.(string)
+		if !ok {
+			return protocol.NewFieldError(pointer(i), fmt.Errorf("also known as uri is not a string: %v", entry))
+		}
+
+		if err := validateAlsoKnownAsURI(uri); err != nil {
+			return protocol.NewFieldError(pointer(i), err)
+		}
+
+		if _, ok := values[uri]; ok {
+			return protocol.NewFieldError(pointer(i), fmt.Errorf("duplicate uri in also known as: %s", uri))
+		}
+
+		values[uri] = true
+	}
+
+	return nil
+}
+
+func validateAlsoKnownAsURI(uri string) error {
+	if uri == "" {
+		return errors.New("also known as uri is empty")
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("also known as uri '%s' is not valid: %s", uri, err.Error())
+	}
+
+	if u.Scheme == "" {
+		return fmt.Errorf("also known as uri '%s' is missing scheme", uri)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestAlsoKnownAsPatch(t *testing.T) {
+	t.Run("success - add", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`["did:domain.com", "https://other.com"]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - remove", func(t *testing.T) {
+		p, err := patch.NewRemoveAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - missing uris", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.AddAlsoKnownAs
+
+		err := NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-also-known-as patch is missing key: uris")
+	})
+	t.Run("error - uris not array", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.RemoveAlsoKnownAs
+		p[patch.UrisKey] = "did:domain.com"
+
+		err := NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid remove-also-known-as value: expected array of interfaces")
+	})
+	t.Run("error - uri not string", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.AddAlsoKnownAs
+		p[patch.UrisKey] = []interface{}{1}
+
+		err := NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "also known as uri is not a string")
+	})
+	t.Run("error - empty uri", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`[""]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "also known as uri is empty")
+	})
+	t.Run("error - missing scheme", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`["domain.com"]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "also known as uri 'domain.com' is missing scheme")
+	})
+	t.Run("error - invalid uri", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`["http://in valid%"]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not valid")
+	})
+	t.Run("error - duplicate uri", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`["did:domain.com", "did:domain.com"]`)
+		require.NoError(t, err)
+
+		err = NewAlsoKnownAsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate uri in also known as: did:domain.com")
+	})
+}
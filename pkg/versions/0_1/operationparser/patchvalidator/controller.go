@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+const didPrefix = "did:"
+
+// NewControllerValidator creates new validator.
+func NewControllerValidator() *ControllerValidator {
+	return &ControllerValidator{}
+}
+
+// ControllerValidator implements validator for "set-controller" patch.
+type ControllerValidator struct {
+}
+
+// Validate validates patch.
+func (v *ControllerValidator) Validate(p patch.Patch) error {
+	value, err := p.GetValue()
+	if err != nil {
+		return err
+	}
+
+	return validateController(value)
+}
+
+// validateController validates controller value which is either a single DID or a set of DIDs
+// (empty set is allowed since it removes controller).
+func validateController(entry interface{}) error {
+	switch value := entry.(type) {
+	case string:
+		return validateControllerDID(value)
+	case []interface{}:
+		values := make(map[string]bool)
+
+		for i, e := range value {
+			controller, ok := e.(string)
+			if !ok {
+				return protocol.NewFieldError(pointer(i), fmt.Errorf("controller is not a string: %v", e))
+			}
+
+			if err := validateControllerDID(controller); err != nil {
+				return protocol.NewFieldError(pointer(i), err)
+			}
+
+			if _, ok := values[controller]; ok {
+				return protocol.NewFieldError(pointer(i), fmt.Errorf("duplicate controller: %s", controller))
+			}
+
+			values[controller] = true
+		}
+
+		return nil
+	default:
+		return errors.New("controller must be a string or an array of strings")
+	}
+}
+
+func validateControllerDID(controller string) error {
+	if controller == "" {
+		return errors.New("controller is empty")
+	}
+
+	// DID has to have method and method specific ID (did:<method>:<method-specific-id>)
+	parts := strings.SplitN(strings.TrimPrefix(controller, didPrefix), ":", 2)
+	if !strings.HasPrefix(controller, didPrefix) || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("controller '%s' is not a valid DID", controller)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestControllerPatch(t *testing.T) {
+	t.Run("success - single value", func(t *testing.T) {
+		p, err := patch.NewSetControllerPatch(`"did:example:123"`)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - set", func(t *testing.T) {
+		p, err := patch.NewSetControllerPatch(`["did:example:123", "did:other:456:789"]`)
+		require.NoError(t, err)
+
+		err = NewControllerValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - empty set", func(t *testing.T) {
+		p, err := patch.NewSetControllerPatch(`[]`)
+		require.NoError(t, err)
+
+		err = NewControllerValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - missing controller", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.SetController
+
+		err := NewControllerValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "set-controller patch is missing key: controller")
+	})
+	t.Run("error - invalid type", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.SetController
+		p[patch.ControllerKey] = 1
+
+		err := NewControllerValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "controller must be a string or an array of strings")
+
+		p[patch.ControllerKey] = []interface{}{1}
+
+		err = NewControllerValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "controller is not a string: 1")
+	})
+	t.Run("error - not a DID", func(t *testing.T) {
+		for _, controller := range []string{`"https://example.com"`, `"did:example"`, `"did::123"`, `["did:example:"]`} {
+			p, err := patch.NewSetControllerPatch(controller)
+			require.NoError(t, err)
+
+			err = NewControllerValidator().Validate(p)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is not a valid DID")
+		}
+	})
+	t.Run("error - empty controller", func(t *testing.T) {
+		p, err := patch.NewSetControllerPatch(`[""]`)
+		require.NoError(t, err)
+
+		err = NewControllerValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "controller is empty")
+	})
+	t.Run("error - duplicate controller", func(t *testing.T) {
+		p, err := patch.NewSetControllerPatch(`["did:example:123", "did:example:123"]`)
+		require.NoError(t, err)
+
+		err = NewControllerValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate controller: did:example:123")
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+// validateDIDCommService validates DIDComm v2 messaging service. Service endpoint is either URI
+// (with optional accept and routingKeys service properties) or object with required uri and optional
+// accept and routingKeys properties.
+func validateDIDCommService(service document.Service) error {
+	if err := validateDIDCommProperties(service); err != nil {
+		return err
+	}
+
+	endpoint := service.Endpoint()
+
+	if _, ok := endpoint.URI(); ok {
+		return nil
+	}
+
+	obj, ok := endpoint.AsMap()
+	if !ok {
+		return fmt.Errorf("%s service endpoint must be a URI or an object", document.DIDCommMessagingServiceType)
+	}
+
+	uri, ok := obj[document.URIProperty].(string)
+	if !ok {
+		return fmt.Errorf("%s service endpoint object must contain '%s' string",
+			document.DIDCommMessagingServiceType, document.URIProperty)
+	}
+
+	if err := document.ValidateServiceEndpointURI(uri); err != nil {
+		return err
+	}
+
+	return validateDIDCommProperties(obj)
+}
+
+func validateDIDCommProperties(obj map[string]interface{}) error {
+	if accept, ok := obj[document.AcceptProperty]; ok {
+		if err := validateStringArray(document.AcceptProperty, accept, validateAccept); err != nil {
+			return err
+		}
+	}
+
+	if routingKeys, ok := obj[document.RoutingKeysProperty]; ok {
+		if err := validateStringArray(document.RoutingKeysProperty, routingKeys, validateRoutingKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateStringArray(property string, entry interface{}, validate func(string) error) error {
+	values, ok := entry.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s service property '%s' must be an array of strings",
+			document.DIDCommMessagingServiceType, property)
+	}
+
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s service property '%s' must be an array of strings",
+				document.DIDCommMessagingServiceType, property)
+		}
+
+		if err := validate(str); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateAccept(accept string) error {
+	if accept == "" {
+		return errors.New("DIDComm accept value is empty")
+	}
+
+	return nil
+}
+
+// validateRoutingKey validates that routing key is either DID URL or relative reference to the key
+// in the same document.
+func validateRoutingKey(key string) error {
+	if strings.HasPrefix(key, "#") && len(key) > 1 {
+		return nil
+	}
+
+	if strings.HasPrefix(key, didPrefix) && strings.Contains(key, "#") {
+		return nil
+	}
+
+	return fmt.Errorf("DIDComm routing key '%s' must be a DID URL or relative reference", key)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestValidateDIDCommService(t *testing.T) {
+	t.Run("success - URI endpoint", func(t *testing.T) {
+		service := newDIDCommService("https://example.com/didcomm")
+		service[document.AcceptProperty] = []interface{}{"didcomm/v2"}
+		service[document.RoutingKeysProperty] = []interface{}{"did:example:mediator#key1", "#key2"}
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("success - object endpoint", func(t *testing.T) {
+		service := newDIDCommService(map[string]interface{}{
+			"uri":         "https://example.com/didcomm",
+			"accept":      []interface{}{"didcomm/v2", "didcomm/aip2;env=rfc587"},
+			"routingKeys": []interface{}{"did:example:mediator#key1"},
+		})
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - object endpoint without uri", func(t *testing.T) {
+		service := newDIDCommService(map[string]interface{}{
+			"accept": []interface{}{"didcomm/v2"},
+		})
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service endpoint object must contain 'uri' string")
+	})
+
+	t.Run("error - object endpoint with invalid uri", func(t *testing.T) {
+		service := newDIDCommService(map[string]interface{}{
+			"uri": "invalid",
+		})
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service endpoint 'invalid' is not a valid URI")
+	})
+
+	t.Run("error - endpoint is not URI or object", func(t *testing.T) {
+		service := newDIDCommService(true)
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true, endpoints: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service endpoint must be a URI, an object or an array")
+
+		err = validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service endpoint must be a URI or an object")
+
+		err = validateDIDCommService(service)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service endpoint must be a URI or an object")
+	})
+
+	t.Run("success - not validated by default", func(t *testing.T) {
+		service := newDIDCommService("https://example.com/didcomm")
+		service[document.AcceptProperty] = "didcomm/v2"
+
+		err := validateServices([]document.Service{service}, serviceRules{})
+		require.NoError(t, err)
+
+		p, err := patch.NewAddServiceEndpointsPatch(`[{"id": "didcomm", "type": "DIDCommMessaging",
+			"serviceEndpoint": "https://example.com/didcomm", "accept": "didcomm/v2"}]`)
+		require.NoError(t, err)
+
+		require.NoError(t, Validate(p))
+
+		err = Validate(p, WithDIDCommServiceValidation(true))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service property 'accept' must be an array of strings")
+	})
+
+	t.Run("error - accept is not an array", func(t *testing.T) {
+		service := newDIDCommService("https://example.com/didcomm")
+		service[document.AcceptProperty] = "didcomm/v2"
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service property 'accept' must be an array of strings")
+	})
+
+	t.Run("error - empty accept", func(t *testing.T) {
+		service := newDIDCommService(map[string]interface{}{
+			"uri":    "https://example.com/didcomm",
+			"accept": []interface{}{""},
+		})
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDComm accept value is empty")
+	})
+
+	t.Run("error - routing key is not a string", func(t *testing.T) {
+		service := newDIDCommService("https://example.com/didcomm")
+		service[document.RoutingKeysProperty] = []interface{}{1}
+
+		err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDCommMessaging service property 'routingKeys' must be an array of strings")
+	})
+
+	t.Run("error - invalid routing key", func(t *testing.T) {
+		for _, key := range []string{"key1", "#", "did:example:mediator"} {
+			service := newDIDCommService(map[string]interface{}{
+				"uri":         "https://example.com/didcomm",
+				"routingKeys": []interface{}{key},
+			})
+
+			err := validateServices([]document.Service{service}, serviceRules{didComm: true})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "DIDComm routing key '"+key+"' must be a DID URL or relative reference")
+		}
+	})
+}
+
+func newDIDCommService(endpoint interface{}) document.Service {
+	return document.Service{
+		"id":              "didcomm",
+		"type":            "DIDCommMessaging",
+		"serviceEndpoint": endpoint,
+	}
+}
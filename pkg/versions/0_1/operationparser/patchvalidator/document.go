@@ -9,10 +9,11 @@ package patchvalidator
 import (
 	"errors"
 	"fmt"
-	"net/url"
 	"regexp"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 )
 
 // nolint:gochecknoglobals
@@ -26,6 +27,8 @@ const (
 	ecdsaSecp256k1VerificationKey2019 = "EcdsaSecp256k1VerificationKey2019"
 	x25519KeyAgreementKey2019         = "X25519KeyAgreementKey2019"
 	ed25519VerificationKey2018        = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020        = "Ed25519VerificationKey2020"
+	bls12381G2Key2020                 = "Bls12381G2Key2020"
 
 	// public keys, services id length.
 	maxIDLength = 50
@@ -33,7 +36,8 @@ const (
 	maxServiceTypeLength = 30
 )
 
-var allowedPurposes = map[document.KeyPurpose]bool{
+// defaultPurposes are standard public key purposes.
+var defaultPurposes = map[document.KeyPurpose]bool{
 	document.KeyPurposeAuthentication:       true,
 	document.KeyPurposeAssertionMethod:      true,
 	document.KeyPurposeKeyAgreement:         true,
@@ -48,7 +52,9 @@ var allowedKeyTypesGeneral = existenceMap{
 	jsonWebKey2020:                    jsonWebKey2020,
 	ecdsaSecp256k1VerificationKey2019: ecdsaSecp256k1VerificationKey2019,
 	ed25519VerificationKey2018:        ed25519VerificationKey2018,
+	ed25519VerificationKey2020:        ed25519VerificationKey2020,
 	x25519KeyAgreementKey2019:         x25519KeyAgreementKey2019,
+	bls12381G2Key2020:                 bls12381G2Key2020,
 }
 
 var allowedKeyTypesVerification = existenceMap{
@@ -56,6 +62,8 @@ var allowedKeyTypesVerification = existenceMap{
 	jsonWebKey2020:                    jsonWebKey2020,
 	ecdsaSecp256k1VerificationKey2019: ecdsaSecp256k1VerificationKey2019,
 	ed25519VerificationKey2018:        ed25519VerificationKey2018,
+	ed25519VerificationKey2020:        ed25519VerificationKey2020,
+	bls12381G2Key2020:                 bls12381G2Key2020,
 }
 
 var allowedKeyTypesAgreement = existenceMap{
@@ -74,35 +82,69 @@ var allowedKeyTypes = map[string]existenceMap{
 	document.KeyPurposeCapabilityInvocation: allowedKeyTypesVerification,
 }
 
-// validatePublicKeys validates public keys.
-func validatePublicKeys(pubKeys []document.PublicKey) error {
+// ValidatePublicKeys validates public keys (e.g. keys modified while applying patches).
+func ValidatePublicKeys(pubKeys []document.PublicKey, opts ...Option) error {
+	o := getOptions(opts)
+
+	if err := validatePublicKeys(pubKeys, o.allowedPurposes()); err != nil {
+		return err
+	}
+
+	if err := validateKeyAlgorithms(pubKeys, o.allowedAlgorithms()); err != nil {
+		return err
+	}
+
+	return validatePublicKeysMaterial(pubKeys, o.keyMaterial)
+}
+
+// validatePublicKeys validates public keys; returned error identifies the invalid key (and property) with
+// JSON pointer relative to the public keys array.
+func validatePublicKeys(pubKeys []document.PublicKey, allowedPurposes map[document.KeyPurpose]bool) error {
 	ids := make(map[string]bool)
 
-	for _, pubKey := range pubKeys {
-		if err := validatePublicKeyProperties(pubKey); err != nil {
-			return err
+	for i, pubKey := range pubKeys {
+		if err := validatePublicKey(pubKey, ids, allowedPurposes); err != nil {
+			return protocol.WithPointer(pointer(i), err)
 		}
+	}
 
-		kid := pubKey.ID()
-		if err := validateID(kid); err != nil {
-			return fmt.Errorf("public key: %s", err.Error())
-		}
+	return nil
+}
 
-		if _, ok := ids[kid]; ok {
-			return fmt.Errorf("duplicate public key id: %s", kid)
-		}
-		ids[kid] = true
+func validatePublicKey(pubKey document.PublicKey, ids map[string]bool, allowedPurposes map[document.KeyPurpose]bool) error {
+	if err := validatePublicKeyProperties(pubKey); err != nil {
+		return err
+	}
 
-		if err := validateKeyPurposes(pubKey); err != nil {
-			return err
-		}
+	kid := pubKey.ID()
+	if err := validateID(kid); err != nil {
+		return protocol.NewFieldError(pointer(document.IDProperty), fmt.Errorf("public key: %s", err.Error()))
+	}
 
-		if !validateKeyTypePurpose(pubKey) {
-			return fmt.Errorf("invalid key type: %s", pubKey.Type())
-		}
+	if _, ok := ids[kid]; ok {
+		return protocol.NewFieldError(pointer(document.IDProperty), fmt.Errorf("duplicate public key id: %s", kid))
+	}
+	ids[kid] = true
+
+	if err := validateKeyPurposes(pubKey, allowedPurposes); err != nil {
+		return protocol.NewFieldError(pointer(document.PurposesProperty), err)
+	}
+
+	if err := validateKeyController(pubKey); err != nil {
+		return protocol.NewFieldError(pointer(document.ControllerProperty), err)
+	}
 
-		if err := validateJWK(pubKey.PublicKeyJwk()); err != nil {
-			return err
+	if !validateKeyTypePurpose(pubKey) {
+		return protocol.NewFieldError(pointer(document.TypeProperty), fmt.Errorf("invalid key type: %s", pubKey.Type()))
+	}
+
+	if err := validateJWK(pubKey.PublicKeyJwk()); err != nil {
+		return protocol.NewFieldError(pointer(document.PublicKeyJwkProperty), err)
+	}
+
+	if pubKey.Type() == bls12381G2Key2020 {
+		if err := validateBLS12381G2JWK(pubKey.PublicKeyJwk()); err != nil {
+			return protocol.NewFieldError(pointer(document.PublicKeyJwkProperty), err)
 		}
 	}
 
@@ -111,18 +153,18 @@ func validatePublicKeys(pubKeys []document.PublicKey) error {
 
 func validatePublicKeyProperties(pubKey document.PublicKey) error {
 	requiredKeys := []string{document.TypeProperty, document.IDProperty, document.PublicKeyJwkProperty}
-	optionalKeys := []string{document.PurposesProperty}
+	optionalKeys := []string{document.PurposesProperty, document.ControllerProperty}
 	allowedKeys := append(requiredKeys, optionalKeys...)
 
 	for _, required := range requiredKeys {
 		if _, ok := pubKey[required]; !ok {
-			return fmt.Errorf("key '%s' is required for public key", required)
+			return protocol.NewFieldError(pointer(required), fmt.Errorf("key '%s' is required for public key", required))
 		}
 	}
 
 	for key := range pubKey {
 		if !contains(allowedKeys, key) {
-			return fmt.Errorf("key '%s' is not allowed for public key", key)
+			return protocol.NewFieldError(pointer(key), fmt.Errorf("key '%s' is not allowed for public key", key))
 		}
 	}
 
@@ -142,16 +184,23 @@ func validateID(id string) error {
 	return nil
 }
 
-// validateServices validates services.
-func validateServices(services []document.Service) error {
+// serviceRules contains optional service validation rules.
+type serviceRules struct {
+	didComm   bool
+	endpoints bool
+}
+
+// validateServices validates services; returned error identifies the invalid service (and property) with
+// JSON pointer relative to the services array.
+func validateServices(services []document.Service, rules serviceRules) error {
 	ids := make(map[string]bool)
-	for _, service := range services {
-		if err := validateService(service); err != nil {
-			return err
+	for i, service := range services {
+		if err := validateService(service, rules); err != nil {
+			return protocol.WithPointer(pointer(i), err)
 		}
 
 		if _, ok := ids[service.ID()]; ok {
-			return fmt.Errorf("duplicate service id: %s", service.ID())
+			return protocol.NewFieldError(pointer(i, document.IDProperty), fmt.Errorf("duplicate service id: %s", service.ID()))
 		}
 
 		ids[service.ID()] = true
@@ -160,19 +209,23 @@ func validateServices(services []document.Service) error {
 	return nil
 }
 
-func validateService(service document.Service) error {
+func validateService(service document.Service, rules serviceRules) error {
 	// expected fields are type, id, and serviceEndpoint and some optional fields
 
 	if err := validateServiceID(service.ID()); err != nil {
-		return err
+		return protocol.NewFieldError(pointer(document.IDProperty), err)
 	}
 
 	if err := validateServiceType(service.Type()); err != nil {
-		return err
+		return protocol.NewFieldError(pointer(document.TypeProperty), err)
 	}
 
-	if err := validateServiceEndpoint(service.ServiceEndpoint()); err != nil {
-		return err
+	if err := validateServiceEndpoint(service.Endpoint(), rules.endpoints); err != nil {
+		return protocol.NewFieldError(pointer(document.ServiceEndpointProperty), err)
+	}
+
+	if rules.didComm && service.Type() == document.DIDCommMessagingServiceType {
+		return validateDIDCommService(service)
 	}
 
 	return nil
@@ -202,31 +255,21 @@ func validateServiceType(serviceType string) error {
 	return nil
 }
 
-func validateServiceEndpoint(serviceEndpoint interface{}) error {
-	if serviceEndpoint == nil {
+func validateServiceEndpoint(serviceEndpoint document.ServiceEndpoint, strict bool) error {
+	if serviceEndpoint.IsEmpty() {
 		return errors.New("service endpoint is missing")
 	}
 
-	uri, ok := serviceEndpoint.(string)
-	if ok {
-		return validateURI(uri)
-	}
-
-	_, ok = serviceEndpoint.([]interface{})
-	if ok {
+	if _, ok := serviceEndpoint.AsList(); ok {
 		return errors.New("service endpoint cannot be an array of objects")
 	}
 
-	return nil
-}
-
-func validateURI(uri string) error {
-	if uri == "" {
-		return errors.New("service endpoint URI is empty")
+	if strict {
+		return serviceEndpoint.Validate()
 	}
 
-	if _, err := url.ParseRequestURI(uri); err != nil {
-		return fmt.Errorf("service endpoint '%s' is not a valid URI: %s", uri, err.Error())
+	if uri, ok := serviceEndpoint.URI(); ok {
+		return document.ValidateServiceEndpointURI(uri)
 	}
 
 	return nil
@@ -245,7 +288,8 @@ func validateKeyTypePurpose(pubKey document.PublicKey) bool {
 	for _, purpose := range pubKey.Purpose() {
 		allowed, ok := allowedKeyTypes[purpose]
 		if !ok {
-			return false
+			// custom purpose
+			allowed = allowedKeyTypesGeneral
 		}
 
 		_, ok = allowed[pubKey.Type()]
@@ -266,9 +310,47 @@ func validateJWK(jwk document.JWK) error {
 	return jwk.Validate()
 }
 
+// validateKeyController validates optional public key controller (if different from DID subject).
+func validateKeyController(pubKey document.PublicKey) error {
+	controller, ok := pubKey[document.ControllerProperty]
+	if !ok {
+		return nil
+	}
+
+	controllerStr, ok := controller.(string)
+	if !ok {
+		return fmt.Errorf("public key controller is not a string: %v", controller)
+	}
+
+	if err := validateControllerDID(controllerStr); err != nil {
+		return fmt.Errorf("public key: %s", err.Error())
+	}
+
+	return nil
+}
+
+// validateBLS12381G2JWK validates that JWK contains BLS12-381 G2 public key.
+func validateBLS12381G2JWK(jwk document.JWK) error {
+	if !jwk.IsBLS12381G2() {
+		return fmt.Errorf("%s key must have kty '%s' and crv '%s'",
+			bls12381G2Key2020, document.BLS12381G2Kty, document.BLS12381G2Crv)
+	}
+
+	x, err := encoder.DecodeString(jwk.X())
+	if err != nil {
+		return fmt.Errorf("%s key has invalid x: %s", bls12381G2Key2020, err.Error())
+	}
+
+	if len(x) != document.BLS12381G2PublicKeySize {
+		return fmt.Errorf("%s key must be %d bytes long", bls12381G2Key2020, document.BLS12381G2PublicKeySize)
+	}
+
+	return nil
+}
+
 // The object MAY include a purposes property, and if included, its value MUST be an array of one or more
 // of the strings listed in allowed purposes array.
-func validateKeyPurposes(pubKey document.PublicKey) error {
+func validateKeyPurposes(pubKey document.PublicKey, allowedPurposes map[document.KeyPurpose]bool) error {
 	_, exists := pubKey[document.PurposesProperty]
 
 	if exists && len(pubKey.Purpose()) == 0 {
@@ -299,9 +381,9 @@ func contains(values []string, value string) bool {
 }
 
 func validateIds(ids []string) error {
-	for _, id := range ids {
+	for i, id := range ids {
 		if err := validateID(id); err != nil {
-			return err
+			return protocol.NewFieldError(pointer(i), err)
 		}
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
 )
 
 func TestValidatePublicKeys(t *testing.T) {
@@ -27,7 +28,7 @@ func TestValidatePublicKeys(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes(data)
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Nil(t, err)
 	})
 
@@ -35,7 +36,15 @@ func TestValidatePublicKeys(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(noPurpose))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
+		require.NoError(t, err)
+	})
+
+	t.Run("success - with controller", func(t *testing.T) {
+		pk := createMockPublicKeyWithTypeAndPurpose(jsonWebKey2020, []interface{}{document.KeyPurposeAuthentication})
+		pk[document.ControllerProperty] = "did:example:123"
+
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.NoError(t, err)
 	})
 }
@@ -45,7 +54,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(emptyPurpose))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "if 'purposes' key is specified, it must contain at least one purpose")
 	})
@@ -53,7 +62,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(wrongPurpose))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "invalid purpose")
 	})
@@ -61,7 +70,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(tooMuchPurpose))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "public key purpose exceeds maximum length")
 	})
@@ -69,7 +78,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(invalidKeyType))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "invalid key type")
 	})
@@ -77,7 +86,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(noID))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "key 'id' is required for public key")
 	})
@@ -85,7 +94,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(idLong))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "public key: id exceeds maximum length")
 	})
@@ -93,7 +102,7 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(duplicateID))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "duplicate public key id")
 	})
@@ -102,10 +111,31 @@ func TestValidatePublicKeysErrors(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(moreProperties))
 		require.Nil(t, err)
 
-		err = validatePublicKeys(doc.PublicKeys())
+		err = validatePublicKeys(doc.PublicKeys(), defaultPurposes)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "key 'other' is not allowed for public key")
 	})
+
+	t.Run("invalid controller", func(t *testing.T) {
+		pk := createMockPublicKeyWithTypeAndPurpose(jsonWebKey2020, []interface{}{document.KeyPurposeAuthentication})
+		pk[document.ControllerProperty] = "controller"
+
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key: controller 'controller' is not a valid DID")
+
+		pk[document.ControllerProperty] = ""
+
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key: controller is empty")
+
+		pk[document.ControllerProperty] = []interface{}{"did:example:123"}
+
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key controller is not a string")
+	})
 }
 
 func TestValidateServices(t *testing.T) {
@@ -113,14 +143,14 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDoc))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.NoError(t, err)
 	})
 	t.Run("error - duplicate service id", func(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocWithDuplicateServices))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "duplicate service id: sid-123_ABC")
 	})
@@ -128,14 +158,14 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocOptionalProperty))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.NoError(t, err)
 	})
 	t.Run("error - missing service id", func(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocNoID))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service id is missing")
 	})
@@ -143,7 +173,7 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocNoType))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service type is missing")
 	})
@@ -151,28 +181,45 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocEndpointMissing))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service endpoint is missing")
 	})
 	t.Run("success - service endpoint is an object", func(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocEndpointIsAnObject))
 		require.NoError(t, err)
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.NoError(t, err)
 	})
 	t.Run("error - service endpoint cannot be an array of objects", func(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocEndpointIsAnArrayOfObjects))
 		require.NoError(t, err)
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service endpoint cannot be an array of objects")
 	})
+	t.Run("success - service endpoint objects are not validated by default", func(t *testing.T) {
+		for _, endpoint := range []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"uri": 1},
+			map[string]interface{}{"uri": "invalid"},
+			true,
+			10.0,
+		} {
+			service := document.Service{"id": "svc", "type": "type", "serviceEndpoint": endpoint}
+
+			err := validateServices([]document.Service{service}, serviceRules{})
+			require.NoError(t, err, endpoint)
+
+			err = validateServices([]document.Service{service}, serviceRules{endpoints: true})
+			require.Error(t, err, endpoint)
+		}
+	})
 	t.Run("error - empty service endpoint URI", func(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocNoServiceEndpointURI))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service endpoint URI is empty")
 	})
@@ -180,7 +227,7 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocLongID))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service: id exceeds maximum length")
 	})
@@ -188,7 +235,7 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocLongType))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service type exceeds maximum length")
 	})
@@ -196,14 +243,14 @@ func TestValidateServices(t *testing.T) {
 		doc, err := document.DidDocumentFromBytes([]byte(serviceDocEndpointNotURI))
 		require.NoError(t, err)
 
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "service endpoint 'hello' is not a valid URI")
 	})
 	t.Run("success - didcomm service", func(t *testing.T) {
 		doc, err := document.DIDDocumentFromReader(reader(t, "testdata/doc.json"))
 		require.NoError(t, err)
-		err = validateServices(doc.Services())
+		err = validateServices(doc.Services(), serviceRules{})
 		require.NoError(t, err)
 	})
 }
@@ -252,21 +299,77 @@ func TestValidateJWK(t *testing.T) {
 	})
 }
 
+func TestBLS12381G2Key(t *testing.T) {
+	blsX := encoder.EncodeToString(make([]byte, document.BLS12381G2PublicKeySize))
+
+	t.Run("success", func(t *testing.T) {
+		pk := createMockPublicKeyWithTypeAndPurpose(bls12381G2Key2020, []interface{}{document.KeyPurposeAssertionMethod})
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - not allowed for key agreement", func(t *testing.T) {
+		pk := createMockPublicKeyWithTypeAndPurpose(bls12381G2Key2020, []interface{}{document.KeyPurposeKeyAgreement})
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid key type: Bls12381G2Key2020")
+	})
+
+	t.Run("error - invalid curve", func(t *testing.T) {
+		pk := createMockPublicKeyWithType(bls12381G2Key2020)
+		pk[document.PublicKeyJwkProperty] = map[string]interface{}{
+			"kty": "EC",
+			"crv": "P-256",
+			"x":   blsX,
+		}
+
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Bls12381G2Key2020 key must have kty 'EC' and crv 'BLS12381_G2'")
+	})
+
+	t.Run("error - invalid x encoding", func(t *testing.T) {
+		pk := createMockPublicKeyWithType(bls12381G2Key2020)
+		pk[document.PublicKeyJwkProperty] = map[string]interface{}{
+			"kty": "EC",
+			"crv": "BLS12381_G2",
+			"x":   "!!!",
+		}
+
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Bls12381G2Key2020 key has invalid x")
+	})
+
+	t.Run("error - invalid x size", func(t *testing.T) {
+		pk := createMockPublicKeyWithType(bls12381G2Key2020)
+		pk[document.PublicKeyJwkProperty] = map[string]interface{}{
+			"kty": "EC",
+			"crv": "BLS12381_G2",
+			"x":   "eA",
+		}
+
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Bls12381G2Key2020 key must be 96 bytes long")
+	})
+}
+
 func TestGeneralKeyPurpose(t *testing.T) {
 	for _, pubKeyType := range allowedKeyTypesAgreement {
 		pk := createMockPublicKeyWithType(pubKeyType)
-		err := validatePublicKeys([]document.PublicKey{pk})
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.NoError(t, err, "valid purpose for type")
 	}
 
 	pk := createMockPublicKeyWithTypeAndPurpose("invalid", []interface{}{document.KeyPurposeAuthentication})
-	err := validatePublicKeys([]document.PublicKey{pk})
+	err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 	require.Error(t, err, "invalid purpose for type")
 }
 
 func TestInvalidKeyPurpose(t *testing.T) {
 	pk := createMockPublicKeyWithTypeAndPurpose(jsonWebKey2020, []interface{}{"invalidpurpose"})
-	err := validatePublicKeys([]document.PublicKey{pk})
+	err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 	require.Error(t, err, "invalid purpose")
 }
 
@@ -291,11 +394,11 @@ func reader(t *testing.T, filename string) io.Reader {
 func testKeyPurpose(t *testing.T, allowedKeys existenceMap, pubKeyPurpose string) {
 	for _, pubKeyType := range allowedKeys {
 		pk := createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose})
-		err := validatePublicKeys([]document.PublicKey{pk})
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.NoError(t, err, "valid purpose for type")
 
 		pk = createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose})
-		err = validatePublicKeys([]document.PublicKey{pk})
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.NoError(t, err, "valid purpose for type")
 	}
 
@@ -306,34 +409,46 @@ func testKeyPurpose(t *testing.T, allowedKeys existenceMap, pubKeyPurpose string
 		}
 
 		pk := createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose, document.KeyPurposeKeyAgreement})
-		err := validatePublicKeys([]document.PublicKey{pk})
+		err := validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.Error(t, err, "invalid purpose for type")
 
 		pk = createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose, document.KeyPurposeAssertionMethod})
-		err = validatePublicKeys([]document.PublicKey{pk})
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.Error(t, err, "invalid purpose for type")
 
 		pk = createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose})
-		err = validatePublicKeys([]document.PublicKey{pk})
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.Error(t, err, "invalid purpose for type")
 
 		pk = createMockPublicKeyWithTypeAndPurpose(pubKeyType, []interface{}{pubKeyPurpose})
-		err = validatePublicKeys([]document.PublicKey{pk})
+		err = validatePublicKeys([]document.PublicKey{pk}, defaultPurposes)
 		require.Error(t, err, "invalid purpose for type")
 	}
 }
 
+func createMockJWK(pubKeyType string) map[string]interface{} {
+	if pubKeyType == bls12381G2Key2020 {
+		return map[string]interface{}{
+			"kty": "EC",
+			"crv": "BLS12381_G2",
+			"x":   encoder.EncodeToString(make([]byte, document.BLS12381G2PublicKeySize)),
+		}
+	}
+
+	return map[string]interface{}{
+		"kty": "kty",
+		"crv": "crv",
+		"x":   "x",
+		"y":   "y",
+	}
+}
+
 func createMockPublicKeyWithTypeAndPurpose(pubKeyType string, purpose []interface{}) document.PublicKey {
 	pk := map[string]interface{}{
-		"id":       "key1",
-		"type":     pubKeyType,
-		"purposes": purpose,
-		"publicKeyJwk": map[string]interface{}{
-			"kty": "kty",
-			"crv": "crv",
-			"x":   "x",
-			"y":   "y",
-		},
+		"id":           "key1",
+		"type":         pubKeyType,
+		"purposes":     purpose,
+		"publicKeyJwk": createMockJWK(pubKeyType),
 	}
 
 	return pk
@@ -341,14 +456,9 @@ func createMockPublicKeyWithTypeAndPurpose(pubKeyType string, purpose []interfac
 
 func createMockPublicKeyWithType(pubKeyType string) document.PublicKey {
 	pk := map[string]interface{}{
-		"id":   "key1",
-		"type": pubKeyType,
-		"publicKeyJwk": map[string]interface{}{
-			"kty": "kty",
-			"crv": "crv",
-			"x":   "x",
-			"y":   "y",
-		},
+		"id":           "key1",
+		"type":         pubKeyType,
+		"publicKeyJwk": createMockJWK(pubKeyType),
 	}
 
 	return pk
@@ -8,22 +8,44 @@ package patchvalidator
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
+// identityPaths contains document paths that cannot be modified by JSON patch if identity path protection
+// is enabled.
+// nolint:gochecknoglobals
+var identityPaths = []string{
+	"/" + document.IDProperty,
+	"/" + document.ControllerProperty,
+}
+
 // NewJSONValidator creates new validator.
-func NewJSONValidator() *JSONValidator {
-	return &JSONValidator{}
+func NewJSONValidator(opts ...Option) *JSONValidator {
+	options := getOptions(opts)
+
+	protectedPaths := append([]string{}, options.protectedPaths...)
+	if options.identityPaths {
+		protectedPaths = append(protectedPaths, identityPaths...)
+	}
+
+	return &JSONValidator{
+		protectedPaths: protectedPaths,
+		identityPaths:  options.identityPaths,
+	}
 }
 
 // JSONValidator implements validator for "ietf-json-patch" patch.
 type JSONValidator struct {
+	protectedPaths []string
+	identityPaths  bool
 }
 
 // Validate validates patch.
@@ -43,32 +65,83 @@ func (v *JSONValidator) Validate(p patch.Patch) error {
 		return err
 	}
 
-	return validateJSONPatches(patchesBytes)
+	return v.validateJSONPatches(patchesBytes)
 }
 
-func validateJSONPatches(patches []byte) error {
+func (v *JSONValidator) validateJSONPatches(patches []byte) error {
 	jsonPatches, err := jsonpatch.DecodePatch(patches)
 	if err != nil {
 		return fmt.Errorf("%s: %s", patch.JSONPatch, err.Error())
 	}
 
-	for _, p := range jsonPatches {
-		pathMsg, ok := p["path"]
-		if !ok {
-			return fmt.Errorf("%s: path not found", patch.JSONPatch)
+	for i, p := range jsonPatches {
+		path, err := getOperationValue(p, "path")
+		if err != nil {
+			return protocol.NewFieldError(pointer(i, "path"), err)
 		}
 
-		var path string
-		if err := json.Unmarshal(*pathMsg, &path); err != nil {
-			return fmt.Errorf("%s: invalid path", patch.JSONPatch)
+		if err := v.validatePath(path); err != nil {
+			return protocol.NewFieldError(pointer(i, "path"), fmt.Errorf("%s: %s", patch.JSONPatch, err.Error()))
 		}
 
-		if strings.HasPrefix(path, "/"+document.ServiceProperty) {
-			return fmt.Errorf("%s: cannot modify services", patch.JSONPatch)
+		// operation and 'from' location are validated only with identity path protection (otherwise
+		// invalid operation is reported when patch is applied)
+		if !v.identityPaths {
+			continue
 		}
 
-		if strings.HasPrefix(path, "/"+document.PublicKeyProperty) {
-			return fmt.Errorf("%s: cannot modify public keys", patch.JSONPatch)
+		op, err := getOperationValue(p, "op")
+		if err != nil {
+			return protocol.NewFieldError(pointer(i, "op"), err)
+		}
+
+		// move removes value from 'from' location so 'from' location must not be protected either
+		// (missing 'from' is reported when patch is applied)
+		if _, ok := p["from"]; ok && op == "move" {
+			from, err := getOperationValue(p, "from")
+			if err != nil {
+				return protocol.NewFieldError(pointer(i, "from"), err)
+			}
+
+			if err := v.validatePath(from); err != nil {
+				return protocol.NewFieldError(pointer(i, "from"), fmt.Errorf("%s: %s", patch.JSONPatch, err.Error()))
+			}
+		}
+	}
+
+	return nil
+}
+
+func getOperationValue(op map[string]*json.RawMessage, key string) (string, error) {
+	msg, ok := op[key]
+	if !ok || msg == nil {
+		return "", fmt.Errorf("%s: %s not found", patch.JSONPatch, key)
+	}
+
+	var value string
+	if err := json.Unmarshal(*msg, &value); err != nil {
+		return "", fmt.Errorf("%s: invalid %s", patch.JSONPatch, key)
+	}
+
+	return value, nil
+}
+
+func (v *JSONValidator) validatePath(path string) error {
+	if path == "" && v.identityPaths {
+		return errors.New("cannot modify document root")
+	}
+
+	if strings.HasPrefix(path, "/"+document.ServiceProperty) {
+		return errors.New("cannot modify services")
+	}
+
+	if strings.HasPrefix(path, "/"+document.PublicKeyProperty) {
+		return errors.New("cannot modify public keys")
+	}
+
+	for _, protected := range v.protectedPaths {
+		if path == protected || strings.HasPrefix(path, protected+"/") {
+			return fmt.Errorf("cannot modify protected path '%s'", protected)
 		}
 	}
 
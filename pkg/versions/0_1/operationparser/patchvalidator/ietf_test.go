@@ -40,6 +40,106 @@ func TestIETFPatch(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify public keys")
 	})
+	t.Run("error - cannot modify id", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/id", "value": "did:other:123"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify protected path '/id'")
+	})
+	t.Run("error - cannot modify controller subtree", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "add", "path": "/controller/0", "value": "did:other:123"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify protected path '/controller'")
+	})
+	t.Run("success - path with protected path prefix", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "add", "path": "/identifier", "value": "value"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - cannot modify document root", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "replace", "path": "", "value": {}}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify document root")
+	})
+	t.Run("error - move from protected path", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "move", "from": "/publicKey", "path": "/keys"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify public keys")
+	})
+	t.Run("error - copy into protected path", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "copy", "from": "/name", "path": "/id"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify protected path '/id'")
+	})
+	t.Run("success - copy from protected path", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "copy", "from": "/id", "path": "/name"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - configured protected path", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "remove", "path": "/alsoKnownAs/0"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator().Validate(p)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithProtectedPaths("/alsoKnownAs")).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify protected path '/alsoKnownAs'")
+
+		err = Validate(p, WithProtectedPaths("/alsoKnownAs"))
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: cannot modify protected path '/alsoKnownAs'")
+	})
+	t.Run("success - identity paths are not protected by default", func(t *testing.T) {
+		for _, ops := range []string{
+			`[{"op": "replace", "path": "/id", "value": "did:other:123"}]`,
+			`[{"op": "add", "path": "/controller/0", "value": "did:other:123"}]`,
+			`[{"op": "replace", "path": "", "value": {}}]`,
+			`[{"op": "move", "from": "/publicKey", "path": "/keys"}]`,
+			`[{"path": "/name", "value": "value"}]`,
+		} {
+			p, err := patch.NewJSONPatch(ops)
+			require.NoError(t, err)
+
+			require.NoError(t, NewJSONValidator().Validate(p), ops)
+			require.NoError(t, Validate(p), ops)
+		}
+	})
+	t.Run("error - missing op", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"path": "/name", "value": "value"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator(WithIdentityPathProtection(true)).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: op not found")
+	})
+	t.Run("error - invalid path", func(t *testing.T) {
+		p, err := patch.NewJSONPatch(`[{"op": "replace", "path": 1, "value": "value"}]`)
+		require.NoError(t, err)
+
+		err = NewJSONValidator().Validate(p)
+		require.Error(t, err)
+		require.Equal(t, err.Error(), "ietf-json-patch: invalid path")
+	})
 	t.Run("error missing patches", func(t *testing.T) {
 		p := make(patch.Patch)
 		p[patch.ActionKey] = patch.JSONPatch
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+// keyAlgorithmSeparator separates key type and curve in key algorithm ('kty:crv').
+const keyAlgorithmSeparator = ":"
+
+// DefaultKeyAlgorithms returns key algorithms ('kty:crv') that are supported by document transformation.
+func DefaultKeyAlgorithms() []string {
+	return []string{
+		"EC:P-256",
+		"EC:P-384",
+		"EC:P-521",
+		"EC:secp256k1",
+		"EC:P-256K",
+		"OKP:Ed25519",
+		"OKP:X25519",
+		document.BLS12381G2Kty + keyAlgorithmSeparator + document.BLS12381G2Crv,
+	}
+}
+
+// WithKeyAlgorithms sets allowed public key algorithms as JWK key type and curve combinations in 'kty:crv'
+// format (e.g. 'EC:P-256', 'OKP:Ed25519'); if not set any key algorithm is allowed.
+func WithKeyAlgorithms(algorithms ...string) Option {
+	return func(opts *options) {
+		opts.keyAlgorithms = append(opts.keyAlgorithms, algorithms...)
+	}
+}
+
+// allowedAlgorithms returns allowed key algorithms (nil if any key algorithm is allowed).
+func (o *options) allowedAlgorithms() map[string]bool {
+	if len(o.keyAlgorithms) == 0 {
+		return nil
+	}
+
+	algorithms := make(map[string]bool)
+	for _, a := range o.keyAlgorithms {
+		algorithms[a] = true
+	}
+
+	return algorithms
+}
+
+// validateKeyAlgorithms validates that public keys use allowed key algorithms.
+func validateKeyAlgorithms(pubKeys []document.PublicKey, allowedAlgorithms map[string]bool) error {
+	if allowedAlgorithms == nil {
+		return nil
+	}
+
+	for i, pubKey := range pubKeys {
+		jwk := pubKey.PublicKeyJwk()
+
+		if !allowedAlgorithms[jwk.Kty()+keyAlgorithmSeparator+jwk.Crv()] {
+			return protocol.NewFieldError(pointer(i, document.PublicKeyJwkProperty), fmt.Errorf("public key '%s': key algorithm kty '%s' crv '%s' is not allowed",
+				pubKey.ID(), jwk.Kty(), jwk.Crv()))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+)
+
+const (
+	ecKty  = "EC"
+	okpKty = "OKP"
+
+	curve25519KeySize = 32
+)
+
+// ecCurves are EC curves supported by key material validation.
+// nolint:gochecknoglobals
+var ecCurves = map[string]elliptic.Curve{
+	"P-256":     elliptic.P256(),
+	"P-384":     elliptic.P384(),
+	"P-521":     elliptic.P521(),
+	"secp256k1": btcec.S256(),
+	"P-256K":    btcec.S256(),
+}
+
+// curve25519P is field prime of curve25519 (2^255 - 19).
+// nolint:gochecknoglobals
+var curve25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// WithKeyMaterialValidation enables validation of public key material (EC points have to be on the curve,
+// OKP keys have to be of the expected size); by default key material is not validated.
+func WithKeyMaterialValidation(enabled bool) Option {
+	return func(opts *options) {
+		opts.keyMaterial = enabled
+	}
+}
+
+// validatePublicKeysMaterial validates key material of public keys (if enabled).
+func validatePublicKeysMaterial(pubKeys []document.PublicKey, enabled bool) error {
+	if !enabled {
+		return nil
+	}
+
+	for i, pubKey := range pubKeys {
+		if err := validateKeyMaterial(pubKey.PublicKeyJwk()); err != nil {
+			return protocol.NewFieldError(pointer(i, document.PublicKeyJwkProperty),
+				fmt.Errorf("public key '%s': %s", pubKey.ID(), err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// validateKeyMaterial validates that key material of JWK with known key type and curve is a valid public key:
+// EC keys have to be points on the curve and OKP keys have to be of the expected size (ed25519 y coordinate
+// has to be in range). Keys with other key types and curves are not validated.
+func validateKeyMaterial(jwk document.JWK) error {
+	switch jwk.Kty() {
+	case ecKty:
+		curve, ok := ecCurves[jwk.Crv()]
+		if !ok {
+			return nil
+		}
+
+		return validateECKey(jwk, curve)
+	case okpKty:
+		switch jwk.Crv() {
+		case "Ed25519":
+			return validateEd25519Key(jwk)
+		case "X25519":
+			_, err := decodeCoordinate(jwk.X(), "x", curve25519KeySize)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateECKey(jwk document.JWK, curve elliptic.Curve) error {
+	size := (curve.Params().BitSize + 7) / 8
+
+	x, err := decodeCoordinate(jwk.X(), "x", size)
+	if err != nil {
+		return err
+	}
+
+	if jwk.Y() == "" {
+		return errors.New("JWK y is missing")
+	}
+
+	y, err := decodeCoordinate(jwk.Y(), "y", size)
+	if err != nil {
+		return err
+	}
+
+	if !curve.IsOnCurve(new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)) {
+		return fmt.Errorf("JWK is not a valid %s public key: point is not on curve", jwk.Crv())
+	}
+
+	return nil
+}
+
+func validateEd25519Key(jwk document.JWK) error {
+	x, err := decodeCoordinate(jwk.X(), "x", ed25519.PublicKeySize)
+	if err != nil {
+		return err
+	}
+
+	// y coordinate is encoded in little-endian with the sign of x in the most significant bit
+	le := make([]byte, len(x))
+	for i := range x {
+		le[len(x)-1-i] = x[i]
+	}
+
+	y := new(big.Int).SetBytes(le)
+	y.SetBit(y, 255, 0)
+
+	if y.Cmp(curve25519P) >= 0 {
+		return errors.New("JWK is not a valid Ed25519 public key: y coordinate out of range")
+	}
+
+	return nil
+}
+
+func decodeCoordinate(value, name string, size int) ([]byte, error) {
+	decoded, err := encoder.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("JWK %s is not base64url encoded: %s", name, err.Error())
+	}
+
+	if len(decoded) != size {
+		return nil, fmt.Errorf("JWK %s has invalid size %d (expected %d)", name, len(decoded), size)
+	}
+
+	return decoded, nil
+}
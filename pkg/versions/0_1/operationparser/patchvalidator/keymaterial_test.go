@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/encoder"
+)
+
+func TestValidateKeyMaterial(t *testing.T) {
+	encode := encoder.EncodeToString
+
+	ecJWK := func(crv string, curve elliptic.Curve) document.JWK {
+		privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		size := (curve.Params().BitSize + 7) / 8
+
+		x := make([]byte, size)
+		y := make([]byte, size)
+
+		copy(x[size-len(privateKey.X.Bytes()):], privateKey.X.Bytes())
+		copy(y[size-len(privateKey.Y.Bytes()):], privateKey.Y.Bytes())
+
+		return document.JWK{"kty": "EC", "crv": crv, "x": encode(x), "y": encode(y)}
+	}
+
+	t.Run("success - valid keys", func(t *testing.T) {
+		publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		for _, jwk := range []document.JWK{
+			ecJWK("P-256", elliptic.P256()),
+			ecJWK("P-384", elliptic.P384()),
+			ecJWK("P-521", elliptic.P521()),
+			ecJWK("secp256k1", btcec.S256()),
+			ecJWK("P-256K", btcec.S256()),
+			{"kty": "OKP", "crv": "Ed25519", "x": encode(publicKey)},
+			{"kty": "OKP", "crv": "X25519", "x": encode(make([]byte, 32))},
+			{"kty": "EC", "crv": "unknown", "x": "garbage"},
+		} {
+			require.NoError(t, validateKeyMaterial(jwk), jwk.Crv())
+		}
+	})
+
+	t.Run("error - point is not on curve", func(t *testing.T) {
+		jwk := ecJWK("P-256", elliptic.P256())
+		jwk["y"] = jwk["x"]
+
+		err := validateKeyMaterial(jwk)
+		require.EqualError(t, err, "JWK is not a valid P-256 public key: point is not on curve")
+
+		// secp256k1 key is not on P-256 curve
+		jwk = ecJWK("secp256k1", btcec.S256())
+		jwk["crv"] = "P-256"
+
+		err = validateKeyMaterial(jwk)
+		require.EqualError(t, err, "JWK is not a valid P-256 public key: point is not on curve")
+	})
+
+	t.Run("error - y is missing", func(t *testing.T) {
+		jwk := ecJWK("P-256", elliptic.P256())
+		delete(jwk, "y")
+
+		err := validateKeyMaterial(jwk)
+		require.EqualError(t, err, "JWK y is missing")
+	})
+
+	t.Run("error - invalid coordinate size", func(t *testing.T) {
+		jwk := ecJWK("P-384", elliptic.P384())
+		jwk["x"] = encode([]byte("short"))
+
+		err := validateKeyMaterial(jwk)
+		require.EqualError(t, err, "JWK x has invalid size 5 (expected 48)")
+
+		err = validateKeyMaterial(document.JWK{"kty": "OKP", "crv": "X25519", "x": encode([]byte("short"))})
+		require.EqualError(t, err, "JWK x has invalid size 5 (expected 32)")
+	})
+
+	t.Run("error - coordinate is not base64url encoded", func(t *testing.T) {
+		jwk := ecJWK("P-256", elliptic.P256())
+		jwk["y"] = "!!!"
+
+		err := validateKeyMaterial(jwk)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWK y is not base64url encoded")
+	})
+
+	t.Run("error - ed25519 y coordinate out of range", func(t *testing.T) {
+		x := []byte(strings.Repeat("\xff", ed25519.PublicKeySize))
+
+		err := validateKeyMaterial(document.JWK{"kty": "OKP", "crv": "Ed25519", "x": encode(x)})
+		require.EqualError(t, err, "JWK is not a valid Ed25519 public key: y coordinate out of range")
+	})
+
+	t.Run("error - public key with invalid key material", func(t *testing.T) {
+		jwk := ecJWK("P-256", elliptic.P256())
+		jwk["y"] = jwk["x"]
+
+		pk := document.PublicKey{
+			"id":           "key1",
+			"type":         "JsonWebKey2020",
+			"purposes":     []interface{}{"authentication"},
+			"publicKeyJwk": map[string]interface{}(jwk),
+		}
+
+		err := validatePublicKeysMaterial([]document.PublicKey{pk}, true)
+		require.EqualError(t, err, "public key 'key1': JWK is not a valid P-256 public key: point is not on curve")
+		require.Equal(t, "/0/publicKeyJwk", protocol.GetPointer(err))
+
+		// key material is not validated by default
+		err = validatePublicKeysMaterial([]document.PublicKey{pk}, false)
+		require.NoError(t, err)
+
+		err = ValidatePublicKeys([]document.PublicKey{pk})
+		require.NoError(t, err)
+
+		err = ValidatePublicKeys([]document.PublicKey{pk}, WithKeyMaterialValidation(true))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "point is not on curve")
+	})
+}
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// NewJSONMergeValidator creates new validator.
+func NewJSONMergeValidator(opts ...Option) *JSONMergeValidator {
+	return &JSONMergeValidator{
+		pathValidator: NewJSONValidator(append(append([]Option{}, opts...), WithIdentityPathProtection(true))...),
+	}
+}
+
+// JSONMergeValidator implements validator for "json-merge-patch" patch. Merge patch is subject to
+// the same protected paths as "ietf-json-patch" (identity paths are always protected).
+type JSONMergeValidator struct {
+	pathValidator *JSONValidator
+}
+
+// Validate validates patch.
+func (v *JSONMergeValidator) Validate(p patch.Patch) error {
+	value, err := p.GetValue()
+	if err != nil {
+		return err
+	}
+
+	mergePatch, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("invalid json merge patch value: expected JSON object")
+	}
+
+	if len(mergePatch) == 0 {
+		return errors.New("invalid json merge patch value: merge patch is empty")
+	}
+
+	return v.validatePaths("", mergePatch)
+}
+
+// validatePaths validates paths (JSON pointers) of all members that will be modified by merge patch.
+func (v *JSONMergeValidator) validatePaths(parent string, mergePatch map[string]interface{}) error {
+	for key, value := range mergePatch {
+		path := parent + "/" + escapePointerToken(key)
+
+		if err := v.pathValidator.validatePath(path); err != nil {
+			return protocol.NewFieldError(path, fmt.Errorf("%s: %s", patch.JSONMergePatch, err.Error()))
+		}
+
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if err := v.validatePaths(path, nested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
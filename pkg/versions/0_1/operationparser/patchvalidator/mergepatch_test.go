@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestJSONMergePatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"name": "value", "address": {"city": null}}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator().Validate(p)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - missing patch", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.JSONMergePatch
+
+		err := NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "json-merge-patch patch is missing key: patch")
+	})
+	t.Run("error - not JSON object", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.JSONMergePatch
+		p[patch.PatchKey] = []interface{}{"value"}
+
+		err := NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid json merge patch value: expected JSON object")
+	})
+	t.Run("error - empty merge patch", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.JSONMergePatch
+		p[patch.PatchKey] = map[string]interface{}{}
+
+		err := NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid json merge patch value: merge patch is empty")
+	})
+	t.Run("error - cannot modify public keys", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"publicKey": null}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Equal(t, "json-merge-patch: cannot modify public keys", err.Error())
+	})
+	t.Run("error - cannot modify services", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"service": []}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Equal(t, "json-merge-patch: cannot modify services", err.Error())
+	})
+	t.Run("error - cannot modify id", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"id": "did:other:123"}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator().Validate(p)
+		require.Error(t, err)
+		require.Equal(t, "json-merge-patch: cannot modify protected path '/id'", err.Error())
+	})
+	t.Run("error - configured nested protected path", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"address": {"city": "Toronto"}}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator(WithProtectedPaths("/address/city")).Validate(p)
+		require.Error(t, err)
+		require.Equal(t, "json-merge-patch: cannot modify protected path '/address/city'", err.Error())
+
+		err = NewJSONMergeValidator(WithProtectedPaths("/address/street")).Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - member name is escaped", func(t *testing.T) {
+		p, err := patch.NewJSONMergePatch(`{"id/x": "value"}`)
+		require.NoError(t, err)
+
+		err = NewJSONMergeValidator().Validate(p)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// NewVerificationRelationshipsValidator creates new validator.
+func NewVerificationRelationshipsValidator(opts ...Option) *VerificationRelationshipsValidator {
+	return &VerificationRelationshipsValidator{
+		allowedPurposes: getOptions(opts).allowedPurposes(),
+	}
+}
+
+// VerificationRelationshipsValidator implements validator for "add-verification-relationships"
+// and "remove-verification-relationships" patches.
+type VerificationRelationshipsValidator struct {
+	allowedPurposes map[document.KeyPurpose]bool
+}
+
+// Validate validates patch.
+func (v *VerificationRelationshipsValidator) Validate(p patch.Patch) error {
+	action, err := p.GetAction()
+	if err != nil {
+		return err
+	}
+
+	value, err := p.GetValue()
+	if err != nil {
+		return err
+	}
+
+	relationships, err := getRequiredArray(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %s", action, err.Error())
+	}
+
+	ids := make(map[string]bool)
+
+	for i, entry := range relationships {
+		relationship, ok := entry.(map[string]interface{})
+		if !ok {
+			return protocol.NewFieldError(pointer(i), fmt.Errorf("invalid %s value: expected object", action))
+		}
+
+		id, err := validateRelationship(document.NewPublicKey(relationship), v.allowedPurposes)
+		if err != nil {
+			return protocol.WithPointer(pointer(i), errors.WithMessage(err, string(action)))
+		}
+
+		if _, ok := ids[id]; ok {
+			return protocol.NewFieldError(pointer(i, document.IDProperty),
+				fmt.Errorf("%s: duplicate public key id: %s", action, id))
+		}
+
+		ids[id] = true
+	}
+
+	return nil
+}
+
+func validateRelationship(relationship document.PublicKey, allowedPurposes map[document.KeyPurpose]bool) (string, error) {
+	for key := range relationship {
+		if key != document.IDProperty && key != document.PurposesProperty {
+			return "", protocol.NewFieldError(pointer(key),
+				fmt.Errorf("key '%s' is not allowed in verification relationship", key))
+		}
+	}
+
+	if err := validateID(relationship.ID()); err != nil {
+		return "", protocol.NewFieldError(pointer(document.IDProperty), fmt.Errorf("public key: %s", err.Error()))
+	}
+
+	if len(relationship.Purpose()) == 0 {
+		return "", protocol.NewFieldError(pointer(document.PurposesProperty), errors.New("missing purposes"))
+	}
+
+	if err := validateKeyPurposes(relationship, allowedPurposes); err != nil {
+		return "", protocol.NewFieldError(pointer(document.PurposesProperty), err)
+	}
+
+	return relationship.ID(), nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestVerificationRelationshipsPatch(t *testing.T) {
+	t.Run("success - add", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - remove", func(t *testing.T) {
+		p, err := patch.NewRemoveVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - custom purpose", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["custom"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator(WithKeyPurposes("custom")).Validate(p)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: custom")
+	})
+	t.Run("error - missing relationships", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.AddVerificationRelationships
+
+		err := NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-verification-relationships patch is missing key: relationships")
+	})
+	t.Run("error - relationships not array", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.AddVerificationRelationships
+		p[patch.RelationshipsKey] = "key1"
+
+		err := NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid add-verification-relationships value: expected array of interfaces")
+	})
+	t.Run("error - relationship not object", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.AddVerificationRelationships
+		p[patch.RelationshipsKey] = []interface{}{"key1"}
+
+		err := NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid add-verification-relationships value: expected object")
+	})
+	t.Run("error - property not allowed", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(
+			`[{"id": "key1", "type": "JsonWebKey2020", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key 'type' is not allowed in verification relationship")
+	})
+	t.Run("error - invalid id", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key#1", "purposes": ["authentication"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-verification-relationships: public key: id contains invalid characters")
+	})
+	t.Run("error - missing purposes", func(t *testing.T) {
+		p, err := patch.NewRemoveVerificationRelationshipsPatch(`[{"id": "key1"}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remove-verification-relationships: missing purposes")
+	})
+	t.Run("error - invalid purpose", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(`[{"id": "key1", "purposes": ["invalid"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose")
+	})
+	t.Run("error - duplicate id", func(t *testing.T) {
+		p, err := patch.NewAddVerificationRelationshipsPatch(
+			`[{"id": "key1", "purposes": ["authentication"]}, {"id": "key1", "purposes": ["assertionMethod"]}]`)
+		require.NoError(t, err)
+
+		err = NewVerificationRelationshipsValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "add-verification-relationships: duplicate public key id: key1")
+	})
+}
@@ -7,20 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package patchvalidator
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/pkg/errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
 // NewReplaceValidator creates new validator.
-func NewReplaceValidator() *ReplaceValidator {
-	return &ReplaceValidator{}
+func NewReplaceValidator(opts ...Option) *ReplaceValidator {
+	o := getOptions(opts)
+
+	return &ReplaceValidator{
+		allowedPurposes:   o.allowedPurposes(),
+		allowedAlgorithms: o.allowedAlgorithms(),
+		uniqueIDs:         o.uniqueIDs,
+		keyMaterial:       o.keyMaterial,
+		serviceRules:      o.serviceRules(),
+	}
 }
 
 // ReplaceValidator implements validator for "replace" patch.
 type ReplaceValidator struct {
+	allowedPurposes   map[document.KeyPurpose]bool
+	allowedAlgorithms map[string]bool
+	uniqueIDs         bool
+	keyMaterial       bool
+	serviceRules      serviceRules
 }
 
 // Validate validates patch.
@@ -37,25 +52,70 @@ func (v *ReplaceValidator) Validate(p patch.Patch) error {
 
 	doc := document.ReplaceDocumentFromJSONLDObject(entryMap)
 
-	allowedKeys := []string{document.ReplaceServiceProperty, document.ReplacePublicKeyProperty}
+	allowedKeys := []string{document.ReplaceServiceProperty, document.ReplacePublicKeyProperty,
+		document.ReplaceAlsoKnownAsProperty, document.ReplaceControllerProperty}
 
 	for key := range doc {
 		if !contains(allowedKeys, key) {
-			return fmt.Errorf("key '%s' is not allowed in replace document", key)
+			return protocol.NewFieldError(pointer(key), fmt.Errorf("key '%s' is not allowed in replace document", key))
 		}
 	}
 
-	if err := validatePublicKeys(doc.PublicKeys()); err != nil {
-		return fmt.Errorf("failed to validate public keys for replace document: %s", err.Error())
+	if err := validatePublicKeys(doc.PublicKeys(), v.allowedPurposes); err != nil {
+		return protocol.WithPointer(pointer(document.ReplacePublicKeyProperty),
+			errors.WithMessage(err, "failed to validate public keys for replace document"))
+	}
+
+	if err := validateKeyAlgorithms(doc.PublicKeys(), v.allowedAlgorithms); err != nil {
+		return protocol.WithPointer(pointer(document.ReplacePublicKeyProperty),
+			errors.WithMessage(err, "failed to validate public keys for replace document"))
+	}
+
+	if err := validatePublicKeysMaterial(doc.PublicKeys(), v.keyMaterial); err != nil {
+		return protocol.WithPointer(pointer(document.ReplacePublicKeyProperty),
+			errors.WithMessage(err, "failed to validate public keys for replace document"))
 	}
 
-	if err := validateServices(doc.Services()); err != nil {
-		return fmt.Errorf("failed to validate services for replace document: %s", err.Error())
+	if err := validateServices(doc.Services(), v.serviceRules); err != nil {
+		return protocol.WithPointer(pointer(document.ReplaceServiceProperty),
+			errors.WithMessage(err, "failed to validate services for replace document"))
+	}
+
+	if v.uniqueIDs {
+		if err := document.CheckUniqueIDs(doc.PublicKeys(), doc.Services()); err != nil {
+			return fmt.Errorf("failed to validate replace document: %s", err.Error())
+		}
+	}
+
+	if err := validateReplaceAlsoKnownAs(doc); err != nil {
+		return protocol.WithPointer(pointer(document.ReplaceAlsoKnownAsProperty),
+			errors.WithMessage(err, "failed to validate also known as for replace document"))
+	}
+
+	if controller, ok := doc[document.ReplaceControllerProperty]; ok {
+		if err := validateController(controller); err != nil {
+			return protocol.WithPointer(pointer(document.ReplaceControllerProperty),
+				errors.WithMessage(err, "failed to validate controller for replace document"))
+		}
 	}
 
 	return nil
 }
 
+func validateReplaceAlsoKnownAs(doc document.ReplaceDocument) error {
+	entry, ok := doc[document.ReplaceAlsoKnownAsProperty]
+	if !ok {
+		return nil
+	}
+
+	uris, err := getRequiredArray(entry)
+	if err != nil {
+		return err
+	}
+
+	return validateAlsoKnownAs(uris)
+}
+
 func getRequiredMap(entry interface{}) (map[string]interface{}, error) {
 	required, ok := entry.(map[string]interface{})
 	if !ok {
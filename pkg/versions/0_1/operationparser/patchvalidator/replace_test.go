@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package patchvalidator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -60,6 +61,66 @@ func TestValidateReplacePatch(t *testing.T) {
 		err = NewReplaceValidator().Validate(p)
 		require.Contains(t, err.Error(), "service endpoint is missing")
 	})
+	t.Run("success - also known as", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(`{"alsoKnownAs": ["https://myblog.example/", "did:example:123"]}`)
+		require.NoError(t, err)
+
+		err = NewReplaceValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - also known as is not an array", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(`{"alsoKnownAs": "did:example:123"}`)
+		require.NoError(t, err)
+
+		err = NewReplaceValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to validate also known as for replace document")
+	})
+	t.Run("success - controller", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(`{"controller": "did:example:123"}`)
+		require.NoError(t, err)
+
+		err = NewReplaceValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("error - controller is not a DID", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(`{"controller": ["https://example.com"]}`)
+		require.NoError(t, err)
+
+		err = NewReplaceValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to validate controller for replace document")
+	})
+	t.Run("error - also known as (duplicate uri)", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(`{"alsoKnownAs": ["did:example:123", "did:example:123"]}`)
+		require.NoError(t, err)
+
+		err = NewReplaceValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate uri in also known as: did:example:123")
+	})
+	t.Run("error - key algorithm not allowed", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(replacePatch))
+		require.NoError(t, err)
+
+		require.NoError(t, NewReplaceValidator(WithKeyAlgorithms("EC:P-256K")).Validate(p))
+
+		err = NewReplaceValidator(WithKeyAlgorithms("EC:P-256")).Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to validate public keys for replace document")
+		require.Contains(t, err.Error(), "key algorithm kty 'EC' crv 'P-256K' is not allowed")
+	})
+	t.Run("error - public key and service with the same id", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(strings.Replace(replacePatch, `"id": "sds3"`, `"id": "key-1"`, 1)))
+		require.NoError(t, err)
+
+		err = NewReplaceValidator(WithUniqueIDValidation(true)).Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate id: key-1 is used by both public key and service")
+
+		err = NewReplaceValidator().Validate(p)
+		require.NoError(t, err)
+	})
 }
 
 const replacePatch = `{
@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// NewReplaceServicesValidator creates new validator.
+func NewReplaceServicesValidator(opts ...Option) *ReplaceServicesValidator {
+	return &ReplaceServicesValidator{rules: getOptions(opts).serviceRules()}
+}
+
+// ReplaceServicesValidator implements validator for "replace-services" patch.
+type ReplaceServicesValidator struct {
+	rules serviceRules
+}
+
+// Validate validates patch.
+func (v *ReplaceServicesValidator) Validate(p patch.Patch) error {
+	value, err := p.GetValue()
+	if err != nil {
+		return err
+	}
+
+	// empty array is allowed (all existing services will be removed)
+	if _, ok := value.([]interface{}); !ok {
+		return errors.New("invalid replace services value: expected array of interfaces")
+	}
+
+	services := document.ParseServices(value)
+
+	return validateServices(services, v.rules)
+}
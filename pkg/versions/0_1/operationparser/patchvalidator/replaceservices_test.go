@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package patchvalidator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+func TestReplaceServiceEndpointsPatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(replaceServiceEndpoints))
+		require.NoError(t, err)
+
+		err = NewReplaceServicesValidator().Validate(p)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - empty services", func(t *testing.T) {
+		p, err := patch.NewReplaceServiceEndpointsPatch(`[]`)
+		require.NoError(t, err)
+
+		err = NewReplaceServicesValidator().Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("missing services", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(replaceServiceEndpoints))
+		require.NoError(t, err)
+
+		delete(p, patch.ServicesKey)
+		err = NewReplaceServicesValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "replace-services patch is missing key: services")
+	})
+	t.Run("error - services not array", func(t *testing.T) {
+		p := make(patch.Patch)
+		p[patch.ActionKey] = patch.ReplaceServiceEndpoints
+		p[patch.ServicesKey] = "invalid"
+
+		err := NewReplaceServicesValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid replace services value: expected array of interfaces")
+	})
+	t.Run("error - service is missing id", func(t *testing.T) {
+		p, err := patch.NewReplaceServiceEndpointsPatch(testAddServiceEndpointsMissingID)
+		require.NoError(t, err)
+
+		err = NewReplaceServicesValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service id is missing")
+	})
+	t.Run("error - duplicate service id", func(t *testing.T) {
+		p, err := patch.NewReplaceServiceEndpointsPatch(`[
+			{"id": "sds1", "type": "SecureDataStore", "serviceEndpoint": "http://hub.com"},
+			{"id": "sds1", "type": "SecureDataStore", "serviceEndpoint": "http://other.com"}
+		]`)
+		require.NoError(t, err)
+
+		err = NewReplaceServicesValidator().Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate service id: sds1")
+	})
+}
+
+const replaceServiceEndpoints = `{
+  "action": "replace-services",
+  "services": [
+    {
+      "id": "sds1",
+      "type": "SecureDataStore",
+      "serviceEndpoint": "http://hub.my-personal-server.com"
+    }
+  ]
+}`
@@ -1,32 +1,226 @@
 package patchvalidator
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
-// Validate validates patch.
-func Validate(p patch.Patch) error {
+// Validator validates patch. Errors may identify the invalid field with JSON pointer relative to
+// the patch value (see protocol.FieldError), e.g. /1/purposes for the second key of add-public-keys patch.
+type Validator interface {
+	Validate(p patch.Patch) error
+}
+
+// Option is a validation option.
+type Option func(opts *options)
+
+type options struct {
+	protectedPaths []string
+	identityPaths  bool
+	keyPurposes    []string
+	keyAlgorithms  []string
+	uniqueIDs      bool
+	keyMaterial    bool
+	didComm        bool
+	endpoints      bool
+}
+
+// WithProtectedPaths sets additional document paths (JSON pointers) that cannot be modified by ietf-json-patch.
+func WithProtectedPaths(paths ...string) Option {
+	return func(opts *options) {
+		opts.protectedPaths = append(opts.protectedPaths, paths...)
+	}
+}
+
+// WithIdentityPathProtection enables protection of document identity paths (id and controller) and document
+// root from ietf-json-patch modifications; 'from' location of move operation is validated as well.
+func WithIdentityPathProtection(enabled bool) Option {
+	return func(opts *options) {
+		opts.identityPaths = enabled
+	}
+}
+
+// WithKeyPurposes sets allowed public key purposes (standard and/or custom purposes); if not set
+// all standard purposes are allowed.
+func WithKeyPurposes(purposes ...string) Option {
+	return func(opts *options) {
+		opts.keyPurposes = append(opts.keyPurposes, purposes...)
+	}
+}
+
+// WithUniqueIDValidation enables validation that ids are unique across public keys and services
+// of replace document.
+func WithUniqueIDValidation(enabled bool) Option {
+	return func(opts *options) {
+		opts.uniqueIDs = enabled
+	}
+}
+
+// WithDIDCommServiceValidation enables validation of DIDCommMessaging services (service endpoint URI or object
+// with uri, accept and routingKeys properties).
+func WithDIDCommServiceValidation(enabled bool) Option {
+	return func(opts *options) {
+		opts.didComm = enabled
+	}
+}
+
+// WithServiceEndpointValidation enables strict validation of service endpoints (see document.ServiceEndpoint
+// Validate); otherwise only URI endpoints are validated and arrays are rejected.
+func WithServiceEndpointValidation(enabled bool) Option {
+	return func(opts *options) {
+		opts.endpoints = enabled
+	}
+}
+
+// serviceRules returns service validation rules.
+func (o *options) serviceRules() serviceRules {
+	return serviceRules{didComm: o.didComm, endpoints: o.endpoints}
+}
+
+// allowedPurposes returns allowed public key purposes.
+func (o *options) allowedPurposes() map[document.KeyPurpose]bool {
+	if len(o.keyPurposes) == 0 {
+		return defaultPurposes
+	}
+
+	purposes := make(map[document.KeyPurpose]bool)
+	for _, p := range o.keyPurposes {
+		purposes[document.KeyPurpose(p)] = true
+	}
+
+	return purposes
+}
+
+func getOptions(opts []Option) *options {
+	o := &options{}
+
+	// apply options
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// nolint:gochecknoglobals
+var (
+	customValidators = make(map[patch.Action]Validator)
+	mutex            sync.RWMutex
+)
+
+// Register registers validator for custom patch action (action has to be registered
+// with patch.RegisterAction). Validators for standard actions cannot be overridden.
+func Register(action patch.Action, v Validator) error {
+	if v == nil {
+		return errors.New("missing validator")
+	}
+
+	if isStandardAction(action) {
+		return fmt.Errorf("validator for standard action '%s' cannot be registered", action)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := customValidators[action]; ok {
+		return fmt.Errorf("validator for action '%s' is already registered", action)
+	}
+
+	customValidators[action] = v
+
+	return nil
+}
+
+// Validate validates patch. Returned error identifies the invalid field with JSON pointer relative
+// to the patch (e.g. /publicKeys/1/purposes).
+func Validate(p patch.Patch, opts ...Option) error {
 	action, err := p.GetAction()
 	if err != nil {
-		return err
+		return protocol.NewFieldError(pointer(patch.ActionKey), err)
+	}
+
+	v, ok := getValidator(action, opts)
+	if !ok {
+		return protocol.NewFieldError(pointer(patch.ActionKey),
+			fmt.Errorf(" validation for action '%s' is not supported", action))
+	}
+
+	if err := v.Validate(p); err != nil {
+		valueKey, e := p.GetValueKey()
+		if e != nil {
+			return err
+		}
+
+		return protocol.WithPointer(pointer(valueKey), err)
 	}
 
+	return nil
+}
+
+func getValidator(action patch.Action, opts []Option) (Validator, bool) {
 	switch action {
 	case patch.Replace:
-		return NewReplaceValidator().Validate(p)
+		return NewReplaceValidator(opts...), true
 	case patch.JSONPatch:
-		return NewJSONValidator().Validate(p)
+		return NewJSONValidator(opts...), true
 	case patch.AddPublicKeys:
-		return NewAddPublicKeysValidator().Validate(p)
+		return NewAddPublicKeysValidator(opts...), true
 	case patch.RemovePublicKeys:
-		return NewRemovePublicKeysValidator().Validate(p)
+		return NewRemovePublicKeysValidator(), true
 	case patch.AddServiceEndpoints:
-		return NewAddServicesValidator().Validate(p)
+		return NewAddServicesValidator(opts...), true
 	case patch.RemoveServiceEndpoints:
-		return NewRemoveServicesValidator().Validate(p)
+		return NewRemoveServicesValidator(), true
+	case patch.ReplaceServiceEndpoints:
+		return NewReplaceServicesValidator(opts...), true
+	case patch.AddAlsoKnownAs, patch.RemoveAlsoKnownAs:
+		return NewAlsoKnownAsValidator(), true
+	case patch.JSONMergePatch:
+		return NewJSONMergeValidator(opts...), true
+	case patch.AddVerificationRelationships, patch.RemoveVerificationRelationships:
+		return NewVerificationRelationshipsValidator(opts...), true
+	case patch.SetController:
+		return NewControllerValidator(), true
+	}
+
+	return getCustomValidator(action)
+}
+
+// pointer returns JSON pointer for the given reference tokens (property names and array indexes).
+func pointer(tokens ...interface{}) string {
+	var b strings.Builder
+
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(escapePointerToken(fmt.Sprint(token)))
+	}
+
+	return b.String()
+}
+
+func getCustomValidator(action patch.Action) (Validator, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	v, ok := customValidators[action]
+
+	return v, ok
+}
+
+func isStandardAction(action patch.Action) bool {
+	switch action {
+	case patch.Replace, patch.JSONPatch, patch.AddPublicKeys, patch.RemovePublicKeys,
+		patch.AddServiceEndpoints, patch.RemoveServiceEndpoints, patch.AddAlsoKnownAs, patch.RemoveAlsoKnownAs,
+		patch.JSONMergePatch, patch.ReplaceServiceEndpoints, patch.AddVerificationRelationships,
+		patch.RemoveVerificationRelationships, patch.SetController:
+		return true
 	}
 
-	return fmt.Errorf(" validation for action '%s' is not supported", action)
+	return false
 }
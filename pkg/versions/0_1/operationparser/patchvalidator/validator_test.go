@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package patchvalidator
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
@@ -57,6 +60,53 @@ func TestValidate(t *testing.T) {
 		err = Validate(p)
 		require.NoError(t, err)
 	})
+	t.Run("success - add also known as", func(t *testing.T) {
+		p, err := patch.NewAddAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - remove also known as", func(t *testing.T) {
+		p, err := patch.NewRemoveAlsoKnownAs(`["did:domain.com"]`)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.NoError(t, err)
+	})
+	t.Run("success - custom patch", func(t *testing.T) {
+		const customAction patch.Action = "validator-custom-action"
+
+		err := patch.RegisterAction(customAction, "data")
+		require.NoError(t, err)
+
+		p, err := patch.NewPatch(customAction, "value")
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validation for action 'validator-custom-action' is not supported")
+
+		err = Register(customAction, &mockValidator{Err: errors.New("custom error")})
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.EqualError(t, err, "custom error")
+
+		err = Register(customAction, &mockValidator{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validator for action 'validator-custom-action' is already registered")
+	})
+	t.Run("error - register validator for standard action", func(t *testing.T) {
+		err := Register(patch.AddPublicKeys, &mockValidator{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validator for standard action 'add-public-keys' cannot be registered")
+	})
+	t.Run("error - register nil validator", func(t *testing.T) {
+		err := Register("some-action", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing validator")
+	})
 	t.Run("error - patch not supported", func(t *testing.T) {
 		p := make(patch.Patch)
 		p[patch.ActionKey] = "invalid"
@@ -64,5 +114,35 @@ func TestValidate(t *testing.T) {
 		err := Validate(p)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "action 'invalid' is not supported")
+		require.Equal(t, "/action", protocol.GetPointer(err))
+	})
+	t.Run("error - pointer to invalid field", func(t *testing.T) {
+		p, err := patch.FromBytes([]byte(addPublicKeysPatch))
+		require.NoError(t, err)
+
+		err = Validate(p, WithKeyPurposes(document.KeyPurposeAuthentication))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid purpose: assertionMethod")
+		require.Equal(t, "/publicKeys/0/purposes", protocol.GetPointer(err))
+
+		err = Validate(p, WithKeyAlgorithms("EC:P-256"))
+		require.Error(t, err)
+		require.Equal(t, "/publicKeys/0/publicKeyJwk", protocol.GetPointer(err))
 	})
+	t.Run("error - pointer to invalid field in replace document", func(t *testing.T) {
+		p, err := patch.NewReplacePatch(replaceDocInvalidPublicKey)
+		require.NoError(t, err)
+
+		err = Validate(p)
+		require.Error(t, err)
+		require.Equal(t, "/document/publicKeys/0/type", protocol.GetPointer(err))
+	})
+}
+
+type mockValidator struct {
+	Err error
+}
+
+func (m *mockValidator) Validate(p patch.Patch) error {
+	return m.Err
 }
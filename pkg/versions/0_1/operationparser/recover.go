@@ -13,8 +13,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
-	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
 	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
@@ -29,23 +29,25 @@ func (p *Parser) ParseRecoverOperation(request []byte, batch bool) (*model.Opera
 
 	signedData, err := p.ParseSignedDataForRecover(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, protocol.WithPointer(signedDataPointer, err)
 	}
 
 	if !batch {
 		err = p.ValidateDelta(schema.Delta)
 		if err != nil {
-			return nil, err
+			return nil, protocol.WithPointer(deltaPointer, err)
 		}
 
 		if schema.Delta.UpdateCommitment == signedData.RecoveryCommitment {
-			return nil, errors.New("recovery and update commitments cannot be equal, re-using public keys is not allowed")
+			return nil, protocol.NewFieldError(deltaPointer+updateCommitmentPointer,
+				errors.New("recovery and update commitments cannot be equal, re-using public keys is not allowed"))
 		}
 	}
 
-	err = hashing.IsValidModelMultihash(signedData.RecoveryKey, schema.RevealValue)
+	err = commitment.VerifyRevealValue(signedData.RecoveryKey, schema.RevealValue)
 	if err != nil {
-		return nil, fmt.Errorf("canonicalized recovery public key hash doesn't match reveal value: %s", err.Error())
+		return nil, protocol.NewFieldError(revealValuePointer,
+			fmt.Errorf("canonicalized recovery public key hash doesn't match reveal value: %s", err.Error()))
 	}
 
 	return &model.Operation{
@@ -89,6 +91,10 @@ func (p *Parser) ParseSignedDataForRecover(compactJWS string) (*model.RecoverSig
 		return nil, fmt.Errorf("validate signed data for recovery: %s", err.Error())
 	}
 
+	if err := p.validateKeyID(jws.ProtectedHeaders, schema.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("validate signed data for recovery: %s", err.Error())
+	}
+
 	return schema, nil
 }
 
@@ -144,17 +150,22 @@ func (p *Parser) validateProtectedHeaders(headers jws.Headers, allowedAlgorithms
 		return errors.New("algorithm cannot be empty in the protected header")
 	}
 
-	allowedHeaders := map[string]bool{
-		jws.HeaderAlgorithm: true,
-		jws.HeaderKeyID:     true,
-	}
-
 	for k := range headers {
-		if _, ok := allowedHeaders[k]; !ok {
+		if !p.isAllowedHeader(k) {
 			return fmt.Errorf("invalid protected header: %s", k)
 		}
 	}
 
+	if err := p.validateCriticalHeaders(headers); err != nil {
+		return err
+	}
+
+	if p.UnencodedPayload {
+		if err := validateUnencodedPayloadHeaders(headers); err != nil {
+			return err
+		}
+	}
+
 	if !contains(allowedAlgorithms, alg) {
 		return errors.Errorf("algorithm '%s' is not in the allowed list %v", alg, allowedAlgorithms)
 	}
@@ -162,16 +173,90 @@ func (p *Parser) validateProtectedHeaders(headers jws.Headers, allowedAlgorithms
 	return nil
 }
 
+func (p *Parser) isAllowedHeader(name string) bool {
+	if p.forbiddenHeaders[name] {
+		return false
+	}
+
+	switch name {
+	case jws.HeaderAlgorithm, jws.HeaderKeyID:
+		return true
+	case jws.HeaderCritical:
+		if p.UnencodedPayload || len(p.criticalHeaders) > 0 {
+			return true
+		}
+	case jws.HeaderB64Payload:
+		if p.UnencodedPayload {
+			return true
+		}
+	}
+
+	return p.allowedHeaders[name] || p.criticalHeaders[name]
+}
+
+// validateCriticalHeaders validates that all critical headers are understood.
+func (p *Parser) validateCriticalHeaders(headers jws.Headers) error {
+	crit, _ := headers.Critical()
+
+	for _, name := range crit {
+		understood := p.criticalHeaders[name] || (name == jws.HeaderB64Payload && p.UnencodedPayload)
+		if !understood {
+			return fmt.Errorf("critical protected header '%s' is not supported", name)
+		}
+	}
+
+	return nil
+}
+
+// validateUnencodedPayloadHeaders validates that 'b64' header (if present) is false and listed in 'crit' header.
+func validateUnencodedPayloadHeaders(headers jws.Headers) error {
+	if _, ok := headers[jws.HeaderB64Payload]; !ok {
+		return nil
+	}
+
+	b64, err := headers.B64Payload()
+	if err != nil {
+		return err
+	}
+
+	if b64 {
+		return errors.New("b64 protected header must be false")
+	}
+
+	crit, _ := headers.Critical()
+
+	for _, name := range crit {
+		if name == jws.HeaderB64Payload {
+			return nil
+		}
+	}
+
+	return errors.New("b64 protected header must be listed in crit protected header")
+}
+
 func (p *Parser) validateRecoverRequest(recover *model.RecoverRequest) error {
-	if recover.DidSuffix == "" {
-		return errors.New("missing did suffix")
+	return p.validateRequest(recover.DidSuffix, recover.SignedData, recover.RevealValue)
+}
+
+// validateRequest validates properties common to update, recover and deactivate requests.
+func (p *Parser) validateRequest(didSuffix, signedData, revealValue string) error {
+	if didSuffix == "" {
+		return protocol.NewFieldError(didSuffixPointer, errors.New("missing did suffix"))
 	}
 
-	if recover.SignedData == "" {
-		return errors.New("missing signed data")
+	if err := p.validateSuffix(didSuffix); err != nil {
+		return protocol.NewFieldError(didSuffixPointer, err)
 	}
 
-	return p.validateMultihash(recover.RevealValue, "reveal value")
+	if signedData == "" {
+		return protocol.NewFieldError(signedDataPointer, errors.New("missing signed data"))
+	}
+
+	if err := p.validateMultihash(revealValue, "reveal value"); err != nil {
+		return protocol.NewFieldError(revealValuePointer, err)
+	}
+
+	return nil
 }
 
 func (p *Parser) validateSigningKey(key *jws.JWK, allowedAlgorithms []string) error {
@@ -184,8 +269,8 @@ func (p *Parser) validateSigningKey(key *jws.JWK, allowedAlgorithms []string) er
 		return fmt.Errorf("signing key validation failed: %s", err.Error())
 	}
 
-	if !contains(allowedAlgorithms, key.Crv) {
-		return errors.Errorf("key algorithm '%s' is not in the allowed list %v", key.Crv, allowedAlgorithms)
+	if !contains(allowedAlgorithms, key.KeyAlgorithm()) {
+		return errors.Errorf("key algorithm '%s' is not in the allowed list %v", key.KeyAlgorithm(), allowedAlgorithms)
 	}
 
 	return nil
@@ -202,17 +287,12 @@ func contains(values []string, value string) bool {
 }
 
 func (p *Parser) validateCommitment(jwk *jws.JWK, nextCommitment string) error {
-	code, err := hashing.GetMultihashCode(nextCommitment)
-	if err != nil {
-		return err
-	}
-
-	currentCommitment, err := commitment.GetCommitment(jwk, uint(code))
+	reused, err := commitment.MatchesJWK(jwk, nextCommitment)
 	if err != nil {
 		return fmt.Errorf("calculate current commitment: %s", err.Error())
 	}
 
-	if currentCommitment == nextCommitment {
+	if reused {
 		return errors.New("re-using public keys for commitment is not allowed")
 	}
 
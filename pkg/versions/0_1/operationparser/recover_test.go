@@ -337,6 +337,17 @@ func TestValidateSigningKey(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "key algorithm 'crv' is not in the allowed list [other]")
 	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		rsaJWK := &jws.JWK{Kty: "RSA", N: "n", E: "AQAB"}
+
+		err := parser.validateSigningKey(rsaJWK, []string{"P-256", "RSA"})
+		require.NoError(t, err)
+
+		err = parser.validateSigningKey(rsaJWK, []string{"P-256"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key algorithm 'RSA' is not in the allowed list [P-256]")
+	})
 }
 
 func TestValidateRecoverRequest(t *testing.T) {
@@ -437,6 +448,107 @@ func TestValidateProtectedHeader(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, "algorithm 'alg-other' is not in the allowed list [alg-1 alg-2]", err.Error())
 	})
+
+	t.Run("unencoded payload", func(t *testing.T) {
+		unencodedParser := New(protocol.Protocol{UnencodedPayload: true})
+
+		protected := getHeaders("alg-1", "kid")
+
+		err := unencodedParser.validateProtectedHeaders(protected, algs)
+		require.NoError(t, err)
+
+		protected[jws.HeaderB64Payload] = false
+		protected[jws.HeaderCritical] = []interface{}{"b64"}
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.NoError(t, err)
+
+		err = parser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid protected header")
+
+		protected[jws.HeaderB64Payload] = true
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "b64 protected header must be false")
+
+		protected[jws.HeaderB64Payload] = "false"
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid b64 header")
+
+		protected[jws.HeaderB64Payload] = false
+		protected[jws.HeaderCritical] = []interface{}{"b64", "other"}
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "critical protected header 'other' is not supported")
+
+		protected[jws.HeaderCritical] = []interface{}{"other"}
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "critical protected header 'other' is not supported")
+
+		protected[jws.HeaderCritical] = []interface{}{}
+
+		err = unencodedParser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "b64 protected header must be listed in crit protected header")
+	})
+
+	t.Run("allowed and forbidden headers", func(t *testing.T) {
+		protected := getHeaders("alg-1", "kid")
+		protected["typ"] = "JWT"
+
+		err := New(protocol.Protocol{AllowedProtectedHeaders: []string{"typ"}}).validateProtectedHeaders(protected, algs)
+		require.NoError(t, err)
+
+		err = New(protocol.Protocol{
+			AllowedProtectedHeaders:   []string{"typ"},
+			ForbiddenProtectedHeaders: []string{"typ"},
+		}).validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid protected header: typ")
+
+		err = New(protocol.Protocol{
+			AllowedProtectedHeaders:   []string{"typ"},
+			ForbiddenProtectedHeaders: []string{jws.HeaderKeyID},
+		}).validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid protected header: kid")
+	})
+
+	t.Run("critical headers", func(t *testing.T) {
+		criticalParser := New(protocol.Protocol{CriticalHeaders: []string{"exp"}})
+
+		protected := getHeaders("alg-1", "kid")
+		protected["exp"] = 1000
+		protected[jws.HeaderCritical] = []interface{}{"exp"}
+
+		err := criticalParser.validateProtectedHeaders(protected, algs)
+		require.NoError(t, err)
+
+		err = parser.validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid protected header")
+
+		// extension header is allowed but caller doesn't understand it
+		err = New(protocol.Protocol{AllowedProtectedHeaders: []string{"exp", jws.HeaderCritical}}).
+			validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "critical protected header 'exp' is not supported")
+
+		protected["nbf"] = 1000
+		protected[jws.HeaderCritical] = []interface{}{"exp", "nbf"}
+
+		err = New(protocol.Protocol{CriticalHeaders: []string{"exp"}, AllowedProtectedHeaders: []string{"nbf"}}).
+			validateProtectedHeaders(protected, algs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "critical protected header 'nbf' is not supported")
+	})
 }
 
 func getHeaders(alg, kid string) jws.Headers {
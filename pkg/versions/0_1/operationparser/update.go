@@ -12,7 +12,8 @@ import (
 	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
@@ -25,24 +26,26 @@ func (p *Parser) ParseUpdateOperation(request []byte, batch bool) (*model.Operat
 
 	signedData, err := p.ParseSignedDataForUpdate(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, protocol.WithPointer(signedDataPointer, err)
 	}
 
 	if !batch {
 		err = p.ValidateDelta(schema.Delta)
 		if err != nil {
-			return nil, err
+			return nil, protocol.WithPointer(deltaPointer, err)
 		}
 
 		err = p.validateCommitment(signedData.UpdateKey, schema.Delta.UpdateCommitment)
 		if err != nil {
-			return nil, fmt.Errorf("calculate current commitment: %s", err.Error())
+			return nil, protocol.NewFieldError(deltaPointer+updateCommitmentPointer,
+				fmt.Errorf("calculate current commitment: %s", err.Error()))
 		}
 	}
 
-	err = hashing.IsValidModelMultihash(signedData.UpdateKey, schema.RevealValue)
+	err = commitment.VerifyRevealValue(signedData.UpdateKey, schema.RevealValue)
 	if err != nil {
-		return nil, fmt.Errorf("canonicalized update public key hash doesn't match reveal value: %s", err.Error())
+		return nil, protocol.NewFieldError(revealValuePointer,
+			fmt.Errorf("canonicalized update public key hash doesn't match reveal value: %s", err.Error()))
 	}
 
 	return &model.Operation{
@@ -86,19 +89,15 @@ func (p *Parser) ParseSignedDataForUpdate(compactJWS string) (*model.UpdateSigne
 		return nil, fmt.Errorf("validate signed data for update: %s", err.Error())
 	}
 
+	if err := p.validateKeyID(jws.ProtectedHeaders, schema.UpdateKey); err != nil {
+		return nil, fmt.Errorf("validate signed data for update: %s", err.Error())
+	}
+
 	return schema, nil
 }
 
 func (p *Parser) validateUpdateRequest(update *model.UpdateRequest) error {
-	if update.DidSuffix == "" {
-		return errors.New("missing did suffix")
-	}
-
-	if update.SignedData == "" {
-		return errors.New("missing signed data")
-	}
-
-	return p.validateMultihash(update.RevealValue, "reveal value")
+	return p.validateRequest(update.DidSuffix, update.SignedData, update.RevealValue)
 }
 
 func (p *Parser) validateSignedDataForUpdate(signedData *model.UpdateSignedDataModel) error {
@@ -106,5 +105,9 @@ func (p *Parser) validateSignedDataForUpdate(signedData *model.UpdateSignedDataM
 		return err
 	}
 
+	if signedData.Nonce != nil && *signedData.Nonce == 0 {
+		return errors.New("nonce must be greater than zero")
+	}
+
 	return p.validateMultihash(signedData.DeltaHash, "delta hash")
 }
@@ -156,6 +156,34 @@ func TestParseSignedDataForUpdate(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, schema)
 	})
+	t.Run("unencoded payload (RFC 7797)", func(t *testing.T) {
+		signedModel := model.UpdateSignedDataModel{
+			DeltaHash: computeMultihash([]byte("hash")),
+			UpdateKey: testJWK,
+		}
+
+		payload, err := json.Marshal(signedModel)
+		require.NoError(t, err)
+
+		signer := NewMockSigner()
+		signer.MockHeaders[jws.HeaderB64Payload] = false
+
+		compactJWS, err := signutil.SignPayload(payload, signer)
+		require.NoError(t, err)
+		require.Contains(t, compactJWS, string(payload))
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.Error(t, err)
+		require.Nil(t, schema)
+		require.Contains(t, err.Error(), "invalid protected header")
+
+		protocolWithUnencodedPayload := p
+		protocolWithUnencodedPayload.UnencodedPayload = true
+
+		schema, err = New(protocolWithUnencodedPayload).ParseSignedDataForUpdate(compactJWS)
+		require.NoError(t, err)
+		require.Equal(t, signedModel.DeltaHash, schema.DeltaHash)
+	})
 	t.Run("invalid JWS compact format", func(t *testing.T) {
 		schema, err := parser.ParseSignedDataForUpdate("invalid")
 		require.Error(t, err)
@@ -178,6 +206,26 @@ func TestParseSignedDataForUpdate(t *testing.T) {
 		require.Nil(t, schema)
 		require.Contains(t, err.Error(), "delta hash is not computed with the required hash algorithms: [18]")
 	})
+	t.Run("error - nonce is zero", func(t *testing.T) {
+		nonce := uint64(0)
+
+		signedModel := model.UpdateSignedDataModel{
+			DeltaHash: computeMultihash([]byte("hash")),
+			UpdateKey: testJWK,
+			Nonce:     &nonce,
+		}
+
+		payload, err := json.Marshal(signedModel)
+		require.NoError(t, err)
+
+		compactJWS, err := signutil.SignPayload(payload, NewMockSigner())
+		require.NoError(t, err)
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.Error(t, err)
+		require.Nil(t, schema)
+		require.Contains(t, err.Error(), "nonce must be greater than zero")
+	})
 	t.Run("payload not JSON object", func(t *testing.T) {
 		compactJWS, err := signutil.SignPayload([]byte("test"), NewMockSigner())
 		require.NoError(t, err)
@@ -236,6 +284,26 @@ func TestValidateUpdateRequest(t *testing.T) {
 		err = parser.validateUpdateRequest(update)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "missing did suffix")
+		require.Equal(t, "/didSuffix", protocol.GetPointer(err))
+	})
+	t.Run("invalid did suffix", func(t *testing.T) {
+		parser := New(protocol.Protocol{
+			MaxOperationHashLength: maxHashLength,
+			MultihashAlgorithms:    []uint{sha2_256},
+			ValidateDIDSuffix:      true,
+		})
+
+		update, err := getDefaultUpdateRequest()
+		require.NoError(t, err)
+
+		update.DidSuffix = "invalid!"
+		err = parser.validateUpdateRequest(update)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "did suffix contains invalid character '!'")
+
+		update.DidSuffix = computeMultihash([]byte("suffix"))
+		err = parser.validateUpdateRequest(update)
+		require.NoError(t, err)
 	})
 	t.Run("invalid reveal value", func(t *testing.T) {
 		update, err := getDefaultUpdateRequest()
@@ -245,6 +313,7 @@ func TestValidateUpdateRequest(t *testing.T) {
 		err = parser.validateUpdateRequest(update)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "reveal value is not computed with the required hash algorithms: [18]")
+		require.Equal(t, "/revealValue", protocol.GetPointer(err))
 	})
 }
 
@@ -7,13 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package txnprovider
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
+	"github.com/trustbloc/edge-core/pkg/log"
+
 	"github.com/trustbloc/sidetree-core-go/pkg/api/cas"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
-	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprovider/models"
 )
@@ -168,14 +171,17 @@ func (h *OperationHandler) createProvisionalIndexFile(chunks []string, provision
 }
 
 func (h *OperationHandler) writeModelToCAS(model interface{}, alias string) (string, error) {
-	bytes, err := docutil.MarshalCanonical(model)
-	if err != nil {
+	// canonical file content is written directly into the buffer (batch files can be large)
+	var buf bytes.Buffer
+	if err := canonicalizer.MarshalCanonicalTo(&buf, model); err != nil {
 		return "", fmt.Errorf("failed to marshal %s file: %s", alias, err.Error())
 	}
 
-	logger.Debugf("%s file: %s", alias, string(bytes))
+	if log.IsEnabledFor(loggerModule, log.DEBUG) {
+		logger.Debugf("%s file: %s", alias, buf.String())
+	}
 
-	compressedBytes, err := h.cp.Compress(h.protocol.CompressionAlgorithm, bytes)
+	compressedBytes, err := h.cp.Compress(h.protocol.CompressionAlgorithm, buf.Bytes())
 	if err != nil {
 		return "", err
 	}
@@ -19,7 +19,9 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprovider/models"
 )
 
-var logger = log.New("sidetree-core-txnhandler")
+const loggerModule = "sidetree-core-txnhandler"
+
+var logger = log.New(loggerModule)
 
 // DCAS interface to access content addressable storage.
 type DCAS interface {
@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package versions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/protocolclient"
+)
+
+//nolint:gomnd
+const (
+	sha2_256 = 18
+
+	defaultMaxOperationCount           = 10000
+	defaultMaxOperationSize            = 2500
+	defaultMaxOperationHashLength      = 100
+	defaultMaxDeltaSize                = 1000
+	defaultMaxCasURILength             = 100
+	defaultCompressionAlgorithm        = "GZIP"
+	defaultMaxCoreIndexFileSize        = 1000000
+	defaultMaxProofFileSize            = 2500000
+	defaultMaxProvisionalIndexFileSize = 1000000
+	defaultMaxChunkFileSize            = 10000000
+)
+
+// Format is format of protocol configuration.
+type Format string
+
+const (
+	// FormatJSON is JSON configuration format.
+	FormatJSON Format = "json"
+
+	// FormatYAML is YAML configuration format.
+	FormatYAML Format = "yaml"
+)
+
+// DefaultProtocol returns default protocol parameters.
+func DefaultProtocol() protocol.Protocol {
+	return protocol.Protocol{
+		MultihashAlgorithms:         []uint{sha2_256},
+		MaxOperationCount:           defaultMaxOperationCount,
+		MaxOperationSize:            defaultMaxOperationSize,
+		MaxOperationHashLength:      defaultMaxOperationHashLength,
+		MaxDeltaSize:                defaultMaxDeltaSize,
+		MaxCasURILength:             defaultMaxCasURILength,
+		CompressionAlgorithm:        defaultCompressionAlgorithm,
+		MaxCoreIndexFileSize:        defaultMaxCoreIndexFileSize,
+		MaxProofFileSize:            defaultMaxProofFileSize,
+		MaxProvisionalIndexFileSize: defaultMaxProvisionalIndexFileSize,
+		MaxChunkFileSize:            defaultMaxChunkFileSize,
+		Patches: []string{"replace", "add-public-keys", "remove-public-keys", "add-services", "remove-services",
+			"ietf-json-patch"},
+		SignatureAlgorithms: []string{"EdDSA", "ES256", "ES256K"},
+		KeyAlgorithms:       []string{"Ed25519", "P-256", "secp256k1"},
+	}
+}
+
+// LoadConfig reads configurations of protocol versions from JSON or YAML file (format is determined
+// by file extension: .json, .yaml or .yml), e.g.:
+//
+//	versions:
+//	  - version: "0.1"
+//	    protocol:
+//	      genesisTime: 0
+//	      maxOperationSize: 2500
+//	      multihashAlgorithms: [18]
+//
+// Parameters that are not specified are set to default values (see DefaultProtocol).
+func LoadConfig(path string) ([]Config, error) {
+	var format Format
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = FormatJSON
+	case ".yaml", ".yml":
+		format = FormatYAML
+	default:
+		return nil, fmt.Errorf("unsupported protocol configuration file extension: %s", path)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol configuration: %s", err.Error())
+	}
+
+	return ParseConfig(data, format)
+}
+
+// ParseConfig parses and validates configurations of protocol versions; parameters that are not
+// specified are set to default values.
+func ParseConfig(data []byte, format Format) ([]Config, error) {
+	if format == FormatYAML {
+		var err error
+
+		data, err = yamlToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse protocol configuration: %s", err.Error())
+		}
+	} else if format != FormatJSON {
+		return nil, fmt.Errorf("unsupported protocol configuration format: %s", format)
+	}
+
+	var file struct {
+		Versions []json.RawMessage `json:"versions"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse protocol configuration: %s", err.Error())
+	}
+
+	if len(file.Versions) == 0 {
+		return nil, errors.New("protocol configuration doesn't contain any versions")
+	}
+
+	configs := make([]Config, len(file.Versions))
+
+	for i, raw := range file.Versions {
+		cfg := Config{Protocol: DefaultProtocol()}
+
+		// unknown properties are rejected so that misspelled parameters are not silently replaced with defaults
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse protocol version[%d] configuration: %s", i, err.Error())
+		}
+
+		if err := validateConfig(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid protocol version[%d] configuration: %s", i, err.Error())
+		}
+
+		configs[i] = cfg
+	}
+
+	return configs, nil
+}
+
+// Load reads configurations of protocol versions from JSON or YAML file and returns protocol client
+// for the configured versions (factories for the versions have to be registered).
+func Load(path string, deps *Dependencies, opts ...protocolclient.Option) (*protocolclient.Client, error) {
+	configs, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(configs, deps, opts...)
+}
+
+func validateConfig(cfg *Config) error {
+	if cfg.Version == "" {
+		return errors.New("missing version")
+	}
+
+	return cfg.Protocol.Validate()
+}
+
+// yamlToJSON converts YAML document to JSON so that protocol parameters are decoded using their JSON names.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	value, err := convertYAML(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// convertYAML converts maps decoded by YAML parser (map[interface{}]interface{}) to JSON objects.
+func convertYAML(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+
+		for key, val := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported key type %T: %v", key, key)
+			}
+
+			converted, err := convertYAML(val)
+			if err != nil {
+				return nil, err
+			}
+
+			obj[k] = converted
+		}
+
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+
+		for i, val := range v {
+			converted, err := convertYAML(val)
+			if err != nil {
+				return nil, err
+			}
+
+			arr[i] = converted
+		}
+
+		return arr, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package versions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, path := range []string{"testdata/protocol.yaml", "testdata/protocol.json"} {
+		configs, err := LoadConfig(path)
+		require.NoError(t, err, path)
+		require.Len(t, configs, 2)
+
+		require.Equal(t, "config-0.1", configs[0].Version)
+		require.Equal(t, uint64(0), configs[0].Protocol.GenesisTime)
+		require.Equal(t, uint(2000), configs[0].Protocol.MaxOperationSize)
+		require.Equal(t, []uint{18}, configs[0].Protocol.MultihashAlgorithms)
+		require.Equal(t, []string{"add-public-keys", "remove-public-keys"}, configs[0].Protocol.Patches)
+		require.Equal(t, uint(defaultMaxOperationCount), configs[0].Protocol.MaxOperationCount)
+
+		// defaults
+		require.Equal(t, "config-1.0", configs[1].Version)
+		require.Equal(t, uint64(500), configs[1].Protocol.GenesisTime)
+		require.Equal(t, uint(100), configs[1].Protocol.MaxOperationCount)
+		require.Equal(t, uint(defaultMaxOperationSize), configs[1].Protocol.MaxOperationSize)
+		require.Equal(t, DefaultProtocol().Patches, configs[1].Protocol.Patches)
+		require.Equal(t, defaultCompressionAlgorithm, configs[1].Protocol.CompressionAlgorithm)
+	}
+
+	t.Run("error - unsupported extension", func(t *testing.T) {
+		configs, err := LoadConfig("testdata/protocol.txt")
+		require.EqualError(t, err, "unsupported protocol configuration file extension: testdata/protocol.txt")
+		require.Nil(t, configs)
+	})
+
+	t.Run("error - file not found", func(t *testing.T) {
+		configs, err := LoadConfig("testdata/invalid.json")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read protocol configuration")
+		require.Nil(t, configs)
+	})
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Run("error - unsupported format", func(t *testing.T) {
+		configs, err := ParseConfig([]byte("{}"), "xml")
+		require.EqualError(t, err, "unsupported protocol configuration format: xml")
+		require.Nil(t, configs)
+	})
+
+	t.Run("error - invalid JSON", func(t *testing.T) {
+		_, err := ParseConfig([]byte("{"), FormatJSON)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse protocol configuration")
+	})
+
+	t.Run("error - invalid YAML", func(t *testing.T) {
+		_, err := ParseConfig([]byte("versions: ["), FormatYAML)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse protocol configuration")
+
+		_, err = ParseConfig([]byte("versions:\n  - 1: value"), FormatYAML)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported key type int: 1")
+	})
+
+	t.Run("error - no versions", func(t *testing.T) {
+		_, err := ParseConfig([]byte(`{"versions":[]}`), FormatJSON)
+		require.EqualError(t, err, "protocol configuration doesn't contain any versions")
+	})
+
+	t.Run("error - unknown parameter", func(t *testing.T) {
+		_, err := ParseConfig([]byte(`{"versions":[{"version":"0.1","protocol":{"maxOperationSise":100}}]}`), FormatJSON)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `failed to parse protocol version[0] configuration: json: unknown field "maxOperationSise"`)
+	})
+
+	t.Run("error - invalid parameters", func(t *testing.T) {
+		tests := []struct {
+			config string
+			err    string
+		}{
+			{`{}`, "missing version"},
+			{`{"version":"0.1","protocol":{"multihashAlgorithms":[]}}`, "missing multihash algorithms"},
+			{`{"version":"0.1","protocol":{"maxOperationCount":0}}`, "max operation count must be greater than zero"},
+			{`{"version":"0.1","protocol":{"maxOperationSize":0}}`, "max operation size must be greater than zero"},
+			{`{"version":"0.1","protocol":{"maxDeltaSize":0}}`, "max delta size must be greater than zero"},
+			{`{"version":"0.1","protocol":{"patches":[]}}`, "missing patches"},
+			{`{"version":"0.1","protocol":{"patches":["unknown"]}}`, "patch action 'unknown' is not supported"},
+			{`{"version":"0.1","protocol":{"multihashAlgorithms":[17]}}`, "multihash algorithm 17 is not supported"},
+			{`{"version":"0.1","protocol":{"maxOperationHashLength":0}}`, "max operation hash length must be greater than zero"},
+			{`{"version":"0.1","protocol":{"maxChunkFileSize":0}}`, "max chunk file size must be greater than zero"},
+			{`{"version":"0.1","protocol":{"maxDeltaSize":3000}}`,
+				"max delta size[3000] cannot be greater than max operation size[2500]"},
+			{`{"version":"0.1","protocol":{"maxPatchSize":1001}}`,
+				"max patch size[1001] cannot be greater than max delta size[1000]"},
+			{`{"version":"0.1","protocol":{"maxDeltaSize":2000,"maxChunkFileSize":1000}}`,
+				"max delta size[2000] cannot be greater than max chunk file size[1000]"},
+			{`{"version":"0.1","protocol":{"compressionAlgorithm":""}}`, "missing compression algorithm"},
+			{`{"version":"0.1","protocol":{"signatureAlgorithms":[]}}`, "missing signature algorithms"},
+			{`{"version":"0.1","protocol":{"keyIdPolicy":"unknown"}}`, "key ID policy 'unknown' is not supported"},
+		}
+
+		for _, test := range tests {
+			_, err := ParseConfig([]byte(`{"versions":[`+test.config+`]}`), FormatJSON)
+			require.EqualError(t, err, "invalid protocol version[0] configuration: "+test.err)
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	require.NoError(t, Register("config-0.1", &mockFactory{}))
+	require.NoError(t, Register("config-1.0", &mockFactory{}))
+
+	pc, err := Load("testdata/protocol.yaml", &Dependencies{})
+	require.NoError(t, err)
+
+	v, err := pc.Get(499)
+	require.NoError(t, err)
+	require.Equal(t, "config-0.1", v.Version())
+	require.Equal(t, uint(2000), v.Protocol().MaxOperationSize)
+
+	v, err = pc.Get(500)
+	require.NoError(t, err)
+	require.Equal(t, "config-1.0", v.Version())
+
+	t.Run("error", func(t *testing.T) {
+		pc, err := Load("testdata/protocol.txt", &Dependencies{})
+		require.Error(t, err)
+		require.Nil(t, pc)
+	})
+}
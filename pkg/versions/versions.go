@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package versions provides registry of protocol version factories. Factories are registered by
+// version name (e.g. versions.Register("0.1", factory.New())) and are used to create protocol versions
+// (parser, applier, validators, batching) for the configured protocol parameters, so that components
+// don't have to be wired manually for each version.
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/cas"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/protocolclient"
+)
+
+// OperationStore interface to access operation store.
+type OperationStore interface {
+	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
+	Put(ops []*operation.AnchoredOperation) error
+}
+
+// CompressionProvider compresses and decompresses batch files.
+type CompressionProvider interface {
+	Compress(alg string, data []byte) ([]byte, error)
+	Decompress(alg string, data []byte) ([]byte, error)
+}
+
+// Dependencies contains external dependencies of protocol version components.
+type Dependencies struct {
+	CasClient           cas.Client
+	OperationStore      OperationStore
+	CompressionProvider CompressionProvider
+}
+
+// Factory creates protocol version for the given protocol parameters.
+type Factory interface {
+	Create(version string, p protocol.Protocol, deps *Dependencies) (protocol.Version, error)
+}
+
+// Config contains name and protocol parameters of protocol version.
+type Config struct {
+	Version  string            `json:"version"`
+	Protocol protocol.Protocol `json:"protocol"`
+}
+
+var (
+	mutex     sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register registers factory for the protocol version.
+func Register(version string, factory Factory) error {
+	if version == "" {
+		return errors.New("missing version")
+	}
+
+	if factory == nil {
+		return errors.New("missing factory")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := factories[version]; ok {
+		return fmt.Errorf("factory for version '%s' is already registered", version)
+	}
+
+	factories[version] = factory
+
+	return nil
+}
+
+// Get returns factory for the protocol version.
+func Get(version string) (Factory, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	factory, ok := factories[version]
+	if !ok {
+		return nil, fmt.Errorf("factory for version '%s' is not registered", version)
+	}
+
+	return factory, nil
+}
+
+// Registered returns (sorted) names of registered protocol versions.
+func Registered() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Create validates protocol parameters and creates protocol version using factory registered for the version.
+func Create(version string, p protocol.Protocol, deps *Dependencies) (protocol.Version, error) {
+	factory, err := Get(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid protocol parameters for version '%s': %s", version, err.Error())
+	}
+
+	v, err := factory.Create(version, p, deps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create protocol version '%s': %s", version, err.Error())
+	}
+
+	return v, nil
+}
+
+// NewClient creates protocol versions for the given configurations and returns protocol client
+// that selects the version by genesis time.
+func NewClient(configs []Config, deps *Dependencies, opts ...protocolclient.Option) (*protocolclient.Client, error) {
+	pvs := make([]protocol.Version, len(configs))
+
+	for i, cfg := range configs {
+		v, err := Create(cfg.Version, cfg.Protocol, deps)
+		if err != nil {
+			return nil, err
+		}
+
+		pvs[i] = v
+	}
+
+	return protocolclient.New(pvs, opts...)
+}
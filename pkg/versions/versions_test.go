@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, Register("register-1.0", &mockFactory{}))
+
+		f, err := Get("register-1.0")
+		require.NoError(t, err)
+		require.NotNil(t, f)
+
+		require.Contains(t, Registered(), "register-1.0")
+	})
+
+	t.Run("error - already registered", func(t *testing.T) {
+		require.NoError(t, Register("register-2.0", &mockFactory{}))
+
+		err := Register("register-2.0", &mockFactory{})
+		require.EqualError(t, err, "factory for version 'register-2.0' is already registered")
+	})
+
+	t.Run("error - missing version", func(t *testing.T) {
+		require.EqualError(t, Register("", &mockFactory{}), "missing version")
+	})
+
+	t.Run("error - missing factory", func(t *testing.T) {
+		require.EqualError(t, Register("register-3.0", nil), "missing factory")
+	})
+
+	t.Run("error - not registered", func(t *testing.T) {
+		f, err := Get("unknown")
+		require.EqualError(t, err, "factory for version 'unknown' is not registered")
+		require.Nil(t, f)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	require.NoError(t, Register("create-1.0", &mockFactory{}))
+	require.NoError(t, Register("create-error", &mockFactory{err: errors.New("factory error")}))
+
+	t.Run("success", func(t *testing.T) {
+		v, err := Create("create-1.0", newProtocol(100), &Dependencies{})
+		require.NoError(t, err)
+		require.Equal(t, "create-1.0", v.Version())
+		require.Equal(t, uint64(100), v.Protocol().GenesisTime)
+	})
+
+	t.Run("error - not registered", func(t *testing.T) {
+		v, err := Create("unknown", protocol.Protocol{}, &Dependencies{})
+		require.EqualError(t, err, "factory for version 'unknown' is not registered")
+		require.Nil(t, v)
+	})
+
+	t.Run("error - invalid protocol parameters", func(t *testing.T) {
+		p := newProtocol(0)
+		p.MaxDeltaSize = p.MaxOperationSize + 1
+
+		v, err := Create("create-1.0", p, &Dependencies{})
+		require.EqualError(t, err, "invalid protocol parameters for version 'create-1.0': "+
+			"max delta size[2501] cannot be greater than max operation size[2500]")
+		require.Nil(t, v)
+	})
+
+	t.Run("error - factory", func(t *testing.T) {
+		v, err := Create("create-error", newProtocol(0), &Dependencies{})
+		require.EqualError(t, err, "failed to create protocol version 'create-error': factory error")
+		require.Nil(t, v)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	require.NoError(t, Register("client-0.1", &mockFactory{}))
+	require.NoError(t, Register("client-1.0", &mockFactory{}))
+
+	t.Run("success", func(t *testing.T) {
+		pc, err := NewClient([]Config{
+			{Version: "client-0.1", Protocol: newProtocol(0)},
+			{Version: "client-1.0", Protocol: newProtocol(500)},
+		}, &Dependencies{})
+		require.NoError(t, err)
+
+		v, err := pc.Get(100)
+		require.NoError(t, err)
+		require.Equal(t, "client-0.1", v.Version())
+
+		v, err = pc.Current()
+		require.NoError(t, err)
+		require.Equal(t, "client-1.0", v.Version())
+	})
+
+	t.Run("error - version not registered", func(t *testing.T) {
+		pc, err := NewClient([]Config{{Version: "unknown"}}, &Dependencies{})
+		require.EqualError(t, err, "factory for version 'unknown' is not registered")
+		require.Nil(t, pc)
+	})
+
+	t.Run("error - no versions", func(t *testing.T) {
+		pc, err := NewClient(nil, &Dependencies{})
+		require.EqualError(t, err, "missing protocol versions")
+		require.Nil(t, pc)
+	})
+}
+
+func newProtocol(genesisTime uint64) protocol.Protocol {
+	p := DefaultProtocol()
+	p.GenesisTime = genesisTime
+
+	return p
+}
+
+type mockFactory struct {
+	err error
+}
+
+func (m *mockFactory) Create(version string, p protocol.Protocol, _ *Dependencies) (protocol.Version, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	v := mocks.GetProtocolVersion(p)
+	v.VersionReturns(version)
+
+	return v, nil
+}